@@ -844,4 +844,42 @@ describe("GameClientOrchestrator", () => {
 
     await orchestrator.shutdown();
   });
+
+  test("applies interest enter and leave changes to world entities", async () => {
+    const { orchestrator, emitLifecycleState, worldState } = createHeadlessHarness({
+      catalog: generatedEffectCatalog,
+      joinResponseOverrides: {
+        players: [
+          { id: "player-1", x: 12, y: 18, facing: "up" },
+          { id: "player-2", x: 30, y: 30, facing: "down" },
+        ],
+      },
+    });
+
+    await orchestrator.boot({});
+    expect(worldState.snapshot().entities.has("player-2")).toBe(true);
+
+    emitLifecycleState({
+      payload: {
+        patches: [],
+        interestEnter: ["npc-1"],
+        interestLeave: ["player-2"],
+        interestNpcs: [
+          { id: "npc-1", x: 52, y: 60, facing: "left", type: "goblin", health: 6, maxHealth: 12 },
+        ],
+      },
+      tick: 20,
+      receivedAt: 20 * 16,
+    });
+
+    const snapshot = worldState.snapshot();
+    expect(snapshot.entities.has("player-2")).toBe(false);
+    const npcState = snapshot.entities.get("npc-1");
+    expect(npcState?.position).toEqual([52, 60]);
+    expect(npcState?.health).toBe(6);
+    expect(npcState?.maxHealth).toBe(12);
+    expect(npcState?.npcType).toBe("goblin");
+
+    await orchestrator.shutdown();
+  });
 });
@@ -172,6 +172,51 @@ describe("ContractLifecycleStore", () => {
     expect(view.recentlyEnded.size).toBe(0);
   });
 
+  test("restores params and colors trimmed against the catalog", () => {
+    setEffectCatalog({
+      attack: generatedEffectCatalog.attack,
+      "blood-splatter": generatedEffectCatalog["blood-splatter"],
+    });
+
+    const store = new ContractLifecycleStore();
+
+    const blood = createSpawn({
+      seq: 1,
+      id: "effect-blood",
+      entryId: "blood-splatter",
+      definitionId: "blood-splatter",
+    });
+    const attack = createSpawn({
+      seq: 1,
+      id: "effect-attack",
+      entryId: "attack",
+      definitionId: "attack",
+    });
+    store.applyBatch({
+      spawns: [
+        {
+          ...blood,
+          catalogColors: true,
+          instance: { ...blood.instance, params: { centerX: 120, centerY: 80 } },
+        },
+        {
+          ...attack,
+          catalogParams: true,
+          instance: { ...attack.instance, params: { width: 48 } },
+        },
+      ],
+    });
+
+    const view = store.snapshot();
+    const bloodEntry = view.entries.get("effect-blood");
+    expect(bloodEntry?.instance.colors).toEqual(["#7a0e12", "#4a090b"]);
+    expect(bloodEntry?.instance.params).toEqual({ centerX: 120, centerY: 80 });
+
+    const attackEntry = view.entries.get("effect-attack");
+    expect(attackEntry?.instance.params).toEqual({ damage: 10, reach: 56, width: 48 });
+    expect(attackEntry?.instance.colors).toBeUndefined();
+  });
+
   test("reports dropped and unknown events", () => {
     const store = new ContractLifecycleStore();
 
@@ -199,5 +244,24 @@ describe("ContractLifecycleStore", () => {
     expect(staleSummary.updates).toEqual([]);
     expect(staleSummary.droppedUpdates).toEqual(["effect-dup"]);
   });
-});
 
+  test("forgets entries that left the area of interest so a re-spawn is accepted", () => {
+    setEffectCatalog({ fireball: generatedEffectCatalog.fireball });
+
+    const store = new ContractLifecycleStore();
+    store.applyBatch({
+      spawns: [createSpawn({ seq: 2, id: "effect-far", entryId: "fireball", definitionId: "fireball" })],
+    });
+    expect(store.has("effect-far")).toBe(true);
+
+    expect(store.forget("effect-far")).toBe(true);
+    expect(store.has("effect-far")).toBe(false);
+    expect(store.snapshot().getEntry("effect-far")).toBeNull();
+
+    const respawn = store.applyBatch({
+      spawns: [createSpawn({ seq: 2, id: "effect-far", entryId: "fireball", definitionId: "fireball" })],
+    });
+    expect(respawn.spawns).toEqual(["effect-far"]);
+    expect(store.forget("ghost")).toBe(false);
+  });
+});
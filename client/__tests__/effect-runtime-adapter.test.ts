@@ -138,5 +138,80 @@ describe("effect-runtime-adapter", () => {
     expect(intent!.options.x).toBe(100);
     expect(intent!.options.y).toBe(120);
   });
-});
 
+  const createCatalogAnimation = (
+    contractId: keyof typeof generatedEffectCatalog,
+    motion: Record<string, number>,
+    geometry: Record<string, unknown>,
+  ): AnimationFrame => {
+    const entry = generatedEffectCatalog[contractId];
+    return {
+      effectId: `effect-${contractId}`,
+      startedAt: 0,
+      durationMs: 0,
+      metadata: {
+        state: "active",
+        contractId: entry.contractId,
+        lastEventKind: "spawn",
+        retained: false,
+        catalog: entry,
+        blocks: entry.blocks,
+        instance: {
+          id: `effect-${contractId}`,
+          definitionId: entry.contractId,
+          startTick: 0,
+          deliveryState: {
+            geometry,
+            motion: { velocityX: 0, velocityY: 0, ...motion },
+          },
+          behaviorState: {
+            ticksRemaining: 1,
+          },
+          replication: entry.definition.client,
+          end: entry.definition.end,
+        },
+      },
+    } as AnimationFrame;
+  };
+
+  test("draws beams as a ray from the caster to their clipped far end", () => {
+    const frame = createCatalogAnimation(
+      "beam",
+      { positionX: 100, positionY: 120, targetX: 220, targetY: 120 },
+      { shape: "segment", length: 240 },
+    );
+
+    const intent = translateRenderAnimation(frame);
+
+    expect(intent).not.toBeNull();
+    expect(intent!.definition.type).toBe("melee-swing");
+    const options = intent!.options as Record<string, number>;
+    expect(options.x).toBe(100);
+    expect(options.width).toBe(120);
+    expect(options.y + options.height / 2).toBe(120);
+  });
+
+  test("maps every built-in area and status effect to a runtime definition", () => {
+    const expected: Record<string, string> = {
+      heal: "impact-burst",
+      "heal-burst": "impact-burst",
+      explosion: "impact-burst",
+      "fire-patch": "fire",
+      "gravity-well": "placeholder-aura",
+    };
+    for (const [contractId, type] of Object.entries(expected)) {
+      const frame = createCatalogAnimation(
+        contractId as keyof typeof generatedEffectCatalog,
+        { positionX: 100, positionY: 120 },
+        { shape: "circle", radius: 40 },
+      );
+
+      const intent = translateRenderAnimation(frame);
+
+      expect(intent, contractId).not.toBeNull();
+      expect(intent!.definition.type, contractId).toBe(type);
+      expect(intent!.options.x, contractId).toBe(100);
+      expect(intent!.options.y, contractId).toBe(120);
+    }
+  });
+});
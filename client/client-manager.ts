@@ -366,6 +366,20 @@ export class GameClientOrchestrator implements ClientOrchestrator {
       worldKeyframeId = keyframeId;
     }
 
+    try {
+      const interestBatch = this.createInterestPatchBatch(payload, {
+        keyframeId: this.resolvePatchKeyframeId(keyframeSequence, patchSequence, worldKeyframeId, "state"),
+        timestamp: frameTime,
+      });
+      if (interestBatch) {
+        this.worldState.applyPatchBatch(interestBatch);
+        worldStateChanged = true;
+        worldFrameTime = worldFrameTime ?? frameTime;
+      }
+    } catch (error) {
+      this.reportError(error);
+    }
+
     if (this.payloadHasField(payload, "patches")) {
       try {
         const patches = normalizeNetworkPatches(payload["patches"], "state.patches");
@@ -497,9 +511,10 @@ export class GameClientOrchestrator implements ClientOrchestrator {
   }
 
   private extractLifecycleBatch(payload: Record<string, unknown>): ContractLifecycleBatch | null {
-    const spawns = payload["effect_spawned"] as
-      | readonly ContractLifecycleSpawnEvent[]
-      | undefined;
+    const spawns = this.mergeActiveEffects(
+      payload["effect_spawned"] as readonly ContractLifecycleSpawnEvent[] | undefined,
+      payload["activeEffects"] as readonly ContractLifecycleSpawnEvent[] | undefined,
+    );
     const updates = payload["effect_update"] as
       | readonly ContractLifecycleUpdateEvent[]
       | undefined;
@@ -542,6 +557,35 @@ export class GameClientOrchestrator implements ClientOrchestrator {
     };
   }
 
+  // Snapshots list every live effect as a spawn-equivalent event. Only effects
+  // this client has not seen are replayed, so a resubscribe picks up in-flight
+  // projectiles and auras without re-spawning ones it already tracks.
+  private mergeActiveEffects(
+    spawns: readonly ContractLifecycleSpawnEvent[] | undefined,
+    activeEffects: readonly ContractLifecycleSpawnEvent[] | undefined,
+  ): readonly ContractLifecycleSpawnEvent[] | undefined {
+    if (!Array.isArray(activeEffects) || activeEffects.length === 0) {
+      return spawns;
+    }
+    const seen = new Set<string>();
+    if (Array.isArray(spawns)) {
+      for (const spawn of spawns) {
+        const id = spawn?.instance?.id;
+        if (typeof id === "string") {
+          seen.add(id);
+        }
+      }
+    }
+    const missing = activeEffects.filter((active) => {
+      const id = active?.instance?.id;
+      return typeof id === "string" && !seen.has(id) && !this.lifecycleStore.has(id);
+    });
+    if (missing.length === 0) {
+      return spawns;
+    }
+    return Array.isArray(spawns) ? [...missing, ...spawns] : missing;
+  }
+
   private prepareForSession(join: JoinResponse): void {
     setEffectCatalog(null);
     this.worldState.reset();
@@ -1279,6 +1323,9 @@ export class GameClientOrchestrator implements ClientOrchestrator {
     if (typeof player.maxHealth === "number" && Number.isFinite(player.maxHealth)) {
       entity.maxHealth = player.maxHealth;
     }
+    if (typeof player.absorb === "number" && Number.isFinite(player.absorb)) {
+      entity.absorb = player.absorb;
+    }
     if (player.inventory && typeof player.inventory === "object") {
       entity.inventory = player.inventory;
     }
@@ -1380,6 +1427,61 @@ export class GameClientOrchestrator implements ClientOrchestrator {
     return entity;
   }
 
+  // createInterestPatchBatch turns interest-culling changes into world
+  // operations: entities that left the area of interest are dropped, and
+  // entities that entered are upserted from the full state the server sends
+  // with them. Contract effects that left are forgotten by the lifecycle store
+  // so their re-spawn on re-entry is accepted.
+  private createInterestPatchBatch(
+    payload: Record<string, unknown>,
+    options: { readonly keyframeId: string; readonly timestamp: number },
+  ): WorldPatchBatch | null {
+    const operations: WorldPatchOperation[] = [];
+
+    const leave = payload["interestLeave"];
+    if (Array.isArray(leave)) {
+      for (const id of leave) {
+        if (typeof id !== "string" || id.length === 0) {
+          continue;
+        }
+        operations.push({ entityId: id, path: [], value: null });
+        this.lifecycleStore.forget(id);
+      }
+    }
+
+    const entering: (WorldEntityState | null)[] = [];
+    if (this.payloadHasField(payload, "interestPlayers")) {
+      for (const player of normalizePlayerSnapshots(payload["interestPlayers"], "state.interestPlayers")) {
+        entering.push(this.translatePlayerEntity(player));
+      }
+    }
+    if (this.payloadHasField(payload, "interestNpcs")) {
+      for (const npc of normalizeNPCSnapshots(payload["interestNpcs"], "state.interestNpcs")) {
+        entering.push(this.translateNPCEntity(npc));
+      }
+    }
+    if (this.payloadHasField(payload, "interestGroundItems")) {
+      for (const item of normalizeGroundItemSnapshots(payload["interestGroundItems"], "state.interestGroundItems")) {
+        entering.push(this.translateGroundItemEntity(item));
+      }
+    }
+    for (const entity of entering) {
+      if (entity) {
+        operations.push({ entityId: entity.id, path: [], value: entity });
+      }
+    }
+
+    if (operations.length === 0) {
+      return null;
+    }
+
+    return {
+      keyframeId: options.keyframeId,
+      timestamp: options.timestamp,
+      operations,
+    };
+  }
+
   private createWorldPatchBatch(
     patches: readonly NetworkPatch[],
     options: { readonly keyframeId: string; readonly timestamp: number },
@@ -1430,6 +1532,8 @@ export class GameClientOrchestrator implements ClientOrchestrator {
       case "player_health":
       case "npc_health":
         return this.translateHealthPatch(entityId, payload);
+      case "player_absorb":
+        return this.translateAbsorbPatch(entityId, payload);
       case "player_inventory":
       case "npc_inventory":
         return this.translateObjectPatch(entityId, "inventory", payload);
@@ -1498,6 +1602,17 @@ export class GameClientOrchestrator implements ClientOrchestrator {
     return operations;
   }
 
+  private translateAbsorbPatch(entityId: string, payload: unknown): readonly WorldPatchOperation[] {
+    if (!payload || typeof payload !== "object") {
+      return [];
+    }
+    const record = payload as Record<string, unknown>;
+    if (typeof record.absorb !== "number" || !Number.isFinite(record.absorb)) {
+      return [];
+    }
+    return [{ entityId, path: ["absorb"], value: record.absorb }];
+  }
+
   private translateObjectPatch(
     entityId: string,
     key: string,
@@ -1,3 +1,4 @@
+import { getEffectCatalogEntry } from "./effect-catalog";
 import { isLifecycleClientManaged } from "./effect-lifecycle-metadata";
 import type {
   EffectContractID,
@@ -18,6 +19,8 @@ export interface ContractLifecycleSpawnEvent {
   readonly seq: number;
   readonly tick?: number | null;
   readonly instance: EffectInstance;
+  readonly catalogParams?: boolean;
+  readonly catalogColors?: boolean;
 }
 
 export interface ContractLifecycleUpdateEvent {
@@ -114,8 +117,48 @@ const normalizeTick = (tick: number | null | undefined): number | null =>
 const normalizeSeq = (seq: number | null | undefined): number | null =>
   typeof seq === "number" && Number.isFinite(seq) ? Math.floor(seq) : null;
 
-const createSpawnPayload = (instance: EffectInstance): SpawnPayload => ({
-  instance: cloneValue(instance),
+const isRecord = (value: unknown): value is Record<string, unknown> =>
+  typeof value === "object" && value !== null && !Array.isArray(value);
+
+// The server trims spawn params and colors that repeat the catalog entry and
+// flags the spawn instead. Merge the catalog defaults back so stored instances
+// match what the simulation spawned.
+const expandSpawnInstance = (spawn: ContractLifecycleSpawnEvent): EffectInstance => {
+  const instance = spawn.instance;
+  if (!spawn.catalogParams && !spawn.catalogColors) {
+    return instance;
+  }
+  const entryId =
+    typeof instance.entryId === "string" && instance.entryId.length > 0
+      ? instance.entryId
+      : instance.definitionId;
+  const blocks: Readonly<Record<string, unknown>> =
+    getEffectCatalogEntry(entryId)?.blocks ?? {};
+  let expanded: EffectInstance = instance;
+  if (spawn.catalogParams) {
+    const params: Record<string, number> = {};
+    const defaults = blocks.parameters;
+    if (isRecord(defaults)) {
+      for (const [key, value] of Object.entries(defaults)) {
+        if (typeof value === "number" && Number.isInteger(value)) {
+          params[key] = value;
+        }
+      }
+    }
+    Object.assign(params, instance.params ?? {});
+    expanded = { ...expanded, params };
+  }
+  if (spawn.catalogColors && Array.isArray(blocks.colors)) {
+    const colors = blocks.colors.filter(
+      (color): color is string => typeof color === "string",
+    );
+    expanded = { ...expanded, colors };
+  }
+  return expanded;
+};
+
+const createSpawnPayload = (spawn: ContractLifecycleSpawnEvent): SpawnPayload => ({
+  instance: cloneValue(expandSpawnInstance(spawn)),
 });
 
 const createUpdatePayload = (
@@ -171,6 +214,23 @@ export class ContractLifecycleStore {
     this.version = 0;
   }
 
+  has(id: string): boolean {
+    return this.entries.has(id);
+  }
+
+  forget(id: string): boolean {
+    if (typeof id !== "string" || id.length === 0) {
+      return false;
+    }
+    const removed = this.entries.delete(id);
+    this.recentlyEnded.delete(id);
+    this.lastSeqById.delete(id);
+    if (removed) {
+      this.version += 1;
+    }
+    return removed;
+  }
+
   snapshot(): ContractLifecycleView {
     const entryView = new Map<string, ContractLifecycleEntry>();
     for (const [id, entry] of this.entries.entries()) {
@@ -255,7 +315,7 @@ export class ContractLifecycleStore {
       }
 
       const tick = normalizeTick(spawn.tick ?? null);
-      const spawnPayload = createSpawnPayload(instance);
+      const spawnPayload = createSpawnPayload(spawn);
       const rawEntryId = spawnPayload.instance.entryId;
       const entryId =
         typeof rawEntryId === "string" && rawEntryId.length > 0 ? rawEntryId : null;
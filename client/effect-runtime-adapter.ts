@@ -381,12 +381,80 @@ const translatePlaceholder: Translator = ({ instance, center, radius, colors })
   };
 };
 
+const BEAM_THICKNESS = 8;
+
+// Beams carry their clipped far end in the motion target, so the ray is drawn
+// as the rectangle spanning the caster and that point.
+const translateBeam: Translator = ({ instance, colors, center, parameters }) => {
+  const motion = instance.deliveryState?.motion ?? {};
+  const geometry = instance.deliveryState?.geometry ?? {};
+  const endX = toNumber(motion?.targetX) ?? center.x;
+  const endY = toNumber(motion?.targetY) ?? center.y;
+  const thickness = positiveOr(toNumber(geometry?.width), BEAM_THICKNESS);
+  const horizontal = Math.abs(endX - center.x) >= Math.abs(endY - center.y);
+  const width = horizontal ? Math.abs(endX - center.x) : thickness;
+  const height = horizontal ? thickness : Math.abs(endY - center.y);
+  const x = horizontal ? Math.min(center.x, endX) : center.x - thickness / 2;
+  const y = horizontal ? center.y - thickness / 2 : Math.min(center.y, endY);
+
+  const options: Record<string, unknown> = {
+    x,
+    y,
+    width,
+    height,
+    effectId: instance.id,
+    fill: colors[0] ?? "rgba(255, 196, 92, 0.6)",
+    stroke: colors[1] ?? "rgba(255, 240, 200, 0.9)",
+  };
+  if (Number.isFinite(parameters.durationMs)) {
+    options.duration = Math.max(0.05, parameters.durationMs / 1000);
+  }
+
+  const signatureProps = {
+    x: roundForSignature(x),
+    y: roundForSignature(y),
+    width: roundForSignature(width),
+    height: roundForSignature(height),
+    fill: options.fill,
+    stroke: options.stroke,
+  };
+
+  return {
+    definition: MeleeSwingEffectDefinition,
+    options: options as Partial<any> & { x: number; y: number },
+    signatureProps,
+  };
+};
+
+// withPalette colours a shared translator for effects whose instances carry
+// no colours of their own.
+const withPalette = (translator: Translator, palette: readonly string[]): Translator =>
+  (input) => translator({ ...input, colors: input.colors.length > 0 ? input.colors : palette });
+
+const HEAL_PALETTE = ["#7cf29a", "#d6ffe0"];
+const EXPLOSION_PALETTE = ["#ff9a3c", "#ffe08a"];
+const GRAVITY_PALETTE = ["#9b7bff", "#4b2fb0"];
+const FIRE_PATCH_PALETTE = ["#ff7a1a", "#ffb347", "#ffd27a"];
+
+// Fire patches burn across their whole radius rather than at a point.
+const translateFirePatch: Translator = (input) =>
+  translateFire({
+    ...input,
+    parameters: { spawnRadius: positiveOr(input.radius, FireEffectDefinition.defaults.spawnRadius), ...input.parameters },
+  });
+
 const TRANSLATORS: Record<string, Translator> = {
   "melee/swing": translateMeleeSwing,
   "visual/blood-splatter": translateBloodSplatter,
   "status/burning-visual": translateFire,
   "status/burning-tick": translateImpactBurst,
   "projectile/fireball": translateFireball,
+  "beam/ray": translateBeam,
+  "status/heal": withPalette(translateImpactBurst, HEAL_PALETTE),
+  "status/heal-burst": withPalette(translateImpactBurst, HEAL_PALETTE),
+  "area/gravity-well": withPalette(translatePlaceholder, GRAVITY_PALETTE),
+  "area/explosion": withPalette(translateImpactBurst, EXPLOSION_PALETTE),
+  "area/fire-patch": withPalette(translateFirePatch, FIRE_PATCH_PALETTE),
 };
 
 const CONTRACT_FALLBACKS: Record<string, string> = {
@@ -1,3 +1,3 @@
 // Code generated by effectsgen. DO NOT EDIT.
 
-export const effectCatalogHash = "aec27f3554bbf8e91d782cb8cede07eac44e3e0ba490e785225ca49f5fdcd3bc" as const;
+export const effectCatalogHash = "016b3d94049a06245f24d420df54668889a387562bb962329bd7102ae7c9cc6c" as const;
@@ -17,9 +17,14 @@ export interface EffectDefinition {
   readonly impact: ImpactPolicy;
   readonly lifetimeTicks: number;
   readonly pierceCount?: number;
+  readonly damageType?: DamageType;
+  readonly falloff?: DamageFalloff;
+  readonly lineOfSight?: boolean;
   readonly params?: Readonly<Record<string, number>>;
+  readonly geometry?: SpawnGeometry;
   readonly hooks: EffectHooks;
   readonly client: ReplicationSpec;
+  readonly presentation?: Presentation;
   readonly end: EndPolicy;
 }
 
@@ -74,6 +79,10 @@ export interface EffectMotionState {
   readonly velocityY: number;
   readonly rangeRemaining?: number;
   readonly travelledLength?: number;
+  readonly targetX?: number;
+  readonly targetY?: number;
+  readonly arcDistance?: number;
+  readonly altitude?: number;
 }
 
 export interface EndConditions {
@@ -105,6 +114,11 @@ export interface InstanceUpdatePayload {
   readonly params?: Readonly<Record<string, number>>;
 }
 
+export interface Presentation {
+  readonly soundId?: string;
+  readonly particles?: string;
+}
+
 export interface ReplicationSpec {
   readonly sendSpawn: boolean;
   readonly sendUpdates: boolean;
@@ -113,12 +127,22 @@ export interface ReplicationSpec {
   readonly updateFields?: Readonly<Record<string, boolean>>;
 }
 
+export interface SpawnGeometry {
+  readonly spawnOffset?: number;
+}
+
 export type AttackEndPayload = InstanceEndPayload;
 
 export type AttackSpawnPayload = InstanceSpawnPayload;
 
 export type AttackUpdatePayload = InstanceUpdatePayload;
 
+export type BeamEndPayload = InstanceEndPayload;
+
+export type BeamSpawnPayload = InstanceSpawnPayload;
+
+export type BeamUpdatePayload = InstanceUpdatePayload;
+
 export type BloodSplatterEndPayload = InstanceEndPayload;
 
 export type BloodSplatterSpawnPayload = InstanceSpawnPayload;
@@ -137,32 +161,89 @@ export type BurningVisualSpawnPayload = InstanceSpawnPayload;
 
 export type BurningVisualUpdatePayload = InstanceUpdatePayload;
 
-export type DeliveryKind = "area" | "target" | "visual";
+export type DamageFalloff = "linear" | "quadratic";
+
+export type DamageType = "fire" | "physical" | "poison";
+
+export type DeliveryKind = "area" | "beam" | "target" | "visual";
 
 export type EndPolicyKind = 0 | 1 | 2;
 
 export type EndReason = "cancelled" | "expired" | "mapChange" | "ownerLost";
 
+export type ExplosionEndPayload = InstanceEndPayload;
+
+export type ExplosionSpawnPayload = InstanceSpawnPayload;
+
+export type ExplosionUpdatePayload = InstanceUpdatePayload;
+
+export type FirePatchEndPayload = InstanceEndPayload;
+
+export type FirePatchSpawnPayload = InstanceSpawnPayload;
+
+export type FirePatchUpdatePayload = InstanceUpdatePayload;
+
 export type FireballEndPayload = InstanceEndPayload;
 
 export type FireballSpawnPayload = InstanceSpawnPayload;
 
 export type FireballUpdatePayload = InstanceUpdatePayload;
 
+export type FirebombEndPayload = InstanceEndPayload;
+
+export type FirebombSpawnPayload = InstanceSpawnPayload;
+
+export type FirebombUpdatePayload = InstanceUpdatePayload;
+
 export type FollowMode = "none" | "owner" | "target";
 
 export type GeometryShape = "arc" | "capsule" | "circle" | "rect" | "segment";
 
+export type GravityWellEndPayload = InstanceEndPayload;
+
+export type GravityWellSpawnPayload = InstanceSpawnPayload;
+
+export type GravityWellUpdatePayload = InstanceUpdatePayload;
+
+export type HealBurstEndPayload = InstanceEndPayload;
+
+export type HealBurstSpawnPayload = InstanceSpawnPayload;
+
+export type HealBurstUpdatePayload = InstanceUpdatePayload;
+
+export type HealEndPayload = InstanceEndPayload;
+
+export type HealSpawnPayload = InstanceSpawnPayload;
+
+export type HealUpdatePayload = InstanceUpdatePayload;
+
 export type ImpactPolicy = "all-in-path" | "first-hit" | "none" | "pierce";
 
 export type MotionKind = "follow" | "instant" | "linear" | "none" | "parabolic";
 
+export type RicochetEndPayload = InstanceEndPayload;
+
+export type RicochetSpawnPayload = InstanceSpawnPayload;
+
+export type RicochetUpdatePayload = InstanceUpdatePayload;
+
+export type SeekerEndPayload = InstanceEndPayload;
+
+export type SeekerSpawnPayload = InstanceSpawnPayload;
+
+export type SeekerUpdatePayload = InstanceUpdatePayload;
+
 export type EffectContractMap = {
   readonly "attack": {
     readonly spawn: AttackSpawnPayload;
     readonly update: AttackUpdatePayload;
     readonly end: AttackEndPayload;
   };
+  readonly "beam": {
+    readonly spawn: BeamSpawnPayload;
+    readonly update: BeamUpdatePayload;
+    readonly end: BeamEndPayload;
+  };
   readonly "blood-splatter": {
     readonly spawn: BloodSplatterSpawnPayload;
     readonly update: BloodSplatterUpdatePayload;
@@ -173,16 +254,56 @@ export type EffectContractMap = {
     readonly update: BurningTickUpdatePayload;
     readonly end: BurningTickEndPayload;
   };
+  readonly "explosion": {
+    readonly spawn: ExplosionSpawnPayload;
+    readonly update: ExplosionUpdatePayload;
+    readonly end: ExplosionEndPayload;
+  };
   readonly "fire": {
     readonly spawn: BurningVisualSpawnPayload;
     readonly update: BurningVisualUpdatePayload;
     readonly end: BurningVisualEndPayload;
   };
+  readonly "fire-patch": {
+    readonly spawn: FirePatchSpawnPayload;
+    readonly update: FirePatchUpdatePayload;
+    readonly end: FirePatchEndPayload;
+  };
   readonly "fireball": {
     readonly spawn: FireballSpawnPayload;
     readonly update: FireballUpdatePayload;
     readonly end: FireballEndPayload;
   };
+  readonly "firebomb": {
+    readonly spawn: FirebombSpawnPayload;
+    readonly update: FirebombUpdatePayload;
+    readonly end: FirebombEndPayload;
+  };
+  readonly "gravity-well": {
+    readonly spawn: GravityWellSpawnPayload;
+    readonly update: GravityWellUpdatePayload;
+    readonly end: GravityWellEndPayload;
+  };
+  readonly "heal": {
+    readonly spawn: HealSpawnPayload;
+    readonly update: HealUpdatePayload;
+    readonly end: HealEndPayload;
+  };
+  readonly "heal-burst": {
+    readonly spawn: HealBurstSpawnPayload;
+    readonly update: HealBurstUpdatePayload;
+    readonly end: HealBurstEndPayload;
+  };
+  readonly "ricochet": {
+    readonly spawn: RicochetSpawnPayload;
+    readonly update: RicochetUpdatePayload;
+    readonly end: RicochetEndPayload;
+  };
+  readonly "seeker": {
+    readonly spawn: SeekerSpawnPayload;
+    readonly update: SeekerUpdatePayload;
+    readonly end: SeekerEndPayload;
+  };
 };
 
 export type EffectContractID = keyof EffectContractMap;
@@ -225,6 +346,19 @@ export const effectContracts = {
       hasPayload: true,
     },
   },
+  "beam": {
+    id: "beam",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
   "blood-splatter": {
     id: "blood-splatter",
     managedByClient: true,
@@ -251,6 +385,19 @@ export const effectContracts = {
       hasPayload: true,
     },
   },
+  "explosion": {
+    id: "explosion",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
   "fire": {
     id: "fire",
     managedByClient: false,
@@ -264,6 +411,19 @@ export const effectContracts = {
       hasPayload: true,
     },
   },
+  "fire-patch": {
+    id: "fire-patch",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
   "fireball": {
     id: "fireball",
     managedByClient: false,
@@ -277,6 +437,84 @@ export const effectContracts = {
       hasPayload: true,
     },
   },
+  "firebomb": {
+    id: "firebomb",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
+  "gravity-well": {
+    id: "gravity-well",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
+  "heal": {
+    id: "heal",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
+  "heal-burst": {
+    id: "heal-burst",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
+  "ricochet": {
+    id: "ricochet",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
+  "seeker": {
+    id: "seeker",
+    managedByClient: false,
+    spawn: {
+      hasPayload: true,
+    },
+    update: {
+      hasPayload: true,
+    },
+    end: {
+      hasPayload: true,
+    },
+  },
 } as const satisfies EffectContractMetadataMap;
 
 export type EffectContracts = typeof effectContracts;
@@ -305,6 +543,10 @@ export const effectCatalog = {
         "motion": "instant",
         "impact": "all-in-path",
         "lifetimeTicks": 1,
+        "damageType": "physical",
+        "geometry": {
+          "spawnOffset": 14
+        },
         "hooks": {
           "onSpawn": "melee.spawn"
         },
@@ -326,6 +568,40 @@ export const effectCatalog = {
         },
     },
   },
+  "beam": {
+    "contractId": "beam",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "beam",
+        "delivery": "beam",
+        "shape": "segment",
+        "motion": "instant",
+        "impact": "first-hit",
+        "lifetimeTicks": 15,
+        "damageType": "fire",
+        "hooks": {
+          "onTick": "beam.tick"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": true,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0,
+          "conditions": {
+            "onExplicitCancel": true
+          }
+        }
+      },
+    "blocks": {
+      "jsEffect": "beam/ray",
+      "parameters": {
+          "damage": 4,
+          "range": 240
+        },
+    },
+  },
   "blood-splatter": {
     "contractId": "blood-splatter",
     "managedByClient": true,
@@ -349,6 +625,10 @@ export const effectCatalog = {
         }
       },
     "blocks": {
+      "colors": [
+          "#7a0e12",
+          "#4a090b"
+        ],
       "jsEffect": "visual/blood-splatter",
       "parameters": {
           "drops": 33
@@ -365,6 +645,7 @@ export const effectCatalog = {
         "motion": "instant",
         "impact": "first-hit",
         "lifetimeTicks": 1,
+        "damageType": "fire",
         "hooks": {
           "onSpawn": "status.burning.tick"
         },
@@ -384,6 +665,39 @@ export const effectCatalog = {
         },
     },
   },
+  "explosion": {
+    "contractId": "explosion",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "explosion",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "instant",
+        "impact": "all-in-path",
+        "lifetimeTicks": 1,
+        "damageType": "fire",
+        "falloff": "linear",
+        "lineOfSight": true,
+        "hooks": {
+          "onSpawn": "area.explosion"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": false,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 1
+        }
+      },
+    "blocks": {
+      "jsEffect": "area/explosion",
+      "parameters": {
+          "damage": 30,
+          "radius": 100
+        },
+    },
+  },
   "fire": {
     "contractId": "fire",
     "managedByClient": false,
@@ -414,6 +728,39 @@ export const effectCatalog = {
         },
     },
   },
+  "fire-patch": {
+    "contractId": "fire-patch",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "fire-patch",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "instant",
+        "impact": "all-in-path",
+        "lifetimeTicks": 150,
+        "damageType": "fire",
+        "hooks": {
+          "onSpawn": "area.hazard.pulse",
+          "onTick": "area.hazard.pulse"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": false,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0
+        }
+      },
+    "blocks": {
+      "jsEffect": "area/fire-patch",
+      "parameters": {
+          "damage": 6,
+          "interval": 5,
+          "radius": 50
+        },
+    },
+  },
   "fireball": {
     "contractId": "fireball",
     "managedByClient": false,
@@ -424,6 +771,10 @@ export const effectCatalog = {
         "motion": "linear",
         "impact": "first-hit",
         "lifetimeTicks": 45,
+        "damageType": "fire",
+        "geometry": {
+          "spawnOffset": 20
+        },
         "hooks": {
           "onSpawn": "projectile.fireball.lifecycle",
           "onTick": "projectile.fireball.lifecycle"
@@ -433,6 +784,10 @@ export const effectCatalog = {
           "sendUpdates": true,
           "sendEnd": true
         },
+        "presentation": {
+          "soundId": "sfx/fireball-cast",
+          "particles": "embers"
+        },
         "end": {
           "kind": 0
         }
@@ -446,6 +801,205 @@ export const effectCatalog = {
         },
     },
   },
+  "firebomb": {
+    "contractId": "firebomb",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "firebomb",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "parabolic",
+        "impact": "all-in-path",
+        "lifetimeTicks": 30,
+        "damageType": "fire",
+        "geometry": {
+          "spawnOffset": 20
+        },
+        "hooks": {
+          "onSpawn": "projectile.fireball.lifecycle",
+          "onTick": "projectile.fireball.lifecycle"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": true,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0
+        }
+      },
+    "blocks": {
+      "jsEffect": "projectile/fireball",
+      "parameters": {
+          "speed": 200,
+          "range": 240,
+          "radius": 16
+        },
+    },
+  },
+  "gravity-well": {
+    "contractId": "gravity-well",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "gravity-well",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "instant",
+        "impact": "all-in-path",
+        "lifetimeTicks": 30,
+        "hooks": {
+          "onSpawn": "area.gravity.pull",
+          "onTick": "area.gravity.pull"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": false,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0
+        }
+      },
+    "blocks": {
+      "jsEffect": "area/gravity-well",
+      "parameters": {
+          "pull": 8,
+          "radius": 160
+        },
+    },
+  },
+  "heal": {
+    "contractId": "heal",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "heal",
+        "delivery": "target",
+        "shape": "rect",
+        "motion": "instant",
+        "impact": "first-hit",
+        "lifetimeTicks": 1,
+        "hooks": {
+          "onSpawn": "target.heal"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": false,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 1
+        }
+      },
+    "blocks": {
+      "jsEffect": "status/heal",
+      "parameters": {
+          "heal": 20,
+          "range": 160
+        },
+    },
+  },
+  "heal-burst": {
+    "contractId": "heal-burst",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "heal-burst",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "instant",
+        "impact": "all-in-path",
+        "lifetimeTicks": 1,
+        "hooks": {
+          "onSpawn": "area.heal.burst"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": false,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 1
+        }
+      },
+    "blocks": {
+      "jsEffect": "status/heal-burst",
+      "parameters": {
+          "heal": 12,
+          "radius": 96
+        },
+    },
+  },
+  "ricochet": {
+    "contractId": "ricochet",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "ricochet",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "linear",
+        "impact": "first-hit",
+        "lifetimeTicks": 30,
+        "damageType": "physical",
+        "geometry": {
+          "spawnOffset": 20
+        },
+        "hooks": {
+          "onSpawn": "projectile.fireball.lifecycle",
+          "onTick": "projectile.fireball.lifecycle"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": true,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0
+        }
+      },
+    "blocks": {
+      "jsEffect": "projectile/fireball",
+      "parameters": {
+          "speed": 320,
+          "range": 320,
+          "radius": 8
+        },
+    },
+  },
+  "seeker": {
+    "contractId": "seeker",
+    "managedByClient": false,
+    "definition": {
+        "typeId": "seeker",
+        "delivery": "area",
+        "shape": "circle",
+        "motion": "linear",
+        "impact": "first-hit",
+        "lifetimeTicks": 45,
+        "damageType": "physical",
+        "geometry": {
+          "spawnOffset": 20
+        },
+        "hooks": {
+          "onSpawn": "projectile.fireball.lifecycle",
+          "onTick": "projectile.fireball.lifecycle"
+        },
+        "client": {
+          "sendSpawn": true,
+          "sendUpdates": true,
+          "sendEnd": true
+        },
+        "end": {
+          "kind": 0
+        }
+      },
+    "blocks": {
+      "jsEffect": "projectile/fireball",
+      "parameters": {
+          "speed": 240,
+          "range": 240,
+          "radius": 10
+        },
+    },
+  },
 } as const satisfies Record<string, EffectCatalogEntry>;
 
 export type EffectCatalog = typeof effectCatalog;
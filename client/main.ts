@@ -25,8 +25,10 @@ import { InMemoryWorldStateStore } from "./world-state";
 setBasePath("https://cdn.jsdelivr.net/npm/@shoelace-style/shoelace@2.20.1/dist/");
 
 const HEALTH_CHECK_URL = "/health";
-const JOIN_URL = "/join";
-const WEBSOCKET_URL = "/ws";
+const ROOM_NAME = new URLSearchParams(window.location.search).get("room");
+const ROOM_QUERY = ROOM_NAME ? `?room=${encodeURIComponent(ROOM_NAME)}` : "";
+const JOIN_URL = `/join${ROOM_QUERY}`;
+const WEBSOCKET_URL = `/ws${ROOM_QUERY}`;
 const HEARTBEAT_INTERVAL_MS = 2000;
 const PROTOCOL_VERSION = 1;
 
@@ -51,12 +51,15 @@ export interface ActorSnapshot {
   readonly facing?: string;
   readonly health?: number;
   readonly maxHealth?: number;
+  readonly absorb?: number;
   readonly inventory?: Record<string, unknown>;
   readonly equipment?: Record<string, unknown>;
   readonly [key: string]: unknown;
 }
 
-export interface PlayerSnapshot extends ActorSnapshot {}
+export interface PlayerSnapshot extends ActorSnapshot {
+  readonly name?: string;
+}
 
 export interface NPCSnapshot extends ActorSnapshot {
   readonly type?: string;
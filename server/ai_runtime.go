@@ -28,6 +28,10 @@ func (w *World) runAI(tick uint64, now time.Time) []Command {
 				X: &npc.X,
 				Y: &npc.Y,
 			},
+			Health: ai.HealthRef{
+				Current: &npc.Health,
+				Max:     &npc.MaxHealth,
+			},
 			Facing: ai.FacingAdapter{
 				Get: func() string { return string(npc.Facing) },
 				Set: func(value string) {
@@ -37,6 +41,7 @@ func (w *World) runAI(tick uint64, now time.Time) []Command {
 			Waypoints:  (*[]ai.Vec2)(&npc.Waypoints),
 			Home:       (*ai.Vec2)(&npc.Home),
 			Blackboard: &npc.Blackboard,
+			OwnerID:    npc.OwnerID,
 			Hooks: ai.NPCHooks{
 				ClearPath: func() { w.clearNPCPath(npc) },
 				EnsurePath: func(target ai.Vec2, tick uint64) bool {
@@ -68,6 +73,9 @@ func (w *World) runAI(tick uint64, now time.Time) []Command {
 		Library: w.aiLibrary,
 		NPCs:    aiNPCs,
 		Players: players,
+
+		DecisionBudget: w.aiDecisionBudget,
+		Cursor:         &w.aiCursor,
 		RandomAngle: func() float64 {
 			return w.randomAngle()
 		},
@@ -90,13 +98,16 @@ func (w *World) runAI(tick uint64, now time.Time) []Command {
 		AbilityCooldown: func(id ai.AbilityID) uint64 {
 			switch id {
 			case ai.AbilityAttack:
-				return uint64(math.Ceil(meleeAttackCooldown.Seconds() * float64(tickRate)))
+				return uint64(math.Ceil(meleeAttackCooldown.Seconds() * float64(w.ticksPerSecond())))
 			case ai.AbilityFireball:
-				return uint64(math.Ceil(fireballCooldown.Seconds() * float64(tickRate)))
+				return uint64(math.Ceil(fireballCooldown.Seconds() * float64(w.ticksPerSecond())))
 			default:
 				return 0
 			}
 		},
+		Hostile: func(actorID, otherID string) bool {
+			return !w.sameFaction(actorID, otherID)
+		},
 	}
 
 	aiCommands := ai.Run(runCfg)
@@ -111,6 +122,19 @@ func (w *World) runAI(tick uint64, now time.Time) []Command {
 	return commands
 }
 
+// SetAIDecisionBudget caps how many NPCs run their AI state machine each tick.
+// NPCs over the budget keep moving on their current intent and are served
+// round-robin on later ticks. Zero or negative values restore the default.
+func (w *World) SetAIDecisionBudget(budget int) {
+	if w == nil {
+		return
+	}
+	if budget < 0 {
+		budget = 0
+	}
+	w.aiDecisionBudget = budget
+}
+
 func convertAICommand(cmd ai.Command) Command {
 	result := Command{
 		OriginTick: cmd.OriginTick,
@@ -1,12 +1,15 @@
 package server
 
 import (
+	"fmt"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
 	ai "mine-and-die/server/internal/ai"
 	worldpkg "mine-and-die/server/internal/world"
+	"mine-and-die/server/logging"
 	stats "mine-and-die/server/stats"
 )
 
@@ -351,6 +354,355 @@ func TestGoblinReturnsToPatrolAfterLosingPlayer(t *testing.T) {
 	}
 }
 
+func TestGoblinTransitionsIdleChaseAttackWhenPlayerApproaches(t *testing.T) {
+	w, npc := newStaticAIWorld()
+	if npc == nil {
+		t.Fatalf("expected goblin NPC")
+	}
+	cfg := w.aiLibrary.ConfigForType(string(NPCTypeGoblin))
+	idleStateID, ok := cfg.StateForBehavior(ai.BehaviorIdle)
+	if !ok {
+		t.Fatalf("expected goblin config to declare an idle state")
+	}
+	npc.AIState = idleStateID
+
+	behavior := func() ai.Behavior {
+		t.Helper()
+		b, ok := cfg.StateBehavior(npc.AIState)
+		if !ok {
+			t.Fatalf("goblin state %d has no behavior", npc.AIState)
+		}
+		return b
+	}
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	tick := uint64(0)
+	step := func() {
+		tick++
+		w.Step(tick, now, dt, nil, nil)
+		now = now.Add(time.Second / tickRate)
+	}
+
+	for i := 0; i < 5; i++ {
+		step()
+	}
+	if got := behavior(); got != ai.BehaviorIdle {
+		t.Fatalf("expected goblin to idle without players nearby, got %s", got)
+	}
+
+	player := &playerState{
+		ActorState: actorState{
+			Actor: Actor{
+				ID:        "player-approach",
+				X:         npc.X + 120,
+				Y:         npc.Y,
+				Facing:    defaultFacing,
+				Health:    baselinePlayerMaxHealth,
+				MaxHealth: baselinePlayerMaxHealth,
+				Inventory: NewInventory(),
+			},
+		},
+		Stats: stats.DefaultComponent(stats.ArchetypePlayer),
+	}
+	w.players[player.ID] = player
+
+	seen := []ai.Behavior{behavior()}
+	for i := 0; i < 200 && seen[len(seen)-1] != ai.BehaviorAttack; i++ {
+		step()
+		if current := behavior(); current != seen[len(seen)-1] {
+			seen = append(seen, current)
+		}
+	}
+
+	want := []ai.Behavior{ai.BehaviorIdle, ai.BehaviorChase, ai.BehaviorAttack}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Fatalf("expected goblin behaviors %v, got %v", want, seen)
+	}
+	if npc.Blackboard.TargetActorID != player.ID {
+		t.Fatalf("expected goblin to attack %q, tracking %q", player.ID, npc.Blackboard.TargetActorID)
+	}
+	if npc.Facing != FacingRight {
+		t.Fatalf("expected goblin to face the player before attacking, got %q", npc.Facing)
+	}
+}
+
+func TestGoblinFleesFromAttackerAtLowHealth(t *testing.T) {
+	w, npc := newStaticAIWorld()
+	if npc == nil {
+		t.Fatalf("expected goblin NPC")
+	}
+	cfg := w.aiLibrary.ConfigForType(string(NPCTypeGoblin))
+
+	player := &playerState{
+		ActorState: actorState{
+			Actor: Actor{
+				ID:        "player-attacker",
+				X:         npc.X + 120,
+				Y:         npc.Y,
+				Facing:    defaultFacing,
+				Health:    baselinePlayerMaxHealth,
+				MaxHealth: baselinePlayerMaxHealth,
+				Inventory: NewInventory(),
+			},
+		},
+		Stats: stats.DefaultComponent(stats.ArchetypePlayer),
+	}
+	w.players[player.ID] = player
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	tick := uint64(0)
+	step := func() {
+		tick++
+		w.Step(tick, now, dt, nil, nil)
+		now = now.Add(time.Second / tickRate)
+	}
+	behavior := func() ai.Behavior {
+		b, _ := cfg.StateBehavior(npc.AIState)
+		return b
+	}
+	distance := func() float64 {
+		return math.Hypot(npc.X-player.X, npc.Y-player.Y)
+	}
+
+	for i := 0; i < 200 && behavior() != ai.BehaviorChase; i++ {
+		step()
+	}
+	if behavior() != ai.BehaviorChase {
+		t.Fatalf("expected healthy goblin to chase the player, got %s", behavior())
+	}
+	for i := 0; i < 10; i++ {
+		step()
+	}
+	closing := distance()
+	if closing >= 120 {
+		t.Fatalf("expected healthy goblin to close in on the player, distance %.2f", closing)
+	}
+
+	npc.Health = npc.MaxHealth * 0.2
+	for i := 0; i < 10 && behavior() != ai.BehaviorFlee; i++ {
+		step()
+	}
+	if behavior() != ai.BehaviorFlee {
+		t.Fatalf("expected wounded goblin to flee, got %s", behavior())
+	}
+	if npc.Blackboard.TargetActorID != player.ID {
+		t.Fatalf("expected goblin to flee from %q, tracking %q", player.ID, npc.Blackboard.TargetActorID)
+	}
+
+	fleeStart := distance()
+	for i := 0; i < 45; i++ {
+		step()
+	}
+	if got := distance(); got <= fleeStart+20 {
+		t.Fatalf("expected wounded goblin to move away from the attacker (%.2f -> %.2f)", fleeStart, got)
+	}
+	if npc.X >= player.X {
+		t.Fatalf("expected goblin to flee away from the attacker, ended at x=%.2f with attacker at x=%.2f", npc.X, player.X)
+	}
+}
+
+func TestAttackingGoblinAlertsAdjacentPackMate(t *testing.T) {
+	hub := newHubWithFullWorld()
+	w := hub.world
+	w.obstacles = nil
+	for id := range w.npcs {
+		delete(w.npcs, id)
+	}
+
+	attacker := newTestPlayerState("player-aggro")
+	attacker.X = 200
+	attacker.Y = 300
+	w.AddPlayer(attacker)
+
+	w.spawnGoblinAt(attacker.X+40, attacker.Y, nil)
+	w.spawnGoblinAt(attacker.X+170, attacker.Y, nil)
+	struck := w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID-1)]
+	ally := w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID)]
+	if struck == nil || ally == nil {
+		t.Fatalf("expected two goblins to spawn")
+	}
+	cfg := w.aiLibrary.ConfigForType(string(NPCTypeGoblin))
+	behavior := func(npc *npcState) ai.Behavior {
+		b, _ := cfg.StateBehavior(npc.AIState)
+		return b
+	}
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	tick := uint64(0)
+	step := func() {
+		tick++
+		w.Step(tick, now, dt, nil, nil)
+		now = now.Add(time.Second / tickRate)
+	}
+	for i := 0; i < 10; i++ {
+		step()
+	}
+	if got := behavior(ally); got == ai.BehaviorChase {
+		t.Fatalf("expected the distant goblin to ignore the player before the attack")
+	}
+
+	eff := &effectState{Type: effectTypeAttack, Owner: attacker.ID, Params: map[string]float64{"healthDelta": -1}}
+	w.invokeNPCHitCallback(eff, struck, now)
+	step()
+
+	for _, npc := range []*npcState{struck, ally} {
+		if npc.Blackboard.TargetActorID != attacker.ID {
+			t.Fatalf("expected %s to target the attacker, tracking %q", npc.ID, npc.Blackboard.TargetActorID)
+		}
+	}
+	if got := behavior(ally); got != ai.BehaviorChase {
+		t.Fatalf("expected the pack mate to chase the attacker, got %s", got)
+	}
+	if got := behavior(struck); got != ai.BehaviorChase && got != ai.BehaviorAttack {
+		t.Fatalf("expected the struck goblin to engage the attacker, got %s", got)
+	}
+
+	start := math.Hypot(ally.X-attacker.X, ally.Y-attacker.Y)
+	for i := 0; i < 15; i++ {
+		step()
+	}
+	if got := math.Hypot(ally.X-attacker.X, ally.Y-attacker.Y); got >= start {
+		t.Fatalf("expected the pack mate to close in on the attacker (%.2f -> %.2f)", start, got)
+	}
+}
+
+func TestTauntPullsNearbyGoblinsOffAnotherPlayer(t *testing.T) {
+	hub := newHubWithFullWorld()
+	w := hub.world
+	w.obstacles = nil
+	for id := range w.npcs {
+		delete(w.npcs, id)
+	}
+
+	victim := newTestPlayerState("player-victim")
+	victim.X = 200
+	victim.Y = 300
+	w.AddPlayer(victim)
+	tank := newTestPlayerState("player-tank")
+	tank.X = 360
+	tank.Y = 300
+	w.AddPlayer(tank)
+
+	w.spawnGoblinAt(victim.X+50, victim.Y, nil)
+	w.spawnGoblinAt(victim.X+50, victim.Y+40, nil)
+	goblins := []*npcState{
+		w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID-1)],
+		w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID)],
+	}
+	for _, goblin := range goblins {
+		if goblin == nil {
+			t.Fatalf("expected two goblins to spawn")
+		}
+	}
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	tick := uint64(0)
+	step := func(commands []Command) {
+		tick++
+		w.Step(tick, now, dt, commands, nil)
+		now = now.Add(time.Second / tickRate)
+	}
+	for i := 0; i < 10; i++ {
+		step(nil)
+	}
+	for _, goblin := range goblins {
+		if goblin.Blackboard.TargetActorID != victim.ID {
+			t.Fatalf("expected %s to engage the nearest player first, tracking %q", goblin.ID, goblin.Blackboard.TargetActorID)
+		}
+	}
+
+	step([]Command{{
+		ActorID:  tank.ID,
+		Type:     CommandAction,
+		IssuedAt: now,
+		Action:   &ActionCommand{Name: actionTaunt},
+	}})
+	for i := 0; i < 30; i++ {
+		step(nil)
+	}
+
+	for _, goblin := range goblins {
+		if goblin.Blackboard.TargetActorID != tank.ID {
+			t.Fatalf("expected %s to be pulled onto the taunting player, tracking %q", goblin.ID, goblin.Blackboard.TargetActorID)
+		}
+		if math.Hypot(goblin.X-tank.X, goblin.Y-tank.Y) >= math.Hypot(goblin.X-victim.X, goblin.Y-victim.Y) {
+			t.Fatalf("expected %s to close in on the taunting player", goblin.ID)
+		}
+	}
+}
+
+func TestSummonedFamiliarFightsGoblinUntilLifetimeEnds(t *testing.T) {
+	hub := newHubWithFullWorld()
+	w := hub.world
+	w.obstacles = nil
+	for id := range w.npcs {
+		delete(w.npcs, id)
+	}
+
+	owner := newTestPlayerState("player-summoner")
+	owner.X = 200
+	owner.Y = 300
+	owner.Facing = FacingRight
+	w.AddPlayer(owner)
+
+	w.spawnGoblinAt(owner.X+200, owner.Y, nil)
+	goblin := w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID)]
+	if goblin == nil {
+		t.Fatalf("expected goblin to spawn")
+	}
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	tick := uint64(0)
+	step := func(commands []Command) {
+		tick++
+		w.Step(tick, now, dt, commands, nil)
+		now = now.Add(time.Second / tickRate)
+	}
+	step([]Command{{
+		ActorID:  owner.ID,
+		Type:     CommandAction,
+		IssuedAt: now,
+		Action:   &ActionCommand{Name: actionSummon},
+	}})
+
+	summons := w.summonsOf(owner.ID)
+	if len(summons) != 1 {
+		t.Fatalf("expected one familiar, got %v", summons)
+	}
+	familiar := w.npcs[summons[0]]
+	if !w.sameFaction(owner.ID, familiar.ID) || w.sameFaction(familiar.ID, goblin.ID) {
+		t.Fatalf("expected the familiar to fight for its owner's faction")
+	}
+
+	for i := 0; i < 90; i++ {
+		step(nil)
+	}
+	if familiar.Blackboard.TargetActorID != goblin.ID {
+		t.Fatalf("expected the familiar to target the goblin, tracking %q", familiar.Blackboard.TargetActorID)
+	}
+	if goblin.Health >= goblin.MaxHealth {
+		t.Fatalf("expected the familiar to wound the goblin")
+	}
+	if owner.Health != owner.MaxHealth {
+		t.Fatalf("expected the familiar to spare its owner, health %.1f", owner.Health)
+	}
+
+	for tick < familiar.DespawnTick {
+		if _, ok := w.npcs[familiar.ID]; !ok {
+			t.Fatalf("expected the familiar to last until its lifetime ends (tick %d of %d)", tick, familiar.DespawnTick)
+		}
+		step(nil)
+	}
+	if _, ok := w.npcs[familiar.ID]; ok {
+		t.Fatalf("expected the familiar to despawn once its lifetime ended")
+	}
+}
+
 func TestGoblinAdvancesWhenWaypointBlocked(t *testing.T) {
 	w, npc := newStaticAIWorld()
 	if npc == nil {
@@ -528,3 +880,252 @@ func TestRatFleesFromNearbyThreat(t *testing.T) {
 		t.Fatalf("expected rat to increase distance from threat (%.2f -> %.2f)", entryDist, finalDist)
 	}
 }
+
+func TestSeededHubDrivesGoblinPatrolDeterministically(t *testing.T) {
+	oneGoblin := func(cfg *worldConfig) {
+		cfg.NPCs = true
+		cfg.GoblinCount = 1
+		cfg.RatCount = 0
+		cfg.NPCCount = 1
+	}
+	run := func() (vec2, int64) {
+		hub, rng := newSeededTestHub("seeded-goblin", oneGoblin)
+		goblinID := fmt.Sprintf("npc-goblin-%d", hub.world.nextNPCID)
+		goblin, ok := hub.world.npcs[goblinID]
+		if !ok {
+			t.Fatalf("expected %s to be spawned, have %d npcs", goblinID, len(hub.world.npcs))
+		}
+		start := vec2{X: goblin.X, Y: goblin.Y}
+
+		now := time.Unix(0, 0)
+		dt := 1.0 / float64(tickRate)
+		for i := 0; i < 60; i++ {
+			now = now.Add(time.Second / time.Duration(tickRate))
+			hub.advance(now, dt)
+		}
+		end := vec2{X: goblin.X, Y: goblin.Y}
+		if end == start {
+			t.Fatalf("expected goblin to patrol away from %+v", start)
+		}
+		return end, rng.Int63()
+	}
+
+	firstPos, firstDraw := run()
+	secondPos, secondDraw := run()
+	if firstPos != secondPos {
+		t.Fatalf("expected identical goblin positions for the same seed, got %+v and %+v", firstPos, secondPos)
+	}
+	if firstDraw != secondDraw {
+		t.Fatalf("expected identical RNG streams for the same seed, got %d and %d", firstDraw, secondDraw)
+	}
+}
+
+func TestConfiguredPatrolRouteOscillatesBetweenPoints(t *testing.T) {
+	route := PatrolRoute{Waypoints: []PatrolPoint{{X: 400, Y: 400}, {X: 640, Y: 400}}}
+	hub, _ := newSeededTestHub("patrol-route", func(cfg *worldConfig) {
+		cfg.NPCs = true
+		cfg.GoblinCount = 1
+		cfg.RatCount = 0
+		cfg.NPCCount = 1
+		cfg.PatrolRoutes = []PatrolRoute{route}
+	})
+	goblin, ok := hub.world.npcs[fmt.Sprintf("npc-goblin-%d", hub.world.nextNPCID)]
+	if !ok {
+		t.Fatalf("expected configured goblin to spawn")
+	}
+	if goblin.X != 400 || goblin.Y != 400 {
+		t.Fatalf("expected goblin to spawn on the first patrol point, got (%.1f, %.1f)", goblin.X, goblin.Y)
+	}
+	if len(goblin.Waypoints) != 2 {
+		t.Fatalf("expected goblin to walk the configured two-point route, got %v", goblin.Waypoints)
+	}
+
+	const arrive = 24.0
+	var visits []int
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+	for i := 0; i < 900 && len(visits) < 4; i++ {
+		now = now.Add(time.Second / time.Duration(tickRate))
+		hub.advance(now, dt)
+		for idx, point := range route.Waypoints {
+			if math.Hypot(goblin.X-point.X, goblin.Y-point.Y) > arrive {
+				continue
+			}
+			if len(visits) == 0 || visits[len(visits)-1] != idx {
+				visits = append(visits, idx)
+			}
+		}
+	}
+	if len(visits) < 4 {
+		t.Fatalf("expected goblin to oscillate between the patrol points, visited %v", visits)
+	}
+	for i, idx := range visits {
+		if idx != (visits[0]+i)%2 {
+			t.Fatalf("expected alternating patrol visits, got %v", visits)
+		}
+	}
+	if math.Abs(goblin.Y-400) > arrive {
+		t.Fatalf("expected goblin to stay on the patrol line, drifted to y=%.1f", goblin.Y)
+	}
+
+	roundTrip := legacyWorldConfigFromSim(simWorldConfigFromLegacy(hub.config))
+	if !reflect.DeepEqual(roundTrip.PatrolRoutes, hub.config.PatrolRoutes) {
+		t.Fatalf("expected patrol routes to survive the snapshot config, got %+v", roundTrip.PatrolRoutes)
+	}
+}
+
+func TestBossGainsRangedAbilityOnlyAfterPhaseThreshold(t *testing.T) {
+	hub, _ := newSeededTestHub("boss-phases")
+	w := hub.world
+	for id := range w.npcs {
+		delete(w.npcs, id)
+	}
+	boss := w.spawnBossAt(400, 400)
+
+	player := newTestPlayerState("player-boss")
+	player.X = boss.X + 150
+	player.Y = boss.Y
+	w.AddPlayer(player)
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+	step := func(ticks int) {
+		for i := 0; i < ticks; i++ {
+			now = now.Add(time.Second / time.Duration(tickRate))
+			hub.advance(now, dt)
+		}
+	}
+	launchedFireball := func() bool {
+		_, ok := boss.Cooldowns[effectTypeFireball]
+		return ok
+	}
+
+	step(30)
+	if _, ok := boss.Cooldowns[effectTypeAttack]; !ok {
+		t.Fatalf("expected phase-one boss to close in and melee the player")
+	}
+	if launchedFireball() {
+		t.Fatalf("expected phase-one boss to have no ranged attack")
+	}
+
+	hit := func(fraction float64) {
+		t.Helper()
+		eff := &effectState{Type: effectTypeAttack, Owner: player.ID, Params: map[string]float64{"healthDelta": -(boss.Health - boss.MaxHealth*fraction)}}
+		w.invokeNPCHitCallback(eff, boss, now)
+	}
+
+	player.X = boss.X + 200
+	player.Y = boss.Y
+	hit(0.6)
+	step(15)
+	if boss.Phase != 0 || launchedFireball() {
+		t.Fatalf("expected boss above the threshold to stay in phase one (phase=%d)", boss.Phase)
+	}
+
+	hit(0.45)
+	if boss.Phase != 1 {
+		t.Fatalf("expected boss below half health to enter phase two, got phase %d", boss.Phase)
+	}
+	if got := boss.Stats.GetDerived(stats.DerivedDamageTakenPhysical); got >= 1 {
+		t.Fatalf("expected phase two to harden the boss, damage taken %.2f", got)
+	}
+	player.X = boss.X + 200
+	player.Y = boss.Y
+	step(15)
+	if !launchedFireball() {
+		t.Fatalf("expected phase-two boss to launch fireballs at the player")
+	}
+}
+
+func TestBossCountSeedsBossesAroundMapCentre(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Seed = "boss-seeding"
+	cfg.Obstacles = false
+	cfg.GoldMines = false
+	cfg.Lava = false
+	cfg.GoblinCount = 0
+	cfg.RatCount = 0
+	cfg.NPCCount = 0
+	cfg.BossCount = 2
+	w := newTestWorld(cfg, logging.NopPublisher{})
+
+	var bosses []*npcState
+	for _, npc := range w.npcs {
+		if npc.Type == NPCTypeBoss {
+			bosses = append(bosses, npc)
+		}
+	}
+	if len(w.npcs) != 2 || len(bosses) != 2 {
+		t.Fatalf("expected bossCount to seed 2 bosses and nothing else, got %d NPCs with %d bosses", len(w.npcs), len(bosses))
+	}
+	for _, boss := range bosses {
+		distance := math.Hypot(boss.X-cfg.Width/2, boss.Y-cfg.Height/2)
+		if math.Abs(distance-360) > 1 {
+			t.Fatalf("expected boss %s on the ring around the map centre, distance %.1f", boss.ID, distance)
+		}
+	}
+
+	roundTrip := legacyWorldConfigFromSim(simWorldConfigFromLegacy(w.config))
+	if roundTrip.BossCount != cfg.BossCount {
+		t.Fatalf("expected bossCount to survive the snapshot config, got %d", roundTrip.BossCount)
+	}
+}
+
+func TestAIDecisionBudgetServesNPCsRoundRobin(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Seed = "ai-budget"
+	cfg.Obstacles = false
+	cfg.GoldMines = false
+	cfg.Lava = false
+	cfg.GoblinCount = 8
+	cfg.RatCount = 0
+	cfg.NPCCount = cfg.GoblinCount
+	w := newTestWorld(cfg, logging.NopPublisher{})
+	if len(w.npcs) != cfg.GoblinCount {
+		t.Fatalf("expected %d goblins, got %d", cfg.GoblinCount, len(w.npcs))
+	}
+
+	const budget = 2
+	w.SetAIDecisionBudget(budget)
+
+	// Patrolling goblins decide every 5 ticks, so with the budget each one
+	// is due again within its cadence plus one full rotation of the pack.
+	maxGap := uint64(5 + (cfg.GoblinCount+budget-1)/budget)
+	lastDecision := make(map[string]uint64, len(w.npcs))
+	for id := range w.npcs {
+		lastDecision[id] = 0
+	}
+
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	for tick := uint64(1); tick <= 120; tick++ {
+		before := make(map[string]vec2, len(w.npcs))
+		moving := make(map[string]bool, len(w.npcs))
+		for id, npc := range w.npcs {
+			before[id] = vec2{X: npc.X, Y: npc.Y}
+			moving[id] = npc.IntentX != 0 || npc.IntentY != 0
+		}
+
+		w.Step(tick, now, dt, nil, nil)
+		now = now.Add(time.Second / tickRate)
+
+		decided := 0
+		for id, npc := range w.npcs {
+			if npc.Blackboard.LastDecisionTick == tick {
+				decided++
+				lastDecision[id] = tick
+				continue
+			}
+			if tick-lastDecision[id] > maxGap {
+				t.Fatalf("npc %s went %d ticks without an AI decision at tick %d", id, tick-lastDecision[id], tick)
+			}
+			stillMoving := npc.IntentX != 0 || npc.IntentY != 0
+			if moving[id] && stillMoving && before[id] == (vec2{X: npc.X, Y: npc.Y}) {
+				t.Fatalf("npc %s stopped moving at tick %d while waiting for its AI turn", id, tick)
+			}
+		}
+		if decided > budget {
+			t.Fatalf("expected at most %d decisions on tick %d, got %d", budget, tick, decided)
+		}
+	}
+}
@@ -37,11 +37,19 @@ const (
 	goldOreMaxSize        = worldpkg.GoldOreMaxSize
 )
 
-// TickRate reports the server tick frequency in hertz.
+// TickRate reports the default server tick frequency in hertz.
 func TickRate() int {
 	return tickRate
 }
 
+// normalizeTickRate falls back to the default frequency for zero or negative rates.
+func normalizeTickRate(rate int) int {
+	if rate <= 0 {
+		return tickRate
+	}
+	return rate
+}
+
 // HeartbeatInterval reports how frequently clients must send heartbeats to stay connected.
 func HeartbeatInterval() time.Duration {
 	return heartbeatInterval
@@ -117,7 +117,7 @@ func assertDeterminismConstructorHarnessParity(t *testing.T, harness constructor
 		t.Fatalf("determinism harness: constructor harness not captured")
 	}
 
-	if harness.internal.Config != harness.legacy.Config {
+	if !reflect.DeepEqual(harness.internal.Config, harness.legacy.Config) {
 		t.Fatalf("determinism harness: config mismatch: internal=%+v legacy=%+v", harness.internal.Config, harness.legacy.Config)
 	}
 	if harness.internal.Seed != harness.legacy.Seed {
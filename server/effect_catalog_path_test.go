@@ -0,0 +1,70 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHubLoadsEffectCatalogFromConfiguredPath(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "balance-b.json")
+	catalogJSON := `[
+  {
+    "id": "fireball-slow",
+    "contractId": "fireball",
+    "definition": {
+      "typeId": "fireball",
+      "delivery": "area",
+      "shape": "circle",
+      "motion": "linear",
+      "impact": "first-hit",
+      "lifetimeTicks": 90,
+      "damageType": "fire",
+      "hooks": {
+        "onSpawn": "projectile.fireball.lifecycle",
+        "onTick": "projectile.fireball.lifecycle"
+      },
+      "client": {"sendSpawn": true, "sendUpdates": true, "sendEnd": true},
+      "end": {"kind": 0}
+    },
+    "jsEffect": "projectile/fireball",
+    "parameters": {"speed": 160, "range": 200, "radius": 12}
+  }
+]`
+	if err := os.WriteFile(catalogPath, []byte(catalogJSON), 0o644); err != nil {
+		t.Fatalf("failed to write catalog: %v", err)
+	}
+	if err := ValidateEffectCatalog(catalogPath); err != nil {
+		t.Fatalf("expected the alternate catalog to validate, got %v", err)
+	}
+	if err := ValidateEffectCatalog(filepath.Join(dir, "missing.json")); err == nil {
+		t.Fatalf("expected a missing catalog to fail validation")
+	}
+
+	cfg := DefaultHubConfig()
+	cfg.EffectCatalogPath = catalogPath
+	hub := NewHubWithConfig(cfg)
+
+	assertAlternateCatalog := func(t *testing.T, stage string) {
+		t.Helper()
+		resolver := hub.world.effectManager.Catalog()
+		if resolver == nil {
+			t.Fatalf("%s: expected the effect manager to load a catalog", stage)
+		}
+		entry, ok := resolver.Resolve("fireball-slow")
+		if !ok || entry.Definition == nil {
+			t.Fatalf("%s: expected the alternate fireball-slow entry to load", stage)
+		}
+		if entry.Definition.LifetimeTicks != 90 {
+			t.Fatalf("%s: expected the alternate lifetime of 90 ticks, got %d", stage, entry.Definition.LifetimeTicks)
+		}
+		if _, ok := resolver.Resolve(effectTypeHealBurst); ok {
+			t.Fatalf("%s: expected entries from the default catalog to be absent", stage)
+		}
+	}
+	assertAlternateCatalog(t, "construction")
+
+	hub.ResetWorld(hub.config)
+	assertAlternateCatalog(t, "reset")
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -65,3 +66,73 @@ func TestSnapshotEffectCatalogIncludesManagedFlag(t *testing.T) {
 		t.Fatalf("expected definition to retain managedByClient flag")
 	}
 }
+
+func TestSnapshotEffectCatalogSurfacesPresentationHints(t *testing.T) {
+	dir := t.TempDir()
+	catalogPath := filepath.Join(dir, "definitions.json")
+	catalogJSON := `[
+  {
+    "id": "fireball",
+    "contractId": "fireball",
+    "definition": {
+      "typeId": "fireball",
+      "delivery": "area",
+      "shape": "circle",
+      "motion": "linear",
+      "impact": "first-hit",
+      "lifetimeTicks": 10,
+      "hooks": {"onSpawn": "projectile.lifecycle"},
+      "client": {"sendSpawn": true},
+      "presentation": {"soundId": "sfx/fireball-cast", "particles": "embers"},
+      "end": {"kind": 0}
+    },
+    "jsEffect": "projectile/fireball"
+  }
+]`
+	if err := os.WriteFile(catalogPath, []byte(catalogJSON), 0o644); err != nil {
+		t.Fatalf("failed to write catalog: %v", err)
+	}
+
+	reg := effectcontract.Registry{
+		{
+			ID:     "fireball",
+			Spawn:  effectcontract.NoPayload,
+			Update: effectcontract.NoPayload,
+			End:    effectcontract.NoPayload,
+		},
+	}
+
+	resolver, err := effectcatalog.Load(reg, catalogPath)
+	if err != nil {
+		t.Fatalf("failed to load resolver: %v", err)
+	}
+
+	snapshot := snapshotEffectCatalog(resolver)
+	entry, ok := snapshot["fireball"]
+	if !ok || entry.Definition == nil {
+		t.Fatalf("expected fireball entry with a definition in snapshot")
+	}
+	want := effectcontract.Presentation{SoundID: "sfx/fireball-cast", Particles: "embers"}
+	if entry.Definition.Presentation != want {
+		t.Fatalf("expected presentation %+v, got %+v", want, entry.Definition.Presentation)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+	var decoded struct {
+		Definition struct {
+			Presentation map[string]string `json:"presentation"`
+		} `json:"definition"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode marshalled metadata: %v", err)
+	}
+	if decoded.Definition.Presentation["soundId"] != "sfx/fireball-cast" {
+		t.Fatalf("expected soundId to reach clients, got %v", decoded.Definition.Presentation)
+	}
+	if decoded.Definition.Presentation["particles"] != "embers" {
+		t.Fatalf("expected particles to reach clients, got %v", decoded.Definition.Presentation)
+	}
+}
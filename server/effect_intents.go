@@ -9,13 +9,18 @@ import (
 )
 
 // durationToTicks converts a wall-clock duration into the number of simulation
-// ticks using the shared tickRate. Durations shorter than a single tick still
+// ticks using the default tickRate. Durations shorter than a single tick still
 // return at least one tick so short-lived effects remain visible.
 func durationToTicks(duration time.Duration) int {
+	return durationToTicksAt(duration, tickRate)
+}
+
+// durationToTicksAt converts a duration into ticks at the given frequency.
+func durationToTicksAt(duration time.Duration, rate int) int {
 	if duration <= 0 {
 		return 0
 	}
-	ticks := int(math.Ceil(duration.Seconds() * float64(tickRate)))
+	ticks := int(math.Ceil(duration.Seconds() * float64(normalizeTickRate(rate))))
 	if ticks < 1 {
 		ticks = 1
 	}
@@ -48,6 +53,52 @@ var projectileIntentConfig = combat.ProjectileIntentConfig{
 	},
 }
 
+// meleeIntentConfig returns the melee intent config with durations converted
+// at the world's tick rate.
+func (w *World) meleeIntentConfig() combat.MeleeIntentConfig {
+	cfg := meleeIntentConfig
+	cfg.Geometry.Arc = w.meleeArc
+	cfg.Geometry.SpawnOffset = w.effectSpawnOffset(effectTypeAttack, 0)
+	rate := w.ticksPerSecond()
+	cfg.DurationToTicks = func(duration time.Duration) int {
+		return durationToTicksAt(duration, rate)
+	}
+	return cfg
+}
+
+// effectSpawnOffset reports how far from the owner's centre, along its facing,
+// the definition places the near edge of its effect. Definitions that leave
+// the offset unset keep the fallback.
+func (w *World) effectSpawnOffset(typeID string, fallback float64) float64 {
+	if w == nil || w.effectManager == nil {
+		return fallback
+	}
+	def := w.effectManager.Definitions()[typeID]
+	if def == nil || def.Geometry.SpawnOffset <= 0 {
+		return fallback
+	}
+	return float64(def.Geometry.SpawnOffset)
+}
+
+// projectileSpawnOffset places the projectile's centre one spawn radius beyond
+// the definition's offset, keeping the template's offset when none is set.
+func (w *World) projectileSpawnOffset(tpl combat.ProjectileIntentTemplate) float64 {
+	offset := w.effectSpawnOffset(tpl.Type, 0)
+	if offset <= 0 {
+		return tpl.SpawnOffset
+	}
+	return offset + tpl.SpawnRadius
+}
+
+// SetMeleeArc switches melee swings to a cone spanning the given angle in
+// degrees. Zero or negative values restore the box hitbox.
+func (w *World) SetMeleeArc(degrees float64) {
+	if w == nil {
+		return
+	}
+	w.meleeArc = math.Min(math.Max(degrees, 0), 360)
+}
+
 // quantizeWorldCoord translates a world-space measurement (expressed in the
 // same units as actor positions) into the fixed-point coordinate system used by
 // the unified effect contract. World units are normalised to tile units so the
@@ -108,6 +159,10 @@ func NewProjectileIntent(owner *combat.AbilityActor, tpl *ProjectileTemplate) (e
 // NewStatusVisualIntent converts a status-effect attachment into an
 // EffectIntent that follows the target actor for the duration of the status.
 func NewStatusVisualIntent(target *actorState, sourceID, effectType string, lifetime time.Duration) (effectcontract.EffectIntent, bool) {
+	return newStatusVisualIntentAt(target, sourceID, effectType, lifetime, tickRate)
+}
+
+func newStatusVisualIntentAt(target *actorState, sourceID, effectType string, lifetime time.Duration, rate int) (effectcontract.EffectIntent, bool) {
 	if target == nil || target.ID == "" || effectType == "" {
 		return effectcontract.EffectIntent{}, false
 	}
@@ -134,7 +189,7 @@ func NewStatusVisualIntent(target *actorState, sourceID, effectType string, life
 		SourceActorID: sourceID,
 		TargetActorID: target.ID,
 		Geometry:      geometry,
-		DurationTicks: durationToTicks(lifetime),
+		DurationTicks: durationToTicksAt(lifetime, rate),
 	}
 
 	if intent.DurationTicks < 1 {
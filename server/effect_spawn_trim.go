@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+
+	effectcatalog "mine-and-die/server/effects/catalog"
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// effectSpawnDefaults holds the catalog-authored values a broadcast spawn can
+// inherit instead of repeating them for every instance.
+type effectSpawnDefaults struct {
+	params map[string]int
+	colors []string
+}
+
+// spawnDefaultsFromEntry reads the integer `parameters` and the `colors`
+// palette blocks of a catalog entry. Non-integer parameters are skipped since
+// instance params are integers and can never match them.
+func spawnDefaultsFromEntry(entry effectcatalog.Entry) effectSpawnDefaults {
+	var defaults effectSpawnDefaults
+	if raw, ok := entry.Blocks["parameters"]; ok {
+		var values map[string]float64
+		if err := json.Unmarshal(raw, &values); err == nil {
+			for key, value := range values {
+				if value != math.Trunc(value) {
+					continue
+				}
+				if defaults.params == nil {
+					defaults.params = make(map[string]int, len(values))
+				}
+				defaults.params[key] = int(value)
+			}
+		}
+	}
+	if raw, ok := entry.Blocks["colors"]; ok {
+		var colors []string
+		if err := json.Unmarshal(raw, &colors); err == nil && len(colors) > 0 {
+			defaults.colors = colors
+		}
+	}
+	return defaults
+}
+
+// trimEffectSpawns returns copies of the spawn events with params and colors
+// that repeat the catalog entry removed. The input events are not modified,
+// so the journal keeps the full payloads.
+func trimEffectSpawns(resolver *effectcatalog.Resolver, events []effectcontract.EffectSpawnEvent) []effectcontract.EffectSpawnEvent {
+	if resolver == nil || len(events) == 0 {
+		return events
+	}
+	lookup := make(map[string]effectSpawnDefaults)
+	trimmed := make([]effectcontract.EffectSpawnEvent, len(events))
+	for i, event := range events {
+		id := event.Instance.EntryID
+		if id == "" {
+			id = event.Instance.DefinitionID
+		}
+		defaults, ok := lookup[id]
+		if !ok {
+			if entry, found := resolver.Resolve(id); found {
+				defaults = spawnDefaultsFromEntry(entry)
+			}
+			lookup[id] = defaults
+		}
+		trimmed[i] = trimEffectSpawn(event, defaults)
+	}
+	return trimmed
+}
+
+// trimEffectSpawn applies the catalog defaults to a single spawn. Params are
+// only trimmed when the instance carries every default key, so merging the
+// overrides over the defaults rebuilds exactly the original map. Colors are
+// dropped only when they equal the catalog palette.
+func trimEffectSpawn(event effectcontract.EffectSpawnEvent, defaults effectSpawnDefaults) effectcontract.EffectSpawnEvent {
+	if len(defaults.params) > 0 && len(event.Instance.Params) > 0 {
+		covered := true
+		for key := range defaults.params {
+			if _, ok := event.Instance.Params[key]; !ok {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			var overrides map[string]int
+			for key, value := range event.Instance.Params {
+				if def, ok := defaults.params[key]; ok && def == value {
+					continue
+				}
+				if overrides == nil {
+					overrides = make(map[string]int)
+				}
+				overrides[key] = value
+			}
+			event.Instance.Params = overrides
+			event.CatalogParams = true
+		}
+	}
+	if len(defaults.colors) > 0 && stringSlicesEqual(event.Instance.Colors, defaults.colors) {
+		event.Instance.Colors = nil
+		event.CatalogColors = true
+	}
+	return event
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	effectcatalog "mine-and-die/server/effects/catalog"
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+// expandEffectSpawn mirrors the client's reconstruction of a trimmed spawn.
+func expandEffectSpawn(t *testing.T, resolver *effectcatalog.Resolver, event effectcontract.EffectSpawnEvent) effectcontract.EffectInstance {
+	t.Helper()
+	instance := event.Instance
+	entry, ok := resolver.Resolve(instance.EntryID)
+	if !ok {
+		t.Fatalf("expected catalog entry %q", instance.EntryID)
+	}
+	defaults := spawnDefaultsFromEntry(entry)
+	if event.CatalogParams {
+		params := make(map[string]int, len(defaults.params)+len(instance.Params))
+		for key, value := range defaults.params {
+			params[key] = value
+		}
+		for key, value := range instance.Params {
+			params[key] = value
+		}
+		instance.Params = params
+	}
+	if event.CatalogColors {
+		instance.Colors = append([]string(nil), defaults.colors...)
+	}
+	return instance
+}
+
+func TestTrimEffectSpawnsOmitsCatalogDefaults(t *testing.T) {
+	hub := newHub()
+	resolver := hub.world.effectManager.Catalog()
+	if resolver == nil {
+		t.Fatalf("expected effect catalog to be loaded")
+	}
+
+	blood := effectcontract.EffectSpawnEvent{
+		Tick: 3,
+		Seq:  1,
+		Instance: effectcontract.EffectInstance{
+			ID:           "contract-effect-1",
+			EntryID:      effectcontract.EffectIDBloodSplatter,
+			DefinitionID: effectcontract.EffectIDBloodSplatter,
+			Params:       map[string]int{"centerX": 120, "centerY": 80},
+			Colors:       internaleffects.BloodSplatterColors(),
+		},
+	}
+	attack := effectcontract.EffectSpawnEvent{
+		Tick: 3,
+		Seq:  1,
+		Instance: effectcontract.EffectInstance{
+			ID:           "contract-effect-2",
+			EntryID:      effectTypeAttack,
+			DefinitionID: effectTypeAttack,
+			Params:       map[string]int{"damage": 10, "reach": 56, "width": 48},
+		},
+	}
+	partial := effectcontract.EffectSpawnEvent{
+		Tick: 3,
+		Seq:  1,
+		Instance: effectcontract.EffectInstance{
+			ID:           "contract-effect-3",
+			EntryID:      effectTypeAttack,
+			DefinitionID: effectTypeAttack,
+			Params:       map[string]int{"healthDelta": -10, "reach": 56, "width": 40},
+		},
+	}
+	events := []effectcontract.EffectSpawnEvent{blood, attack, partial}
+	originals := make([]effectcontract.EffectInstance, len(events))
+	for i, event := range events {
+		originals[i] = event.Instance
+	}
+
+	trimmed := trimEffectSpawns(resolver, events)
+
+	data, err := json.Marshal(trimmed[0])
+	if err != nil {
+		t.Fatalf("failed to encode trimmed spawn: %v", err)
+	}
+	var encoded struct {
+		Instance      map[string]json.RawMessage `json:"instance"`
+		CatalogColors bool                       `json:"catalogColors"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatalf("failed to decode trimmed spawn: %v", err)
+	}
+	if _, ok := encoded.Instance["colors"]; ok || !encoded.CatalogColors {
+		t.Fatalf("expected blood splatter colors to reference the catalog, got %s", data)
+	}
+	if trimmed[0].CatalogParams {
+		t.Fatalf("expected per-instance blood params to be sent as-is")
+	}
+
+	if !trimmed[1].CatalogParams || !reflect.DeepEqual(trimmed[1].Instance.Params, map[string]int{"width": 48}) {
+		t.Fatalf("expected only the overridden attack param, got %+v (catalogParams=%t)", trimmed[1].Instance.Params, trimmed[1].CatalogParams)
+	}
+	if trimmed[2].CatalogParams || !reflect.DeepEqual(trimmed[2].Instance.Params, partial.Instance.Params) {
+		t.Fatalf("expected params missing a catalog key to be left intact, got %+v", trimmed[2].Instance.Params)
+	}
+
+	for i, event := range trimmed {
+		if got := expandEffectSpawn(t, resolver, event); !reflect.DeepEqual(got, originals[i]) {
+			t.Fatalf("spawn %d did not reconstruct: got %+v want %+v", i, got, originals[i])
+		}
+		if !reflect.DeepEqual(events[i].Instance, originals[i]) {
+			t.Fatalf("expected trimming to leave spawn %d untouched, got %+v", i, events[i].Instance)
+		}
+	}
+}
@@ -90,6 +90,78 @@ func newProjectileTemplates() map[string]*ProjectileTemplate {
 			},
 			Cooldown: fireballCooldown,
 		},
+		effectTypeFirebomb: {
+			Type:        effectTypeFirebomb,
+			Speed:       firebombSpeed,
+			MaxDistance: firebombRange,
+			Lifetime:    firebombLifetime,
+			SpawnRadius: firebombSize / 2,
+			SpawnOffset: playerHalf + fireballSpawnGap + firebombSize/2,
+			TravelMode: TravelModeConfig{
+				StraightLine: true,
+				ArcHeight:    firebombArcHeight,
+			},
+			ImpactRules: ImpactRuleConfig{
+				AffectsOwner: false,
+			},
+			Params: map[string]float64{
+				"radius":      firebombSize / 2,
+				"speed":       firebombSpeed,
+				"range":       firebombRange,
+				"healthDelta": -firebombDamage,
+			},
+			Cooldown: firebombCooldown,
+		},
+		effectTypeSeeker: {
+			Type:        effectTypeSeeker,
+			Speed:       seekerSpeed,
+			MaxDistance: seekerRange,
+			Lifetime:    seekerLifetime,
+			SpawnRadius: seekerSize / 2,
+			SpawnOffset: playerHalf + fireballSpawnGap + seekerSize/2,
+			TravelMode: TravelModeConfig{
+				StraightLine: true,
+				Homing:       true,
+				TurnRate:     seekerTurnRate,
+				HomingRange:  seekerHomingRange,
+			},
+			ImpactRules: ImpactRuleConfig{
+				StopOnHit:    true,
+				MaxTargets:   1,
+				AffectsOwner: false,
+			},
+			Params: map[string]float64{
+				"radius":      seekerSize / 2,
+				"speed":       seekerSpeed,
+				"range":       seekerRange,
+				"healthDelta": -seekerDamage,
+			},
+			Cooldown: seekerCooldown,
+		},
+		effectTypeRicochet: {
+			Type:        effectTypeRicochet,
+			Speed:       ricochetSpeed,
+			MaxDistance: ricochetRange,
+			Lifetime:    ricochetLifetime,
+			SpawnRadius: ricochetSize / 2,
+			SpawnOffset: playerHalf + fireballSpawnGap + ricochetSize/2,
+			TravelMode: TravelModeConfig{
+				StraightLine: true,
+				Bounces:      ricochetBounces,
+			},
+			ImpactRules: ImpactRuleConfig{
+				StopOnHit:    true,
+				MaxTargets:   1,
+				AffectsOwner: false,
+			},
+			Params: map[string]float64{
+				"radius":      ricochetSize / 2,
+				"speed":       ricochetSpeed,
+				"range":       ricochetRange,
+				"healthDelta": -ricochetDamage,
+			},
+			Cooldown: ricochetCooldown,
+		},
 	}
 }
 
@@ -466,6 +538,7 @@ func (w *World) advanceProjectile(eff *effectState, now time.Time, dt float64) b
 			return state != nil && state.Projectile != nil
 		},
 		Dimensions: w.dimensions,
+		WrapBounds: w.config.Wrap,
 		ComputeArea: func(effect any) worldpkg.Obstacle {
 			state, _ := effect.(*effectState)
 			if state == nil {
@@ -483,6 +556,7 @@ func (w *World) advanceProjectile(eff *effectState, now time.Time, dt float64) b
 			}
 			w.SetEffectPosition(state, x, y)
 		},
+		LocateActor: w.livingActorPosition,
 		StopAdapter: w.projectileStopAdapter,
 		BindStopConfig: func(bindings worldpkg.ProjectileStopConfig, effect any, at time.Time) any {
 			state, _ := effect.(*effectState)
@@ -576,6 +650,7 @@ func (w *World) advanceProjectile(eff *effectState, now time.Time, dt float64) b
 				Delta:       stepCfg.Delta,
 				WorldWidth:  stepCfg.WorldWidth,
 				WorldHeight: stepCfg.WorldHeight,
+				WrapBounds:  stepCfg.WrapBounds,
 				ComputeArea: func() combat.Rectangle {
 					if stepCfg.ComputeArea == nil {
 						return combat.Rectangle{}
@@ -589,6 +664,7 @@ func (w *World) advanceProjectile(eff *effectState, now time.Time, dt float64) b
 					}
 					return stepCfg.AnyObstacleOverlap(worldpkg.Obstacle{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height})
 				},
+				LocateTarget:      stepCfg.LocateActor,
 				SetPosition:       stepCfg.SetPosition,
 				SetRemainingRange: setRemainingRange,
 				Stop:              combatStop,
@@ -652,6 +728,12 @@ func (w *World) advanceProjectile(eff *effectState, now time.Time, dt float64) b
 							Raw:    target.Raw,
 						})
 					},
+					Deflects: func(target combat.ProjectileOverlapTarget) bool {
+						return w.isParrying(target.ID, now)
+					},
+					OnDeflect: func(target combat.ProjectileOverlapTarget) {
+						w.reflectProjectile(state, target.ID, now)
+					},
 				},
 			}
 
@@ -676,6 +758,16 @@ func (w *World) actorByID(id string) *actorState {
 	return nil
 }
 
+// livingActorPosition reports where the actor stands while it is alive so
+// homing projectiles drop their lock on death or removal.
+func (w *World) livingActorPosition(id string) (float64, float64, bool) {
+	actor := w.actorByID(id)
+	if actor == nil || actor.Health <= 0 {
+		return 0, 0, false
+	}
+	return actor.X, actor.Y, true
+}
+
 func (w *World) maybeExplodeOnExpiry(eff *effectState, now time.Time) {
 	stopCfg := w.projectileStopConfig(eff, now)
 	stopCfg.Options = combat.ProjectileStopOptions{TriggerExpiry: true}
@@ -764,7 +856,7 @@ func (w *World) maybeSpawnBloodSplatter(eff *effectState, target *npcState, now
 			TileSize:  tileSize,
 			Footprint: playerHalf * 2,
 			Duration:  bloodSplatterDuration,
-			TickRate:  tickRate,
+			TickRate:  w.ticksPerSecond(),
 		})
 		if ok {
 			w.effectManager.EnqueueIntent(intent)
@@ -828,6 +920,10 @@ func bindEffectHitAdapters(w *World) {
 				return
 			}
 			w.recordEffectHitTelemetry((*effectState)(effect), targetID, actualDelta)
+			w.recordSessionHit(effect.Owner, targetID, actualDelta)
+			w.recordKillCredit(effect.Owner, targetID, actualDelta)
+			w.markCombat(effect.Owner, targetID, actualDelta)
+			w.interruptRecall(targetID, actualDelta)
 		},
 		DropAllInventory: func(actor *worldstate.ActorState, reason string) {
 			if actor == nil {
@@ -845,6 +941,22 @@ func bindEffectHitAdapters(w *World) {
 			}
 			w.applyStatusEffect((*actorState)(actor), StatusEffectType(status), ownerID, now)
 		},
+		RollCritical: func(effect *worldeffects.State, targetID string) (float64, bool) {
+			return w.rollCritical((*effectState)(effect), targetID)
+		},
+		AmplifyDamage: func(effect *worldeffects.State, targetID string) float64 {
+			return w.damageDealtMultiplier((*effectState)(effect))
+		},
+		AttenuateDamage: func(effect *worldeffects.State, targetID string) float64 {
+			return w.damageFalloffMultiplier((*effectState)(effect), targetID)
+		},
+		ResistDamage: func(effect *worldeffects.State, targetID string) float64 {
+			return w.damageTakenMultiplier((*effectState)(effect), targetID)
+		},
+		AbsorbDamage: w.absorbDamage,
+		ApplyLifesteal: func(effect *worldeffects.State, targetID string, damage float64) {
+			w.applyLifesteal((*effectState)(effect), targetID, damage)
+		},
 		IsPlayer: func(id string) bool {
 			_, ok := w.players[id]
 			return ok
@@ -864,6 +976,15 @@ func bindEffectHitAdapters(w *World) {
 			}
 			w.maybeSpawnBloodSplatter(eff, npc, now)
 		},
+		OnHit: func(effect any, target any, now time.Time) {
+			eff, _ := effect.(*effectState)
+			npc, _ := target.(*npcState)
+			if eff == nil || npc == nil {
+				return
+			}
+			w.alertNPCPack(eff, npc)
+			w.advanceNPCPhase(npc)
+		},
 		IsAlive: func(target any) bool {
 			npc, _ := target.(*npcState)
 			if npc == nil {
@@ -102,6 +102,36 @@ func DefaultPaths() []string {
 	return []string{filepath.Join("..", "config", "effects", "definitions.json")}
 }
 
+// PathsFor returns the catalog paths to load for a configured path: the path
+// itself when set, otherwise DefaultPaths.
+func PathsFor(path string) []string {
+	if strings.TrimSpace(path) == "" {
+		return DefaultPaths()
+	}
+	return []string{path}
+}
+
+// Validate loads the catalog at path against reg and reports the first
+// problem found. Unlike Load it treats a missing file as an error, so a
+// mistyped override is caught instead of silently loading nothing.
+func Validate(reg contract.Registry, path string) error {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return fmt.Errorf("catalog: empty path")
+	}
+	if _, err := os.Stat(trimmed); err != nil {
+		return fmt.Errorf("catalog: %w", err)
+	}
+	resolver, err := Load(reg, trimmed)
+	if err != nil {
+		return err
+	}
+	if len(resolver.Entries()) == 0 {
+		return fmt.Errorf("catalog: %s defines no entries", trimmed)
+	}
+	return nil
+}
+
 // Load constructs a Resolver backed by the provided contract registry and
 // catalog file paths.
 func Load(reg contract.Registry, paths ...string) (*Resolver, error) {
@@ -9,6 +9,15 @@ const (
 	EffectIDBloodSplatter = "blood-splatter"
 	EffectIDBurningTick   = "burning-tick"
 	EffectIDBurningVisual = "fire"
+	EffectIDBeam          = "beam"
+	EffectIDHeal          = "heal"
+	EffectIDHealBurst     = "heal-burst"
+	EffectIDGravityWell   = "gravity-well"
+	EffectIDExplosion     = "explosion"
+	EffectIDFirePatch     = "fire-patch"
+	EffectIDFirebomb      = "firebomb"
+	EffectIDSeeker        = "seeker"
+	EffectIDRicochet      = "ricochet"
 )
 
 // BuiltInRegistry enumerates the contract payload declarations for the existing
@@ -46,4 +55,58 @@ var BuiltInRegistry = Registry{
 		End:    (*BloodSplatterEndPayload)(nil),
 		Owner:  LifecycleOwnerClient,
 	},
+	{
+		ID:     EffectIDBeam,
+		Spawn:  (*BeamSpawnPayload)(nil),
+		Update: (*BeamUpdatePayload)(nil),
+		End:    (*BeamEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDHeal,
+		Spawn:  (*HealSpawnPayload)(nil),
+		Update: (*HealUpdatePayload)(nil),
+		End:    (*HealEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDHealBurst,
+		Spawn:  (*HealBurstSpawnPayload)(nil),
+		Update: (*HealBurstUpdatePayload)(nil),
+		End:    (*HealBurstEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDGravityWell,
+		Spawn:  (*GravityWellSpawnPayload)(nil),
+		Update: (*GravityWellUpdatePayload)(nil),
+		End:    (*GravityWellEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDExplosion,
+		Spawn:  (*ExplosionSpawnPayload)(nil),
+		Update: (*ExplosionUpdatePayload)(nil),
+		End:    (*ExplosionEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDFirePatch,
+		Spawn:  (*FirePatchSpawnPayload)(nil),
+		Update: (*FirePatchUpdatePayload)(nil),
+		End:    (*FirePatchEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDFirebomb,
+		Spawn:  (*FirebombSpawnPayload)(nil),
+		Update: (*FirebombUpdatePayload)(nil),
+		End:    (*FirebombEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDSeeker,
+		Spawn:  (*SeekerSpawnPayload)(nil),
+		Update: (*SeekerUpdatePayload)(nil),
+		End:    (*SeekerEndPayload)(nil),
+	},
+	{
+		ID:     EffectIDRicochet,
+		Spawn:  (*RicochetSpawnPayload)(nil),
+		Update: (*RicochetUpdatePayload)(nil),
+		End:    (*RicochetEndPayload)(nil),
+	},
 }
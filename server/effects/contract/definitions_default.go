@@ -12,6 +12,8 @@ func BuiltInDefinitions() map[string]*EffectDefinition {
 			Motion:        MotionKindInstant,
 			Impact:        ImpactPolicyAllInPath,
 			LifetimeTicks: 1,
+			DamageType:    DamageTypePhysical,
+			Geometry:      SpawnGeometry{SpawnOffset: 14},
 			Hooks: EffectHooks{
 				OnSpawn: HookMeleeSpawn,
 			},
@@ -30,6 +32,8 @@ func BuiltInDefinitions() map[string]*EffectDefinition {
 			Motion:        MotionKindLinear,
 			Impact:        ImpactPolicyFirstHit,
 			LifetimeTicks: 45,
+			DamageType:    DamageTypeFire,
+			Geometry:      SpawnGeometry{SpawnOffset: 20},
 			Hooks: EffectHooks{
 				OnSpawn: HookProjectileLifecycle,
 				OnTick:  HookProjectileLifecycle,
@@ -39,6 +43,10 @@ func BuiltInDefinitions() map[string]*EffectDefinition {
 				SendUpdates: true,
 				SendEnd:     true,
 			},
+			Presentation: Presentation{
+				SoundID:   "sfx/fireball-cast",
+				Particles: "embers",
+			},
 			End: EndPolicy{Kind: EndDuration},
 		},
 		EffectIDBurningTick: {
@@ -48,6 +56,7 @@ func BuiltInDefinitions() map[string]*EffectDefinition {
 			Motion:        MotionKindInstant,
 			Impact:        ImpactPolicyFirstHit,
 			LifetimeTicks: 1,
+			DamageType:    DamageTypeFire,
 			Hooks: EffectHooks{
 				OnSpawn: HookStatusBurningDamage,
 			},
@@ -95,5 +104,117 @@ func BuiltInDefinitions() map[string]*EffectDefinition {
 			},
 			End: EndPolicy{Kind: EndDuration},
 		},
+		EffectIDBeam: {
+			TypeID:        EffectIDBeam,
+			Delivery:      DeliveryKindBeam,
+			Shape:         GeometryShapeSegment,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyFirstHit,
+			LifetimeTicks: 15,
+			DamageType:    DamageTypeFire,
+			Hooks: EffectHooks{
+				OnTick: HookBeamTick,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: true,
+				SendEnd:     true,
+			},
+			End: EndPolicy{
+				Kind:       EndDuration,
+				Conditions: EndConditions{OnExplicitCancel: true},
+			},
+		},
+		EffectIDHeal: {
+			TypeID:        EffectIDHeal,
+			Delivery:      DeliveryKindTarget,
+			Shape:         GeometryShapeRect,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyFirstHit,
+			LifetimeTicks: 1,
+			Hooks: EffectHooks{
+				OnSpawn: HookTargetHeal,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: false,
+				SendEnd:     true,
+			},
+			End: EndPolicy{Kind: EndInstant},
+		},
+		EffectIDHealBurst: {
+			TypeID:        EffectIDHealBurst,
+			Delivery:      DeliveryKindArea,
+			Shape:         GeometryShapeCircle,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyAllInPath,
+			LifetimeTicks: 1,
+			Hooks: EffectHooks{
+				OnSpawn: HookAreaHealBurst,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: false,
+				SendEnd:     true,
+			},
+			End: EndPolicy{Kind: EndInstant},
+		},
+		EffectIDGravityWell: {
+			TypeID:        EffectIDGravityWell,
+			Delivery:      DeliveryKindArea,
+			Shape:         GeometryShapeCircle,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyAllInPath,
+			LifetimeTicks: 30,
+			Hooks: EffectHooks{
+				OnSpawn: HookAreaGravityPull,
+				OnTick:  HookAreaGravityPull,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: false,
+				SendEnd:     true,
+			},
+			End: EndPolicy{Kind: EndDuration},
+		},
+		EffectIDExplosion: {
+			TypeID:        EffectIDExplosion,
+			Delivery:      DeliveryKindArea,
+			Shape:         GeometryShapeCircle,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyAllInPath,
+			LifetimeTicks: 1,
+			DamageType:    DamageTypeFire,
+			Falloff:       DamageFalloffLinear,
+			LineOfSight:   true,
+			Hooks: EffectHooks{
+				OnSpawn: HookAreaExplosion,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: false,
+				SendEnd:     true,
+			},
+			End: EndPolicy{Kind: EndInstant},
+		},
+		EffectIDFirePatch: {
+			TypeID:        EffectIDFirePatch,
+			Delivery:      DeliveryKindArea,
+			Shape:         GeometryShapeCircle,
+			Motion:        MotionKindInstant,
+			Impact:        ImpactPolicyAllInPath,
+			LifetimeTicks: 150,
+			DamageType:    DamageTypeFire,
+			Hooks: EffectHooks{
+				OnSpawn: HookAreaHazardPulse,
+				OnTick:  HookAreaHazardPulse,
+			},
+			Client: ReplicationSpec{
+				SendSpawn:   true,
+				SendUpdates: false,
+				SendEnd:     true,
+			},
+			End: EndPolicy{Kind: EndDuration},
+		},
 	}
 }
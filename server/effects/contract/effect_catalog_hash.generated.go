@@ -2,4 +2,4 @@
 
 package contract
 
-const EffectCatalogHash = "aec27f3554bbf8e91d782cb8cede07eac44e3e0ba490e785225ca49f5fdcd3bc"
+const EffectCatalogHash = "016b3d94049a06245f24d420df54668889a387562bb962329bd7102ae7c9cc6c"
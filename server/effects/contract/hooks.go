@@ -9,4 +9,10 @@ const (
 	HookStatusBurningVisual = "status.burning.visual"
 	HookStatusBurningDamage = "status.burning.tick"
 	HookVisualBloodSplatter = "visual.blood.splatter"
+	HookBeamTick            = "beam.tick"
+	HookTargetHeal          = "target.heal"
+	HookAreaHealBurst       = "area.heal.burst"
+	HookAreaGravityPull     = "area.gravity.pull"
+	HookAreaExplosion       = "area.explosion"
+	HookAreaHazardPulse     = "area.hazard.pulse"
 )
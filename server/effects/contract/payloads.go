@@ -73,3 +73,84 @@ type BloodSplatterUpdatePayload = InstanceUpdatePayload
 
 // BloodSplatterEndPayload mirrors blood splatter end payloads.
 type BloodSplatterEndPayload = InstanceEndPayload
+
+// BeamSpawnPayload represents the spawn payload for beams.
+type BeamSpawnPayload = InstanceSpawnPayload
+
+// BeamUpdatePayload captures the per-tick beam updates.
+type BeamUpdatePayload = InstanceUpdatePayload
+
+// BeamEndPayload captures beam end payloads.
+type BeamEndPayload = InstanceEndPayload
+
+// HealSpawnPayload represents the spawn payload for targeted heals.
+type HealSpawnPayload = InstanceSpawnPayload
+
+// HealUpdatePayload captures targeted heal updates.
+type HealUpdatePayload = InstanceUpdatePayload
+
+// HealEndPayload captures targeted heal end payloads.
+type HealEndPayload = InstanceEndPayload
+
+// HealBurstSpawnPayload represents the spawn payload for group heals.
+type HealBurstSpawnPayload = InstanceSpawnPayload
+
+// HealBurstUpdatePayload captures group heal updates.
+type HealBurstUpdatePayload = InstanceUpdatePayload
+
+// HealBurstEndPayload captures group heal end payloads.
+type HealBurstEndPayload = InstanceEndPayload
+
+// GravityWellSpawnPayload represents the spawn payload for gravity wells.
+type GravityWellSpawnPayload = InstanceSpawnPayload
+
+// GravityWellUpdatePayload captures gravity well updates.
+type GravityWellUpdatePayload = InstanceUpdatePayload
+
+// GravityWellEndPayload captures gravity well end payloads.
+type GravityWellEndPayload = InstanceEndPayload
+
+// ExplosionSpawnPayload represents the spawn payload for explosions.
+type ExplosionSpawnPayload = InstanceSpawnPayload
+
+// ExplosionUpdatePayload captures explosion updates.
+type ExplosionUpdatePayload = InstanceUpdatePayload
+
+// ExplosionEndPayload captures explosion end payloads.
+type ExplosionEndPayload = InstanceEndPayload
+
+// FirePatchSpawnPayload represents the spawn payload for fire patches.
+type FirePatchSpawnPayload = InstanceSpawnPayload
+
+// FirePatchUpdatePayload captures fire patch updates.
+type FirePatchUpdatePayload = InstanceUpdatePayload
+
+// FirePatchEndPayload captures fire patch end payloads.
+type FirePatchEndPayload = InstanceEndPayload
+
+// FirebombSpawnPayload represents the spawn payload for lobbed firebombs.
+type FirebombSpawnPayload = InstanceSpawnPayload
+
+// FirebombUpdatePayload captures firebomb updates.
+type FirebombUpdatePayload = InstanceUpdatePayload
+
+// FirebombEndPayload captures firebomb end payloads.
+type FirebombEndPayload = InstanceEndPayload
+
+// SeekerSpawnPayload represents the spawn payload for homing seekers.
+type SeekerSpawnPayload = InstanceSpawnPayload
+
+// SeekerUpdatePayload captures seeker updates.
+type SeekerUpdatePayload = InstanceUpdatePayload
+
+// SeekerEndPayload captures seeker end payloads.
+type SeekerEndPayload = InstanceEndPayload
+
+// RicochetSpawnPayload represents the spawn payload for bouncing ricochets.
+type RicochetSpawnPayload = InstanceSpawnPayload
+
+// RicochetUpdatePayload captures ricochet updates.
+type RicochetUpdatePayload = InstanceUpdatePayload
+
+// RicochetEndPayload captures ricochet end payloads.
+type RicochetEndPayload = InstanceEndPayload
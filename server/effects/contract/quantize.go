@@ -0,0 +1,67 @@
+package contract
+
+import "math"
+
+// DefaultTileSize is the world-unit tile size the world quantization helpers
+// fall back to when the caller passes a non-positive one.
+const DefaultTileSize = 40.0
+
+// maxQuantized bounds quantized coordinates to the int32 range so every
+// platform, including 32-bit ones, stores the same value.
+const maxQuantized = math.MaxInt32
+
+// QuantizeCoord converts a tile-space coordinate into the fixed-point units of
+// CoordScale, rounding halfway values away from zero.
+//
+// The result is identical on every platform: the expression has no
+// multiply-add the compiler could fuse, NaN maps to zero, and values beyond
+// the int32 range saturate instead of relying on Go's implementation-defined
+// float-to-int conversion.
+func QuantizeCoord(value float64) int {
+	return roundToQuantized(value * CoordScale)
+}
+
+// DequantizeCoord converts fixed-point units back into tile space. The
+// conversion is exact because CoordScale is a power of two.
+func DequantizeCoord(value int) float64 {
+	return float64(value) / CoordScale
+}
+
+// QuantizeWorldCoord converts a world-space measurement into fixed-point
+// units by normalizing it to tiles of tileSize first. A non-positive tileSize
+// selects DefaultTileSize.
+//
+// For any coordinate within range, QuantizeWorldCoord(DequantizeWorldCoord(q,
+// tileSize), tileSize) == q, so quantizing a dequantized value is idempotent,
+// and the dequantized value lies within half a sub-unit, tileSize/(2*
+// CoordScale), of the original.
+func QuantizeWorldCoord(value float64, tileSize float64) int {
+	return QuantizeCoord(value / normalizedTileSize(tileSize))
+}
+
+// DequantizeWorldCoord converts fixed-point units back into world space using
+// the provided tile size. A non-positive tileSize selects DefaultTileSize.
+func DequantizeWorldCoord(value int, tileSize float64) float64 {
+	return DequantizeCoord(value) * normalizedTileSize(tileSize)
+}
+
+func normalizedTileSize(tileSize float64) float64 {
+	if tileSize <= 0 || math.IsNaN(tileSize) || math.IsInf(tileSize, 0) {
+		return DefaultTileSize
+	}
+	return tileSize
+}
+
+func roundToQuantized(scaled float64) int {
+	if math.IsNaN(scaled) {
+		return 0
+	}
+	rounded := math.Round(scaled)
+	if rounded >= maxQuantized {
+		return maxQuantized
+	}
+	if rounded <= -maxQuantized {
+		return -maxQuantized
+	}
+	return int(rounded)
+}
@@ -0,0 +1,89 @@
+package contract
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// quantizeSample is a generated world coordinate and tile size.
+type quantizeSample struct {
+	Value    float64
+	TileSize float64
+}
+
+// Generate draws coordinates across a large world and tile sizes from a
+// sixteenth of a tile up to 256 units.
+func (quantizeSample) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(quantizeSample{
+		Value:    (r.Float64()*2 - 1) * 1e6,
+		TileSize: 1.0/16 + r.Float64()*256,
+	})
+}
+
+func quickConfig() *quick.Config {
+	return &quick.Config{MaxCount: 20000, Rand: rand.New(rand.NewSource(1))}
+}
+
+func TestQuantizeWorldCoordIsIdempotent(t *testing.T) {
+	property := func(s quantizeSample) bool {
+		q := QuantizeWorldCoord(s.Value, s.TileSize)
+		return QuantizeWorldCoord(DequantizeWorldCoord(q, s.TileSize), s.TileSize) == q
+	}
+	if err := quick.Check(property, quickConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tileSize := range []float64{1, 7, 16, 32, DefaultTileSize, 48, 64, 100} {
+		for q := -5000; q <= 5000; q++ {
+			if got := QuantizeWorldCoord(DequantizeWorldCoord(q, tileSize), tileSize); got != q {
+				t.Fatalf("tile %.0f: requantizing %d gave %d", tileSize, q, got)
+			}
+		}
+	}
+}
+
+func TestQuantizeWorldCoordRoundTripsWithinHalfASubUnit(t *testing.T) {
+	property := func(s quantizeSample) bool {
+		back := DequantizeWorldCoord(QuantizeWorldCoord(s.Value, s.TileSize), s.TileSize)
+		tolerance := s.TileSize/(2*CoordScale) + 1e-9*math.Max(1, math.Abs(s.Value))
+		return math.Abs(back-s.Value) <= tolerance
+	}
+	if err := quick.Check(property, quickConfig()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestQuantizeWorldCoordMatchesGoldenValues pins results that every platform
+// must reproduce, including halfway rounding and out-of-range inputs.
+func TestQuantizeWorldCoordMatchesGoldenValues(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    float64
+		tileSize float64
+		want     int
+	}{
+		{name: "zero", value: 0, tileSize: 40, want: 0},
+		{name: "one tile", value: 40, tileSize: 40, want: 16},
+		{name: "sub-unit", value: 2.5, tileSize: 40, want: 1},
+		{name: "halfway rounds up", value: 1.25, tileSize: 40, want: 1},
+		{name: "negative halfway rounds down", value: -1.25, tileSize: 40, want: -1},
+		{name: "below halfway", value: 1.2, tileSize: 40, want: 0},
+		{name: "odd tile", value: 100, tileSize: 7, want: 229},
+		{name: "default tile", value: 80, tileSize: 0, want: 32},
+		{name: "negative tile falls back", value: 80, tileSize: -4, want: 32},
+		{name: "NaN", value: math.NaN(), tileSize: 40, want: 0},
+		{name: "positive infinity saturates", value: math.Inf(1), tileSize: 40, want: math.MaxInt32},
+		{name: "negative infinity saturates", value: math.Inf(-1), tileSize: 40, want: -math.MaxInt32},
+		{name: "huge saturates", value: 1e300, tileSize: 40, want: math.MaxInt32},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := QuantizeWorldCoord(tc.value, tc.tileSize); got != tc.want {
+				t.Fatalf("QuantizeWorldCoord(%v, %v) = %d, want %d", tc.value, tc.tileSize, got, tc.want)
+			}
+		})
+	}
+}
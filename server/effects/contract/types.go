@@ -18,6 +18,8 @@ const (
 	DeliveryKindTarget DeliveryKind = "target"
 	// DeliveryKindVisual represents cosmetic-only effects with no gameplay impact.
 	DeliveryKindVisual DeliveryKind = "visual"
+	// DeliveryKindBeam represents instant lines traced from the owner each tick.
+	DeliveryKindBeam DeliveryKind = "beam"
 )
 
 // FollowMode decouples "is Target delivery" from how an instance anchors/updates its transform.
@@ -61,6 +63,27 @@ const (
 	ImpactPolicyNone       ImpactPolicy = "none"
 )
 
+// DamageFalloff selects how an area effect's damage shrinks with a target's
+// distance from the impact centre. Definitions without a falloff deal the same
+// damage across the whole area.
+type DamageFalloff string
+
+const (
+	// DamageFalloffLinear scales damage by 1 - d/r.
+	DamageFalloffLinear DamageFalloff = "linear"
+	// DamageFalloffQuadratic scales damage by (1 - d/r)^2.
+	DamageFalloffQuadratic DamageFalloff = "quadratic"
+)
+
+// DamageType classifies the damage an effect deals so targets can resist it.
+type DamageType string
+
+const (
+	DamageTypePhysical DamageType = "physical"
+	DamageTypeFire     DamageType = "fire"
+	DamageTypePoison   DamageType = "poison"
+)
+
 // EndReason qualifies why an effect ended; used in EffectEndEvent and for analytics.
 type EndReason string
 
@@ -135,6 +158,13 @@ type EffectMotionState struct {
 	VelocityY       int `json:"velocityY"`
 	RangeRemaining  int `json:"rangeRemaining,omitempty"`
 	TravelledLength int `json:"travelledLength,omitempty"`
+	// TargetX/TargetY is the landing point of parabolic motion, or the clipped
+	// far end of a beam. ArcDistance is the ground distance from launch to
+	// landing, and Altitude the current height above the ground track.
+	TargetX     int `json:"targetX,omitempty"`
+	TargetY     int `json:"targetY,omitempty"`
+	ArcDistance int `json:"arcDistance,omitempty"`
+	Altitude    int `json:"altitude,omitempty"`
 }
 
 // EffectDeliveryState stores the runtime state required to advance an instance.
@@ -183,6 +213,20 @@ type EffectInstance struct {
 	End           EndPolicy           `json:"end"`
 }
 
+// SpawnGeometry positions a definition's effects relative to the owner.
+// Zero values fall back to the runtime defaults for the effect.
+type SpawnGeometry struct {
+	SpawnOffset int `json:"spawnOffset,omitempty" jsonschema:"description=Distance in world units from the owner's centre along its facing where the effect's near edge originates.,minimum=0"`
+}
+
+// Presentation carries client-only cues for a definition. The simulation never
+// reads it; clients use it to play the same sound and particles for every
+// instance of the effect.
+type Presentation struct {
+	SoundID   string `json:"soundId,omitempty" jsonschema:"description=Audio cue the client plays when the effect spawns."`
+	Particles string `json:"particles,omitempty" jsonschema:"description=Particle preset the client renders alongside the effect."`
+}
+
 // EffectHooks reference behavior callbacks associated with a definition.
 type EffectHooks struct {
 	OnSpawn  string `json:"onSpawn,omitempty" jsonschema:"description=Callback invoked when the effect instance spawns."`
@@ -194,15 +238,20 @@ type EffectHooks struct {
 // EffectDefinition describes the canonical behaviour for an effect type.
 type EffectDefinition struct {
 	TypeID        string          `json:"typeId" jsonschema:"title=Effect Type ID,description=Canonical identifier for the gameplay effect.,pattern=^[a-z0-9-]+$,minLength=1,required"`
-	Delivery      DeliveryKind    `json:"delivery" jsonschema:"title=Delivery Mode,description=How the effect is delivered in the world.,enum=area,enum=target,enum=visual,required"`
+	Delivery      DeliveryKind    `json:"delivery" jsonschema:"title=Delivery Mode,description=How the effect is delivered in the world.,enum=area,enum=target,enum=visual,enum=beam,required"`
 	Shape         GeometryShape   `json:"shape" jsonschema:"title=Primary Shape,description=Default geometry used by the effect.,enum=circle,enum=rect,enum=arc,enum=segment,enum=capsule,required"`
 	Motion        MotionKind      `json:"motion" jsonschema:"title=Motion Profile,description=Movement behaviour applied to the instance.,enum=none,enum=instant,enum=linear,enum=parabolic,enum=follow,required"`
 	Impact        ImpactPolicy    `json:"impact" jsonschema:"title=Impact Policy,description=Collision resolution policy.,enum=first-hit,enum=all-in-path,enum=pierce,enum=none,required"`
 	LifetimeTicks int             `json:"lifetimeTicks" jsonschema:"title=Lifetime Ticks,description=Duration in simulation ticks before expiry.,minimum=0,required"`
 	PierceCount   int             `json:"pierceCount,omitempty" jsonschema:"description=Number of additional targets an instance may pierce.,minimum=0"`
+	DamageType    DamageType      `json:"damageType,omitempty" jsonschema:"description=Damage classification used for target resistances.,enum=physical,enum=fire,enum=poison"`
+	Falloff       DamageFalloff   `json:"falloff,omitempty" jsonschema:"description=How area damage scales with distance from the impact centre.,enum=linear,enum=quadratic"`
+	LineOfSight   bool            `json:"lineOfSight,omitempty" jsonschema:"description=Area effects only reach targets with a clear line from their centre."`
 	Params        map[string]int  `json:"params,omitempty" jsonschema:"description=Optional numeric designer parameters exposed to gameplay."`
+	Geometry      SpawnGeometry   `json:"geometry,omitempty" jsonschema:"description=Spawn placement relative to the owning actor."`
 	Hooks         EffectHooks     `json:"hooks" jsonschema:"description=Lifecycle callbacks executed by the server runtime.,required"`
 	Client        ReplicationSpec `json:"client" jsonschema:"description=Authoritative replication contract for clients.,required"`
+	Presentation  Presentation    `json:"presentation,omitempty" jsonschema:"description=Client-only audio and particle cues. Ignored by the simulation."`
 	End           EndPolicy       `json:"end" jsonschema:"description=Termination behaviour configuration.,required"`
 }
 
@@ -211,6 +260,12 @@ type EffectSpawnEvent struct {
 	Tick     Tick           `json:"tick"`
 	Seq      Seq            `json:"seq"`
 	Instance EffectInstance `json:"instance"` // baseline payload (may be a subset if UpdateFields used)
+	// CatalogParams marks broadcasts whose instance params were trimmed to the
+	// values that differ from the catalog entry's parameters; clients merge the
+	// overrides over those defaults. CatalogColors marks instances whose colors
+	// were omitted because they equal the catalog entry's palette.
+	CatalogParams bool `json:"catalogParams,omitempty"`
+	CatalogColors bool `json:"catalogColors,omitempty"`
 }
 
 // EffectUpdateEvent captures partial updates emitted for an active effect instance.
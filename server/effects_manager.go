@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	effectcatalog "mine-and-die/server/effects/catalog"
@@ -18,10 +19,23 @@ type EffectManager struct {
 	world *World
 }
 
+// ValidateEffectCatalog reports whether path holds an effect catalog the
+// server can load: the file must exist, parse, and only reference contracts
+// from the built-in registry. Use it before setting
+// HubConfig.EffectCatalogPath, since worlds fall back to the built-in
+// definitions when the catalog fails to load.
+func ValidateEffectCatalog(path string) error {
+	return effectcatalog.Validate(effectcontract.BuiltInRegistry, path)
+}
+
 func newEffectManager(world *World) *EffectManager {
 	definitions := effectcontract.BuiltInDefinitions()
+	catalogPath := ""
+	if world != nil {
+		catalogPath = world.effectCatalogPath
+	}
 	var resolver *effectcatalog.Resolver
-	if r, err := effectcatalog.Load(effectcontract.BuiltInRegistry, effectcatalog.DefaultPaths()...); err == nil {
+	if r, err := effectcatalog.Load(effectcontract.BuiltInRegistry, effectcatalog.PathsFor(catalogPath)...); err == nil {
 		if loaded := r.DefinitionsByContractID(); len(loaded) > 0 {
 			for id, def := range loaded {
 				if _, exists := definitions[id]; exists {
@@ -75,6 +89,15 @@ func (m *EffectManager) Hooks() map[string]internaleffects.HookSet {
 	return m.core.Hooks()
 }
 
+// RegisterHook adds a custom behavior hook effect definitions can reference
+// by name. See worldeffects.Manager.RegisterHook.
+func (m *EffectManager) RegisterHook(name string, hook EffectHookSet) error {
+	if m == nil || m.core == nil {
+		return fmt.Errorf("effect hook %q: effect manager unavailable", name)
+	}
+	return m.core.RegisterHook(name, hook)
+}
+
 func (m *EffectManager) Instances() map[string]*effectcontract.EffectInstance {
 	if m == nil || m.core == nil {
 		return nil
@@ -82,6 +105,27 @@ func (m *EffectManager) Instances() map[string]*effectcontract.EffectInstance {
 	return m.core.Instances()
 }
 
+func (m *EffectManager) ActiveSpawnEvents() []effectcontract.EffectSpawnEvent {
+	if m == nil || m.core == nil {
+		return nil
+	}
+	return m.core.ActiveSpawnEvents()
+}
+
+func (m *EffectManager) Checkpoint() internaleffects.Checkpoint {
+	if m == nil || m.core == nil {
+		return internaleffects.Checkpoint{}
+	}
+	return m.core.Checkpoint()
+}
+
+func (m *EffectManager) RestoreCheckpoint(checkpoint internaleffects.Checkpoint) {
+	if m == nil || m.core == nil {
+		return
+	}
+	m.core.RestoreCheckpoint(checkpoint)
+}
+
 func (m *EffectManager) Catalog() *effectcatalog.Resolver {
 	if m == nil || m.core == nil {
 		return nil
@@ -103,6 +147,13 @@ func (m *EffectManager) TotalDrained() int {
 	return m.core.TotalDrained()
 }
 
+func (m *EffectManager) CancelOwned(ownerID string) int {
+	if m == nil || m.core == nil {
+		return 0
+	}
+	return m.core.CancelOwned(ownerID)
+}
+
 func (m *EffectManager) Core() *worldeffects.Manager {
 	if m == nil || m.core == nil {
 		return nil
@@ -209,6 +260,7 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 				}
 
 				tick64 := uint64(tick)
+				rewind := world.takeMeleeRewind(actorID, tick64)
 
 				cfg := worldpkg.ResolveMeleeImpactConfig{
 					EffectType: effect.Type,
@@ -218,21 +270,30 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 					Tick:       tick64,
 					Now:        now,
 					Area:       worldpkg.Obstacle{X: area.X, Y: area.Y, Width: area.Width, Height: area.Height},
-					Obstacles:  world.obstacles,
+					Cone: worldpkg.MeleeCone{
+						OriginX: area.OriginX,
+						OriginY: area.OriginY,
+						Radius:  area.Radius,
+						Facing:  area.Facing,
+						Arc:     area.Arc,
+					},
+					Obstacles: world.obstacles,
 					ForEachPlayer: func(visit func(id string, x, y float64, reference any)) {
 						for id, player := range world.players {
-							if player == nil {
+							if player == nil || world.summonAllies(actorID, id) {
 								continue
 							}
-							visit(id, player.X, player.Y, player)
+							x, y := rewind.position(id, player.X, player.Y)
+							visit(id, x, y, player)
 						}
 					},
 					ForEachNPC: func(visit func(id string, x, y float64, reference any)) {
 						for id, npc := range world.npcs {
-							if npc == nil {
+							if npc == nil || world.summonAllies(actorID, id) {
 								continue
 							}
-							visit(id, npc.X, npc.Y, npc)
+							x, y := rewind.position(id, npc.X, npc.Y)
+							visit(id, x, y, npc)
 						}
 					},
 					GivePlayerGold: func(id string) (bool, error) {
@@ -246,6 +307,9 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 							_, addErr := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 1})
 							return addErr
 						})
+						if err == nil {
+							world.recordSessionGold(id, 1)
+						}
 						return true, err
 					},
 					GiveNPCGold: func(id string) (bool, error) {
@@ -307,7 +371,7 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 		},
 		Projectile: worldpkg.ProjectileHookConfig{
 			TileSize: tileSize,
-			TickRate: tickRate,
+			TickRate: world.ticksPerSecond(),
 			LookupTemplate: func(definitionID string) *internaleffects.ProjectileTemplate {
 				if world == nil {
 					return nil
@@ -340,12 +404,12 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 				if world == nil {
 					return false
 				}
-				return world.advanceProjectile((*internaleffects.State)(effect), now, dt)
+				return world.advanceProjectile((*internaleffects.State)(effect), now, dt*world.TimeScale())
 			},
 		},
 		Blood: worldpkg.BloodHookConfig{
 			TileSize:        tileSize,
-			TickRate:        tickRate,
+			TickRate:        world.ticksPerSecond(),
 			DefaultSize:     playerHalf * 2,
 			DefaultDuration: bloodSplatterDuration,
 			Params:          newBloodSplatterParams,
@@ -363,6 +427,106 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 				world.recordEffectSpawn(effectType, category)
 			},
 		},
+		Beam: worldpkg.BeamHookConfig{
+			TileSize:      tileSize,
+			DefaultRange:  beamDefaultRange,
+			DefaultDamage: beamDefaultDamage,
+			LookupOwner: func(actorID string) *internaleffects.BeamOwner {
+				if world == nil {
+					return nil
+				}
+				return world.beamOwner(actorID)
+			},
+			Trace: func(fromX, fromY, toX, toY float64) (float64, float64) {
+				if world == nil {
+					return toX, toY
+				}
+				return world.traceBeam(fromX, fromY, toX, toY)
+			},
+			ResolveHit: func(effect *worldeffects.State, segment internaleffects.BeamSegment, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveBeamHit((*internaleffects.State)(effect), segment, now)
+			},
+		},
+		Target: worldpkg.TargetHookConfig{
+			DefaultHealthDelta: healAmount,
+			ResolveHit: func(effect *worldeffects.State, targetID string, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveTargetHit((*internaleffects.State)(effect), targetID, now)
+			},
+		},
+		AreaHeal: worldpkg.AreaHealHookConfig{
+			TileSize:           tileSize,
+			DefaultRadius:      healBurstRadius,
+			DefaultHealthDelta: healBurstAmount,
+			LookupOwner: func(actorID string) *internaleffects.AreaHealOwner {
+				if world == nil {
+					return nil
+				}
+				return world.healBurstOwner(actorID)
+			},
+			ResolveHits: func(effect *worldeffects.State, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveHealBurst((*internaleffects.State)(effect), now)
+			},
+		},
+		AreaPull: worldpkg.AreaPullHookConfig{
+			TileSize:      tileSize,
+			DefaultRadius: gravityWellRadius,
+			DefaultPull:   gravityWellPull,
+			LookupOwner: func(actorID string) *internaleffects.AreaPullOwner {
+				if world == nil {
+					return nil
+				}
+				return world.gravityWellOwner(actorID)
+			},
+			ResolvePull: func(effect *worldeffects.State, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveGravityPull((*internaleffects.State)(effect))
+			},
+		},
+		Explosion: worldpkg.AreaExplosionHookConfig{
+			TileSize:           tileSize,
+			DefaultRadius:      explosionRadius,
+			DefaultHealthDelta: -explosionDamage,
+			LookupOwner: func(actorID string) *internaleffects.AreaExplosionOwner {
+				if world == nil {
+					return nil
+				}
+				return world.explosionOwner(actorID)
+			},
+			ResolveHits: func(effect *worldeffects.State, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveExplosion((*internaleffects.State)(effect), now)
+			},
+		},
+		Hazard: worldpkg.AreaHazardHookConfig{
+			TileSize:           tileSize,
+			DefaultRadius:      firePatchRadius,
+			DefaultHealthDelta: -firePatchDamage,
+			LookupOwner: func(actorID string) *internaleffects.AreaHazardOwner {
+				if world == nil {
+					return nil
+				}
+				return world.hazardOwner(actorID)
+			},
+			ResolveHits: func(effect *worldeffects.State, now time.Time) {
+				if world == nil {
+					return
+				}
+				world.resolveHazardPulse((*internaleffects.State)(effect), now)
+			},
+		},
 	}
 
 	hooks := worldpkg.BuildEffectManagerHooks(hookCfg)
@@ -404,7 +568,7 @@ func defaultEffectHookRegistry(world *World) map[string]internaleffects.HookSet
 		FallbackLifetime: worldpkg.BurningTickInterval,
 		TileSize:         tileSize,
 		DefaultFootprint: playerHalf * 2,
-		TickRate:         tickRate,
+		TickRate:         world.ticksPerSecond(),
 		LookupActor:      lookupContractActor,
 		ExtendLifetime: func(fields statuspkg.StatusEffectLifetimeFields, expiresAt time.Time) {
 			statuspkg.ExtendStatusEffectLifetime(fields, expiresAt)
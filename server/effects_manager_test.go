@@ -167,3 +167,62 @@ func bindAbilityGatesForTest(t *testing.T, world *World, constructed *worldpkg.W
 	world.meleeAbilityGate = gates.Melee
 	world.projectileAbilityGate = gates.Projectile
 }
+
+func TestRegisteredEffectHookRunsOnSpawn(t *testing.T) {
+	hub := newHubWithFullWorld()
+
+	const effectType = "effect.test.ignite"
+	const hookID = "ignite"
+
+	ignite := EffectHookSet{
+		OnSpawn: func(rt internaleffects.Runtime, instance *effectcontract.EffectInstance, tick effectcontract.Tick, now time.Time) {
+			if instance.BehaviorState.Extra == nil {
+				instance.BehaviorState.Extra = make(map[string]int)
+			}
+			instance.BehaviorState.Extra["ignited"]++
+			instance.BehaviorState.Stacks = map[string]int{"burning": 3}
+		},
+	}
+	if err := hub.RegisterEffectHook(hookID, ignite); err != nil {
+		t.Fatalf("expected hook registration to succeed, got %v", err)
+	}
+	if err := hub.RegisterEffectHook(hookID, ignite); err == nil {
+		t.Fatalf("expected duplicate hook name to be rejected")
+	}
+	if err := hub.RegisterEffectHook(effectcontract.HookMeleeSpawn, ignite); err == nil {
+		t.Fatalf("expected built-in hook name to be rejected")
+	}
+
+	// Registered hooks carry over to worlds built by a reset.
+	hub.ResetWorld(hub.CurrentConfig())
+	manager := hub.world.effectManager
+	manager.Definitions()[effectType] = &effectcontract.EffectDefinition{
+		TypeID:        effectType,
+		LifetimeTicks: 3,
+		Hooks:         effectcontract.EffectHooks{OnSpawn: hookID},
+		Client:        effectcontract.ReplicationSpec{SendSpawn: true, SendEnd: true},
+		End:           effectcontract.EndPolicy{Kind: effectcontract.EndDuration},
+	}
+	manager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectType,
+		TypeID:        effectType,
+		DurationTicks: 3,
+		SourceActorID: "owner-1",
+	})
+
+	now := time.Now()
+	manager.RunTick(effectcontract.Tick(1), now, nil)
+	manager.RunTick(effectcontract.Tick(2), now.Add(time.Millisecond), nil)
+
+	if len(manager.Instances()) != 1 {
+		t.Fatalf("expected one active instance, got %d", len(manager.Instances()))
+	}
+	for _, inst := range manager.Instances() {
+		if got := inst.BehaviorState.Extra["ignited"]; got != 1 {
+			t.Fatalf("expected the spawn hook to run once, ran %d times", got)
+		}
+		if got := inst.BehaviorState.Stacks["burning"]; got != 3 {
+			t.Fatalf("expected the spawn hook to set burning stacks, got %d", got)
+		}
+	}
+}
@@ -1,10 +1,6 @@
 package server
 
-import (
-	"math"
-
-	effectcontract "mine-and-die/server/effects/contract"
-)
+import effectcontract "mine-and-die/server/effects/contract"
 
 // FixedVec represents a 2D vector using the fixed-point coordinate system
 // defined by COORD_SCALE. All values are stored as integers to guarantee
@@ -43,16 +39,15 @@ type FixedCapsule struct {
 }
 
 // QuantizeCoord converts a floating point coordinate into the shared
-// fixed-point representation. Values are rounded to the nearest sub-unit to
-// keep round-trips deterministic.
+// fixed-point representation. See effectcontract.QuantizeCoord.
 func QuantizeCoord(value float64) int {
-	return int(math.Round(value * effectcontract.CoordScale))
+	return effectcontract.QuantizeCoord(value)
 }
 
 // DequantizeCoord converts a fixed-point coordinate back into floating point
 // space. The helper is primarily intended for debugging and diagnostics code.
 func DequantizeCoord(value int) float64 {
-	return float64(value) / effectcontract.CoordScale
+	return effectcontract.DequantizeCoord(value)
 }
 
 // QuantizeVelocity converts a world-units-per-second velocity into the
@@ -185,3 +185,39 @@ func TestWorldAttachTelemetryConfiguresEffectRegistryOverflow(t *testing.T) {
 		t.Fatalf("expected overflow counter to increment, got %d", got)
 	}
 }
+
+func TestFlushEffectTriggersCollapsesRepeatOverlapWithinTick(t *testing.T) {
+	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	now := time.Unix(0, 0)
+
+	// The same effect overlaps the same target on two sub-steps of one tick.
+	for step := 0; step < 2; step++ {
+		w.QueueEffectTrigger(EffectTrigger{
+			Type:           effectTypeAttack,
+			SourceEffectID: "effect-swing",
+			TargetID:       "npc-target",
+		}, now.Add(time.Duration(step)*time.Millisecond))
+	}
+	w.QueueEffectTrigger(EffectTrigger{
+		Type:           effectTypeAttack,
+		SourceEffectID: "effect-swing",
+		TargetID:       "npc-other",
+	}, now)
+
+	triggers := w.flushEffectTriggersLocked()
+	if len(triggers) != 2 {
+		t.Fatalf("expected one trigger per target, got %d: %+v", len(triggers), triggers)
+	}
+	if triggers[0].TargetID != "npc-target" || triggers[1].TargetID != "npc-other" {
+		t.Fatalf("expected first-occurrence order to be kept, got %+v", triggers)
+	}
+
+	w.QueueEffectTrigger(EffectTrigger{
+		Type:           effectTypeAttack,
+		SourceEffectID: "effect-swing",
+		TargetID:       "npc-target",
+	}, now.Add(time.Second))
+	if next := w.flushEffectTriggersLocked(); len(next) != 1 {
+		t.Fatalf("expected a new tick to allow the hit trigger again, got %d", len(next))
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	itemspkg "mine-and-die/server/internal/items"
 	loggingeconomy "mine-and-die/server/logging/economy"
+	stats "mine-and-die/server/stats"
 )
 
 const groundPickupRadius = tileSize
@@ -157,6 +158,23 @@ func (w *World) nearestGroundItem(actor *actorState, itemType ItemType) (*itemsp
 	return itemspkg.NearestGroundItem(w.groundItems, toWorldActor(actor), string(itemType))
 }
 
+// pickupRadiusFor scales the base pickup radius by the player's pickup reach
+// stat. Actors without a stats component fall back to the base radius.
+func (w *World) pickupRadiusFor(actorID string) float64 {
+	if w == nil {
+		return groundPickupRadius
+	}
+	player, ok := w.players[actorID]
+	if !ok || player == nil {
+		return groundPickupRadius
+	}
+	reach := player.Stats.GetDerived(stats.DerivedPickupReach)
+	if reach <= 0 {
+		return groundPickupRadius
+	}
+	return groundPickupRadius * reach
+}
+
 func (w *World) pickupNearestGold(actor *actorState) (*itemspkg.PickupResult, *itemspkg.PickupFailure) {
 	if w == nil || actor == nil {
 		return nil, &itemspkg.PickupFailure{Reason: itemspkg.PickupFailureReasonNotFound}
@@ -166,13 +184,16 @@ func (w *World) pickupNearestGold(actor *actorState) (*itemspkg.PickupResult, *i
 	if worldActor == nil {
 		return nil, &itemspkg.PickupFailure{Reason: itemspkg.PickupFailureReasonNotFound}
 	}
+	if failure := w.lootLockedFailure(actor, ItemTypeGold); failure != nil {
+		return nil, failure
+	}
 
-	return itemspkg.PickupNearestItem(
+	result, failure := itemspkg.PickupNearestItem(
 		w.groundItems,
 		w.groundItemsByTile,
 		worldActor,
 		string(ItemTypeGold),
-		groundPickupRadius,
+		w.pickupRadiusFor(actor.ID),
 		func(stack itemspkg.ItemStack) error {
 			return w.MutateInventory(actor.ID, func(inv *Inventory) error {
 				_, addErr := inv.AddStack(ItemStack{
@@ -185,6 +206,10 @@ func (w *World) pickupNearestGold(actor *actorState) (*itemspkg.PickupResult, *i
 		},
 		w.AppendPatch,
 	)
+	if failure == nil && result != nil {
+		w.recordSessionGold(actor.ID, result.Quantity)
+	}
+	return result, failure
 }
 
 func (w *World) dropGold(actor *actorState, quantity int, reason string) (*itemspkg.DropResult, *itemspkg.DropFailure) {
@@ -438,6 +463,9 @@ func (w *World) dropAllInventory(actor *actorState, reason string) int {
 	if !ok {
 		return 0
 	}
+	if reason == "death" {
+		cfg.LogDrop = w.reserveDeathLoot(actor.ID, cfg.LogDrop)
+	}
 
 	return itemspkg.InvokeGroundDrop(cfg, func(d itemspkg.GroundDropDelegates) int {
 		return itemspkg.DropAllInventory(d, reason, inventoryDrain, equipmentDrain)
@@ -6,15 +6,18 @@ import (
 	"errors"
 	"fmt"
 	stdlog "log"
+	"math"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	effectcatalog "mine-and-die/server/effects/catalog"
 	effectcontract "mine-and-die/server/effects/contract"
 	internaleffects "mine-and-die/server/internal/effects"
 	itemspkg "mine-and-die/server/internal/items"
+	journal "mine-and-die/server/internal/journal"
 	"mine-and-die/server/internal/net/proto"
 	"mine-and-die/server/internal/sim"
 	simpaches "mine-and-die/server/internal/sim/patches"
@@ -40,6 +43,35 @@ type Hub struct {
 	publisher       logging.Publisher
 	telemetry       *telemetryCounters
 	broadcastFanout *broadcastFanout
+	lootTables      map[NPCType]LootTable
+	staleInventory  StaleInventoryPolicy
+	meleeArc        float64
+	meleeCombo      MeleeComboConfig
+	lagCompensation int
+	interestRadius  float64
+	batchAcks       bool
+	writeTimeout    time.Duration
+	maxWriteFails   int
+	reconnectGrace  time.Duration
+	disconnectAfter time.Duration
+	idleKickAfter   time.Duration
+	maxWorldSize    float64
+	aiBudget        int
+	tickRate        int
+	timeScale       float64
+	// effectHooks are the custom effect behavior hooks registered through
+	// RegisterEffectHook, reapplied to every world the hub builds.
+	effectHooks map[string]EffectHookSet
+	// effectCatalogPath is the catalog file every world in this hub loads;
+	// empty uses the default catalog.
+	effectCatalogPath string
+
+	// privileged holds players allowed to run debug console commands; guarded
+	// by mu.
+	privileged map[string]struct{}
+
+	// reconnectSessions maps reconnect tokens to their player; guarded by mu.
+	reconnectSessions map[string]*reconnectSession
 
 	resubscribeBaselines map[string]simpaches.PlayerView
 
@@ -47,14 +79,19 @@ type Hub struct {
 	tick   atomic.Uint64
 	seq    atomic.Uint64
 
+	// nextSpectatorID numbers spectator subscriptions, which have no player.
+	nextSpectatorID atomic.Uint64
+
 	defaultKeyframeInterval int
 	keyframeInterval        atomic.Int64
+	keyframeCadence         KeyframeCadenceConfig
 	lastKeyframeSeq         atomic.Uint64
 	lastKeyframeTick        atomic.Uint64
 
 	resyncNext               atomic.Bool
 	forceKeyframeNext        atomic.Bool
 	tickBudgetAlarmTriggered atomic.Bool
+	paused                   atomic.Bool
 }
 
 func (h *Hub) engineDeps() sim.Deps {
@@ -107,6 +144,14 @@ func (h *Hub) Tick() uint64 {
 	return h.tick.Load()
 }
 
+// TickRate reports the simulation frequency in hertz.
+func (h *Hub) TickRate() int {
+	if h == nil {
+		return tickRate
+	}
+	return normalizeTickRate(h.tickRate)
+}
+
 // Now returns the hub's current clock reading.
 func (h *Hub) Now() time.Time {
 	return h.now()
@@ -149,12 +194,44 @@ type subscriber struct {
 	lastCommandSeq atomic.Uint64
 	limiter        keyframeRateLimiter
 
+	// lastKeyframeTick is the tick of the last keyframe delivered to this
+	// subscriber; adaptive keyframe cadence measures from it.
+	lastKeyframeTick atomic.Uint64
+	// catchingUp is set while the subscriber's send queue is backed up; it
+	// skips deltas until a catch-up keyframe is delivered.
+	catchingUp atomic.Bool
+
 	sendQueue chan sendRequest
 	closed    chan struct{}
 	closeOnce sync.Once
 	closeErr  atomic.Value
 
+	// writeTimeout bounds every outbound write. After maxWriteFailures
+	// consecutive timed-out writes the subscriber is closed as too slow;
+	// writeFailures counts the current streak.
+	writeTimeout     time.Duration
+	maxWriteFailures int
+	writeFailures    atomic.Int32
+
 	telemetry subscriberQueueTelemetry
+
+	// interest holds the entity IDs inside the subscriber's area of interest
+	// as of the last broadcast. It is guarded by the hub mutex.
+	interest interestSet
+	// withheldEffects holds the effect IDs whose spawn was kept from this
+	// subscriber because their owner was hidden by stealth, so their updates
+	// and end stay hidden too. It is guarded by the hub mutex.
+	withheldEffects map[string]struct{}
+	// culledEffects holds the effect IDs outside the subscriber's area of
+	// interest whose updates and end are withheld until they re-enter. It is
+	// guarded by the hub mutex.
+	culledEffects map[string]struct{}
+
+	ackMu      sync.Mutex
+	pendingAck proto.CommandAck
+
+	// chatLimiter throttles chat messages sent over this connection.
+	chatLimiter keyframeRateLimiter
 }
 
 type sendRequest struct {
@@ -248,7 +325,7 @@ func (s *subscriber) writeWithDeadline(base time.Time, data []byte) error {
 	}
 	req := sendRequest{
 		data:     data,
-		deadline: base.Add(writeWait),
+		deadline: base.Add(s.writeTimeout),
 		result:   make(chan error, 1),
 	}
 	return s.enqueue(req, true)
@@ -270,14 +347,58 @@ func (s *subscriber) StoreLastCommandSeq(seq uint64) {
 	s.lastCommandSeq.Store(seq)
 }
 
+// QueueCommandAck records an acknowledgement to flush with the next state
+// broadcast. Only the highest sequence is kept since acking it implies every
+// earlier command.
+func (s *subscriber) QueueCommandAck(ack proto.CommandAck) {
+	if s == nil || ack.Seq == 0 {
+		return
+	}
+	s.ackMu.Lock()
+	if ack.Seq >= s.pendingAck.Seq {
+		s.pendingAck = ack
+	}
+	s.ackMu.Unlock()
+}
+
+func (s *subscriber) hasPendingCommandAck() bool {
+	if s == nil {
+		return false
+	}
+	s.ackMu.Lock()
+	defer s.ackMu.Unlock()
+	return s.pendingAck.Seq > 0
+}
+
+func (s *subscriber) takePendingCommandAck() (proto.CommandAck, bool) {
+	if s == nil {
+		return proto.CommandAck{}, false
+	}
+	s.ackMu.Lock()
+	defer s.ackMu.Unlock()
+	ack := s.pendingAck
+	s.pendingAck = proto.CommandAck{}
+	return ack, ack.Seq > 0
+}
+
 func newSubscriber(conn subscriberConn, telemetry subscriberQueueTelemetry) *subscriber {
+	return newSubscriberWithWriteLimits(conn, telemetry, writeWait, defaultMaxWriteFailures)
+}
+
+// newSubscriberWithWriteLimits builds a subscriber whose writes time out
+// after writeTimeout and which is closed after maxWriteFailures consecutive
+// timeouts.
+func newSubscriberWithWriteLimits(conn subscriberConn, telemetry subscriberQueueTelemetry, writeTimeout time.Duration, maxWriteFailures int) *subscriber {
 	sub := &subscriber{
-		conn:      conn,
-		limiter:   newKeyframeRateLimiter(keyframeLimiterCapacity, keyframeLimiterRefillPer),
-		sendQueue: make(chan sendRequest, subscriberSendQueueSize),
-		closed:    make(chan struct{}),
-		telemetry: telemetry,
-	}
+		conn:             conn,
+		limiter:          newKeyframeRateLimiter(keyframeLimiterCapacity, keyframeLimiterRefillPer),
+		sendQueue:        make(chan sendRequest, subscriberSendQueueSize),
+		closed:           make(chan struct{}),
+		telemetry:        telemetry,
+		writeTimeout:     writeTimeout,
+		maxWriteFailures: maxWriteFailures,
+	}
+	sub.chatLimiter = newKeyframeRateLimiter(chatLimiterCapacity, chatLimiterRefillPer)
 	sub.recordQueueDepth(0)
 	go sub.runWriter()
 	return sub
@@ -328,10 +449,10 @@ func (s *subscriber) runWriter() {
 			s.recordQueueDepth(len(s.sendQueue))
 			return
 		case req := <-s.sendQueue:
-			err := s.send(req)
+			err := s.recordWriteResult(s.send(req))
 			s.finishRequest(req, err)
 			s.recordQueueDepth(len(s.sendQueue))
-			if err != nil {
+			if err != nil && !errors.Is(err, errSubscriberWriteDropped) {
 				s.recordCloseError(err)
 				s.Close()
 				s.drainPending(err)
@@ -401,7 +522,7 @@ func (s *subscriber) enqueue(req sendRequest, wait bool) error {
 }
 
 func (s *subscriber) EnqueueBroadcast(base time.Time, data []byte) error {
-	req := sendRequest{data: data, deadline: base.Add(writeWait)}
+	req := sendRequest{data: data, deadline: base.Add(s.writeTimeout)}
 	return s.enqueue(req, false)
 }
 
@@ -435,12 +556,23 @@ const (
 
 	commandRejectUnknownActor  = "unknown_actor"
 	commandRejectInvalidAction = "invalid_action"
+	commandRejectUnknownEffect = "unknown_effect"
+	commandRejectSpectator     = "spectator"
+	commandRejectInvalidTarget = "invalid_target"
+	commandRejectOutOfRange    = "out_of_range"
 )
 
 const (
 	CommandRejectUnknownActor  = commandRejectUnknownActor
 	CommandRejectInvalidAction = commandRejectInvalidAction
+	CommandRejectUnknownEffect = commandRejectUnknownEffect
 	CommandRejectQueueLimit    = sim.CommandRejectQueueLimit
+	CommandRejectSpectator     = commandRejectSpectator
+	CommandRejectInvalidTarget = commandRejectInvalidTarget
+	CommandRejectOutOfRange    = commandRejectOutOfRange
+
+	// ActionEmote is the action name of cosmetic emotes.
+	ActionEmote = actionEmote
 )
 
 type keyframeLookupStatus int
@@ -497,6 +629,72 @@ type HubConfig struct {
 	KeyframeInterval int
 	Logger           telemetry.Logger
 	Metrics          telemetry.Metrics
+	// NPCLootTables configures the extra drops rolled when NPCs of each type die.
+	NPCLootTables map[NPCType]LootTable
+	// StaleInventory decides whether players removed for missed heartbeats
+	// drop or bank their items instead of losing them.
+	StaleInventory StaleInventoryPolicy
+	// MeleeArc swings melee attacks as a cone of this many degrees instead of
+	// the box in front of the attacker. Zero keeps the box.
+	MeleeArc float64
+	// MeleeCombo escalates rapid consecutive melee swings into a stronger
+	// finisher. The zero value disables combos.
+	MeleeCombo MeleeComboConfig
+	// LagCompensationTicks resolves melee hits against where targets stood
+	// at the swing command's origin tick, interpolated from the keyframe
+	// history and rewinding at most this many ticks. Zero disables it.
+	LagCompensationTicks int
+	// BatchCommandAcks defers command acknowledgements until the next state
+	// broadcast instead of writing one frame per accepted command.
+	BatchCommandAcks bool
+	// WriteTimeout bounds each outbound websocket write. Zero keeps the
+	// default of ten seconds.
+	WriteTimeout time.Duration
+	// MaxWriteFailures disconnects a subscriber whose writes time out this
+	// many times in a row. Zero keeps the default of three; a successful
+	// write resets the count.
+	MaxWriteFailures int
+	// TickRate sets the simulation frequency in hertz. Zero or negative
+	// values fall back to the default rate.
+	TickRate int
+	// ReconnectGrace keeps a dropped player's state for this long so the
+	// client can resume it with the join token. Zero removes players on
+	// disconnect.
+	ReconnectGrace time.Duration
+	// InterestRadius limits delta broadcasts to entities within this distance
+	// of each subscriber's player. Zero disables interest management.
+	InterestRadius float64
+	// DisconnectAfter removes players whose last heartbeat is older than this.
+	// Zero keeps the default of three heartbeat intervals. Individual players
+	// can be given a different timeout with SetPlayerDisconnectTimeout.
+	DisconnectAfter time.Duration
+	// IdleKickAfter removes players that send no movement or action commands
+	// for this long even while their heartbeats keep arriving. Zero disables
+	// the idle kick.
+	IdleKickAfter time.Duration
+	// MaxWorldSize caps the width and height accepted by ValidateWorldConfig.
+	// Zero, or anything above worldpkg.MaxDimension, keeps MaxDimension.
+	MaxWorldSize float64
+	// AIDecisionBudget caps how many NPCs run their AI state machine per tick;
+	// the rest keep moving and are served round-robin on later ticks. Zero
+	// keeps the default cap.
+	AIDecisionBudget int
+	// KeyframeCadence adapts the keyframe interval per subscriber to its ack
+	// lag. The zero value sends every keyframe to every subscriber.
+	KeyframeCadence KeyframeCadenceConfig
+	// World configures the world generated at startup. The zero value matches
+	// worldpkg.DefaultConfig.
+	World worldConfig
+	// EffectCatalogPath loads effect definitions from this JSON catalog
+	// instead of config/effects/definitions.json. Check it with
+	// ValidateEffectCatalog first; an unreadable file leaves the hub on the
+	// built-in definitions only. Empty keeps the default catalog.
+	EffectCatalogPath string
+	// ConsoleOperators lists the player IDs granted the debug console
+	// privilege for commands such as time_scale and list_effects, as if
+	// SetConsolePrivilege had been called for each. Player IDs are assigned
+	// in join order ("player-1", "player-2", ...).
+	ConsoleOperators []string
 }
 
 func DefaultHubConfig() HubConfig {
@@ -509,7 +707,7 @@ func newHub(pubs ...logging.Publisher) *Hub {
 }
 
 func NewHubWithConfig(hubCfg HubConfig, pubs ...logging.Publisher) *Hub {
-	cfg := worldpkg.DefaultConfig().Normalized()
+	cfg := hubCfg.World.Normalized()
 	var pub logging.Publisher
 	if len(pubs) > 0 && pubs[0] != nil {
 		pub = pubs[0]
@@ -538,13 +736,25 @@ func NewHubWithConfig(hubCfg HubConfig, pubs ...logging.Publisher) *Hub {
 		}
 	}
 
+	rate := normalizeTickRate(hubCfg.TickRate)
 	telemetryCounters := newTelemetryCounters(metrics)
+	telemetryCounters.setTickRate(rate)
 
 	world := requireLegacyWorld(worldpkg.ConstructLegacy(cfg, pub, worldpkg.Deps{
-		Publisher:        pub,
-		JournalTelemetry: telemetryCounters,
+		Publisher:         pub,
+		JournalTelemetry:  telemetryCounters,
+		TickRate:          rate,
+		EffectCatalogPath: hubCfg.EffectCatalogPath,
 	}))
 	cfg = world.config
+	world.SetNPCLootTables(hubCfg.NPCLootTables)
+	world.SetStaleInventoryPolicy(hubCfg.StaleInventory)
+	world.SetDisconnectTimeout(hubCfg.DisconnectAfter)
+	world.SetIdleKickTimeout(hubCfg.IdleKickAfter)
+	world.SetMeleeArc(hubCfg.MeleeArc)
+	world.SetMeleeCombo(hubCfg.MeleeCombo)
+	world.SetLagCompensation(hubCfg.LagCompensationTicks)
+	world.SetAIDecisionBudget(hubCfg.AIDecisionBudget)
 
 	engineDeps := sim.Deps{
 		Logger:  hubCfg.Logger,
@@ -568,10 +778,30 @@ func NewHubWithConfig(hubCfg HubConfig, pubs ...logging.Publisher) *Hub {
 		publisher:               pub,
 		telemetry:               telemetryCounters,
 		defaultKeyframeInterval: interval,
+		keyframeCadence:         hubCfg.KeyframeCadence.normalized(),
 		resubscribeBaselines:    nil,
+		lootTables:              hubCfg.NPCLootTables,
+		staleInventory:          hubCfg.StaleInventory,
+		meleeArc:                hubCfg.MeleeArc,
+		meleeCombo:              hubCfg.MeleeCombo,
+		lagCompensation:         hubCfg.LagCompensationTicks,
+		interestRadius:          hubCfg.InterestRadius,
+		batchAcks:               hubCfg.BatchCommandAcks,
+		writeTimeout:            hubCfg.WriteTimeout,
+		maxWriteFails:           hubCfg.MaxWriteFailures,
+		reconnectGrace:          hubCfg.ReconnectGrace,
+		disconnectAfter:         hubCfg.DisconnectAfter,
+		idleKickAfter:           hubCfg.IdleKickAfter,
+		maxWorldSize:            hubCfg.MaxWorldSize,
+		aiBudget:                hubCfg.AIDecisionBudget,
+		tickRate:                rate,
+		effectCatalogPath:       hubCfg.EffectCatalogPath,
+	}
+	for _, id := range hubCfg.ConsoleOperators {
+		hub.SetConsolePrivilege(strings.TrimSpace(id), true)
 	}
 	loopCfg := sim.LoopConfig{
-		TickRate:        tickRate,
+		TickRate:        rate,
 		CatchupMaxTicks: tickBudgetCatchupMaxTicks,
 		CommandCapacity: commandBufferCapacity,
 		PerActorLimit:   commandQueuePerActorLimit,
@@ -601,6 +831,7 @@ func NewHubWithConfig(hubCfg HubConfig, pubs ...logging.Publisher) *Hub {
 		OnQueueWarning: func(length int) {
 			hub.logf("[backpressure] pendingCommands=%d; investigate tick latency or raise throttle thresholds", length)
 		},
+		Paused: hub.paused.Load,
 	}
 	engine, err := sim.NewEngine(
 		engineAdapter,
@@ -612,6 +843,7 @@ func NewHubWithConfig(hubCfg HubConfig, pubs ...logging.Publisher) *Hub {
 		panic(fmt.Sprintf("hub: failed to construct engine: %v", err))
 	}
 	hub.engine = engine
+	hub.warnMissingActionEffects()
 
 	hub.world.attachTelemetry(hub.telemetry)
 	hub.world.AttachJournalTelemetry(hub.telemetry)
@@ -812,6 +1044,7 @@ func (h *Hub) seedPlayerState(playerID string, now time.Time) *playerState {
 		},
 		Stats:         statsComp,
 		LastHeartbeat: now,
+		LastInput:     now,
 		Cooldowns:     make(map[string]time.Time),
 		Path:          playerPathState{ArriveRadius: defaultPlayerArriveRadius},
 	}
@@ -826,11 +1059,15 @@ func (h *Hub) Join() joinResponse {
 	player := h.seedPlayerState(playerID, now)
 
 	h.mu.Lock()
+	h.pruneReconnectSessionsLocked(now)
 	h.world.AddPlayer(player)
+	token := h.issueReconnectTokenLocked(playerID)
 	snapshot := h.simSnapshotLocked(true, false)
 	players := legacyPlayersFromSim(snapshot.Players)
 	npcs := legacyNPCsFromSim(snapshot.NPCs)
 	groundItems := itemspkg.CloneGroundItems(snapshot.GroundItems)
+	corpses := simCorpsesFromLegacy(h.world.CorpsesSnapshot())
+	filter := h.stealthFilterLocked(playerID, h.world.stealthedActors())
 	cfg := h.config
 	h.mu.Unlock()
 
@@ -849,14 +1086,16 @@ func (h *Hub) Join() joinResponse {
 	return joinResponse{
 		Ver:               ProtocolVersion,
 		ID:                playerID,
-		Players:           snapshot.Players,
-		NPCs:              snapshot.NPCs,
+		Players:           filterStealthPlayers(snapshot.Players, filter),
+		NPCs:              filterStealthNPCs(snapshot.NPCs, filter),
 		Obstacles:         snapshot.Obstacles,
 		GroundItems:       snapshot.GroundItems,
+		Corpses:           corpses,
 		Config:            simWorldConfigFromLegacy(cfg),
 		Resync:            true,
 		KeyframeInterval:  h.CurrentKeyframeInterval(),
 		EffectCatalogHash: effectcontract.EffectCatalogHash,
+		ReconnectToken:    token,
 	}
 }
 
@@ -876,12 +1115,29 @@ func (h *Hub) ResetWorld(cfg worldConfig) ([]Player, []NPC) {
 	}
 
 	newW := requireLegacyWorld(worldpkg.ConstructLegacy(cfg, h.publisher, worldpkg.Deps{
-		Publisher:        h.publisher,
-		JournalTelemetry: h.telemetry,
+		Publisher:         h.publisher,
+		JournalTelemetry:  h.telemetry,
+		TickRate:          h.tickRate,
+		EffectCatalogPath: h.effectCatalogPath,
 	}))
 	cfg = newW.config
 	newW.attachTelemetry(h.telemetry)
 	newW.AttachJournalTelemetry(h.telemetry)
+	newW.SetNPCLootTables(h.lootTables)
+	newW.SetStaleInventoryPolicy(h.staleInventory)
+	newW.SetDisconnectTimeout(h.disconnectAfter)
+	newW.SetIdleKickTimeout(h.idleKickAfter)
+	for id, timeout := range h.world.disconnectOverrides {
+		newW.SetPlayerDisconnectTimeout(id, timeout)
+	}
+	newW.SetMeleeArc(h.meleeArc)
+	newW.SetMeleeCombo(h.meleeCombo)
+	newW.SetLagCompensation(h.lagCompensation)
+	newW.SetAIDecisionBudget(h.aiBudget)
+	newW.SetTimeScale(h.timeScale)
+	if err := newW.registerEffectHooks(h.effectHooks); err != nil {
+		h.logf("[effects] failed to register effect hooks on reset world: %v", err)
+	}
 	for _, id := range playerIDs {
 		newW.AddPlayer(h.seedPlayerState(id, now))
 	}
@@ -900,6 +1156,11 @@ func (h *Hub) ResetWorld(cfg worldConfig) ([]Player, []NPC) {
 		players, npcs = h.world.Snapshot(now)
 	}
 	h.resubscribeBaselines = nil
+	for token, session := range h.reconnectSessions {
+		if session.Player != nil {
+			delete(h.reconnectSessions, token)
+		}
+	}
 	h.mu.Unlock()
 
 	h.tick.Store(0)
@@ -909,6 +1170,12 @@ func (h *Hub) ResetWorld(cfg worldConfig) ([]Player, []NPC) {
 	return players, npcs
 }
 
+// ValidateWorldConfig rejects a requested world whose width or height falls
+// outside the hub's size limits.
+func (h *Hub) ValidateWorldConfig(cfg worldConfig) error {
+	return cfg.ValidateSize(h.maxWorldSize)
+}
+
 // CurrentConfig returns a copy of the active world configuration.
 func (h *Hub) CurrentConfig() worldConfig {
 	h.mu.Lock()
@@ -932,7 +1199,7 @@ func (h *Hub) Subscribe(playerID string, conn subscriberConn) (*subscriber, []si
 		existing.Close()
 	}
 
-	sub := newSubscriber(conn, h.telemetry)
+	sub := h.newSubscriber(conn)
 	h.subscribers[playerID] = sub
 	snapshot := h.simSnapshotLocked(true, false)
 	return sub, snapshot.Players, snapshot.NPCs, snapshot.GroundItems, true
@@ -997,7 +1264,9 @@ func (h *Hub) disconnect(playerID string, target *subscriber) ([]Player, []NPC)
 		delete(h.subscribers, playerID)
 	}
 
-	removed := h.world.RemovePlayer(playerID)
+	now := h.now()
+	detached := h.detachForReconnectLocked(playerID, now)
+	removed := detached || h.world.RemovePlayer(playerID)
 	var players []Player
 	var npcs []NPC
 	if removed {
@@ -1005,13 +1274,14 @@ func (h *Hub) disconnect(playerID string, target *subscriber) ([]Player, []NPC)
 			snapshot := h.engine.Snapshot()
 			players, npcs = legacyActorsFromSimSnapshot(snapshot)
 		} else {
-			now := h.now()
 			players, npcs = h.world.Snapshot(now)
 		}
 	}
 	h.mu.Unlock()
 
+	slow := false
 	if subOK {
+		slow = h.publishSlowClientDisconnect(playerID, sub)
 		sub.Close()
 	}
 
@@ -1019,12 +1289,19 @@ func (h *Hub) disconnect(playerID string, target *subscriber) ([]Player, []NPC)
 		return nil, nil
 	}
 
+	reason := "manual"
+	if detached {
+		reason = "reconnect_grace"
+	} else if slow {
+		reason = "slow_client"
+	}
+
 	logginglifecycle.PlayerDisconnected(
 		context.Background(),
 		h.publisher,
 		h.tick.Load(),
 		logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")},
-		logginglifecycle.PlayerDisconnectedPayload{Reason: "manual"},
+		logginglifecycle.PlayerDisconnectedPayload{Reason: reason},
 		nil,
 	)
 
@@ -1091,22 +1368,121 @@ func (h *Hub) ClearPlayerPath(playerID string) (sim.Command, bool, string) {
 
 // HandleAction queues an action command for processing on the next tick.
 func (h *Hub) HandleAction(playerID, action string) (sim.Command, bool, string) {
-	switch action {
-	case effectTypeAttack, effectTypeFireball:
-	default:
+	return h.HandleAimedAction(playerID, action, 0, 0)
+}
+
+// HandleEmote queues a cosmetic emote. It plays an animation on nearby clients
+// and never touches the simulation.
+func (h *Hub) HandleEmote(playerID, emote string) (sim.Command, bool, string) {
+	return h.enqueueAction(playerID, sim.ActionCommand{Name: actionEmote, Emote: emote})
+}
+
+// HandleAimedAction queues an action command carrying a precise aim vector.
+// Projectile actions fire along the normalized vector; a zero vector keeps the
+// player's cardinal facing.
+func (h *Hub) HandleAimedAction(playerID, action string, aimX, aimY float64) (sim.Command, bool, string) {
+	return h.enqueueAction(playerID, sim.ActionCommand{Name: action, AimX: aimX, AimY: aimY})
+}
+
+// HandleTargetedAction queues an action aimed at a specific actor, such as a
+// heal on an ally. Heals aimed at an enemy, a missing or dead actor, or one out
+// of range are rejected before they are queued.
+func (h *Hub) HandleTargetedAction(playerID, action, targetID string) (sim.Command, bool, string) {
+	return h.enqueueAction(playerID, sim.ActionCommand{Name: action, TargetID: targetID})
+}
+
+// actionEffects lists every action a player may queue, mapped to the contract
+// effect definition it spawns. Actions that resolve without the effect
+// manager, such as detect, shield, parry, recall, and cancelAction, map to "".
+var actionEffects = map[string]string{
+	effectTypeAttack:      effectTypeAttack,
+	effectTypeFireball:    effectTypeFireball,
+	effectTypeFirebomb:    effectTypeFirebomb,
+	effectTypeSeeker:      effectTypeSeeker,
+	effectTypeRicochet:    effectTypeRicochet,
+	effectTypeBeam:        effectTypeBeam,
+	effectTypeHeal:        effectTypeHeal,
+	effectTypeHealBurst:   effectTypeHealBurst,
+	effectTypeGravityWell: effectTypeGravityWell,
+	effectTypeExplosion:   effectTypeExplosion,
+	effectTypeFirePatch:   effectTypeFirePatch,
+	actionStealth:         "",
+	effectTypeDetect:      "",
+	effectTypeShield:      "",
+	actionParry:           "",
+	actionHaste:           "",
+	actionTaunt:           "",
+	actionSummon:          "",
+	actionRecall:          "",
+	actionCancel:          "",
+	actionEmote:           "",
+}
+
+func (h *Hub) enqueueAction(playerID string, action sim.ActionCommand) (sim.Command, bool, string) {
+	if _, ok := actionEffects[action.Name]; !ok {
+		return sim.Command{}, false, commandRejectInvalidAction
+	}
+	if action.Name == actionEmote && !IsEmote(action.Emote) {
 		return sim.Command{}, false, commandRejectInvalidAction
 	}
+	if typeID, ok := actionEffectType(action.Name); ok && !h.hasEffectDefinition(typeID) {
+		h.logf("[effects] rejected action=%q player=%q: effect type %q has no catalog definition", action.Name, playerID, typeID)
+		return sim.Command{}, false, commandRejectUnknownEffect
+	}
+	if action.Name == effectTypeHeal {
+		var reason string
+		h.mu.Lock()
+		if caster := h.world.actorByID(playerID); caster != nil {
+			reason = h.world.healTargetRejection(caster, action.TargetID)
+		}
+		h.mu.Unlock()
+		if reason != "" {
+			return sim.Command{}, false, reason
+		}
+	}
 
 	cmd := sim.Command{
-		Type: sim.CommandAction,
-		Action: &sim.ActionCommand{
-			Name: action,
-		},
+		Type:   sim.CommandAction,
+		Action: &action,
 	}
 
 	return h.enqueuePlayerCommand(playerID, cmd)
 }
 
+// actionEffectType reports the contract effect definition an action spawns,
+// or false when the action spawns none or is not accepted at all.
+func actionEffectType(action string) (string, bool) {
+	typeID := actionEffects[action]
+	return typeID, typeID != ""
+}
+
+func (h *Hub) hasEffectDefinition(typeID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.world == nil || h.world.effectManager == nil {
+		return false
+	}
+	definition, ok := h.world.effectManager.Definitions()[typeID]
+	return ok && definition != nil
+}
+
+// warnMissingActionEffects logs every accepted action whose effect definition
+// is absent from the loaded catalog, so a broken catalog surfaces at startup
+// rather than on the first cast.
+func (h *Hub) warnMissingActionEffects() {
+	actions := make([]string, 0, len(actionEffects))
+	for action := range actionEffects {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		typeID, ok := actionEffectType(action)
+		if ok && !h.hasEffectDefinition(typeID) {
+			h.logf("[effects] action=%q references effect type %q with no catalog definition; casts will be rejected", action, typeID)
+		}
+	}
+}
+
 // HandleConsoleCommand executes a debug console command for the player.
 func (h *Hub) HandleConsoleCommand(playerID, cmd string, qty int) (proto.ConsoleAck, bool) {
 	ack := proto.NewConsoleAck(cmd)
@@ -1274,6 +1650,65 @@ func (h *Hub) HandleConsoleCommand(playerID, cmd string, qty int) (proto.Console
 		ack.StackID = stackID
 		h.broadcastState(nil, nil, nil, groundItems)
 		return ack, true
+	case "stash_deposit", "stash_withdraw":
+		if qty < 0 {
+			ack.Status = "error"
+			if cmd == "stash_deposit" {
+				ack.Reason = "invalid_inventory_slot"
+			} else {
+				ack.Reason = "invalid_stash_slot"
+			}
+			return ack, true
+		}
+		h.mu.Lock()
+		if _, ok := h.world.players[playerID]; !ok {
+			h.mu.Unlock()
+			ack.Status = "error"
+			ack.Reason = "unknown_actor"
+			return ack, true
+		}
+		var (
+			moved ItemStack
+			err   error
+		)
+		if cmd == "stash_deposit" {
+			moved, err = h.world.DepositToStash(playerID, qty)
+		} else {
+			moved, err = h.world.WithdrawFromStash(playerID, qty)
+		}
+		h.mu.Unlock()
+		if err != nil {
+			ack.Status = "error"
+			ack.Reason = stashErrorReason(err)
+			return ack, true
+		}
+		ack.Status = "ok"
+		ack.Qty = moved.Quantity
+		h.broadcastState(nil, nil, nil, nil)
+		return ack, true
+	case "time_scale":
+		if qty < 0 {
+			ack.Status = "error"
+			ack.Reason = "invalid_quantity"
+			return ack, true
+		}
+		h.mu.Lock()
+		if !h.isPrivilegedLocked(playerID) {
+			h.mu.Unlock()
+			ack.Status = "error"
+			ack.Reason = "forbidden"
+			return ack, true
+		}
+		h.world.SetTimeScale(float64(qty) / 100)
+		h.timeScale = h.world.TimeScale()
+		applied := h.timeScale
+		h.mu.Unlock()
+
+		ack.Status = "ok"
+		ack.Qty = int(math.Round(applied * 100))
+		return ack, true
+	case "list_effects":
+		return h.handleListEffects(playerID, qty), true
 	default:
 		ack.Status = "error"
 		ack.Reason = "unknown_command"
@@ -1302,22 +1737,35 @@ func equipErrorReason(err error) string {
 	}
 }
 
+func stashErrorReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, errStashUnknownActor):
+		return "unknown_actor"
+	case errors.Is(err, errStashNotNearby):
+		return "no_stash_nearby"
+	case errors.Is(err, errStashInvalidInventorySlot):
+		return "invalid_inventory_slot"
+	case errors.Is(err, errStashInvalidSlot):
+		return "invalid_stash_slot"
+	case errors.Is(err, errStashEmptySlot):
+		return "empty_slot"
+	default:
+		return "internal_error"
+	}
+}
+
 // UpdateHeartbeat records the most recent heartbeat time and RTT for a player.
 func (h *Hub) UpdateHeartbeat(playerID string, receivedAt time.Time, clientSent int64) (time.Duration, bool) {
+	if IsSpectatorID(playerID) {
+		return heartbeatRTT(receivedAt, clientSent), h.hasSubscriber(playerID)
+	}
 	if !h.playerExists(playerID) {
 		return 0, false
 	}
 
-	var rtt time.Duration
-	if clientSent > 0 {
-		clientTime := time.UnixMilli(clientSent)
-		if clientTime.Before(receivedAt.Add(5 * time.Second)) {
-			rtt = receivedAt.Sub(clientTime)
-			if rtt < 0 {
-				rtt = 0
-			}
-		}
-	}
+	rtt := heartbeatRTT(receivedAt, clientSent)
 
 	cmd := sim.Command{
 		OriginTick: h.tick.Load(),
@@ -1337,6 +1785,23 @@ func (h *Hub) UpdateHeartbeat(playerID string, receivedAt time.Time, clientSent
 	return rtt, true
 }
 
+// heartbeatRTT derives the round trip from the client's send timestamp,
+// ignoring timestamps from the future.
+func heartbeatRTT(receivedAt time.Time, clientSent int64) time.Duration {
+	if clientSent <= 0 {
+		return 0
+	}
+	clientTime := time.UnixMilli(clientSent)
+	if !clientTime.Before(receivedAt.Add(5 * time.Second)) {
+		return 0
+	}
+	rtt := receivedAt.Sub(clientTime)
+	if rtt < 0 {
+		rtt = 0
+	}
+	return rtt
+}
+
 // processLoopStep applies post-step bookkeeping and returns converted snapshots
 // alongside subscribers that should be closed.
 func (h *Hub) processLoopStep(result sim.LoopStepResult) ([]Player, []NPC, []EffectTrigger, []itemspkg.GroundItem, []*subscriber) {
@@ -1347,6 +1812,7 @@ func (h *Hub) processLoopStep(result sim.LoopStepResult) ([]Player, []NPC, []Eff
 	if h.telemetry != nil {
 		h.telemetry.RecordEffectsActive(len(h.world.effects))
 	}
+	h.pruneReconnectSessionsLocked(h.now())
 	toClose := make([]*subscriber, 0, len(result.RemovedPlayers))
 	for _, id := range result.RemovedPlayers {
 		if sub, ok := h.subscribers[id]; ok {
@@ -1379,7 +1845,9 @@ func (h *Hub) handleLoopStep(result sim.LoopStepResult) {
 	for _, sub := range toClose {
 		sub.Close()
 	}
-	h.broadcastState(players, npcs, triggers, groundItems)
+	if len(toClose) > 0 || !h.stepIsIdle(triggers) {
+		h.broadcastState(players, npcs, triggers, groundItems)
+	}
 	duration := result.Duration
 	if h.telemetry != nil {
 		h.telemetry.RecordTickDuration(duration)
@@ -1419,6 +1887,36 @@ func (h *Hub) RunSimulation(stop <-chan struct{}) {
 	h.engine.Run(stop)
 }
 
+// Pause halts simulation advancement in RunSimulation. Commands keep
+// buffering and heartbeats keep flowing until Resume is called.
+func (h *Hub) Pause() {
+	if h == nil {
+		return
+	}
+	if h.paused.CompareAndSwap(false, true) {
+		h.logf("[tick] simulation paused at tick=%d", h.tick.Load())
+	}
+}
+
+// Resume restarts simulation advancement after Pause. The first step after
+// resuming covers a single tick rather than the time spent paused.
+func (h *Hub) Resume() {
+	if h == nil {
+		return
+	}
+	if h.paused.CompareAndSwap(true, false) {
+		h.logf("[tick] simulation resumed at tick=%d", h.tick.Load())
+	}
+}
+
+// Paused reports whether the simulation loop is currently halted.
+func (h *Hub) Paused() bool {
+	if h == nil {
+		return false
+	}
+	return h.paused.Load()
+}
+
 func (h *Hub) resetTickBudgetAlarm() {
 	if h.telemetry != nil {
 		h.telemetry.ResetTickBudgetOverrunStreak()
@@ -1461,6 +1959,7 @@ func (h *Hub) DiagnosticsSnapshot() []diagnosticsPlayer {
 			LastHeartbeat: state.LastHeartbeat.UnixMilli(),
 			RTTMillis:     state.LastRTT.Milliseconds(),
 			LastAck:       ack,
+			Session:       state.Session,
 		})
 	}
 	return players
@@ -1510,6 +2009,47 @@ func (h *Hub) SetKeyframeInterval(interval int) int {
 	return normalized
 }
 
+// stepIsIdle reports whether a tick broadcast would carry nothing new: no
+// staged patches, no effect events or triggers, no batched acks waiting, and
+// no keyframe or resync due.
+// Idle ticks skip the outbound write entirely.
+func (h *Hub) stepIsIdle(triggers []EffectTrigger) bool {
+	if h == nil || len(triggers) > 0 {
+		return false
+	}
+	if h.resyncNext.Load() || h.forceKeyframeNext.Load() {
+		return false
+	}
+	if h.keyframeCadence.enabled() {
+		if h.anySubscriberKeyframeDue() {
+			return false
+		}
+	} else if h.keyframeIntervalElapsed() {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.anySubscriberCatchUpReadyLocked() {
+		return false
+	}
+	if h.batchAcks {
+		for _, sub := range h.subscribers {
+			if sub.hasPendingCommandAck() {
+				return false
+			}
+		}
+	}
+	if h.engine == nil {
+		return len(h.world.snapshotPatchesLocked()) == 0
+	}
+	if len(h.engine.SnapshotPatches()) > 0 {
+		return false
+	}
+	batch := h.engine.SnapshotEffectEvents()
+	return len(batch.Spawns) == 0 && len(batch.Updates) == 0 && len(batch.Ends) == 0
+}
+
 func (h *Hub) shouldIncludeSnapshot() bool {
 	interval := h.CurrentKeyframeInterval()
 	if interval <= 1 {
@@ -1518,6 +2058,16 @@ func (h *Hub) shouldIncludeSnapshot() bool {
 	if h.forceKeyframeNext.CompareAndSwap(true, false) {
 		return true
 	}
+	return h.keyframeIntervalElapsed()
+}
+
+// keyframeIntervalElapsed reports whether the configured cadence calls for a
+// keyframe on the current tick.
+func (h *Hub) keyframeIntervalElapsed() bool {
+	interval := h.CurrentKeyframeInterval()
+	if interval <= 1 {
+		return true
+	}
 	interval64 := uint64(interval)
 	if interval64 == 0 {
 		return true
@@ -1531,6 +2081,20 @@ func (h *Hub) shouldIncludeSnapshot() bool {
 }
 
 func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.EffectTrigger, groundItems []itemspkg.GroundItem, drainPatches bool, includeSnapshot bool) ([]byte, int, error) {
+	msg, entities, restore := h.composeStateMessage(players, npcs, triggers, groundItems, drainPatches, includeSnapshot)
+	data, err := proto.EncodeStateSnapshot(msg)
+	if err != nil {
+		restore()
+		return nil, 0, err
+	}
+	return data, entities, nil
+}
+
+// composeStateMessage assembles the next state message and returns it along
+// with the entity count and a restore hook. Calling restore puts any drained
+// patches and effect events back into the journal so a failed send does not
+// lose them.
+func (h *Hub) composeStateMessage(players []sim.Player, npcs []sim.NPC, triggers []sim.EffectTrigger, groundItems []itemspkg.GroundItem, drainPatches bool, includeSnapshot bool) (stateMessage, int, func()) {
 	h.mu.Lock()
 	engine := h.engine
 	var (
@@ -1700,7 +2264,8 @@ func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.
 					continue
 				}
 				if _, ok := alive[patch.EntityID]; !ok {
-					if patch.Kind == sim.PatchPlayerRemoved {
+					switch patch.Kind {
+					case sim.PatchPlayerRemoved, sim.PatchCorpseAdded, sim.PatchCorpseContents, sim.PatchCorpseRemoved:
 						filtered = append(filtered, patch)
 						continue
 					}
@@ -1732,9 +2297,27 @@ func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.
 
 	cfg := h.config
 	simCfg := simWorldConfigFromLegacy(cfg)
+	var (
+		scheduledTasks []sim.ScheduledTask
+		rngState       *sim.RNGState
+		corpses        []sim.Corpse
+		activeEffects  []effectcontract.EffectSpawnEvent
+		effectCatalog  *effectcatalog.Resolver
+	)
+	effectTransportEnabled := engine != nil
+	if effectTransportEnabled && h.world.effectManager != nil {
+		effectCatalog = h.world.effectManager.Catalog()
+	}
+	if includeSnapshot {
+		scheduledTasks = h.world.scheduledTasksSnapshot()
+		rngState = h.world.rngStateSnapshot()
+		corpses = simCorpsesFromLegacy(h.world.CorpsesSnapshot())
+		if effectTransportEnabled {
+			activeEffects = h.world.effectManager.ActiveSpawnEvents()
+		}
+	}
 	tick := h.tick.Load()
 	seq, resync := h.nextStateMeta(drainPatches)
-	effectTransportEnabled := engine != nil
 	h.mu.Unlock()
 
 	effectBatch := EffectEventBatch{}
@@ -1755,26 +2338,36 @@ func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.
 	keyframeSeq := h.lastKeyframeSeq.Load()
 	if includeSnapshot {
 		simFrame := sim.Keyframe{
-			Tick:        tick,
-			Sequence:    seq,
-			Players:     simutil.ClonePlayers(players),
-			NPCs:        simutil.CloneNPCs(npcs),
-			Obstacles:   simutil.CloneObstacles(obstacles),
-			GroundItems: itemspkg.CloneGroundItems(groundItems),
-			Config:      simCfg,
+			Tick:           tick,
+			Sequence:       seq,
+			Players:        simutil.ClonePlayers(players),
+			NPCs:           simutil.CloneNPCs(npcs),
+			Obstacles:      simutil.CloneObstacles(obstacles),
+			GroundItems:    itemspkg.CloneGroundItems(groundItems),
+			Corpses:        simutil.CloneCorpses(corpses),
+			ScheduledTasks: scheduledTasks,
+			RNG:            rngState,
+			ActiveEffects:  journal.CloneEffectSpawnEvents(activeEffects),
+			Config:         simCfg,
 		}
 		var record sim.KeyframeRecordResult
 		if engine != nil {
 			record = engine.RecordKeyframe(simFrame)
 		} else {
 			legacyFrame := keyframe{
-				Tick:        tick,
-				Sequence:    seq,
-				Players:     legacyPlayersFromSim(players),
-				NPCs:        legacyNPCsFromSim(npcs),
-				Obstacles:   legacyObstaclesFromSim(obstacles),
-				GroundItems: itemspkg.CloneGroundItems(groundItems),
-				Config:      cfg,
+				Tick:           tick,
+				Sequence:       seq,
+				Players:        legacyPlayersFromSim(players),
+				NPCs:           legacyNPCsFromSim(npcs),
+				Obstacles:      legacyObstaclesFromSim(obstacles),
+				GroundItems:    itemspkg.CloneGroundItems(groundItems),
+				Corpses:        simutil.CloneCorpses(corpses),
+				ScheduledTasks: scheduledTasks,
+				ActiveEffects:  journal.CloneEffectSpawnEvents(activeEffects),
+				Config:         cfg,
+			}
+			if rngState != nil {
+				legacyFrame.RNG = rngState
 			}
 			legacyRecord := h.world.RecordKeyframe(legacyFrame)
 			record = simKeyframeRecordResultFromLegacy(legacyRecord)
@@ -1805,6 +2398,7 @@ func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.
 		Obstacles:        obstacles,
 		EffectTriggers:   triggers,
 		GroundItems:      groundItems,
+		Corpses:          corpses,
 		Patches:          patches,
 		Tick:             tick,
 		Sequence:         seq,
@@ -1817,37 +2411,37 @@ func (h *Hub) marshalState(players []sim.Player, npcs []sim.NPC, triggers []sim.
 		msg.Resync = true
 	}
 	if effectTransportEnabled {
-		msg.EffectSpawns = effectBatch.Spawns
+		msg.EffectSpawns = trimEffectSpawns(effectCatalog, effectBatch.Spawns)
 		msg.EffectUpdates = effectBatch.Updates
 		msg.EffectEnds = effectBatch.Ends
 		if len(effectBatch.LastSeqByID) > 0 {
 			msg.EffectSeqCursors = effectBatch.LastSeqByID
 		}
+		msg.ActiveEffects = trimEffectSpawns(effectCatalog, activeEffects)
 	}
 
 	entities := len(msg.Players) + len(msg.NPCs) + len(msg.Obstacles) + len(msg.EffectTriggers) + len(msg.GroundItems)
 	if effectTransportEnabled && (len(msg.EffectSpawns) > 0 || len(msg.EffectUpdates) > 0 || len(msg.EffectEnds) > 0) {
 		entities += len(msg.EffectSpawns) + len(msg.EffectUpdates) + len(msg.EffectEnds)
 	}
-	data, err := proto.EncodeStateSnapshot(msg)
-	if err != nil {
-		if drainPatches {
-			h.mu.Lock()
-			if engine != nil {
-				if len(restorableSimPatches) > 0 {
-					engine.RestorePatches(restorableSimPatches)
-				}
-			} else if len(restorableLegacyPatches) > 0 {
-				h.world.RestorePatches(restorableLegacyPatches)
-			}
-			if effectTransportEnabled {
-				engine.RestoreEffectEvents(simEffectBatch)
+	restore := func() {
+		if !drainPatches {
+			return
+		}
+		h.mu.Lock()
+		if engine != nil {
+			if len(restorableSimPatches) > 0 {
+				engine.RestorePatches(restorableSimPatches)
 			}
-			h.mu.Unlock()
+		} else if len(restorableLegacyPatches) > 0 {
+			h.world.RestorePatches(restorableLegacyPatches)
 		}
-		return nil, 0, err
+		if effectTransportEnabled {
+			engine.RestoreEffectEvents(simEffectBatch)
+		}
+		h.mu.Unlock()
 	}
-	return data, entities, nil
+	return msg, entities, restore
 }
 
 // MarshalState serializes a world snapshot using the legacy hub marshaller.
@@ -1906,15 +2500,17 @@ func (h *Hub) lookupKeyframe(sequence uint64) (keyframeMessage, keyframeLookupSt
 	frame, ok := engine.KeyframeBySequence(sequence)
 	if ok {
 		snapshot := keyframeMessage{
-			Ver:         ProtocolVersion,
-			Type:        proto.TypeKeyframe,
-			Sequence:    frame.Sequence,
-			Tick:        frame.Tick,
-			Players:     simutil.ClonePlayers(frame.Players),
-			NPCs:        simutil.CloneNPCs(frame.NPCs),
-			Obstacles:   simutil.CloneObstacles(frame.Obstacles),
-			GroundItems: itemspkg.CloneGroundItems(frame.GroundItems),
-			Config:      frame.Config,
+			Ver:           ProtocolVersion,
+			Type:          proto.TypeKeyframe,
+			Sequence:      frame.Sequence,
+			Tick:          frame.Tick,
+			Players:       simutil.ClonePlayers(frame.Players),
+			NPCs:          simutil.CloneNPCs(frame.NPCs),
+			Obstacles:     simutil.CloneObstacles(frame.Obstacles),
+			GroundItems:   itemspkg.CloneGroundItems(frame.GroundItems),
+			Corpses:       simutil.CloneCorpses(frame.Corpses),
+			ActiveEffects: journal.CloneEffectSpawnEvents(frame.ActiveEffects),
+			Config:        frame.Config,
 		}
 		return snapshot, keyframeLookupFound
 	}
@@ -1947,6 +2543,7 @@ func (h *Hub) HandleKeyframeRequest(playerID string, sub *subscriber, sequence u
 			h.telemetry.IncrementKeyframeRateLimited()
 		}
 		h.logf("[keyframe] rate_limited player=%s sequence=%d", playerID, sequence)
+		h.publishKeyframeNack(playerID, sequence, "rate_limited")
 		nack := &keyframeNackMessage{
 			Ver:      ProtocolVersion,
 			Type:     proto.TypeKeyframeNack,
@@ -1967,6 +2564,9 @@ func (h *Hub) HandleKeyframeRequest(playerID string, sub *subscriber, sequence u
 			h.telemetry.RecordKeyframeRequest(latency, true)
 		}
 		h.logf("[keyframe] served player=%s sequence=%d tick=%d latency_ms=%d", playerID, snapshot.Sequence, snapshot.Tick, latency.Milliseconds())
+		if filter := h.viewerStealthFilter(playerID); !filter.empty() {
+			snapshot = filterStealthKeyframe(snapshot, filter)
+		}
 		return snapshot, nil, true
 	case keyframeLookupExpired:
 		if h.telemetry != nil {
@@ -1974,6 +2574,7 @@ func (h *Hub) HandleKeyframeRequest(playerID string, sub *subscriber, sequence u
 			h.telemetry.IncrementKeyframeExpired()
 		}
 		h.logf("[keyframe] expired player=%s sequence=%d", playerID, sequence)
+		h.publishKeyframeNack(playerID, sequence, "expired")
 		nack := &keyframeNackMessage{
 			Ver:      ProtocolVersion,
 			Type:     proto.TypeKeyframeNack,
@@ -1992,6 +2593,42 @@ func (h *Hub) HandleKeyframeRequest(playerID string, sub *subscriber, sequence u
 	}
 }
 
+// ReportCommandSeqAnomaly records a command sequence from the player that
+// was duplicated, reordered, or skipped ahead of last, the newest sequence
+// acknowledged before it. kind is one of the loggingnetwork.CommandSeq*
+// values.
+func (h *Hub) ReportCommandSeqAnomaly(playerID, kind string, last, seq uint64) {
+	loggingnetwork.CommandSeqAnomaly(
+		context.Background(),
+		h.publisher,
+		h.tick.Load(),
+		logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")},
+		loggingnetwork.CommandSeqPayload{Kind: kind, Last: last, Seq: seq},
+		nil,
+	)
+}
+
+// publishKeyframeNack records a refused keyframe request together with the
+// journal window the client would have needed to fall inside.
+func (h *Hub) publishKeyframeNack(playerID string, sequence uint64, reason string) {
+	h.mu.Lock()
+	engine := h.engine
+	h.mu.Unlock()
+
+	payload := loggingnetwork.KeyframeNackPayload{Requested: sequence, Reason: reason}
+	if engine != nil {
+		payload.Size, payload.Oldest, payload.Newest = engine.KeyframeWindow()
+	}
+	loggingnetwork.KeyframeNack(
+		context.Background(),
+		h.publisher,
+		h.tick.Load(),
+		logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")},
+		payload,
+		nil,
+	)
+}
+
 func (h *Hub) broadcastState(players []Player, npcs []NPC, triggers []EffectTrigger, groundItems []itemspkg.GroundItem) {
 	if h == nil {
 		return
@@ -2022,7 +2659,7 @@ func (h *Hub) broadcastState(players []Player, npcs []NPC, triggers []EffectTrig
 
 func (h *Hub) executeBroadcast(players []Player, npcs []NPC, triggers []EffectTrigger, groundItems []itemspkg.GroundItem) {
 	h.scheduleResyncIfNeeded()
-	includeSnapshot := h.shouldIncludeSnapshot()
+	includeSnapshot, everyone := h.planKeyframe()
 	simPlayers := simPlayersFromLegacy(players)
 	simNPCs := simNPCsFromLegacy(npcs)
 	var simTriggers []sim.EffectTrigger
@@ -2033,8 +2670,10 @@ func (h *Hub) executeBroadcast(players []Player, npcs []NPC, triggers []EffectTr
 	if len(groundItems) > 0 {
 		clonedGroundItems = itemspkg.CloneGroundItems(groundItems)
 	}
-	data, entities, err := h.marshalState(simPlayers, simNPCs, simTriggers, clonedGroundItems, true, includeSnapshot)
+	msg, entities, restore := h.composeStateMessage(simPlayers, simNPCs, simTriggers, clonedGroundItems, true, includeSnapshot)
+	data, err := proto.EncodeStateSnapshot(msg)
 	if err != nil {
+		restore()
 		h.logf("failed to marshal state message: %v", err)
 		return
 	}
@@ -2064,15 +2703,69 @@ func (h *Hub) executeBroadcast(players []Player, npcs []NPC, triggers []EffectTr
 		)
 	}
 
+	var interestEntities map[string]interestEntity
 	h.mu.Lock()
 	subs := make(map[string]*subscriber, len(h.subscribers))
+	filters := make(map[string]stealthFilter)
+	withheld := make(map[string]map[string]struct{})
+	stealthed := h.world.stealthedActors()
 	for id, sub := range h.subscribers {
 		subs[id] = sub
+		if filter := h.stealthFilterLocked(id, stealthed); !filter.empty() {
+			filters[id] = filter
+		}
+		if len(sub.withheldEffects) > 0 {
+			withheld[id] = sub.withheldEffects
+		}
+	}
+	if h.interestRadius > 0 {
+		interestEntities = h.interestEntitiesLocked()
 	}
 	h.mu.Unlock()
 
+	var (
+		deltaMsg  stateMessage
+		deltaData []byte
+	)
 	for id, sub := range subs {
-		err := sub.EnqueueBroadcast(h.now(), data)
+		baseMsg, baseData, viewerSnapshot := msg, data, includeSnapshot
+		if includeSnapshot && !everyone && !h.subscriberKeyframeDue(sub, msg.Tick) {
+			if deltaData == nil {
+				deltaMsg = withoutSnapshot(msg)
+				deltaData, err = proto.EncodeStateSnapshot(deltaMsg)
+				if err != nil {
+					h.logf("failed to marshal delta state message: %v", err)
+					deltaData = nil
+				}
+			}
+			if deltaData != nil {
+				baseMsg, baseData, viewerSnapshot = deltaMsg, deltaData, false
+			}
+		}
+		if !h.admitStateBroadcast(id, sub, viewerSnapshot) {
+			continue
+		}
+		if viewerSnapshot {
+			sub.lastKeyframeTick.Store(msg.Tick)
+		}
+		viewerMsg, payload, viewerEntities := baseMsg, baseData, interestEntities
+		filter := filters[id]
+		if !viewerSnapshot {
+			// Keyframes re-announce every visible effect, so effects withheld
+			// from earlier deltas only stay hidden between keyframes.
+			filter = filter.withEffects(withheld[id])
+		}
+		if !filter.empty() || withheld[id] != nil {
+			viewerMsg, payload = h.stealthPayload(baseMsg, baseData, filter, sub)
+			viewerEntities = withoutEntities(interestEntities, filter)
+		}
+		if viewerEntities != nil {
+			payload = h.interestPayload(viewerMsg, payload, viewerSnapshot, id, sub, viewerEntities)
+		}
+		err := h.flushCommandAck(sub)
+		if err == nil {
+			err = sub.EnqueueBroadcast(h.now(), payload)
+		}
 		if err != nil {
 			h.logf("failed to send update to %s: %v", id, err)
 			players, npcs := h.Disconnect(id)
@@ -2087,6 +2780,27 @@ func (h *Hub) executeBroadcast(players []Player, npcs []NPC, triggers []EffectTr
 	}
 }
 
+// BatchCommandAcks reports whether command acknowledgements are deferred to
+// the next state broadcast.
+func (h *Hub) BatchCommandAcks() bool {
+	return h != nil && h.batchAcks
+}
+
+// flushCommandAck enqueues the subscriber's pending acknowledgement ahead of
+// the state payload so the client reconciles before applying the tick.
+func (h *Hub) flushCommandAck(sub *subscriber) error {
+	ack, ok := sub.takePendingCommandAck()
+	if !ok {
+		return nil
+	}
+	data, err := proto.EncodeCommandAck(ack)
+	if err != nil {
+		h.logf("failed to marshal batched command ack: %v", err)
+		return nil
+	}
+	return sub.EnqueueBroadcast(h.now(), data)
+}
+
 // BroadcastState sends a snapshot to every active subscriber.
 func (h *Hub) BroadcastState(players []Player, npcs []NPC, triggers []EffectTrigger, groundItems []itemspkg.GroundItem) {
 	h.broadcastState(players, npcs, triggers, groundItems)
@@ -2137,6 +2851,7 @@ func filterPlayerPatches(patches []sim.Patch) []sim.Patch {
 			sim.PatchPlayerHealth,
 			sim.PatchPlayerInventory,
 			sim.PatchPlayerEquipment,
+			sim.PatchPlayerAbsorb,
 			sim.PatchPlayerRemoved:
 			filtered = append(filtered, patch)
 		}
@@ -0,0 +1,56 @@
+package server
+
+const (
+	// subscriberLagDepth is the send queue depth at which a subscriber counts
+	// as lagging. Queuing more deltas behind that backlog only adds latency,
+	// so a lagging subscriber skips them and catches up from a keyframe once
+	// its queue has drained to subscriberRecoveredDepth.
+	subscriberLagDepth       = subscriberSendQueueSize / 2
+	subscriberRecoveredDepth = subscriberSendQueueSize / 8
+)
+
+// catchUpReady reports whether a lagging subscriber has drained its send
+// queue far enough to receive its catch-up keyframe.
+func (s *subscriber) catchUpReady() bool {
+	return s != nil && s.catchingUp.Load() && len(s.sendQueue) <= subscriberRecoveredDepth
+}
+
+// anySubscriberCatchUpReady reports whether a lagging subscriber is waiting
+// on a catch-up keyframe.
+func (h *Hub) anySubscriberCatchUpReady() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.anySubscriberCatchUpReadyLocked()
+}
+
+func (h *Hub) anySubscriberCatchUpReadyLocked() bool {
+	for _, sub := range h.subscribers {
+		if sub.catchUpReady() {
+			return true
+		}
+	}
+	return false
+}
+
+// admitStateBroadcast decides whether a state payload is queued for the
+// subscriber. A delta that finds the send queue at subscriberLagDepth
+// switches the subscriber to keyframe-only catch-up. While catching up every
+// payload is skipped until the queue has drained and a keyframe is ready,
+// which then replaces the skipped deltas.
+func (h *Hub) admitStateBroadcast(id string, sub *subscriber, snapshot bool) bool {
+	depth := len(sub.sendQueue)
+	if sub.catchingUp.Load() {
+		if !snapshot || depth > subscriberRecoveredDepth {
+			return false
+		}
+		sub.catchingUp.Store(false)
+		h.logf("[broadcast] subscriber %s recovered (depth=%d); sending catch-up keyframe", id, depth)
+		return true
+	}
+	if snapshot || depth < subscriberLagDepth {
+		return true
+	}
+	sub.catchingUp.Store(true)
+	h.logf("[broadcast] subscriber %s lagging (depth=%d); skipping deltas until it catches up", id, depth)
+	return false
+}
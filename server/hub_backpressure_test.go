@@ -0,0 +1,129 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// gatedPayloadConn records payloads but only completes a write once the test
+// releases it, standing in for a client that reads too slowly.
+type gatedPayloadConn struct {
+	payloadRecordingConn
+	gate chan struct{}
+}
+
+func newGatedPayloadConn() *gatedPayloadConn {
+	return &gatedPayloadConn{gate: make(chan struct{}, subscriberSendQueueSize*2)}
+}
+
+func (c *gatedPayloadConn) Write(data []byte) error {
+	<-c.gate
+	return c.payloadRecordingConn.Write(data)
+}
+
+func (c *gatedPayloadConn) release(count int) {
+	for i := 0; i < count; i++ {
+		c.gate <- struct{}{}
+	}
+}
+
+func (c *gatedPayloadConn) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.payloads)
+}
+
+func waitQueueDepth(t *testing.T, sub *subscriber, depth int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if len(sub.sendQueue) == depth {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected send queue depth %d, got %d", depth, len(sub.sendQueue))
+}
+
+func TestLaggingSubscriberSkipsDeltasForCatchUpKeyframe(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 1000
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+	worldCfg := fullyFeaturedTestWorldConfig()
+	worldCfg.NPCs = false
+	worldCfg.GoblinCount = 0
+	worldCfg.RatCount = 0
+	worldCfg.NPCCount = 0
+	hub.ResetWorld(worldCfg)
+
+	player := newTestPlayerState("slow-reader")
+	conn := newGatedPayloadConn()
+	sub := newSubscriber(conn, nil)
+	hub.mu.Lock()
+	hub.world.AddPlayer(player)
+	hub.subscribers[player.ID] = sub
+	hub.mu.Unlock()
+	t.Cleanup(sub.Close)
+
+	tick := uint64(0)
+	broadcast := func() {
+		tick++
+		hub.tick.Store(tick)
+		hub.broadcastState(nil, nil, nil, nil)
+	}
+
+	// The opening keyframe occupies the writer, so every delta after it backs
+	// up in the send queue until the lag threshold is reached.
+	broadcast()
+	waitQueueDepth(t, sub, 0)
+	for i := 0; i < subscriberLagDepth; i++ {
+		broadcast()
+	}
+	waitQueueDepth(t, sub, subscriberLagDepth)
+	for i := 0; i < 20; i++ {
+		broadcast()
+	}
+	if !sub.catchingUp.Load() {
+		t.Fatalf("expected the backed-up subscriber to switch to catch-up")
+	}
+	if depth := len(sub.sendQueue); depth != subscriberLagDepth {
+		t.Fatalf("expected deltas past the lag threshold to be skipped, queue depth %d", depth)
+	}
+
+	// Draining the backlog makes the next broadcast a keyframe for it alone.
+	queued := 1 + subscriberLagDepth
+	conn.release(queued)
+	conn.waitPayload(t, queued-1)
+	waitQueueDepth(t, sub, 0)
+	broadcast()
+	broadcast()
+	conn.release(2)
+	conn.waitPayload(t, queued+1)
+
+	if got := conn.count(); got != queued+2 {
+		t.Fatalf("expected %d payloads without the skipped deltas, got %d", queued+2, got)
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	var catchUp, after stateMessage
+	if err := json.Unmarshal(conn.payloads[queued], &catchUp); err != nil {
+		t.Fatalf("failed to decode catch-up payload: %v", err)
+	}
+	if err := json.Unmarshal(conn.payloads[queued+1], &after); err != nil {
+		t.Fatalf("failed to decode payload after recovery: %v", err)
+	}
+	if len(catchUp.Players) == 0 {
+		t.Fatalf("expected the first payload after recovery to be a keyframe")
+	}
+	if catchUp.Tick != tick-1 {
+		t.Fatalf("expected the catch-up keyframe at tick %d, got %d", tick-1, catchUp.Tick)
+	}
+	if len(after.Players) != 0 {
+		t.Fatalf("expected deltas to resume once caught up")
+	}
+	if sub.catchingUp.Load() {
+		t.Fatalf("expected the subscriber to leave catch-up after its keyframe")
+	}
+}
@@ -2,11 +2,13 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sync"
 	"testing"
 	"time"
 
+	"mine-and-die/server/internal/sim"
 	"mine-and-die/server/logging"
 )
 
@@ -317,3 +319,104 @@ func TestSubscriberRecordsQueueTelemetry(t *testing.T) {
 		t.Fatalf("expected drop depth %d, got %d", subscriberSendQueueSize, drops[0])
 	}
 }
+
+func TestIdleTicksSkipDeltaBroadcastUntilKeyframe(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 3
+	hub := NewHubWithConfig(cfg)
+	// Broadcast inline so the sequence counter reflects each tick immediately.
+	hub.broadcastFanout = nil
+
+	conn := &recordingSubscriberConn{}
+	sub := newSubscriber(conn, nil)
+	hub.mu.Lock()
+	hub.subscribers["idle-observer"] = sub
+	hub.mu.Unlock()
+	t.Cleanup(sub.Close)
+
+	now := time.Unix(1_700_000_000, 0).UTC()
+	step := func() {
+		tick := hub.tick.Add(1)
+		now = now.Add(time.Second / time.Duration(tickRate))
+		result := hub.engine.Advance(sim.LoopTickContext{Tick: tick, Now: now, Delta: 1.0 / float64(tickRate)})
+		hub.handleLoopStep(result)
+	}
+
+	step()
+	conn.waitWrites(t, 1)
+	if seq := hub.seq.Load(); seq != 1 {
+		t.Fatalf("expected initial keyframe broadcast, got sequence %d", seq)
+	}
+
+	step()
+	step()
+	if seq := hub.seq.Load(); seq != 1 {
+		t.Fatalf("expected idle ticks to skip delta broadcasts, got sequence %d", seq)
+	}
+
+	step()
+	conn.waitWrites(t, 2)
+	if seq := hub.seq.Load(); seq != 2 {
+		t.Fatalf("expected keyframe tick to broadcast, got sequence %d", seq)
+	}
+	if last := hub.lastKeyframeTick.Load(); last != hub.tick.Load() {
+		t.Fatalf("expected broadcast on tick %d to be a keyframe, last keyframe tick %d", hub.tick.Load(), last)
+	}
+}
+
+func TestSubscribeSnapshotIncludesActiveEffects(t *testing.T) {
+	hub, _ := newSeededTestHub("active-effects", func(cfg *worldConfig) {
+		cfg.NPCs = false
+		cfg.GoblinCount = 0
+		cfg.RatCount = 0
+		cfg.NPCCount = 0
+	})
+	hub.broadcastFanout = nil
+
+	caster := hub.Join()
+	if _, ok, reason := hub.HandleAction(caster.ID, effectTypeFireball); !ok {
+		t.Fatalf("expected fireball action to be accepted, got %q", reason)
+	}
+	dt := 1.0 / float64(tickRate)
+	for i := 0; i < 3; i++ {
+		runAdvance(hub, dt)
+	}
+
+	var fireballID string
+	for id, instance := range hub.world.effectManager.Instances() {
+		if instance != nil && instance.DefinitionID == effectTypeFireball {
+			fireballID = id
+		}
+	}
+	if fireballID == "" {
+		t.Fatalf("expected fireball to still be in flight")
+	}
+
+	late := hub.Join()
+	_, players, npcs, groundItems, ok := hub.Subscribe(late.ID, &recordingSubscriberConn{})
+	if !ok {
+		t.Fatalf("expected subscribe to succeed")
+	}
+	data, _, err := hub.MarshalState(players, npcs, nil, groundItems, false, true)
+	if err != nil {
+		t.Fatalf("failed to marshal initial state: %v", err)
+	}
+
+	var msg stateMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("failed to decode initial state: %v", err)
+	}
+	if len(msg.ActiveEffects) != 1 {
+		t.Fatalf("expected one active effect in the initial snapshot, got %d", len(msg.ActiveEffects))
+	}
+	active := msg.ActiveEffects[0]
+	if active.Instance.ID != fireballID || active.Instance.DefinitionID != effectTypeFireball {
+		t.Fatalf("expected active fireball %s, got %+v", fireballID, active.Instance)
+	}
+	if active.Instance.OwnerActorID != caster.ID {
+		t.Fatalf("expected fireball owned by %s, got %s", caster.ID, active.Instance.OwnerActorID)
+	}
+	if active.Seq == 0 {
+		t.Fatalf("expected active effect to carry its current sequence")
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"mine-and-die/server/internal/net/proto"
+	"mine-and-die/server/logging"
+	loggingchat "mine-and-die/server/logging/chat"
+)
+
+const (
+	// maxChatRunes bounds the length of a single chat message.
+	maxChatRunes = 200
+
+	// chatLimiterCapacity and chatLimiterRefillPer allow a short burst of
+	// messages and then one message per second.
+	chatLimiterCapacity  = 5
+	chatLimiterRefillPer = 1.0
+
+	chatRejectEmpty       = "empty"
+	chatRejectTooLong     = "too_long"
+	chatRejectInvalid     = "invalid_text"
+	chatRejectRateLimited = "rate_limited"
+)
+
+// HandleChat validates a chat message from the player and relays it to every
+// subscriber in the hub that can see the sender. With interest management
+// enabled only players within the interest radius receive it; spectators and
+// the sender always do. It returns the rejection reason when the message is
+// not delivered.
+func (h *Hub) HandleChat(playerID string, sub *subscriber, text string) (string, bool) {
+	tick := h.tick.Load()
+	actor := logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")}
+	reject := func(reason string) (string, bool) {
+		loggingchat.Rejected(
+			context.Background(),
+			h.publisher,
+			tick,
+			actor,
+			loggingchat.RejectedPayload{Reason: reason, Length: utf8.RuneCountInString(text)},
+			nil,
+		)
+		return reason, false
+	}
+
+	if IsSpectatorID(playerID) {
+		return reject(commandRejectSpectator)
+	}
+	text = strings.TrimSpace(text)
+	if reason := validateChatText(text); reason != "" {
+		return reject(reason)
+	}
+	if sub != nil && !sub.chatLimiter.allow(h.now()) {
+		return reject(chatRejectRateLimited)
+	}
+
+	h.mu.Lock()
+	sender, ok := h.world.players[playerID]
+	if !ok {
+		h.mu.Unlock()
+		return reject(commandRejectUnknownActor)
+	}
+	radiusSq := h.interestRadius * h.interestRadius
+	recipients := make([]*subscriber, 0, len(h.subscribers))
+	for id, candidate := range h.subscribers {
+		if h.interestRadius > 0 && id != playerID && !IsSpectatorID(id) {
+			player, ok := h.world.players[id]
+			if !ok {
+				continue
+			}
+			dx := player.X - sender.X
+			dy := player.Y - sender.Y
+			if dx*dx+dy*dy > radiusSq {
+				continue
+			}
+		}
+		recipients = append(recipients, candidate)
+	}
+	h.mu.Unlock()
+
+	data, err := proto.EncodeChat(proto.ChatMessage{From: playerID, Text: text, Tick: tick})
+	if err != nil {
+		h.logf("failed to marshal chat message from %s: %v", playerID, err)
+		return reject(chatRejectInvalid)
+	}
+	now := h.now()
+	for _, recipient := range recipients {
+		if err := recipient.EnqueueBroadcast(now, data); err != nil {
+			h.logf("failed to relay chat from %s: %v", playerID, err)
+		}
+	}
+
+	loggingchat.Message(
+		context.Background(),
+		h.publisher,
+		tick,
+		actor,
+		loggingchat.MessagePayload{Text: text, Recipients: len(recipients)},
+		nil,
+	)
+	return "", true
+}
+
+// validateChatText returns the rejection reason for an already trimmed chat
+// message, or an empty string when it may be sent.
+func validateChatText(text string) string {
+	if text == "" {
+		return chatRejectEmpty
+	}
+	if !utf8.ValidString(text) {
+		return chatRejectInvalid
+	}
+	if utf8.RuneCountInString(text) > maxChatRunes {
+		return chatRejectTooLong
+	}
+	for _, r := range text {
+		if unicode.IsControl(r) {
+			return chatRejectInvalid
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	loggingchat "mine-and-die/server/logging/chat"
+)
+
+func (c *payloadRecordingConn) waitChat(t *testing.T, index int) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		if len(c.payloads) > index {
+			data := c.payloads[index]
+			c.mu.Unlock()
+			var msg map[string]any
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("failed to decode payload %d: %v", index, err)
+			}
+			return msg
+		}
+		c.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for payload %d", index)
+	return nil
+}
+
+func (c *payloadRecordingConn) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.payloads)
+}
+
+func TestHandleChatRelaysAndRejects(t *testing.T) {
+	pub := &keyframeNackCapturePublisher{}
+	hub := NewHubWithConfig(DefaultHubConfig(), pub)
+	hub.broadcastFanout = nil
+
+	sender := newTestPlayerState("sender")
+	listener := newTestPlayerState("listener")
+	hub.mu.Lock()
+	hub.world.AddPlayer(sender)
+	hub.world.AddPlayer(listener)
+	hub.mu.Unlock()
+
+	senderConn := &payloadRecordingConn{}
+	listenerConn := &payloadRecordingConn{}
+	senderSub := newSubscriber(senderConn, nil)
+	listenerSub := newSubscriber(listenerConn, nil)
+	hub.mu.Lock()
+	hub.subscribers[sender.ID] = senderSub
+	hub.subscribers[listener.ID] = listenerSub
+	hub.mu.Unlock()
+	t.Cleanup(senderSub.Close)
+	t.Cleanup(listenerSub.Close)
+
+	if reason, ok := hub.HandleChat(sender.ID, senderSub, "  hello there  "); !ok {
+		t.Fatalf("expected chat to be accepted, got %q", reason)
+	}
+	delivered := listenerConn.waitChat(t, 0)
+	if delivered["type"] != "chat" || delivered["from"] != sender.ID || delivered["text"] != "hello there" {
+		t.Fatalf("expected listener to receive the trimmed chat, got %+v", delivered)
+	}
+	if echoed := senderConn.waitChat(t, 0); echoed["text"] != "hello there" {
+		t.Fatalf("expected sender to receive its own chat, got %+v", echoed)
+	}
+
+	if reason, ok := hub.HandleChat(sender.ID, senderSub, strings.Repeat("a", maxChatRunes+1)); ok || reason != chatRejectTooLong {
+		t.Fatalf("expected oversized chat to be rejected as %q, got ok=%v reason=%q", chatRejectTooLong, ok, reason)
+	}
+	if reason, ok := hub.HandleChat(sender.ID, senderSub, "   "); ok || reason != chatRejectEmpty {
+		t.Fatalf("expected blank chat to be rejected as %q, got ok=%v reason=%q", chatRejectEmpty, ok, reason)
+	}
+
+	accepted := 1
+	var spamReason string
+	for i := 0; i < chatLimiterCapacity*2; i++ {
+		reason, ok := hub.HandleChat(sender.ID, senderSub, "spam")
+		if !ok {
+			spamReason = reason
+			break
+		}
+		accepted++
+	}
+	if spamReason != chatRejectRateLimited {
+		t.Fatalf("expected spam to be rate limited, got %q", spamReason)
+	}
+	if accepted != chatLimiterCapacity {
+		t.Fatalf("expected %d chats before the limit, got %d", chatLimiterCapacity, accepted)
+	}
+	listenerConn.waitChat(t, accepted-1)
+	if got := listenerConn.count(); got != accepted {
+		t.Fatalf("expected listener to receive only accepted chats, got %d want %d", got, accepted)
+	}
+
+	var messages, rejections int
+	for _, event := range pub.events {
+		switch event.Type {
+		case loggingchat.EventMessage:
+			messages++
+			if event.Actor.ID != sender.ID || event.Severity != logging.SeverityInfo {
+				t.Fatalf("unexpected chat message event %+v", event)
+			}
+		case loggingchat.EventRejected:
+			rejections++
+		}
+	}
+	if messages != accepted || rejections != 3 {
+		t.Fatalf("expected %d message and 3 rejected events, got %d and %d", accepted, messages, rejections)
+	}
+}
+
+func TestHandleChatRespectsInterestRadius(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.InterestRadius = 200
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	sender := newTestPlayerState("sender")
+	near := newTestPlayerState("near")
+	far := newTestPlayerState("far")
+	hub.mu.Lock()
+	hub.world.AddPlayer(sender)
+	hub.world.AddPlayer(near)
+	hub.world.AddPlayer(far)
+	hub.world.SetPosition(sender.ID, 300, 300)
+	hub.world.SetPosition(near.ID, 350, 300)
+	hub.world.SetPosition(far.ID, 2000, 1500)
+	hub.mu.Unlock()
+
+	conns := map[string]*payloadRecordingConn{}
+	for _, id := range []string{sender.ID, near.ID, far.ID} {
+		conn := &payloadRecordingConn{}
+		sub := newSubscriber(conn, nil)
+		conns[id] = conn
+		hub.mu.Lock()
+		hub.subscribers[id] = sub
+		hub.mu.Unlock()
+		t.Cleanup(sub.Close)
+	}
+
+	if reason, ok := hub.HandleChat(sender.ID, hub.subscribers[sender.ID], "anyone nearby?"); !ok {
+		t.Fatalf("expected chat to be accepted, got %q", reason)
+	}
+	if msg := conns[near.ID].waitChat(t, 0); msg["text"] != "anyone nearby?" {
+		t.Fatalf("expected nearby player to receive chat, got %+v", msg)
+	}
+	conns[sender.ID].waitChat(t, 0)
+	if got := conns[far.ID].count(); got != 0 {
+		t.Fatalf("expected far player to receive no chat, got %d payloads", got)
+	}
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"mine-and-die/server/internal/sim"
 )
@@ -56,3 +58,88 @@ func TestEnqueueCommandEnforcesPerActorLimit(t *testing.T) {
 		t.Fatalf("expected 1 drop recorded for move commands, got %d", dropsByReason[string(sim.CommandMove)])
 	}
 }
+
+func TestActionWithMissingEffectDefinitionIsRejected(t *testing.T) {
+	hub := newHubWithFullWorld()
+	player := newTestPlayerState("caster")
+	player.Cooldowns = make(map[string]time.Time)
+	hub.world.players[player.ID] = player
+
+	delete(hub.world.effectManager.Definitions(), effectTypeFireball)
+
+	if _, ok, reason := hub.HandleAction(player.ID, effectTypeFireball); ok || reason != commandRejectUnknownEffect {
+		t.Fatalf("expected fireball without a definition to be rejected as %q, ok=%t reason=%q", commandRejectUnknownEffect, ok, reason)
+	}
+	if _, ok, reason := hub.HandleAction(player.ID, effectTypeAttack); !ok {
+		t.Fatalf("expected attack to stay available, got %q", reason)
+	}
+
+	runAdvance(hub, 1.0/15.0)
+	for _, eff := range hub.world.effectManager.Instances() {
+		if eff.DefinitionID == effectTypeFireball {
+			t.Fatalf("expected no fireball instance after the rejected action")
+		}
+	}
+}
+
+func TestActionEffectsDriveAcceptanceAndCatalogCheck(t *testing.T) {
+	hub := newHubWithFullWorld()
+	for action := range actionEffects {
+		typeID, spawns := actionEffectType(action)
+		if spawns && !hub.hasEffectDefinition(typeID) {
+			t.Fatalf("expected action %q to reference a catalog definition, %q is missing", action, typeID)
+		}
+	}
+	if _, ok := actionEffectType("not-an-action"); ok {
+		t.Fatalf("expected unknown actions to spawn no effect")
+	}
+
+	player := newTestPlayerState("caster")
+	player.Cooldowns = make(map[string]time.Time)
+	hub.world.players[player.ID] = player
+	if _, ok, reason := hub.HandleAction(player.ID, "not-an-action"); ok || reason != commandRejectInvalidAction {
+		t.Fatalf("expected an action missing from actionEffects to be rejected, ok=%t reason=%q", ok, reason)
+	}
+}
+
+func TestCommandDropsAreBrokenDownByCommandType(t *testing.T) {
+	hub := newHub()
+
+	mover := "player-move"
+	caster := "player-action"
+	for i := 0; i < commandQueuePerActorLimit; i++ {
+		hub.engine.Enqueue(sim.Command{ActorID: mover, Type: sim.CommandMove, Move: &sim.MoveCommand{DX: 1}})
+		hub.engine.Enqueue(sim.Command{ActorID: caster, Type: sim.CommandAction, Action: &sim.ActionCommand{Name: effectTypeAttack}})
+	}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := hub.engine.Enqueue(sim.Command{ActorID: mover, Type: sim.CommandMove, Move: &sim.MoveCommand{DX: -1}}); ok {
+			t.Fatalf("expected move beyond the per-actor limit to be dropped")
+		}
+	}
+	if ok, _ := hub.engine.Enqueue(sim.Command{ActorID: caster, Type: sim.CommandAction, Action: &sim.ActionCommand{Name: effectTypeAttack}}); ok {
+		t.Fatalf("expected action beyond the per-actor limit to be dropped")
+	}
+
+	snapshot := hub.TelemetrySnapshot()
+	if got := snapshot.CommandDropsByType[string(sim.CommandMove)]; got != 2 {
+		t.Fatalf("expected 2 move drops, got %d", got)
+	}
+	if got := snapshot.CommandDropsByType[string(sim.CommandAction)]; got != 1 {
+		t.Fatalf("expected 1 action drop, got %d", got)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("failed to encode telemetry snapshot: %v", err)
+	}
+	var decoded struct {
+		CommandDropsByType map[string]uint64 `json:"commandDropsByType"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode telemetry snapshot: %v", err)
+	}
+	if decoded.CommandDropsByType[string(sim.CommandMove)] != 2 || decoded.CommandDropsByType[string(sim.CommandAction)] != 1 {
+		t.Fatalf("expected diagnostics payload to carry per-type drops, got %v", decoded.CommandDropsByType)
+	}
+}
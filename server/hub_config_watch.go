@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// errStructuralConfigChange reports a reloaded world config that changes
+// fields only a reset can apply.
+var errStructuralConfigChange = errors.New("structural world config change requires a reset")
+
+// ReloadWorldConfig applies the tunable fields of cfg to the running world in
+// place. A config that differs from the active one in any structural field is
+// rejected with errStructuralConfigChange and leaves the world untouched.
+func (h *Hub) ReloadWorldConfig(cfg worldConfig) error {
+	cfg = cfg.Normalized()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.config.SameStructure(cfg) {
+		return errStructuralConfigChange
+	}
+	h.config = h.config.WithTunables(cfg)
+	h.world.applyConfigTunables(cfg)
+	return nil
+}
+
+// WatchWorldConfig polls the world config file at path every interval and
+// reloads its tunables whenever the contents change, until stop closes.
+// Failed reloads are logged and retried on the next change.
+func (h *Hub) WatchWorldConfig(path string, interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	watcher := &worldConfigWatcher{hub: h, path: path}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if changed, err := watcher.poll(); err != nil {
+			h.logf("[config] reload of %s failed: %v", path, err)
+		} else if changed {
+			h.logf("[config] applied world tunables from %s", path)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// worldConfigWatcher remembers the last contents read from a watched config
+// file so unchanged files are not reapplied on every poll.
+type worldConfigWatcher struct {
+	hub  *Hub
+	path string
+	last []byte
+}
+
+// poll reads the watched file and reloads it when its contents differ from
+// the previous poll. It reports whether tunables were applied.
+func (w *worldConfigWatcher) poll() (bool, error) {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	if w.last != nil && bytes.Equal(data, w.last) {
+		return false, nil
+	}
+	w.last = data
+
+	var cfg worldConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return false, fmt.Errorf("failed to parse world config: %w", err)
+	}
+	if err := w.hub.ReloadWorldConfig(cfg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// applyConfigTunables copies the tunable fields of cfg onto the live world
+// configuration, mirroring them into the internal world.
+func (w *World) applyConfigTunables(cfg worldConfig) {
+	if w == nil {
+		return
+	}
+	w.config = w.config.WithTunables(cfg)
+	if w.internalWorld != nil {
+		w.internalWorld.ApplyTunables(cfg)
+	}
+}
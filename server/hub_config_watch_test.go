@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeWatchedWorldConfig(t *testing.T, path string, cfg worldConfig) {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to encode world config: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("failed to write world config: %v", err)
+	}
+}
+
+func TestWatchedConfigLavaDamageUpdatesLiveWorld(t *testing.T) {
+	hub := newHubWithFullWorld()
+	path := filepath.Join(t.TempDir(), "world.json")
+	watcher := &worldConfigWatcher{hub: hub, path: path}
+
+	cfg := hub.CurrentConfig()
+	writeWatchedWorldConfig(t, path, cfg)
+	if _, err := watcher.poll(); err != nil {
+		t.Fatalf("initial poll failed: %v", err)
+	}
+	if changed, err := watcher.poll(); err != nil || changed {
+		t.Fatalf("expected unchanged file to be skipped, changed=%v err=%v", changed, err)
+	}
+
+	cfg.LavaDamagePerSecond = 40
+	writeWatchedWorldConfig(t, path, cfg)
+	changed, err := watcher.poll()
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if !changed {
+		t.Fatalf("expected edited file to be applied")
+	}
+	if got := hub.world.config.LavaDamageRate(); got != 40 {
+		t.Fatalf("expected live lava damage 40, got %.2f", got)
+	}
+	if got := hub.world.internalWorld.Config().LavaDamageRate(); got != 40 {
+		t.Fatalf("expected internal world lava damage 40, got %.2f", got)
+	}
+	if got := hub.CurrentConfig().LavaDamagePerSecond; got != 40 {
+		t.Fatalf("expected hub config to keep the tunable, got %.2f", got)
+	}
+
+	now := time.Now()
+	hub.world.obstacles = []Obstacle{{ID: "lava-test", Type: obstacleTypeLava, X: 200, Y: 200, Width: 80, Height: 80}}
+	walker := newTestPlayerState("walker")
+	walker.X = 220
+	walker.Y = 220
+	walker.LastHeartbeat = now
+	hub.world.players[walker.ID] = walker
+
+	players, _, _, _, _ := hub.advance(now, 1.0)
+	damaged := findPlayer(players, walker.ID)
+	if damaged == nil {
+		t.Fatalf("expected player snapshot")
+	}
+	expected := baselinePlayerMaxHealth - 40*burningTickInterval.Seconds()
+	if math.Abs(damaged.Health-expected) > 1e-6 {
+		t.Fatalf("expected reloaded lava damage to leave health %.2f, got %.2f", expected, damaged.Health)
+	}
+}
+
+func TestWatchedConfigRejectsStructuralChanges(t *testing.T) {
+	hub := newHubWithFullWorld()
+	path := filepath.Join(t.TempDir(), "world.json")
+	watcher := &worldConfigWatcher{hub: hub, path: path}
+
+	before := hub.CurrentConfig()
+	cfg := before
+	cfg.GoblinCount++
+	cfg.LavaDamagePerSecond = 40
+	writeWatchedWorldConfig(t, path, cfg)
+
+	if _, err := watcher.poll(); !errors.Is(err, errStructuralConfigChange) {
+		t.Fatalf("expected structural change to be rejected, got %v", err)
+	}
+	if got := hub.world.config.LavaDamageRate(); got != lavaDamagePerSecond {
+		t.Fatalf("expected rejected reload to leave lava damage at %.2f, got %.2f", lavaDamagePerSecond, got)
+	}
+	if !hub.CurrentConfig().SameStructure(before) {
+		t.Fatalf("expected rejected reload to leave the hub config unchanged")
+	}
+}
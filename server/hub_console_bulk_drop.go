@@ -0,0 +1,131 @@
+package server
+
+import (
+	itemspkg "mine-and-die/server/internal/items"
+	"mine-and-die/server/internal/net/proto"
+)
+
+// consoleBulkDropMaxEntries caps how many item entries one bulk_drop may
+// carry so a single message cannot stall the hub.
+const consoleBulkDropMaxEntries = 32
+
+// HandleBulkDrop answers the bulk_drop console command: it drops every listed
+// item type and quantity from the player's inventory onto the ground around
+// them in one call. The whole request is validated against the inventory
+// before anything moves, so a mix the player cannot cover drops nothing.
+func (h *Hub) HandleBulkDrop(playerID string, items []proto.ConsoleItem) proto.ConsoleAck {
+	ack := proto.NewConsoleAck("bulk_drop")
+	if len(items) == 0 || len(items) > consoleBulkDropMaxEntries {
+		ack.Status = "error"
+		ack.Reason = "invalid_quantity"
+		return ack
+	}
+	drops := make([]ItemStack, 0, len(items))
+	for _, item := range items {
+		if _, ok := ItemDefinitionFor(ItemType(item.Type)); !ok {
+			ack.Status = "error"
+			ack.Reason = "unknown_item"
+			return ack
+		}
+		if item.Qty <= 0 {
+			ack.Status = "error"
+			ack.Reason = "invalid_quantity"
+			return ack
+		}
+		drops = append(drops, ItemStack{Type: ItemType(item.Type), Quantity: item.Qty})
+	}
+
+	h.mu.Lock()
+	player, ok := h.world.players[playerID]
+	if !ok {
+		h.mu.Unlock()
+		ack.Status = "error"
+		ack.Reason = "unknown_actor"
+		return ack
+	}
+	dropped, reason := h.world.bulkDrop(&player.ActorState, drops, "manual")
+	if reason != "" {
+		h.mu.Unlock()
+		ack.Status = "error"
+		ack.Reason = reason
+		return ack
+	}
+	groundItems := h.legacyGroundItemsSnapshotLocked()
+	h.mu.Unlock()
+
+	ack.Status = "ok"
+	ack.Qty = dropped
+	h.broadcastState(nil, nil, nil, groundItems)
+	return ack
+}
+
+// bulkDrop moves each requested stack from the actor's inventory onto the
+// ground at the actor's tile, merging with matching ground stacks. It returns
+// the total quantity dropped, or a console failure reason when the inventory
+// does not hold every requested quantity, in which case nothing is dropped.
+// Ground items that scatter past the world edge are pulled back inside.
+func (w *World) bulkDrop(actor *actorState, drops []ItemStack, reason string) (int, string) {
+	if w == nil || actor == nil {
+		return 0, "unknown_actor"
+	}
+	wanted := make(map[ItemType]int, len(drops))
+	for _, drop := range drops {
+		wanted[drop.Type] += drop.Quantity
+	}
+	for itemType, quantity := range wanted {
+		if actor.Inventory.QuantityOf(itemType) < quantity {
+			return 0, "insufficient_items"
+		}
+	}
+
+	bounds := w.bounds()
+	setPosition := itemspkg.GroundItemPositionJournalSetter(w.AppendPatch)
+	total := 0
+	for _, drop := range drops {
+		var removed []ItemStack
+		err := w.MutateInventory(actor.ID, func(inv *Inventory) error {
+			var removeErr error
+			removed, removeErr = removeInventoryQuantity(inv, drop.Type, drop.Quantity)
+			return removeErr
+		})
+		if err != nil {
+			return total, "inventory_error"
+		}
+		for _, stack := range removed {
+			item := w.upsertGroundItem(actor, stack, reason)
+			if item == nil {
+				continue
+			}
+			total += stack.Quantity
+			if x, y := bounds.ConfineX(item.X), bounds.ConfineY(item.Y); x != item.X || y != item.Y {
+				setPosition(item, x, y)
+			}
+		}
+	}
+	return total, ""
+}
+
+// removeInventoryQuantity takes quantity items of itemType out of inv,
+// newest slots first, and returns the removed stacks with their fungibility
+// keys intact.
+func removeInventoryQuantity(inv *Inventory, itemType ItemType, quantity int) ([]ItemStack, error) {
+	var removed []ItemStack
+	remaining := quantity
+	for i := len(inv.Slots) - 1; i >= 0 && remaining > 0; i-- {
+		slot := inv.Slots[i]
+		if slot.Item.Type != itemType || slot.Item.Quantity <= 0 {
+			continue
+		}
+		take := slot.Item.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		stack, err := inv.RemoveQuantity(i, take)
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, stack)
+		remaining -= take
+	}
+	return removed, nil
+}
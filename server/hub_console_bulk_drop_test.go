@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+
+	"mine-and-die/server/internal/net/proto"
+)
+
+func TestBulkDropProducesMergedGroundStacks(t *testing.T) {
+	hub := newHubWithFullWorld()
+
+	// Standing in the corner makes the scatter reach past the world edge.
+	player := newTestPlayerState("bulk-dropper")
+	player.X = playerHalf
+	player.Y = playerHalf
+	hub.world.AddPlayer(player)
+	for _, stack := range []ItemStack{
+		{Type: ItemTypeGold, Quantity: 30},
+		{Type: ItemTypeHealthPotion, Quantity: 5},
+		{Type: ItemTypeRatTail, Quantity: 2},
+	} {
+		if err := hub.world.MutateInventory(player.ID, func(inv *Inventory) error {
+			_, err := inv.AddStack(stack)
+			return err
+		}); err != nil {
+			t.Fatalf("failed to seed %s: %v", stack.Type, err)
+		}
+	}
+
+	ack := hub.HandleBulkDrop(player.ID, []proto.ConsoleItem{{Type: string(ItemTypeGold), Qty: 40}, {Type: string(ItemTypeRatTail), Qty: 1}})
+	if ack.Status != "error" || ack.Reason != "insufficient_items" {
+		t.Fatalf("expected an uncovered mix to be rejected, got %+v", ack)
+	}
+	if len(hub.world.GroundItemsSnapshot()) != 0 || player.Inventory.QuantityOf(ItemTypeRatTail) != 2 {
+		t.Fatalf("expected a rejected bulk drop to leave inventory and ground untouched")
+	}
+
+	ack = hub.HandleBulkDrop(player.ID, []proto.ConsoleItem{
+		{Type: string(ItemTypeGold), Qty: 10},
+		{Type: string(ItemTypeHealthPotion), Qty: 3},
+		{Type: string(ItemTypeGold), Qty: 5},
+		{Type: string(ItemTypeRatTail), Qty: 2},
+	})
+	if ack.Status != "ok" || ack.Qty != 20 {
+		t.Fatalf("expected bulk drop of 20 items to succeed, got %+v", ack)
+	}
+
+	want := map[string]int{string(ItemTypeGold): 15, string(ItemTypeHealthPotion): 3, string(ItemTypeRatTail): 2}
+	items := hub.world.GroundItemsSnapshot()
+	if len(items) != len(want) {
+		t.Fatalf("expected %d merged ground stacks, got %+v", len(want), items)
+	}
+	bounds := hub.world.bounds()
+	for _, item := range items {
+		if item.Qty != want[item.Type] {
+			t.Fatalf("expected ground %s stack of %d, got %d", item.Type, want[item.Type], item.Qty)
+		}
+		if item.X != bounds.ConfineX(item.X) || item.Y != bounds.ConfineY(item.Y) {
+			t.Fatalf("expected ground %s stack inside the world, got (%.1f, %.1f)", item.Type, item.X, item.Y)
+		}
+	}
+	if got := player.Inventory.QuantityOf(ItemTypeGold); got != 15 {
+		t.Fatalf("expected 15 gold left in inventory, got %d", got)
+	}
+	if got := player.Inventory.QuantityOf(ItemTypeHealthPotion); got != 2 {
+		t.Fatalf("expected 2 potions left in inventory, got %d", got)
+	}
+	if got := player.Inventory.QuantityOf(ItemTypeRatTail); got != 0 {
+		t.Fatalf("expected no rat tails left in inventory, got %d", got)
+	}
+
+	if ack := hub.HandleBulkDrop(player.ID, []proto.ConsoleItem{{Type: "mystery", Qty: 1}}); ack.Reason != "unknown_item" {
+		t.Fatalf("expected unknown item types to be rejected, got %+v", ack)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"math"
+	"sort"
+
+	"mine-and-die/server/internal/net/proto"
+)
+
+// consoleEffectsRadius is how far from the player list_effects looks when the
+// command does not pass its own radius.
+const consoleEffectsRadius = 8 * tileSize
+
+// handleListEffects answers the privileged list_effects console command with
+// the live contract effect instances within radius world units of the player.
+// A radius of zero uses consoleEffectsRadius.
+func (h *Hub) handleListEffects(playerID string, radius int) proto.ConsoleAck {
+	ack := proto.NewConsoleAck("list_effects")
+	if radius < 0 {
+		ack.Status = "error"
+		ack.Reason = "invalid_quantity"
+		return ack
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.isPrivilegedLocked(playerID) {
+		ack.Status = "error"
+		ack.Reason = "forbidden"
+		return ack
+	}
+	player, ok := h.world.players[playerID]
+	if !ok {
+		ack.Status = "error"
+		ack.Reason = "unknown_actor"
+		return ack
+	}
+	reach := float64(radius)
+	if reach == 0 {
+		reach = consoleEffectsRadius
+	}
+	ack.Status = "ok"
+	ack.Effects = h.world.effectsNear(player.X, player.Y, reach)
+	ack.Qty = len(ack.Effects)
+	return ack
+}
+
+// effectsNear reports the contract effect instances whose position lies within
+// radius of (x, y), ordered by instance ID. Instances backed by a runtime
+// effect report the centre of its footprint; the rest report their quantized
+// motion position.
+func (w *World) effectsNear(x, y, radius float64) []proto.ConsoleEffect {
+	if w == nil || w.effectManager == nil {
+		return nil
+	}
+	bounds := w.bounds()
+	var effects []proto.ConsoleEffect
+	for id, instance := range w.effectManager.Instances() {
+		if instance == nil {
+			continue
+		}
+		ex := DequantizeCoord(instance.DeliveryState.Motion.PositionX) * tileSize
+		ey := DequantizeCoord(instance.DeliveryState.Motion.PositionY) * tileSize
+		if eff := w.effectManager.WorldEffect(id); eff != nil {
+			ex = eff.X + eff.Width/2
+			ey = eff.Y + eff.Height/2
+		}
+		dx, dy := bounds.Delta(x, y, ex, ey)
+		if math.Hypot(dx, dy) > radius {
+			continue
+		}
+		effects = append(effects, proto.ConsoleEffect{
+			ID:             id,
+			Type:           instance.DefinitionID,
+			Owner:          instance.OwnerActorID,
+			TicksRemaining: instance.BehaviorState.TicksRemaining,
+			X:              ex,
+			Y:              ey,
+		})
+	}
+	sort.Slice(effects, func(i, j int) bool { return effects[i].ID < effects[j].ID })
+	return effects
+}
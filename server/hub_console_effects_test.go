@@ -0,0 +1,79 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListEffectsReportsFireballLifetime(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	hub.world.npcs = make(map[string]*npcState)
+	shooterID := "shooter"
+	now := time.Now()
+
+	shooter := newTestPlayerState(shooterID)
+	shooter.X = 200
+	shooter.Y = 200
+	shooter.Facing = FacingRight
+	shooter.LastHeartbeat = now
+	shooter.Cooldowns = make(map[string]time.Time)
+	hub.world.players[shooterID] = shooter
+
+	if ack, _ := hub.HandleConsoleCommand(shooterID, "list_effects", 0); ack.Status != "error" || ack.Reason != "forbidden" {
+		t.Fatalf("expected unprivileged list_effects to be rejected, got %+v", ack)
+	}
+	hub.SetConsolePrivilege(shooterID, true)
+
+	if _, ok, _ := hub.HandleAction(shooterID, effectTypeFireball); !ok {
+		t.Fatalf("expected fireball action to be recognized")
+	}
+	runAdvance(hub, 1.0/float64(tickRate))
+
+	listed := func() int {
+		t.Helper()
+		ack, _ := hub.HandleConsoleCommand(shooterID, "list_effects", 0)
+		if ack.Status != "ok" || ack.Qty != 1 || len(ack.Effects) != 1 {
+			t.Fatalf("expected list_effects to report the fireball, got %+v", ack)
+		}
+		entry := ack.Effects[0]
+		if entry.Type != effectTypeFireball || entry.Owner != shooterID {
+			t.Fatalf("expected the shooter's fireball, got %+v", entry)
+		}
+		if entry.X <= shooter.X {
+			t.Fatalf("expected the fireball ahead of the shooter, got x %.1f", entry.X)
+		}
+		if instance := hub.world.effectManager.Instances()[entry.ID]; instance == nil || instance.BehaviorState.TicksRemaining != entry.TicksRemaining {
+			t.Fatalf("expected the listed lifetime to match the instance, got %+v", entry)
+		}
+		return entry.TicksRemaining
+	}
+
+	first := listed()
+	if first <= 0 {
+		t.Fatalf("expected the fireball to have lifetime left, got %d", first)
+	}
+	runAdvance(hub, 1.0/float64(tickRate))
+	if second := listed(); second != first-1 {
+		t.Fatalf("expected one tick of lifetime to elapse, got %d then %d", first, second)
+	}
+}
+
+func TestConsoleOperatorsGrantPrivilegeOnJoin(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ConsoleOperators = []string{"player-1"}
+	hub := NewHubWithConfig(cfg)
+
+	operator := hub.Join()
+	guest := hub.Join()
+
+	if ack, _ := hub.HandleConsoleCommand(operator.ID, "list_effects", 0); ack.Status != "ok" {
+		t.Fatalf("expected configured operator %s to run list_effects, got %+v", operator.ID, ack)
+	}
+	if ack, _ := hub.HandleConsoleCommand(operator.ID, "time_scale", 50); ack.Status != "ok" || ack.Qty != 50 {
+		t.Fatalf("expected configured operator %s to run time_scale, got %+v", operator.ID, ack)
+	}
+	if ack, _ := hub.HandleConsoleCommand(guest.ID, "list_effects", 0); ack.Status != "error" || ack.Reason != "forbidden" {
+		t.Fatalf("expected %s without the privilege to be rejected, got %+v", guest.ID, ack)
+	}
+}
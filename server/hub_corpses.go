@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+
+	"mine-and-die/server/internal/net/proto"
+)
+
+// HandleCorpseLoot moves the stack in the given slot of a corpse into the
+// player's inventory. An empty corpseID loots the nearest corpse in reach.
+func (h *Hub) HandleCorpseLoot(playerID, corpseID string, slot int) proto.LootResult {
+	result := proto.LootResult{CorpseID: corpseID, Slot: slot}
+	if slot < 0 {
+		result.Status = "error"
+		result.Reason = "invalid_corpse_slot"
+		return result
+	}
+	h.mu.Lock()
+	moved, lootedID, err := h.world.LootCorpse(playerID, corpseID, slot)
+	h.mu.Unlock()
+	result.CorpseID = lootedID
+	if err != nil {
+		result.Status = "error"
+		result.Reason = corpseErrorReason(err)
+		return result
+	}
+	result.Status = "ok"
+	result.Item = string(moved.Type)
+	result.Qty = moved.Quantity
+	h.broadcastState(nil, nil, nil, nil)
+	return result
+}
+
+func corpseErrorReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, errCorpseUnknownActor):
+		return "unknown_actor"
+	case errors.Is(err, errCorpseNotNearby):
+		return "no_corpse_nearby"
+	case errors.Is(err, errCorpseLootLocked):
+		return "loot_locked"
+	case errors.Is(err, errCorpseInvalidSlot):
+		return "invalid_corpse_slot"
+	case errors.Is(err, errCorpseEmptySlot):
+		return "empty_slot"
+	default:
+		return "internal_error"
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmoteBroadcastsTriggerWithoutSimulationEffect(t *testing.T) {
+	hub := newHub()
+	emoter := hub.Join()
+	watcher := hub.Join()
+
+	hub.mu.Lock()
+	emoterHealth := hub.world.players[emoter.ID].Health
+	watcherHealth := hub.world.players[watcher.ID].Health
+	hub.mu.Unlock()
+
+	if _, ok, reason := hub.HandleEmote(emoter.ID, "dance-off"); ok || reason != commandRejectInvalidAction {
+		t.Fatalf("expected unknown emote to be rejected as %q, got ok=%v reason=%q", commandRejectInvalidAction, ok, reason)
+	}
+	if _, ok, reason := hub.HandleEmote(emoter.ID, "wave"); !ok {
+		t.Fatalf("expected wave emote to be accepted, got %q", reason)
+	}
+
+	_, _, triggers, _, _ := hub.advance(time.Now(), 1.0/float64(tickRate))
+	var emote *EffectTrigger
+	for i := range triggers {
+		if triggers[i].Type == emoteTriggerPrefix+"wave" {
+			emote = &triggers[i]
+		}
+	}
+	if emote == nil {
+		t.Fatalf("expected an emote trigger in the broadcast batch, got %+v", triggers)
+	}
+	if emote.TargetID != emoter.ID || emote.Duration != emoteDurationMs {
+		t.Fatalf("expected trigger anchored on %s for %dms, got %+v", emoter.ID, emoteDurationMs, *emote)
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if got := hub.world.players[emoter.ID].Health; got != emoterHealth {
+		t.Fatalf("expected emoter health to stay %.1f, got %.1f", emoterHealth, got)
+	}
+	if got := hub.world.players[watcher.ID].Health; got != watcherHealth {
+		t.Fatalf("expected watcher health to stay %.1f, got %.1f", watcherHealth, got)
+	}
+	if instances := hub.world.effectManager.Instances(); len(instances) != 0 {
+		t.Fatalf("expected emote to spawn no contract instances, got %d", len(instances))
+	}
+}
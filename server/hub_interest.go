@@ -0,0 +1,336 @@
+package server
+
+import (
+	"sort"
+
+	itemspkg "mine-and-die/server/internal/items"
+	"mine-and-die/server/internal/net/proto"
+	"mine-and-die/server/internal/sim"
+	simpatches "mine-and-die/server/internal/sim/patches/typed"
+)
+
+// interestEntity captures where a broadcast entity currently sits and which
+// patch kind re-announces its position when it enters a subscriber's area of
+// interest. Contract marks effects owned by the effect manager, whose
+// lifecycle travels as spawn, update and end events.
+type interestEntity struct {
+	X        float64
+	Y        float64
+	PosKind  sim.PatchKind
+	Contract bool
+}
+
+// interestArrivals carries the full state of the entities entering a
+// subscriber's area of interest.
+type interestArrivals struct {
+	players     []sim.Player
+	npcs        []sim.NPC
+	groundItems []itemspkg.GroundItem
+	effects     []simpatches.EffectSpawnEvent
+}
+
+// interestSet tracks the entity IDs a subscriber currently receives updates for.
+type interestSet map[string]struct{}
+
+// interestEntitiesLocked gathers the position of every entity that can carry
+// patches. Callers must hold h.mu.
+func (h *Hub) interestEntitiesLocked() map[string]interestEntity {
+	if h == nil || h.world == nil {
+		return nil
+	}
+	w := h.world
+	entities := make(map[string]interestEntity, len(w.players)+len(w.npcs)+len(w.groundItems)+len(w.effects))
+	for id, player := range w.players {
+		if player == nil {
+			continue
+		}
+		entities[id] = interestEntity{X: player.X, Y: player.Y, PosKind: sim.PatchPlayerPos}
+	}
+	for id, npc := range w.npcs {
+		if npc == nil {
+			continue
+		}
+		entities[id] = interestEntity{X: npc.X, Y: npc.Y, PosKind: sim.PatchNPCPos}
+	}
+	for id, item := range w.groundItems {
+		if item == nil {
+			continue
+		}
+		entities[id] = interestEntity{X: item.X, Y: item.Y, PosKind: sim.PatchGroundItemPos}
+	}
+	for _, eff := range w.effects {
+		if eff == nil || eff.ID == "" {
+			continue
+		}
+		entities[eff.ID] = interestEntity{X: eff.X + eff.Width/2, Y: eff.Y + eff.Height/2, PosKind: sim.PatchEffectPos}
+	}
+	if w.effectManager != nil {
+		for id, instance := range w.effectManager.Instances() {
+			if instance == nil {
+				continue
+			}
+			entity, ok := entities[id]
+			if !ok {
+				eff := w.effectManager.RuntimeEffect(id)
+				if eff == nil {
+					continue
+				}
+				entity = interestEntity{X: eff.X + eff.Width/2, Y: eff.Y + eff.Height/2, PosKind: sim.PatchEffectPos}
+			}
+			entity.Contract = true
+			entities[id] = entity
+		}
+	}
+	return entities
+}
+
+// interestArrivalsLocked snapshots the full state of the entering entities.
+// Contract effects are re-announced through a spawn event carrying their
+// current sequence. Callers must hold h.mu.
+func (h *Hub) interestArrivalsLocked(enter []string, entities map[string]interestEntity) interestArrivals {
+	var arrivals interestArrivals
+	if h == nil || h.world == nil || len(enter) == 0 {
+		return arrivals
+	}
+	w := h.world
+	var (
+		players  []Player
+		npcs     []NPC
+		contract map[string]struct{}
+	)
+	for _, id := range enter {
+		if player, ok := w.players[id]; ok && player != nil {
+			players = append(players, player.Snapshot())
+			continue
+		}
+		if npc, ok := w.npcs[id]; ok && npc != nil {
+			npcs = append(npcs, npc.Snapshot())
+			continue
+		}
+		if item, ok := w.groundItems[id]; ok && item != nil {
+			arrivals.groundItems = append(arrivals.groundItems, item.GroundItem)
+			continue
+		}
+		if entities[id].Contract {
+			if contract == nil {
+				contract = make(map[string]struct{})
+			}
+			contract[id] = struct{}{}
+		}
+	}
+	arrivals.players = simPlayersFromLegacy(players)
+	arrivals.npcs = simNPCsFromLegacy(npcs)
+	if len(contract) > 0 && w.effectManager != nil {
+		var spawns []simpatches.EffectSpawnEvent
+		for _, event := range w.effectManager.ActiveSpawnEvents() {
+			if _, ok := contract[event.Instance.ID]; ok {
+				spawns = append(spawns, event)
+			}
+		}
+		arrivals.effects = trimEffectSpawns(w.effectManager.Catalog(), spawns)
+	}
+	return arrivals
+}
+
+// visibleFrom returns the entities within radius of the viewer's position.
+func visibleFrom(viewer interestEntity, entities map[string]interestEntity, radius float64) interestSet {
+	visible := make(interestSet, len(entities))
+	radiusSq := radius * radius
+	for id, entity := range entities {
+		dx := entity.X - viewer.X
+		dy := entity.Y - viewer.Y
+		if dx*dx+dy*dy <= radiusSq {
+			visible[id] = struct{}{}
+		}
+	}
+	return visible
+}
+
+// interestCrossings lists the entities that entered and left the area of
+// interest since the previous message, in ID order.
+func interestCrossings(visible, previous interestSet) (enter, leave []string) {
+	for id := range visible {
+		if _, ok := previous[id]; !ok {
+			enter = append(enter, id)
+		}
+	}
+	for id := range previous {
+		if _, ok := visible[id]; !ok {
+			leave = append(leave, id)
+		}
+	}
+	sort.Strings(enter)
+	sort.Strings(leave)
+	return enter, leave
+}
+
+// cullStateForViewer filters a delta message down to the viewer's area of
+// interest. Patches for entities outside the radius are withheld, patches for
+// entities without a known position (removals) always pass, and entities that
+// crossed the boundary since the previous message are listed in
+// InterestEnter/InterestLeave. Entering players, NPCs and ground items travel
+// in full alongside, entering contract effects are re-spawned, and entering
+// legacy effects receive a position patch.
+//
+// Contract effect events follow the same radius: spawns and updates outside
+// it are withheld, and effects that left or spawned outside it are tracked in
+// culled so their end is withheld too. It returns the culled set to carry
+// into the next message.
+func cullStateForViewer(msg stateMessage, visible interestSet, enter, leave []string, entities map[string]interestEntity, arrivals interestArrivals, culled map[string]struct{}) (stateMessage, map[string]struct{}) {
+	culled = copyIDSet(culled)
+	for _, id := range leave {
+		if entities[id].Contract {
+			culled[id] = struct{}{}
+		}
+	}
+	for _, id := range enter {
+		delete(culled, id)
+	}
+	outside := func(id string) bool {
+		if _, ok := culled[id]; ok {
+			return true
+		}
+		if _, known := entities[id]; !known {
+			return false
+		}
+		_, ok := visible[id]
+		return !ok
+	}
+
+	result := msg
+	result.InterestEnter = enter
+	result.InterestLeave = leave
+	result.InterestPlayers = arrivals.players
+	result.InterestNPCs = arrivals.npcs
+	result.InterestGroundItems = arrivals.groundItems
+
+	announced := make(map[string]struct{}, len(enter))
+	for _, player := range arrivals.players {
+		announced[player.ID] = struct{}{}
+	}
+	for _, npc := range arrivals.npcs {
+		announced[npc.ID] = struct{}{}
+	}
+	for _, item := range arrivals.groundItems {
+		announced[item.ID] = struct{}{}
+	}
+
+	patches := make([]sim.Patch, 0, len(msg.Patches)+len(enter))
+	for _, patch := range msg.Patches {
+		if _, known := entities[patch.EntityID]; known {
+			if _, ok := visible[patch.EntityID]; !ok {
+				continue
+			}
+			if patch.Kind == entities[patch.EntityID].PosKind {
+				announced[patch.EntityID] = struct{}{}
+			}
+		}
+		patches = append(patches, patch)
+	}
+
+	if len(msg.EffectSpawns) > 0 || len(arrivals.effects) > 0 {
+		spawns := make([]simpatches.EffectSpawnEvent, 0, len(msg.EffectSpawns)+len(arrivals.effects))
+		for _, spawn := range msg.EffectSpawns {
+			id := spawn.Instance.ID
+			if outside(id) {
+				culled[id] = struct{}{}
+				continue
+			}
+			announced[id] = struct{}{}
+			spawns = append(spawns, spawn)
+		}
+		for _, spawn := range arrivals.effects {
+			if _, ok := announced[spawn.Instance.ID]; ok {
+				continue
+			}
+			announced[spawn.Instance.ID] = struct{}{}
+			spawns = append(spawns, spawn)
+		}
+		result.EffectSpawns = spawns
+	}
+	if len(msg.EffectUpdates) > 0 {
+		result.EffectUpdates = make([]simpatches.EffectUpdateEvent, 0, len(msg.EffectUpdates))
+		for _, update := range msg.EffectUpdates {
+			if !outside(update.ID) {
+				result.EffectUpdates = append(result.EffectUpdates, update)
+			}
+		}
+	}
+	if len(msg.EffectEnds) > 0 {
+		result.EffectEnds = make([]simpatches.EffectEndEvent, 0, len(msg.EffectEnds))
+		for _, end := range msg.EffectEnds {
+			if outside(end.ID) {
+				delete(culled, end.ID)
+				continue
+			}
+			result.EffectEnds = append(result.EffectEnds, end)
+		}
+	}
+	if len(msg.EffectSeqCursors) > 0 {
+		result.EffectSeqCursors = make(map[string]simpatches.EffectSeq, len(msg.EffectSeqCursors))
+		for id, seq := range msg.EffectSeqCursors {
+			if !outside(id) {
+				result.EffectSeqCursors[id] = seq
+			}
+		}
+	}
+
+	for _, id := range enter {
+		entity := entities[id]
+		if _, ok := announced[id]; ok || entity.Contract {
+			continue
+		}
+		patches = append(patches, sim.Patch{
+			Kind:     entity.PosKind,
+			EntityID: id,
+			Payload:  sim.PositionPayload{X: entity.X, Y: entity.Y},
+		})
+	}
+	result.Patches = patches
+	return result, culled
+}
+
+func copyIDSet(ids map[string]struct{}) map[string]struct{} {
+	copied := make(map[string]struct{}, len(ids))
+	for id := range ids {
+		copied[id] = struct{}{}
+	}
+	return copied
+}
+
+// interestPayload returns the bytes to send to a single subscriber. Keyframes
+// stay authoritative and are sent in full; they reset the subscriber's
+// interest and culled effect sets so the next delta only reports boundary
+// crossings. Viewers without a player in the world receive the unfiltered
+// payload.
+func (h *Hub) interestPayload(msg stateMessage, data []byte, includeSnapshot bool, viewerID string, sub *subscriber, entities map[string]interestEntity) []byte {
+	viewer, ok := entities[viewerID]
+	if !ok || sub == nil {
+		return data
+	}
+	visible := visibleFrom(viewer, entities, h.interestRadius)
+
+	h.mu.Lock()
+	previous, culled := sub.interest, sub.culledEffects
+	sub.interest = visible
+	if includeSnapshot || previous == nil {
+		sub.culledEffects = nil
+		h.mu.Unlock()
+		return data
+	}
+	enter, leave := interestCrossings(visible, previous)
+	arrivals := h.interestArrivalsLocked(enter, entities)
+	h.mu.Unlock()
+
+	result, culled := cullStateForViewer(msg, visible, enter, leave, entities, arrivals, culled)
+	h.mu.Lock()
+	sub.culledEffects = culled
+	h.mu.Unlock()
+
+	encoded, err := proto.EncodeStateSnapshot(result)
+	if err != nil {
+		h.logf("failed to marshal culled state for %s: %v", viewerID, err)
+		return data
+	}
+	return encoded
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	"mine-and-die/server/internal/sim"
+	simpatches "mine-and-die/server/internal/sim/patches/typed"
+)
+
+type payloadRecordingConn struct {
+	mu       sync.Mutex
+	payloads [][]byte
+}
+
+func (c *payloadRecordingConn) Write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.payloads = append(c.payloads, append([]byte(nil), data...))
+	return nil
+}
+
+func (c *payloadRecordingConn) SetWriteDeadline(time.Time) error { return nil }
+
+func (c *payloadRecordingConn) Close() error { return nil }
+
+func (c *payloadRecordingConn) waitPayload(t *testing.T, index int) stateMessage {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		if len(c.payloads) > index {
+			data := c.payloads[index]
+			c.mu.Unlock()
+			var msg stateMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("failed to decode payload %d: %v", index, err)
+			}
+			return msg
+		}
+		c.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for payload %d", index)
+	return stateMessage{}
+}
+
+func patchEntityIDs(msg stateMessage) map[string]bool {
+	ids := make(map[string]bool, len(msg.Patches))
+	for _, patch := range msg.Patches {
+		ids[patch.EntityID] = true
+	}
+	return ids
+}
+
+func TestInterestCullingWithholdsFarPatches(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 1000
+	cfg.InterestRadius = 200
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+	worldCfg := fullyFeaturedTestWorldConfig()
+	worldCfg.NPCs = false
+	worldCfg.GoblinCount = 0
+	worldCfg.RatCount = 0
+	worldCfg.NPCCount = 0
+	hub.ResetWorld(worldCfg)
+
+	viewer := newTestPlayerState("viewer")
+	near := newTestPlayerState("near")
+	far := newTestPlayerState("far")
+	hub.mu.Lock()
+	hub.world.AddPlayer(viewer)
+	hub.world.AddPlayer(near)
+	hub.world.AddPlayer(far)
+	hub.world.SetPosition(viewer.ID, 300, 300)
+	hub.world.SetPosition(near.ID, 350, 300)
+	hub.world.SetPosition(far.ID, 2000, 1500)
+	hub.mu.Unlock()
+
+	conn := &payloadRecordingConn{}
+	sub := newSubscriber(conn, nil)
+	hub.mu.Lock()
+	hub.subscribers[viewer.ID] = sub
+	hub.mu.Unlock()
+	t.Cleanup(sub.Close)
+
+	hub.tick.Store(1)
+	hub.forceKeyframe()
+	hub.broadcastState(nil, nil, nil, nil)
+	keyframe := conn.waitPayload(t, 0)
+	if len(keyframe.Players) != 3 {
+		t.Fatalf("expected keyframe to carry every player, got %d", len(keyframe.Players))
+	}
+
+	hub.mu.Lock()
+	hub.tick.Add(1)
+	hub.world.SetPosition(near.ID, 360, 310)
+	hub.world.SetPosition(far.ID, 2010, 1510)
+	hub.mu.Unlock()
+	hub.broadcastState(nil, nil, nil, nil)
+
+	delta := conn.waitPayload(t, 1)
+	ids := patchEntityIDs(delta)
+	if !ids[near.ID] {
+		t.Fatalf("expected nearby patch to be delivered, got %+v", delta.Patches)
+	}
+	if ids[far.ID] {
+		t.Fatalf("expected far patch to be withheld, got %+v", delta.Patches)
+	}
+	if len(delta.InterestEnter) != 0 || len(delta.InterestLeave) != 0 {
+		t.Fatalf("expected no interest changes, got enter=%v leave=%v", delta.InterestEnter, delta.InterestLeave)
+	}
+
+	hub.mu.Lock()
+	hub.tick.Add(1)
+	hub.world.SetPosition(far.ID, 320, 320)
+	hub.world.SetPosition(near.ID, 1800, 1200)
+	hub.mu.Unlock()
+	hub.broadcastState(nil, nil, nil, nil)
+
+	crossing := conn.waitPayload(t, 2)
+	if len(crossing.InterestEnter) != 1 || crossing.InterestEnter[0] != far.ID {
+		t.Fatalf("expected %s to enter interest, got %v", far.ID, crossing.InterestEnter)
+	}
+	if len(crossing.InterestLeave) != 1 || crossing.InterestLeave[0] != near.ID {
+		t.Fatalf("expected %s to leave interest, got %v", near.ID, crossing.InterestLeave)
+	}
+	ids = patchEntityIDs(crossing)
+	if ids[near.ID] {
+		t.Fatalf("expected the leaving player's patch to be withheld, got %+v", crossing.Patches)
+	}
+	if len(crossing.InterestPlayers) != 1 || crossing.InterestPlayers[0].ID != far.ID {
+		t.Fatalf("expected the entering player's full state, got %+v", crossing.InterestPlayers)
+	}
+	if entered := crossing.InterestPlayers[0]; entered.Health != far.Health || entered.MaxHealth != far.MaxHealth {
+		t.Fatalf("expected the entering player's state to match the world, got %+v", entered)
+	}
+}
+
+func TestInterestCullingWithholdsFarEffectLifecycle(t *testing.T) {
+	entities := map[string]interestEntity{
+		"viewer":  {X: 0, Y: 0, PosKind: sim.PatchPlayerPos},
+		"fx-near": {X: 40, Y: 0, PosKind: sim.PatchEffectPos, Contract: true},
+		"fx-far":  {X: 900, Y: 0, PosKind: sim.PatchEffectPos, Contract: true},
+	}
+	visible := visibleFrom(entities["viewer"], entities, 200)
+	spawn := func(id string) simpatches.EffectSpawnEvent {
+		return simpatches.EffectSpawnEvent{Seq: 1, Instance: effectcontract.EffectInstance{ID: id}}
+	}
+	update := func(id string) simpatches.EffectUpdateEvent {
+		return simpatches.EffectUpdateEvent{Seq: 2, ID: id}
+	}
+
+	spawned, culled := cullStateForViewer(stateMessage{
+		EffectSpawns:  []simpatches.EffectSpawnEvent{spawn("fx-near"), spawn("fx-far")},
+		EffectUpdates: []simpatches.EffectUpdateEvent{update("fx-near"), update("fx-far")},
+		EffectSeqCursors: map[string]simpatches.EffectSeq{
+			"fx-near": 2,
+			"fx-far":  2,
+		},
+	}, visible, nil, nil, entities, interestArrivals{}, nil)
+	if len(spawned.EffectSpawns) != 1 || spawned.EffectSpawns[0].Instance.ID != "fx-near" {
+		t.Fatalf("expected only the nearby spawn, got %+v", spawned.EffectSpawns)
+	}
+	if len(spawned.EffectUpdates) != 1 || spawned.EffectUpdates[0].ID != "fx-near" {
+		t.Fatalf("expected only the nearby update, got %+v", spawned.EffectUpdates)
+	}
+	if _, ok := spawned.EffectSeqCursors["fx-far"]; ok {
+		t.Fatalf("expected the far cursor to be withheld, got %+v", spawned.EffectSeqCursors)
+	}
+
+	// Once the far effect ends it has no position any more, so only the
+	// culled set keeps its end from reaching the viewer.
+	delete(entities, "fx-far")
+	ended, culled := cullStateForViewer(stateMessage{
+		EffectEnds: []simpatches.EffectEndEvent{{Seq: 3, ID: "fx-far"}},
+	}, visible, nil, nil, entities, interestArrivals{}, culled)
+	if len(ended.EffectEnds) != 0 {
+		t.Fatalf("expected the far end to be withheld, got %+v", ended.EffectEnds)
+	}
+	if len(culled) != 0 {
+		t.Fatalf("expected the culled set to forget ended effects, got %v", culled)
+	}
+
+	// An effect re-entering the area is re-spawned from its current state.
+	entered, culled := cullStateForViewer(stateMessage{}, visible, []string{"fx-near"}, nil, entities, interestArrivals{
+		effects: []simpatches.EffectSpawnEvent{spawn("fx-near")},
+	}, map[string]struct{}{"fx-near": {}})
+	if len(entered.EffectSpawns) != 1 || entered.EffectSpawns[0].Instance.ID != "fx-near" {
+		t.Fatalf("expected the entering effect to be re-spawned, got %+v", entered.EffectSpawns)
+	}
+	if len(culled) != 0 {
+		t.Fatalf("expected the entering effect to leave the culled set, got %v", culled)
+	}
+	if len(entered.Patches) != 0 {
+		t.Fatalf("expected no position patch for a re-spawned contract effect, got %+v", entered.Patches)
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+// KeyframeCadenceConfig bounds the per-subscriber keyframe interval, in ticks,
+// used when adaptive cadence is enabled. A subscriber whose acknowledgements
+// lag behind the current tick gets keyframes more often, down to MinInterval;
+// a caught-up subscriber gets them as rarely as MaxInterval. A zero
+// MaxInterval disables adaptive cadence.
+type KeyframeCadenceConfig struct {
+	MinInterval int
+	MaxInterval int
+}
+
+func (cfg KeyframeCadenceConfig) normalized() KeyframeCadenceConfig {
+	if cfg.MaxInterval <= 0 {
+		return KeyframeCadenceConfig{}
+	}
+	if cfg.MinInterval < 1 {
+		cfg.MinInterval = 1
+	}
+	if cfg.MaxInterval < cfg.MinInterval {
+		cfg.MaxInterval = cfg.MinInterval
+	}
+	return cfg
+}
+
+func (cfg KeyframeCadenceConfig) enabled() bool {
+	return cfg.MaxInterval > 0
+}
+
+// planKeyframe decides whether the next broadcast carries a snapshot and
+// whether every subscriber must receive it. Forced keyframes go to everyone;
+// with adaptive cadence, other keyframes only go to subscribers that are due.
+// A subscriber recovering from backpressure gets a keyframe of its own.
+func (h *Hub) planKeyframe() (includeSnapshot bool, everyone bool) {
+	if !h.keyframeCadence.enabled() {
+		includeSnapshot = h.shouldIncludeSnapshot()
+		if !includeSnapshot && h.anySubscriberCatchUpReady() {
+			return true, false
+		}
+		return includeSnapshot, includeSnapshot
+	}
+	if h.forceKeyframeNext.CompareAndSwap(true, false) {
+		return true, true
+	}
+	return h.anySubscriberKeyframeDue(), false
+}
+
+// anySubscriberKeyframeDue reports whether at least one subscriber's adaptive
+// cadence calls for a keyframe on the current tick.
+func (h *Hub) anySubscriberKeyframeDue() bool {
+	tick := h.tick.Load()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sub := range h.subscribers {
+		if h.subscriberKeyframeDue(sub, tick) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Hub) subscriberKeyframeDue(sub *subscriber, tick uint64) bool {
+	if sub == nil {
+		return false
+	}
+	if sub.catchUpReady() {
+		return true
+	}
+	last := sub.lastKeyframeTick.Load()
+	if last == 0 || tick < last {
+		return true
+	}
+	return tick-last >= uint64(h.subscriberKeyframeInterval(sub, tick))
+}
+
+// subscriberKeyframeInterval returns the adaptive keyframe interval for the
+// subscriber. The interval starts at MaxInterval for a subscriber acking the
+// current tick and shrinks by one tick per tick of ack lag, never dropping
+// below MinInterval. Subscribers that have not acked anything yet use the hub
+// cadence clamped into the configured bounds.
+func (h *Hub) subscriberKeyframeInterval(sub *subscriber, tick uint64) int {
+	cadence := h.keyframeCadence
+	if !cadence.enabled() {
+		return h.CurrentKeyframeInterval()
+	}
+	ack := sub.lastAck.Load()
+	if ack == 0 {
+		interval := h.CurrentKeyframeInterval()
+		if interval < cadence.MinInterval {
+			interval = cadence.MinInterval
+		}
+		if interval > cadence.MaxInterval {
+			interval = cadence.MaxInterval
+		}
+		return interval
+	}
+	var lag uint64
+	if tick > ack {
+		lag = tick - ack
+	}
+	span := uint64(cadence.MaxInterval - cadence.MinInterval)
+	if lag >= span {
+		return cadence.MinInterval
+	}
+	return cadence.MaxInterval - int(lag)
+}
+
+// withoutSnapshot strips the keyframe body from a state message so
+// subscribers that are not due for a keyframe receive it as a delta.
+func withoutSnapshot(msg stateMessage) stateMessage {
+	msg.Players = nil
+	msg.NPCs = nil
+	msg.Obstacles = nil
+	msg.GroundItems = nil
+	msg.ActiveEffects = nil
+	return msg
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"mine-and-die/server/internal/net/proto"
+)
+
+func countKeyframePayloads(t *testing.T, conn *payloadRecordingConn) (keyframes, total int) {
+	t.Helper()
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	for i, data := range conn.payloads {
+		var msg stateMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("failed to decode payload %d: %v", i, err)
+		}
+		if msg.Type != proto.TypeState {
+			continue
+		}
+		total++
+		if len(msg.Players) > 0 {
+			keyframes++
+		}
+	}
+	return keyframes, total
+}
+
+func TestAdaptiveKeyframeCadenceFavoursLaggingSubscribers(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 6
+	cfg.KeyframeCadence = KeyframeCadenceConfig{MinInterval: 2, MaxInterval: 12}
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+	worldCfg := fullyFeaturedTestWorldConfig()
+	worldCfg.NPCs = false
+	worldCfg.GoblinCount = 0
+	worldCfg.RatCount = 0
+	worldCfg.NPCCount = 0
+	hub.ResetWorld(worldCfg)
+
+	lagging := newTestPlayerState("lagging")
+	caughtUp := newTestPlayerState("caught-up")
+	hub.mu.Lock()
+	hub.world.AddPlayer(lagging)
+	hub.world.AddPlayer(caughtUp)
+	hub.mu.Unlock()
+
+	laggingConn := &payloadRecordingConn{}
+	caughtUpConn := &payloadRecordingConn{}
+	laggingSub := newSubscriber(laggingConn, nil)
+	caughtUpSub := newSubscriber(caughtUpConn, nil)
+	hub.mu.Lock()
+	hub.subscribers[lagging.ID] = laggingSub
+	hub.subscribers[caughtUp.ID] = caughtUpSub
+	hub.mu.Unlock()
+	t.Cleanup(laggingSub.Close)
+	t.Cleanup(caughtUpSub.Close)
+
+	const ticks = 60
+	for tick := uint64(1); tick <= ticks; tick++ {
+		hub.tick.Store(tick)
+		if tick == 1 {
+			hub.RecordAck(lagging.ID, tick)
+		}
+		hub.RecordAck(caughtUp.ID, tick)
+		hub.broadcastState(nil, nil, nil, nil)
+		// Pace the ticks so neither send queue backs up into catch-up mode.
+		laggingConn.waitPayload(t, int(tick)-1)
+		caughtUpConn.waitPayload(t, int(tick)-1)
+	}
+
+	laggingKeyframes, laggingTotal := countKeyframePayloads(t, laggingConn)
+	caughtUpKeyframes, caughtUpTotal := countKeyframePayloads(t, caughtUpConn)
+	if laggingTotal != ticks || caughtUpTotal != ticks {
+		t.Fatalf("expected %d state payloads per subscriber, got lagging=%d caught-up=%d", ticks, laggingTotal, caughtUpTotal)
+	}
+	if laggingKeyframes <= caughtUpKeyframes {
+		t.Fatalf("expected lagging subscriber to receive more keyframes, got lagging=%d caught-up=%d", laggingKeyframes, caughtUpKeyframes)
+	}
+	if maxExpected := ticks/cfg.KeyframeCadence.MaxInterval + 1; caughtUpKeyframes > maxExpected {
+		t.Fatalf("expected caught-up subscriber to receive at most %d keyframes, got %d", maxExpected, caughtUpKeyframes)
+	}
+	if minExpected := ticks / (cfg.KeyframeCadence.MinInterval * 2); laggingKeyframes < minExpected {
+		t.Fatalf("expected lagging subscriber to receive at least %d keyframes, got %d", minExpected, laggingKeyframes)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minDisplayNameRunes = 2
+	maxDisplayNameRunes = 16
+
+	nameRejectInvalid = "invalid_name"
+	nameRejectTaken   = "name_taken"
+)
+
+// DisplayName returns the player's display name, which is empty until one is
+// set.
+func (h *Hub) DisplayName(playerID string) (string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	player, ok := h.world.players[playerID]
+	if !ok {
+		return "", false
+	}
+	return player.DisplayName, true
+}
+
+// SetDisplayName validates and stores a display name for the player and
+// forces a keyframe so every subscriber picks it up from the next snapshot.
+// Names are trimmed, must be unique within the hub ignoring case, and may only
+// contain letters, digits, spaces, '-' and '_'. It returns the stored name or
+// the rejection reason.
+func (h *Hub) SetDisplayName(playerID, name string) (string, string, bool) {
+	if IsSpectatorID(playerID) {
+		return "", commandRejectSpectator, false
+	}
+	name = strings.TrimSpace(name)
+	if !validDisplayName(name) {
+		return "", nameRejectInvalid, false
+	}
+
+	h.mu.Lock()
+	player, ok := h.world.players[playerID]
+	if !ok {
+		h.mu.Unlock()
+		return "", commandRejectUnknownActor, false
+	}
+	for id, other := range h.world.players {
+		if id != playerID && strings.EqualFold(other.DisplayName, name) {
+			h.mu.Unlock()
+			return "", nameRejectTaken, false
+		}
+	}
+	changed := player.DisplayName != name
+	player.DisplayName = name
+	h.mu.Unlock()
+
+	if changed {
+		h.forceKeyframe()
+	}
+	return name, "", true
+}
+
+// validDisplayName checks the length and character set of a trimmed name.
+func validDisplayName(name string) bool {
+	if !utf8.ValidString(name) {
+		return false
+	}
+	length := utf8.RuneCountInString(name)
+	if length < minDisplayNameRunes || length > maxDisplayNameRunes {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == ' ', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
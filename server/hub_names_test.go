@@ -0,0 +1,65 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetDisplayNameAppearsInNextSnapshotAndRejectsDuplicates(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 1000
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	first := hub.Join()
+	second := hub.Join()
+
+	conn := &payloadRecordingConn{}
+	sub := newSubscriber(conn, nil)
+	hub.mu.Lock()
+	hub.subscribers[second.ID] = sub
+	hub.mu.Unlock()
+	t.Cleanup(sub.Close)
+
+	hub.tick.Store(1)
+	hub.forceKeyframe()
+	hub.broadcastState(nil, nil, nil, nil)
+	conn.waitPayload(t, 0)
+
+	name, reason, ok := hub.SetDisplayName(first.ID, "  Ada_Lovelace ")
+	if !ok || name != "Ada_Lovelace" {
+		t.Fatalf("expected trimmed name to be stored, got name=%q ok=%v reason=%q", name, ok, reason)
+	}
+	if got, _ := hub.DisplayName(first.ID); got != "Ada_Lovelace" {
+		t.Fatalf("expected query to return the stored name, got %q", got)
+	}
+
+	hub.tick.Add(1)
+	hub.broadcastState(nil, nil, nil, nil)
+	snapshot := conn.waitPayload(t, 1)
+	var found bool
+	for _, player := range snapshot.Players {
+		if player.ID == first.ID {
+			found = true
+			if player.Name != "Ada_Lovelace" {
+				t.Fatalf("expected snapshot to carry the display name, got %q", player.Name)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected the name change to force a snapshot carrying %s, got %+v", first.ID, snapshot.Players)
+	}
+
+	if _, reason, ok := hub.SetDisplayName(second.ID, "ada_lovelace"); ok || reason != nameRejectTaken {
+		t.Fatalf("expected duplicate name to be rejected as %q, got ok=%v reason=%q", nameRejectTaken, ok, reason)
+	}
+	if _, reason, ok := hub.SetDisplayName(second.ID, strings.Repeat("x", maxDisplayNameRunes+1)); ok || reason != nameRejectInvalid {
+		t.Fatalf("expected oversized name to be rejected as %q, got ok=%v reason=%q", nameRejectInvalid, ok, reason)
+	}
+	if _, reason, ok := hub.SetDisplayName(second.ID, "<script>"); ok || reason != nameRejectInvalid {
+		t.Fatalf("expected punctuation to be rejected as %q, got ok=%v reason=%q", nameRejectInvalid, ok, reason)
+	}
+	if _, _, ok := hub.SetDisplayName(first.ID, "Ada_Lovelace"); !ok {
+		t.Fatalf("expected a player to keep re-setting its own name")
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPausedHubBuffersCommandsUntilResume(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.TickRate = 60
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	join := hub.Join()
+	conn := &recordingSubscriberConn{}
+	if _, _, _, _, ok := hub.Subscribe(join.ID, conn); !ok {
+		t.Fatalf("expected subscribe to succeed")
+	}
+
+	hub.Pause()
+	if !hub.Paused() {
+		t.Fatalf("expected hub to report paused state")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		hub.RunSimulation(stop)
+		close(done)
+	}()
+	stopped := false
+	stopLoop := func() {
+		if !stopped {
+			stopped = true
+			close(stop)
+			<-done
+		}
+	}
+	defer stopLoop()
+
+	tickBefore := hub.tick.Load()
+	patchesBefore := len(hub.engine.SnapshotPatches())
+	writesBefore, _ := conn.snapshot()
+	if _, ok, reason := hub.UpdateIntent(join.ID, 1, 0, string(FacingRight)); !ok {
+		t.Fatalf("expected intent to buffer while paused, got %q", reason)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if tick := hub.tick.Load(); tick != tickBefore {
+		t.Fatalf("expected tick to stay at %d while paused, got %d", tickBefore, tick)
+	}
+	if patches := len(hub.engine.SnapshotPatches()); patches != patchesBefore {
+		t.Fatalf("expected no patches while paused, got %d new", patches-patchesBefore)
+	}
+	if writes, _ := conn.snapshot(); writes != writesBefore {
+		t.Fatalf("expected no broadcasts while paused, got %d", writes-writesBefore)
+	}
+	if pending := hub.engine.Pending(); pending == 0 {
+		t.Fatalf("expected the intent command to stay buffered")
+	}
+
+	hub.Resume()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.tick.Load() <= tickBefore {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected simulation to advance after resume")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	stopLoop()
+
+	if intentX := hub.world.players[join.ID].IntentX; intentX != 1 {
+		t.Fatalf("expected buffered intent to apply after resume, got %.2f", intentX)
+	}
+}
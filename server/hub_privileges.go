@@ -0,0 +1,26 @@
+package server
+
+// SetConsolePrivilege grants or revokes access to debug console commands such
+// as time_scale for the given player.
+func (h *Hub) SetConsolePrivilege(playerID string, privileged bool) {
+	if h == nil || playerID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !privileged {
+		delete(h.privileged, playerID)
+		return
+	}
+	if h.privileged == nil {
+		h.privileged = make(map[string]struct{})
+	}
+	h.privileged[playerID] = struct{}{}
+}
+
+// isPrivilegedLocked reports whether the player may run debug console
+// commands. Callers must hold h.mu.
+func (h *Hub) isPrivilegedLocked(playerID string) bool {
+	_, ok := h.privileged[playerID]
+	return ok
+}
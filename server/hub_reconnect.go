@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	logging "mine-and-die/server/logging"
+	logginglifecycle "mine-and-die/server/logging/lifecycle"
+)
+
+var (
+	errReconnectUnknown = errors.New("unknown reconnect token")
+	errReconnectExpired = errors.New("reconnect token expired")
+)
+
+// reconnectSession ties a reconnect token to its player. While the client is
+// connected Player is nil and the entity lives in the world; after a drop the
+// detached state is parked here until Expires. Swept marks a player the stale
+// sweep removed, whose banked items wait in the stash keyed by the token.
+type reconnectSession struct {
+	PlayerID string
+	Player   *playerState
+	Swept    bool
+	Expires  time.Time
+}
+
+func newReconnectToken() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// issueReconnectTokenLocked registers a token for the player when reconnect
+// grace is enabled. Callers must hold h.mu.
+func (h *Hub) issueReconnectTokenLocked(playerID string) string {
+	if h.reconnectGrace <= 0 {
+		return ""
+	}
+	token := newReconnectToken()
+	if token == "" {
+		return ""
+	}
+	if h.reconnectSessions == nil {
+		h.reconnectSessions = make(map[string]*reconnectSession)
+	}
+	h.reconnectSessions[token] = &reconnectSession{PlayerID: playerID}
+	h.world.setStaleStashKey(playerID, token)
+	return token
+}
+
+// pruneReconnectSessionsLocked forgets sessions whose grace window has passed
+// or whose player left the world some other way, and opens a grace window
+// for players the stale sweep removed. Expiring sessions hand whatever they
+// still hold to the stale inventory policy: a parked player's items are
+// dropped or banked as if the sweep had removed them, and items banked under
+// the token move to the player's own stash. Callers must hold h.mu.
+func (h *Hub) pruneReconnectSessionsLocked(now time.Time) {
+	for token, session := range h.reconnectSessions {
+		if session.Player != nil || session.Swept {
+			if !now.Before(session.Expires) {
+				delete(h.reconnectSessions, token)
+				h.world.retireStaleStash(token, session.PlayerID)
+				if session.Player != nil {
+					h.world.releaseStaleInventory(session.Player)
+				}
+			}
+			continue
+		}
+		if _, ok := h.world.players[session.PlayerID]; ok {
+			continue
+		}
+		if h.world.hasStash(token) {
+			// The stale sweep banked the player's items under the token;
+			// keep the session open for the grace window so they can be
+			// claimed back.
+			session.Swept = true
+			session.Expires = now.Add(h.reconnectGrace)
+			continue
+		}
+		delete(h.reconnectSessions, token)
+		h.world.retireStaleStash(token, session.PlayerID)
+	}
+}
+
+// detachForReconnectLocked moves the player out of the world into its
+// reconnect session instead of discarding it. It reports false when the
+// player has no session. Callers must hold h.mu.
+func (h *Hub) detachForReconnectLocked(playerID string, now time.Time) bool {
+	if h.reconnectGrace <= 0 {
+		return false
+	}
+	for _, session := range h.reconnectSessions {
+		if session.PlayerID != playerID || session.Player != nil {
+			continue
+		}
+		player, ok := h.world.players[playerID]
+		if !ok {
+			return false
+		}
+		if !h.world.RemovePlayer(playerID) {
+			return false
+		}
+		session.Player = player
+		session.Expires = now.Add(h.reconnectGrace)
+		return true
+	}
+	return false
+}
+
+// ResumeSession re-attaches the player owning token and returns its ID.
+// Detached players are restored with their inventory, position, and health
+// intact as long as the grace window has not elapsed. Players the stale sweep
+// removed rejoin fresh under the same ID, carrying whatever the bank policy
+// stashed under the token.
+func (h *Hub) ResumeSession(token string) (string, error) {
+	now := h.now()
+
+	h.mu.Lock()
+	session, ok := h.reconnectSessions[token]
+	if !ok {
+		h.pruneReconnectSessionsLocked(now)
+		h.mu.Unlock()
+		return "", errReconnectUnknown
+	}
+	if (session.Player != nil || session.Swept) && !now.Before(session.Expires) {
+		h.pruneReconnectSessionsLocked(now)
+		h.mu.Unlock()
+		return "", errReconnectExpired
+	}
+	resumed := session.Player
+	if session.Swept {
+		resumed = h.seedPlayerState(session.PlayerID, now)
+		h.world.claimStash(token, resumed)
+		h.world.setStaleStashKey(session.PlayerID, token)
+		session.Swept = false
+	}
+	if resumed != nil {
+		resumed.LastHeartbeat = now
+		h.world.AddPlayer(resumed)
+		session.Player = nil
+		session.Expires = time.Time{}
+	}
+	playerID := session.PlayerID
+	h.mu.Unlock()
+
+	if resumed != nil {
+		logginglifecycle.PlayerJoined(
+			context.Background(),
+			h.publisher,
+			h.tick.Load(),
+			logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")},
+			logginglifecycle.PlayerJoinedPayload{SpawnX: resumed.X, SpawnY: resumed.Y},
+			map[string]any{"resumed": true},
+		)
+		h.forceKeyframe()
+	}
+	return playerID, nil
+}
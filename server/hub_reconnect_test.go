@@ -0,0 +1,216 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconnectTokenResumesWithinGraceWindow(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ReconnectGrace = time.Minute
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	join := hub.Join()
+	if join.ReconnectToken == "" {
+		t.Fatalf("expected join to issue a reconnect token")
+	}
+	baseline := hub.world.players[join.ID].Inventory.QuantityOf(ItemTypeGold)
+	if err := hub.world.MutateInventory(join.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+
+	sub, _, _, _, ok := hub.Subscribe(join.ID, &recordingSubscriberConn{})
+	if !ok {
+		t.Fatalf("expected subscribe to succeed")
+	}
+	hub.DisconnectSubscriber(join.ID, sub)
+	if hub.HasPlayer(join.ID) {
+		t.Fatalf("expected dropped player to leave the world while detached")
+	}
+
+	playerID, err := hub.ResumeSession(join.ReconnectToken)
+	if err != nil {
+		t.Fatalf("expected resume within grace window, got %v", err)
+	}
+	if playerID != join.ID {
+		t.Fatalf("expected to resume %s, got %s", join.ID, playerID)
+	}
+	player, ok := hub.world.players[playerID]
+	if !ok {
+		t.Fatalf("expected resumed player to be back in the world")
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != baseline+7 {
+		t.Fatalf("expected resumed inventory to keep %d gold, got %d", baseline+7, qty)
+	}
+}
+
+func TestReconnectTokenExpiresAfterGraceWindow(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ReconnectGrace = time.Minute
+	cfg.StaleInventory = StaleInventoryDrop
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	join := hub.Join()
+	baseline := hub.world.players[join.ID].Inventory.QuantityOf(ItemTypeGold)
+	if err := hub.world.MutateInventory(join.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+	hub.Disconnect(join.ID)
+
+	hub.mu.Lock()
+	session, ok := hub.reconnectSessions[join.ReconnectToken]
+	if !ok || session.Player == nil {
+		hub.mu.Unlock()
+		t.Fatalf("expected disconnect to park the player")
+	}
+	session.Expires = time.Now().Add(-time.Second)
+	hub.mu.Unlock()
+
+	if _, err := hub.ResumeSession(join.ReconnectToken); err != errReconnectExpired {
+		t.Fatalf("expected expired token error, got %v", err)
+	}
+	if hub.HasPlayer(join.ID) {
+		t.Fatalf("expected expired player to stay removed")
+	}
+	dropped := 0
+	for _, item := range hub.world.GroundItemsSnapshot() {
+		if item.Type == string(ItemTypeGold) {
+			dropped += item.Qty
+		}
+	}
+	if dropped != baseline+7 {
+		t.Fatalf("expected the expired player's %d gold on the ground, found %d", baseline+7, dropped)
+	}
+
+	fresh := hub.Join()
+	if fresh.ID == join.ID {
+		t.Fatalf("expected a new player id after expiry")
+	}
+	player := hub.world.players[fresh.ID]
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != baseline {
+		t.Fatalf("expected fresh player to start with %d gold, got %d", baseline, qty)
+	}
+}
+
+func TestReconnectTokenClaimsItemsBankedByStaleSweep(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ReconnectGrace = time.Minute
+	cfg.StaleInventory = StaleInventoryBank
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	join := hub.Join()
+	baseline := hub.world.players[join.ID].Inventory.QuantityOf(ItemTypeGold)
+	if err := hub.world.MutateInventory(join.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+
+	hub.mu.Lock()
+	hub.world.players[join.ID].LastHeartbeat = time.Now().Add(-disconnectAfter - time.Second)
+	hub.mu.Unlock()
+	hub.advance(time.Now(), 0)
+	if hub.HasPlayer(join.ID) {
+		t.Fatalf("expected the stale sweep to remove the player")
+	}
+	if qty := hub.world.StashContents(join.ReconnectToken).QuantityOf(ItemTypeGold); qty != baseline+7 {
+		t.Fatalf("expected the sweep to bank %d gold under the reconnect token, got %d", baseline+7, qty)
+	}
+
+	playerID, err := hub.ResumeSession(join.ReconnectToken)
+	if err != nil {
+		t.Fatalf("expected resume after the sweep, got %v", err)
+	}
+	if playerID != join.ID {
+		t.Fatalf("expected to resume %s, got %s", join.ID, playerID)
+	}
+	player, ok := hub.world.players[playerID]
+	if !ok {
+		t.Fatalf("expected resumed player to be back in the world")
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != 2*baseline+7 {
+		t.Fatalf("expected the banked %d gold on top of a fresh %d, got %d", baseline+7, baseline, qty)
+	}
+	if hub.world.hasStash(join.ReconnectToken) {
+		t.Fatalf("expected the claimed stash to be forgotten")
+	}
+}
+
+func TestTickPrunesExpiredReconnectSessions(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ReconnectGrace = time.Minute
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	join := hub.Join()
+	hub.Disconnect(join.ID)
+
+	hub.mu.Lock()
+	session, ok := hub.reconnectSessions[join.ReconnectToken]
+	if !ok || session.Player == nil {
+		hub.mu.Unlock()
+		t.Fatalf("expected disconnect to park the player")
+	}
+	session.Expires = time.Now().Add(-time.Second)
+	hub.mu.Unlock()
+
+	hub.advance(time.Now(), 0)
+
+	hub.mu.Lock()
+	_, ok = hub.reconnectSessions[join.ReconnectToken]
+	hub.mu.Unlock()
+	if ok {
+		t.Fatalf("expected the tick to prune the expired session without a join or resume")
+	}
+}
+
+func TestExpiredReconnectSessionBanksParkedInventory(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.ReconnectGrace = time.Minute
+	cfg.StaleInventory = StaleInventoryBank
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+
+	parked := hub.Join()
+	swept := hub.Join()
+	baseline := hub.world.players[parked.ID].Inventory.QuantityOf(ItemTypeGold)
+
+	hub.Disconnect(parked.ID)
+	hub.mu.Lock()
+	hub.world.players[swept.ID].LastHeartbeat = time.Now().Add(-disconnectAfter - time.Second)
+	hub.mu.Unlock()
+	hub.advance(time.Now(), 0)
+
+	hub.mu.Lock()
+	for _, token := range []string{parked.ReconnectToken, swept.ReconnectToken} {
+		session, ok := hub.reconnectSessions[token]
+		if !ok {
+			hub.mu.Unlock()
+			t.Fatalf("expected an open session for token %s", token)
+		}
+		session.Expires = time.Now().Add(-time.Second)
+	}
+	hub.mu.Unlock()
+	hub.advance(time.Now(), 0)
+
+	for _, id := range []string{parked.ID, swept.ID} {
+		if qty := hub.world.StashContents(id).QuantityOf(ItemTypeGold); qty != baseline {
+			t.Fatalf("expected %s's %d gold to end up in their stash after the grace window, got %d", id, baseline, qty)
+		}
+	}
+	for _, token := range []string{parked.ReconnectToken, swept.ReconnectToken} {
+		if hub.world.hasStash(token) {
+			t.Fatalf("expected no stash left under expired token %s", token)
+		}
+	}
+}
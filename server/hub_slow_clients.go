@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"mine-and-die/server/logging"
+	loggingnetwork "mine-and-die/server/logging/network"
+)
+
+// defaultMaxWriteFailures is how many consecutive timed-out writes a
+// subscriber survives when HubConfig.MaxWriteFailures is unset.
+const defaultMaxWriteFailures = 3
+
+var (
+	errSubscriberWriteDropped = errors.New("subscriber write timed out")
+	errSubscriberTooSlow      = errors.New("subscriber too slow")
+)
+
+// ErrSubscriberWriteDropped reports a write that timed out and was dropped
+// while the subscriber stays connected. Any other write error means the
+// connection is gone.
+var ErrSubscriberWriteDropped = errSubscriberWriteDropped
+
+// newSubscriber builds a subscriber using the hub's write timeout and
+// slow-client threshold.
+func (h *Hub) newSubscriber(conn subscriberConn) *subscriber {
+	timeout := h.writeTimeout
+	if timeout <= 0 {
+		timeout = writeWait
+	}
+	limit := h.maxWriteFails
+	if limit <= 0 {
+		limit = defaultMaxWriteFailures
+	}
+	return newSubscriberWithWriteLimits(conn, h.telemetry, timeout, limit)
+}
+
+// recordWriteResult tracks the subscriber's streak of timed-out writes. A
+// timeout below the threshold becomes errSubscriberWriteDropped so the writer
+// keeps going; the timeout that reaches it becomes errSubscriberTooSlow.
+// Other results pass through, and a successful write ends the streak.
+func (s *subscriber) recordWriteResult(err error) error {
+	if err == nil {
+		s.writeFailures.Store(0)
+		return nil
+	}
+	if !isWriteTimeout(err) {
+		return err
+	}
+	failures := int(s.writeFailures.Add(1))
+	if failures < s.maxWriteFailures {
+		return fmt.Errorf("%w: %v", errSubscriberWriteDropped, err)
+	}
+	return fmt.Errorf("%w after %d consecutive write timeouts: %v", errSubscriberTooSlow, failures, err)
+}
+
+func isWriteTimeout(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// publishSlowClientDisconnect records a network event when sub was closed for
+// timing out too many writes in a row, and reports whether it was.
+func (h *Hub) publishSlowClientDisconnect(playerID string, sub *subscriber) bool {
+	if sub == nil || !errors.Is(sub.closeError(), errSubscriberTooSlow) {
+		return false
+	}
+	loggingnetwork.SlowClientDisconnected(
+		context.Background(),
+		h.publisher,
+		h.tick.Load(),
+		logging.EntityRef{ID: playerID, Kind: logging.EntityKind("player")},
+		loggingnetwork.SlowClientPayload{
+			Failures:       int(sub.writeFailures.Load()),
+			WriteTimeoutMs: sub.writeTimeout.Milliseconds(),
+		},
+		nil,
+	)
+	return true
+}
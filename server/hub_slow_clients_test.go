@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	logginglifecycle "mine-and-die/server/logging/lifecycle"
+	loggingnetwork "mine-and-die/server/logging/network"
+)
+
+// timeoutSubscriberConn fails writes with a deadline timeout while slow is set.
+type timeoutSubscriberConn struct {
+	mu   sync.Mutex
+	slow bool
+}
+
+func (c *timeoutSubscriberConn) Write([]byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.slow {
+		return os.ErrDeadlineExceeded
+	}
+	return nil
+}
+
+func (c *timeoutSubscriberConn) SetWriteDeadline(time.Time) error { return nil }
+
+func (c *timeoutSubscriberConn) Close() error { return nil }
+
+func (c *timeoutSubscriberConn) setSlow(slow bool) {
+	c.mu.Lock()
+	c.slow = slow
+	c.mu.Unlock()
+}
+
+type slowClientCapturePublisher struct {
+	mu     sync.Mutex
+	events []logging.Event
+}
+
+func (p *slowClientCapturePublisher) Publish(_ context.Context, event logging.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func TestSubscriberDisconnectsAfterConsecutiveWriteTimeouts(t *testing.T) {
+	pub := &slowClientCapturePublisher{}
+	cfg := DefaultHubConfig()
+	cfg.WriteTimeout = 250 * time.Millisecond
+	cfg.MaxWriteFailures = 3
+	hub := NewHubWithConfig(cfg, pub)
+	// Broadcast inline so no queued state write lands on the test subscriber.
+	hub.broadcastFanout = nil
+	join := hub.Join()
+
+	conn := &timeoutSubscriberConn{}
+	sub, _, _, _, ok := hub.Subscribe(join.ID, conn)
+	if !ok {
+		t.Fatalf("expected subscribe to succeed")
+	}
+	if got := sub.writeTimeout; got != cfg.WriteTimeout {
+		t.Fatalf("expected configured write timeout %s, got %s", cfg.WriteTimeout, got)
+	}
+
+	// A successful write in between ends the streak.
+	conn.setSlow(true)
+	for i := 0; i < 2; i++ {
+		if err := sub.Write([]byte("state")); !errors.Is(err, ErrSubscriberWriteDropped) {
+			t.Fatalf("expected timeout %d to be dropped, got %v", i+1, err)
+		}
+	}
+	conn.setSlow(false)
+	if err := sub.Write([]byte("state")); err != nil {
+		t.Fatalf("expected write to recover, got %v", err)
+	}
+
+	conn.setSlow(true)
+	for i := 0; i < 2; i++ {
+		if err := sub.Write([]byte("state")); !errors.Is(err, ErrSubscriberWriteDropped) {
+			t.Fatalf("expected timeout %d after recovery to be dropped, got %v", i+1, err)
+		}
+	}
+	if !hub.HasPlayer(join.ID) {
+		t.Fatalf("expected the player to stay connected below the threshold")
+	}
+
+	err := sub.Write([]byte("state"))
+	if !errors.Is(err, errSubscriberTooSlow) {
+		t.Fatalf("expected the third consecutive timeout to give up, got %v", err)
+	}
+	// The websocket handler disconnects on any write error that was not dropped.
+	hub.DisconnectSubscriber(join.ID, sub)
+	if hub.HasPlayer(join.ID) {
+		t.Fatalf("expected the slow client to be disconnected")
+	}
+	select {
+	case <-sub.closed:
+	default:
+		t.Fatalf("expected the slow subscriber to be closed")
+	}
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	var slowEvents []logging.Event
+	var disconnects []logginglifecycle.PlayerDisconnectedPayload
+	for _, event := range pub.events {
+		switch event.Type {
+		case loggingnetwork.EventSlowClientDisconnected:
+			slowEvents = append(slowEvents, event)
+		case logginglifecycle.EventPlayerDisconnected:
+			if payload, ok := event.Payload.(logginglifecycle.PlayerDisconnectedPayload); ok {
+				disconnects = append(disconnects, payload)
+			}
+		}
+	}
+	if len(slowEvents) != 1 {
+		t.Fatalf("expected one slow client event, got %d", len(slowEvents))
+	}
+	if slowEvents[0].Actor.ID != join.ID {
+		t.Fatalf("expected slow client event for %s, got %q", join.ID, slowEvents[0].Actor.ID)
+	}
+	want := loggingnetwork.SlowClientPayload{Failures: 3, WriteTimeoutMs: 250}
+	if payload, ok := slowEvents[0].Payload.(loggingnetwork.SlowClientPayload); !ok || payload != want {
+		t.Fatalf("unexpected slow client payload: got %+v want %+v", slowEvents[0].Payload, want)
+	}
+	if len(disconnects) != 1 || disconnects[0].Reason != "slow_client" {
+		t.Fatalf("expected one slow_client disconnect, got %+v", disconnects)
+	}
+}
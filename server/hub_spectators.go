@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	itemspkg "mine-and-die/server/internal/items"
+	"mine-and-die/server/internal/sim"
+)
+
+// spectatorIDPrefix marks subscriber keys that belong to spectators rather
+// than players. Player IDs never use it.
+const spectatorIDPrefix = "spectator-"
+
+// IsSpectatorID reports whether the subscriber ID names a spectator.
+func IsSpectatorID(id string) bool {
+	return strings.HasPrefix(id, spectatorIDPrefix)
+}
+
+// NextSpectatorID allocates the subscriber ID for a new spectator.
+func (h *Hub) NextSpectatorID() string {
+	return fmt.Sprintf("%s%d", spectatorIDPrefix, h.nextSpectatorID.Add(1))
+}
+
+// SubscribeSpectator attaches a connection that receives state broadcasts
+// without a player entity. It mirrors Subscribe so transports can pick either
+// one; the ID must come from NextSpectatorID and not already be subscribed.
+// Spectators never appear in the world, so the simulation and the command
+// pipeline treat them as unknown actors.
+func (h *Hub) SubscribeSpectator(spectatorID string, conn subscriberConn) (*subscriber, []sim.Player, []sim.NPC, []itemspkg.GroundItem, bool) {
+	if !IsSpectatorID(spectatorID) {
+		return nil, nil, nil, nil, false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, taken := h.subscribers[spectatorID]; taken {
+		return nil, nil, nil, nil, false
+	}
+
+	sub := h.newSubscriber(conn)
+	h.subscribers[spectatorID] = sub
+	snapshot := h.simSnapshotLocked(true, false)
+	return sub, snapshot.Players, snapshot.NPCs, snapshot.GroundItems, true
+}
+
+func (h *Hub) hasSubscriber(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.subscribers[id]
+	return ok
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"mine-and-die/server/internal/net/proto"
+	"mine-and-die/server/internal/sim"
+	simpatches "mine-and-die/server/internal/sim/patches/typed"
+)
+
+// stealthFilter lists what a single viewer may not see: the stealthed actors
+// hidden from it and the effects that would give their position away.
+type stealthFilter struct {
+	actors  map[string]struct{}
+	effects map[string]struct{}
+}
+
+func (f stealthFilter) empty() bool {
+	return len(f.actors) == 0 && len(f.effects) == 0
+}
+
+func (f stealthFilter) hidesActor(id string) bool {
+	_, ok := f.actors[id]
+	return ok
+}
+
+func (f stealthFilter) hidesEffect(id string) bool {
+	_, ok := f.effects[id]
+	return ok
+}
+
+// hidesEntity reports whether patches for the entity must be withheld.
+func (f stealthFilter) hidesEntity(id string) bool {
+	return f.hidesActor(id) || f.hidesEffect(id)
+}
+
+// SetActorStealthed applies or clears the stealthed status on the actor; see
+// World.SetActorStealthed.
+func (h *Hub) SetActorStealthed(actorID string, stealthed bool) {
+	now := h.now()
+	h.mu.Lock()
+	h.world.SetActorStealthed(actorID, stealthed, now)
+	h.mu.Unlock()
+}
+
+// stealthFilterLocked builds the filter for one viewer from the live world.
+// Callers must hold h.mu.
+func (h *Hub) stealthFilterLocked(viewerID string, stealthed map[string]*actorState) stealthFilter {
+	hidden := h.world.hiddenActorsFor(viewerID, stealthed)
+	return stealthFilter{actors: hidden, effects: h.world.hiddenEffectsFor(hidden)}
+}
+
+// viewerStealthFilter builds the filter for a viewer outside a broadcast, such
+// as a join response or a keyframe served on request.
+func (h *Hub) viewerStealthFilter(viewerID string) stealthFilter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.stealthFilterLocked(viewerID, h.world.stealthedActors())
+}
+
+// withEffects returns a copy of the filter that also hides the given effects.
+func (f stealthFilter) withEffects(ids map[string]struct{}) stealthFilter {
+	effects := make(map[string]struct{}, len(f.effects)+len(ids))
+	for id := range f.effects {
+		effects[id] = struct{}{}
+	}
+	for id := range ids {
+		effects[id] = struct{}{}
+	}
+	return stealthFilter{actors: f.actors, effects: effects}
+}
+
+// filterStealthState strips hidden actors, their patches, and the effect
+// events and triggers that would reveal them from a state message. Spawns
+// owned by or following a hidden actor are added to the filter's effects
+// so the rest of the effect's lifecycle is withheld as well; the filter must
+// own its effect set.
+func filterStealthState(msg stateMessage, filter stealthFilter) stateMessage {
+	filtered := msg
+	filtered.Players = filterStealthPlayers(msg.Players, filter)
+	filtered.NPCs = filterStealthNPCs(msg.NPCs, filter)
+	filtered.EffectSpawns = filterStealthSpawns(msg.EffectSpawns, filter)
+	filtered.ActiveEffects = filterStealthSpawns(msg.ActiveEffects, filter)
+	filtered.Patches = make([]sim.Patch, 0, len(msg.Patches))
+	for _, patch := range msg.Patches {
+		if !filter.hidesEntity(patch.EntityID) {
+			filtered.Patches = append(filtered.Patches, patch)
+		}
+	}
+	if len(msg.EffectUpdates) > 0 {
+		filtered.EffectUpdates = make([]simpatches.EffectUpdateEvent, 0, len(msg.EffectUpdates))
+		for _, update := range msg.EffectUpdates {
+			if !filter.hidesEffect(update.ID) {
+				filtered.EffectUpdates = append(filtered.EffectUpdates, update)
+			}
+		}
+	}
+	if len(msg.EffectEnds) > 0 {
+		filtered.EffectEnds = make([]simpatches.EffectEndEvent, 0, len(msg.EffectEnds))
+		for _, end := range msg.EffectEnds {
+			if !filter.hidesEffect(end.ID) {
+				filtered.EffectEnds = append(filtered.EffectEnds, end)
+			}
+		}
+	}
+	if len(msg.EffectSeqCursors) > 0 {
+		filtered.EffectSeqCursors = make(map[string]simpatches.EffectSeq, len(msg.EffectSeqCursors))
+		for id, seq := range msg.EffectSeqCursors {
+			if !filter.hidesEffect(id) {
+				filtered.EffectSeqCursors[id] = seq
+			}
+		}
+	}
+	if len(msg.EffectTriggers) > 0 {
+		filtered.EffectTriggers = make([]sim.EffectTrigger, 0, len(msg.EffectTriggers))
+		for _, trigger := range msg.EffectTriggers {
+			if filter.hidesEffect(trigger.ID) || filter.hidesEffect(trigger.SourceEffectID) || filter.hidesActor(trigger.TargetID) {
+				continue
+			}
+			filtered.EffectTriggers = append(filtered.EffectTriggers, trigger)
+		}
+	}
+	return filtered
+}
+
+// filterStealthKeyframe strips hidden actors and their effects from a
+// keyframe served on request.
+func filterStealthKeyframe(msg keyframeMessage, filter stealthFilter) keyframeMessage {
+	filter = filter.withEffects(nil)
+	filtered := msg
+	filtered.Players = filterStealthPlayers(msg.Players, filter)
+	filtered.NPCs = filterStealthNPCs(msg.NPCs, filter)
+	filtered.ActiveEffects = filterStealthSpawns(msg.ActiveEffects, filter)
+	return filtered
+}
+
+func filterStealthPlayers(players []sim.Player, filter stealthFilter) []sim.Player {
+	if players == nil {
+		return nil
+	}
+	filtered := make([]sim.Player, 0, len(players))
+	for _, player := range players {
+		if !filter.hidesActor(player.ID) {
+			filtered = append(filtered, player)
+		}
+	}
+	return filtered
+}
+
+func filterStealthNPCs(npcs []sim.NPC, filter stealthFilter) []sim.NPC {
+	if npcs == nil {
+		return nil
+	}
+	filtered := make([]sim.NPC, 0, len(npcs))
+	for _, npc := range npcs {
+		if !filter.hidesActor(npc.ID) {
+			filtered = append(filtered, npc)
+		}
+	}
+	return filtered
+}
+
+func filterStealthSpawns(spawns []simpatches.EffectSpawnEvent, filter stealthFilter) []simpatches.EffectSpawnEvent {
+	if len(spawns) == 0 {
+		return spawns
+	}
+	filtered := make([]simpatches.EffectSpawnEvent, 0, len(spawns))
+	for _, spawn := range spawns {
+		instance := spawn.Instance
+		if filter.hidesActor(instance.OwnerActorID) || filter.hidesActor(instance.FollowActorID) {
+			filter.effects[instance.ID] = struct{}{}
+		}
+		if !filter.hidesEffect(instance.ID) {
+			filtered = append(filtered, spawn)
+		}
+	}
+	return filtered
+}
+
+// stealthPayload filters a state message for one viewer, re-encodes it, and
+// records which effects stay withheld from the subscriber. The shared payload
+// is returned unchanged when encoding fails.
+func (h *Hub) stealthPayload(msg stateMessage, data []byte, filter stealthFilter, sub *subscriber) (stateMessage, []byte) {
+	filter = filter.withEffects(nil)
+	filtered := filterStealthState(msg, filter)
+
+	withheld := make(map[string]struct{}, len(filter.effects))
+	for id := range filter.effects {
+		withheld[id] = struct{}{}
+	}
+	for _, end := range msg.EffectEnds {
+		delete(withheld, end.ID)
+	}
+	h.mu.Lock()
+	sub.withheldEffects = withheld
+	h.mu.Unlock()
+
+	encoded, err := proto.EncodeStateSnapshot(filtered)
+	if err != nil {
+		h.logf("failed to marshal stealth-filtered state: %v", err)
+		return msg, data
+	}
+	return filtered, encoded
+}
+
+// withoutEntities copies the interest entity map minus the filtered IDs so
+// stealthed actors and their effects are never announced through interest
+// enter patches.
+func withoutEntities(entities map[string]interestEntity, filter stealthFilter) map[string]interestEntity {
+	if entities == nil {
+		return nil
+	}
+	filtered := make(map[string]interestEntity, len(entities))
+	for id, entity := range entities {
+		if !filter.hidesEntity(id) {
+			filtered[id] = entity
+		}
+	}
+	return filtered
+}
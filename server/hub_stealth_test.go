@@ -0,0 +1,205 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	"mine-and-die/server/internal/sim"
+	"mine-and-die/server/logging"
+)
+
+func playerIDs(msg stateMessage) map[string]bool {
+	ids := make(map[string]bool, len(msg.Players))
+	for _, player := range msg.Players {
+		ids[player.ID] = true
+	}
+	return ids
+}
+
+func TestDetectRevealsStealthedActorToCasterForItsDuration(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.KeyframeInterval = 1
+	hub := NewHubWithConfig(cfg)
+	hub.broadcastFanout = nil
+	worldCfg := fullyFeaturedTestWorldConfig()
+	worldCfg.NPCs = false
+	worldCfg.GoblinCount = 0
+	worldCfg.RatCount = 0
+	worldCfg.NPCCount = 0
+	hub.ResetWorld(worldCfg)
+
+	caster := newTestPlayerState("caster")
+	bystander := newTestPlayerState("bystander")
+	sneak := newTestPlayerState("sneak")
+	hub.mu.Lock()
+	hub.world.AddPlayer(caster)
+	hub.world.AddPlayer(bystander)
+	hub.world.AddPlayer(sneak)
+	hub.world.SetPosition(caster.ID, 300, 300)
+	hub.world.SetPosition(bystander.ID, 260, 300)
+	hub.world.SetPosition(sneak.ID, 380, 300)
+	hub.mu.Unlock()
+	hub.SetActorStealthed(sneak.ID, true)
+
+	casterConn := &payloadRecordingConn{}
+	bystanderConn := &payloadRecordingConn{}
+	casterSub := newSubscriber(casterConn, nil)
+	bystanderSub := newSubscriber(bystanderConn, nil)
+	hub.mu.Lock()
+	hub.subscribers[caster.ID] = casterSub
+	hub.subscribers[bystander.ID] = bystanderSub
+	hub.mu.Unlock()
+	t.Cleanup(casterSub.Close)
+	t.Cleanup(bystanderSub.Close)
+
+	hub.tick.Store(1)
+	hub.forceKeyframe()
+	hub.broadcastState(nil, nil, nil, nil)
+	if playerIDs(casterConn.waitPayload(t, 0))[sneak.ID] {
+		t.Fatalf("expected stealthed actor to be absent before detect")
+	}
+
+	if _, ok, reason := hub.HandleAction(caster.ID, effectTypeDetect); !ok {
+		t.Fatalf("expected detect action to be accepted, got %q", reason)
+	}
+	start := time.Now()
+	hub.advance(start, 1.0/15.0)
+	hub.forceKeyframe()
+	hub.broadcastState(nil, nil, nil, nil)
+
+	if !playerIDs(casterConn.waitPayload(t, 1))[sneak.ID] {
+		t.Fatalf("expected detect to reveal the stealthed actor to the caster")
+	}
+	if playerIDs(bystanderConn.waitPayload(t, 1))[sneak.ID] {
+		t.Fatalf("expected stealthed actor to stay hidden from other players")
+	}
+
+	hub.advance(start.Add(detectDuration+time.Second), 1.0/15.0)
+	hub.forceKeyframe()
+	hub.broadcastState(nil, nil, nil, nil)
+
+	if playerIDs(casterConn.waitPayload(t, 2))[sneak.ID] {
+		t.Fatalf("expected stealthed actor to be hidden again once detect expired")
+	}
+}
+
+func TestStealthedActorIsLeftOutOfJoinAndKeyframeResponses(t *testing.T) {
+	hub := newHub()
+	hub.SetKeyframeInterval(1)
+	sneak := newTestPlayerState("sneak")
+	hub.mu.Lock()
+	hub.world.AddPlayer(sneak)
+	hub.mu.Unlock()
+	hub.SetActorStealthed(sneak.ID, true)
+
+	join := hub.Join()
+	for _, player := range join.Players {
+		if player.ID == sneak.ID {
+			t.Fatalf("expected the join response to leave out the stealthed actor")
+		}
+	}
+
+	data, _, err := hub.marshalState(nil, nil, nil, nil, true, true)
+	if err != nil {
+		t.Fatalf("marshalState returned error: %v", err)
+	}
+	var msg stateMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("failed to decode state payload: %v", err)
+	}
+	keyframeHas := func(viewerID string) bool {
+		snapshot, _, ok := hub.HandleKeyframeRequest(viewerID, nil, msg.Sequence)
+		if !ok {
+			t.Fatalf("expected keyframe %d to be served", msg.Sequence)
+		}
+		for _, player := range snapshot.Players {
+			if player.ID == sneak.ID {
+				return true
+			}
+		}
+		return false
+	}
+	if keyframeHas(join.ID) {
+		t.Fatalf("expected a requested keyframe to leave out the stealthed actor")
+	}
+	if !keyframeHas(sneak.ID) {
+		t.Fatalf("expected the stealthed actor to see itself in a requested keyframe")
+	}
+}
+
+func TestStealthFilterWithholdsEffectsThatRevealHiddenActors(t *testing.T) {
+	hub := newHub()
+	sub := newSubscriber(&payloadRecordingConn{}, nil)
+	t.Cleanup(sub.Close)
+	filter := stealthFilter{actors: map[string]struct{}{"sneak": {}}}
+
+	msg := stateMessage{
+		EffectSpawns: []effectcontract.EffectSpawnEvent{
+			{Instance: effectcontract.EffectInstance{ID: "contract-sneak", OwnerActorID: "sneak"}},
+			{Instance: effectcontract.EffectInstance{ID: "contract-visible", OwnerActorID: "other"}},
+		},
+		EffectUpdates: []effectcontract.EffectUpdateEvent{{ID: "contract-sneak"}, {ID: "contract-visible"}},
+		EffectTriggers: []sim.EffectTrigger{
+			{ID: "hit-sneak", Type: "blood-splatter", TargetID: "sneak"},
+			{ID: "hit-other", Type: "blood-splatter", TargetID: "other"},
+		},
+		Patches: []sim.Patch{
+			{Kind: sim.PatchEffectPos, EntityID: "contract-sneak"},
+			{Kind: sim.PatchPlayerPos, EntityID: "sneak"},
+			{Kind: sim.PatchPlayerPos, EntityID: "other"},
+		},
+	}
+	filtered, _ := hub.stealthPayload(msg, nil, filter, sub)
+
+	if len(filtered.EffectSpawns) != 1 || filtered.EffectSpawns[0].Instance.ID != "contract-visible" {
+		t.Fatalf("expected only the visible spawn to remain, got %+v", filtered.EffectSpawns)
+	}
+	if len(filtered.EffectUpdates) != 1 || filtered.EffectUpdates[0].ID != "contract-visible" {
+		t.Fatalf("expected only the visible update to remain, got %+v", filtered.EffectUpdates)
+	}
+	if len(filtered.EffectTriggers) != 1 || filtered.EffectTriggers[0].TargetID != "other" {
+		t.Fatalf("expected hits on the hidden actor to be withheld, got %+v", filtered.EffectTriggers)
+	}
+	if len(filtered.Patches) != 1 || filtered.Patches[0].EntityID != "other" {
+		t.Fatalf("expected patches for the hidden actor and its effect to be withheld, got %+v", filtered.Patches)
+	}
+
+	// The owner's instance is gone by the time the effect ends, so only the
+	// subscriber's withheld set keeps its end hidden.
+	hub.mu.Lock()
+	withheld := sub.withheldEffects
+	hub.mu.Unlock()
+	end := stateMessage{EffectEnds: []effectcontract.EffectEndEvent{{ID: "contract-sneak"}}}
+	filtered, _ = hub.stealthPayload(end, nil, stealthFilter{}.withEffects(withheld), sub)
+	if len(filtered.EffectEnds) != 0 {
+		t.Fatalf("expected the withheld effect's end to stay hidden, got %+v", filtered.EffectEnds)
+	}
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := sub.withheldEffects["contract-sneak"]; ok {
+		t.Fatalf("expected an ended effect to be dropped from the withheld set")
+	}
+}
+
+func TestStealthedActorLosesStealthOnDeath(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	sneak := newTestPlayerState("sneak")
+	world.AddPlayer(sneak)
+	now := time.Unix(0, 0)
+	world.SetActorStealthed(sneak.ID, true, now)
+	if len(world.stealthedActors()) != 1 {
+		t.Fatalf("expected the actor to be stealthed")
+	}
+
+	sneak.Health = 0
+	world.Step(1, now.Add(time.Second/15), 1.0/15.0, nil, nil)
+
+	if _, ok := sneak.StatusEffects[StatusEffectStealthed]; ok {
+		t.Fatalf("expected death to strip the stealthed status")
+	}
+	if len(world.stealthedActors()) != 0 {
+		t.Fatalf("expected no stealthed actors after the death")
+	}
+}
@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Behavior classifies what an authored state does so callers can reason about
+// NPCs without knowing each archetype's state names.
+type Behavior uint8
+
+const (
+	// BehaviorIdle holds position, typically while a timer runs.
+	BehaviorIdle Behavior = iota
+	// BehaviorWander roams between waypoints or random destinations.
+	BehaviorWander
+	// BehaviorChase closes distance on the tracked target.
+	BehaviorChase
+	// BehaviorAttack uses abilities against a target in range.
+	BehaviorAttack
+	// BehaviorFlee paths away from the tracked threat.
+	BehaviorFlee
+	// BehaviorReturn heads back to the NPC's home after being leashed.
+	BehaviorReturn
+	// BehaviorFollow keeps a summoned NPC close to its owner.
+	BehaviorFollow
+)
+
+var behaviorNames = [...]string{
+	BehaviorIdle:   "idle",
+	BehaviorWander: "wander",
+	BehaviorChase:  "chase",
+	BehaviorAttack: "attack",
+	BehaviorFlee:   "flee",
+	BehaviorReturn: "return",
+	BehaviorFollow: "follow",
+}
+
+// String returns the authoring name of the behaviour.
+func (b Behavior) String() string {
+	if int(b) < len(behaviorNames) {
+		return behaviorNames[b]
+	}
+	return fmt.Sprintf("behavior(%d)", uint8(b))
+}
+
+func parseBehavior(name string) (Behavior, error) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	if lower == "" {
+		return 0, fmt.Errorf("missing behavior")
+	}
+	for idx, candidate := range behaviorNames {
+		if candidate == lower {
+			return Behavior(idx), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown behavior %q", name)
+}
+
+// StateBehavior returns the behaviour of the provided state index.
+func (cfg *CompiledConfig) StateBehavior(id uint8) (Behavior, bool) {
+	if cfg == nil || int(id) >= len(cfg.states) {
+		return 0, false
+	}
+	return cfg.states[id].behavior, true
+}
+
+// StateForBehavior returns the first state index with the given behaviour.
+func (cfg *CompiledConfig) StateForBehavior(behavior Behavior) (uint8, bool) {
+	if cfg == nil {
+		return 0, false
+	}
+	for idx := range cfg.states {
+		if cfg.states[idx].behavior == behavior {
+			return uint8(idx), true
+		}
+	}
+	return 0, false
+}
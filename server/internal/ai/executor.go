@@ -34,6 +34,19 @@ type RunConfig struct {
 
 	AbilityCommand  func(AbilityID) (string, bool)
 	AbilityCooldown func(AbilityID) uint64
+
+	// Hostile reports whether the second actor is an enemy of the first.
+	// When unset every other actor counts as hostile.
+	Hostile func(actorID, otherID string) bool
+
+	// DecisionBudget caps how many NPCs run their state machine this tick.
+	// Zero or negative values fall back to maxDecisionsPerTick. NPCs over the
+	// budget stay due and only refresh their blackboard.
+	DecisionBudget int
+	// Cursor, when set, carries the round-robin position between ticks. Run
+	// starts at the NPC whose ID it names and stores the first NPC deferred
+	// by the budget, or clears it when every due NPC was served.
+	Cursor *string
 }
 
 // Run executes the AI state machines for the provided NPCs and returns the
@@ -58,11 +71,26 @@ func Run(cfg RunConfig) []Command {
 		return npcs[i].ID < npcs[j].ID
 	})
 
+	budget := cfg.DecisionBudget
+	if budget <= 0 {
+		budget = maxDecisionsPerTick
+	}
+	start := 0
+	if cfg.Cursor != nil && *cfg.Cursor != "" {
+		cursor := *cfg.Cursor
+		start = sort.Search(len(npcs), func(i int) bool { return npcs[i].ID >= cursor })
+		if start == len(npcs) {
+			start = 0
+		}
+	}
+
 	env := runEnv{cfg: cfg}
 	commands := make([]Command, 0)
 	decisions := 0
+	deferred := ""
 
-	for _, npc := range npcs {
+	for i := range npcs {
+		npc := npcs[(start+i)%len(npcs)]
 		if npc.Blackboard.NextDecisionAt > cfg.Tick {
 			updateBlackboard(&env, npc)
 			continue
@@ -74,12 +102,20 @@ func Run(cfg RunConfig) []Command {
 		if compiled == nil || len(compiled.states) == 0 {
 			continue
 		}
-		if decisions >= maxDecisionsPerTick {
+		if decisions >= budget {
+			if deferred == "" {
+				deferred = npc.ID
+			}
 			npc.Blackboard.NextDecisionAt = cfg.Tick + 1
+			updateBlackboard(&env, npc)
 			continue
 		}
 		decisions++
 
+		if taunter, ok := env.tauntTarget(npc, cfg.Tick); ok {
+			npc.Blackboard.TargetActorID = taunter
+		}
+
 		stateIndex := *npc.AIState
 		if int(stateIndex) >= len(compiled.states) {
 			stateIndex = compiled.initialState
@@ -120,6 +156,9 @@ func Run(cfg RunConfig) []Command {
 		updateBlackboard(&env, npc)
 	}
 
+	if cfg.Cursor != nil {
+		*cfg.Cursor = deferred
+	}
 	return commands
 }
 
@@ -210,6 +249,12 @@ func evaluateCondition(env *runEnv, cfg *CompiledConfig, npc *NPC, transition *c
 			radius = 4
 		}
 		id, distSq, ok := env.closestPlayer(npc.Position.XValue(), npc.Position.YValue())
+		if taunter, taunted := env.tauntTarget(npc, tick); taunted {
+			x, y, _ := env.actorPosition(taunter)
+			dx := x - npc.Position.XValue()
+			dy := y - npc.Position.YValue()
+			id, distSq, ok = taunter, dx*dx+dy*dy, true
+		}
 		if !ok {
 			return false
 		}
@@ -283,6 +328,61 @@ func evaluateCondition(env *runEnv, cfg *CompiledConfig, npc *NPC, transition *c
 			return true
 		}
 		return false
+	case conditionEnemyWithin:
+		var params actorWithinParams
+		if int(transition.paramIndex) < len(cfg.actorWithinParams) {
+			params = cfg.actorWithinParams[transition.paramIndex]
+		}
+		radius := params.Radius
+		if radius <= 0 {
+			radius = 6
+		}
+		id, distSq, ok := env.closestEnemy(npc)
+		if !ok {
+			return false
+		}
+		if distSq <= radius*radius {
+			npc.Blackboard.TargetActorID = id
+			return true
+		}
+		return false
+	case conditionTargetWithin:
+		if npc.Blackboard.TargetActorID == "" {
+			return false
+		}
+		var params targetWithinParams
+		if int(transition.paramIndex) < len(cfg.targetWithinParams) {
+			params = cfg.targetWithinParams[transition.paramIndex]
+		}
+		x, y, ok := env.actorPosition(npc.Blackboard.TargetActorID)
+		if !ok {
+			return false
+		}
+		return math.Hypot(x-npc.Position.XValue(), y-npc.Position.YValue()) <= params.Radius
+	case conditionHealthBelow:
+		var params healthThresholdParams
+		if int(transition.paramIndex) < len(cfg.healthThresholdParams) {
+			params = cfg.healthThresholdParams[transition.paramIndex]
+		}
+		fraction, ok := npc.Health.Fraction()
+		return ok && fraction < params.Fraction
+	case conditionHealthAbove:
+		var params healthThresholdParams
+		if int(transition.paramIndex) < len(cfg.healthThresholdParams) {
+			params = cfg.healthThresholdParams[transition.paramIndex]
+		}
+		fraction, ok := npc.Health.Fraction()
+		return ok && fraction >= params.Fraction
+	case conditionLeashed:
+		var params leashedParams
+		if int(transition.paramIndex) < len(cfg.leashedParams) {
+			params = cfg.leashedParams[transition.paramIndex]
+		}
+		if params.Distance <= 0 || npc.Home == nil {
+			return false
+		}
+		home := npc.home()
+		return math.Hypot(npc.Position.XValue()-home.X, npc.Position.YValue()-home.Y) > params.Distance
 	default:
 		return false
 	}
@@ -353,6 +453,19 @@ func actionMoveToward(env *runEnv, cfg *CompiledConfig, npc *NPC, action compile
 		}
 		target = Vec2{X: x, Y: y}
 		ok = true
+	case moveTargetOwner:
+		x, y, exists := env.actorPosition(npc.OwnerID)
+		if !exists {
+			npc.clearPath()
+			return
+		}
+		// Stop short of the owner instead of crowding onto them.
+		if math.Hypot(x-npc.Position.XValue(), y-npc.Position.YValue()) <= npc.Blackboard.ArriveRadius {
+			npc.clearPath()
+			return
+		}
+		target = Vec2{X: x, Y: y}
+		ok = true
 	case moveTargetVector:
 		x := clamp(npc.Position.XValue()+params.Vector.X, worldpkg.PlayerHalf, width-worldpkg.PlayerHalf)
 		y := clamp(npc.Position.YValue()+params.Vector.Y, worldpkg.PlayerHalf, height-worldpkg.PlayerHalf)
@@ -710,6 +823,61 @@ func (env *runEnv) closestPlayer(x, y float64) (string, float64, bool) {
 	return bestID, bestDist, true
 }
 
+// tauntTarget reports the player whose taunt overrides the NPC's target
+// selection at tick. Expired taunts, and taunts from players no longer in the
+// world, are cleared from the blackboard.
+func (env *runEnv) tauntTarget(npc *NPC, tick uint64) (string, bool) {
+	if env == nil || npc == nil || npc.Blackboard.TauntedBy == "" {
+		return "", false
+	}
+	taunter := npc.Blackboard.TauntedBy
+	if tick < npc.Blackboard.TauntUntil {
+		for _, player := range env.cfg.Players {
+			if player.ID == taunter {
+				return taunter, true
+			}
+		}
+	}
+	npc.Blackboard.TauntedBy = ""
+	npc.Blackboard.TauntUntil = 0
+	return "", false
+}
+
+// closestEnemy returns the nearest player or other NPC that is hostile to npc.
+func (env *runEnv) closestEnemy(npc *NPC) (string, float64, bool) {
+	if env == nil || npc == nil {
+		return "", 0, false
+	}
+	x, y := npc.Position.XValue(), npc.Position.YValue()
+	bestID := ""
+	bestDist := math.MaxFloat64
+	consider := func(id string, cx, cy float64) {
+		if id == npc.ID || (env.cfg.Hostile != nil && !env.cfg.Hostile(npc.ID, id)) {
+			return
+		}
+		dx := cx - x
+		dy := cy - y
+		distSq := dx*dx + dy*dy
+		if distSq < bestDist-1e-6 || (math.Abs(distSq-bestDist) <= 1e-6 && id < bestID) {
+			bestDist = distSq
+			bestID = id
+		}
+	}
+	for _, player := range env.cfg.Players {
+		consider(player.ID, player.X, player.Y)
+	}
+	for _, other := range env.cfg.NPCs {
+		if other == nil {
+			continue
+		}
+		consider(other.ID, other.Position.XValue(), other.Position.YValue())
+	}
+	if bestID == "" {
+		return "", 0, false
+	}
+	return bestID, bestDist, true
+}
+
 func (env *runEnv) closestNonRatActor(npc *NPC) (string, float64, bool) {
 	if env == nil || npc == nil {
 		return "", 0, false
@@ -45,9 +45,13 @@ type CompiledConfig struct {
 	cooldownReadyParams     []cooldownReadyParams
 	stuckParams             []stuckParams
 	actorWithinParams       []actorWithinParams
+	targetWithinParams      []targetWithinParams
+	healthThresholdParams   []healthThresholdParams
+	leashedParams           []leashedParams
 }
 
 type compiledState struct {
+	behavior    Behavior
 	cadence     uint16
 	enterTimer  uint16
 	actions     []compiledAction
@@ -107,6 +111,18 @@ type actorWithinParams struct {
 	Radius float64
 }
 
+type targetWithinParams struct {
+	Radius float64
+}
+
+type healthThresholdParams struct {
+	Fraction float64
+}
+
+type leashedParams struct {
+	Distance float64
+}
+
 type lostSightParams struct {
 	Distance float64
 }
@@ -152,6 +168,7 @@ const (
 	moveTargetWaypoint moveTarget = iota
 	moveTargetPlayer
 	moveTargetVector
+	moveTargetOwner
 )
 
 const (
@@ -173,6 +190,11 @@ const (
 	conditionCooldownReady
 	conditionStuck
 	conditionNonRatWithin
+	conditionTargetWithin
+	conditionHealthBelow
+	conditionHealthAbove
+	conditionLeashed
+	conditionEnemyWithin
 )
 
 // MustLoadLibrary loads the embedded authoring configs or panics on failure.
@@ -295,6 +317,19 @@ func (cfg *CompiledConfig) StateName(id uint8) string {
 	return cfg.stateNames[id]
 }
 
+// StateIndex returns the index of the state with the given name, ignoring case.
+func (cfg *CompiledConfig) StateIndex(name string) (uint8, bool) {
+	if cfg == nil {
+		return 0, false
+	}
+	for idx, candidate := range cfg.stateNames {
+		if strings.EqualFold(candidate, strings.TrimSpace(name)) {
+			return uint8(idx), true
+		}
+	}
+	return 0, false
+}
+
 // StateNames returns a copy of the compiled state's human-readable names.
 func (cfg *CompiledConfig) StateNames() []string {
 	if cfg == nil {
@@ -323,6 +358,9 @@ func compileConfig(id uint16, authoring authoringConfig) (*CompiledConfig, error
 		cooldownReadyParams:     make([]cooldownReadyParams, 0),
 		stuckParams:             make([]stuckParams, 0),
 		actorWithinParams:       make([]actorWithinParams, 0),
+		targetWithinParams:      make([]targetWithinParams, 0),
+		healthThresholdParams:   make([]healthThresholdParams, 0),
+		leashedParams:           make([]leashedParams, 0),
 	}
 
 	compiled.defaults = blackboardDefaults{
@@ -354,7 +392,12 @@ func compileConfig(id uint16, authoring authoringConfig) (*CompiledConfig, error
 	compiled.initialState = 0
 
 	for _, state := range authoring.States {
+		behavior, err := parseBehavior(state.Behavior)
+		if err != nil {
+			return nil, fmt.Errorf("state %q: %w", state.ID, err)
+		}
 		compiledState := compiledState{
+			behavior:    behavior,
 			cadence:     state.TickEvery,
 			enterTimer:  state.DurationTicks,
 			actions:     make([]compiledAction, 0, len(state.Actions)),
@@ -428,9 +471,18 @@ func compileConfig(id uint16, authoring authoringConfig) (*CompiledConfig, error
 			case conditionStuck:
 				compiled.stuckParams = append(compiled.stuckParams, stuckParams{Decisions: transition.Decisions, Epsilon: transition.Epsilon})
 				compiledTransition.paramIndex = uint16(len(compiled.stuckParams) - 1)
-			case conditionNonRatWithin:
+			case conditionNonRatWithin, conditionEnemyWithin:
 				compiled.actorWithinParams = append(compiled.actorWithinParams, actorWithinParams{Radius: transition.Radius})
 				compiledTransition.paramIndex = uint16(len(compiled.actorWithinParams) - 1)
+			case conditionTargetWithin:
+				compiled.targetWithinParams = append(compiled.targetWithinParams, targetWithinParams{Radius: transition.Radius})
+				compiledTransition.paramIndex = uint16(len(compiled.targetWithinParams) - 1)
+			case conditionHealthBelow, conditionHealthAbove:
+				compiled.healthThresholdParams = append(compiled.healthThresholdParams, healthThresholdParams{Fraction: transition.Fraction})
+				compiledTransition.paramIndex = uint16(len(compiled.healthThresholdParams) - 1)
+			case conditionLeashed:
+				compiled.leashedParams = append(compiled.leashedParams, leashedParams{Distance: transition.Distance})
+				compiledTransition.paramIndex = uint16(len(compiled.leashedParams) - 1)
 			}
 
 			target := strings.TrimSpace(transition.ToState)
@@ -491,6 +543,16 @@ func parseConditionID(name string) (conditionID, error) {
 		return conditionStuck, nil
 	case "nonratwithin":
 		return conditionNonRatWithin, nil
+	case "targetwithin":
+		return conditionTargetWithin, nil
+	case "healthbelow":
+		return conditionHealthBelow, nil
+	case "healthabove":
+		return conditionHealthAbove, nil
+	case "leashed":
+		return conditionLeashed, nil
+	case "enemywithin":
+		return conditionEnemyWithin, nil
 	default:
 		return 0, fmt.Errorf("unknown condition %q", name)
 	}
@@ -502,6 +564,8 @@ func parseMoveTarget(target string) moveTarget {
 		return moveTargetPlayer
 	case "vector":
 		return moveTargetVector
+	case "owner":
+		return moveTargetOwner
 	default:
 		return moveTargetWaypoint
 	}
@@ -536,6 +600,7 @@ type authoringDefaults struct {
 
 type authoringState struct {
 	ID            string                `json:"id"`
+	Behavior      string                `json:"behavior"`
 	TickEvery     uint16                `json:"tick_every"`
 	DurationTicks uint16                `json:"duration_ticks"`
 	Actions       []authoringAction     `json:"actions"`
@@ -565,6 +630,7 @@ type authoringTransition struct {
 	Ability   string  `json:"ability,omitempty"`
 	Decisions uint8   `json:"decisions,omitempty"`
 	Epsilon   float64 `json:"epsilon,omitempty"`
+	Fraction  float64 `json:"fraction,omitempty"`
 }
 
 type authoringVector struct {
@@ -35,6 +35,21 @@ func (r PositionRef) YValue() float64 {
 	return *r.Y
 }
 
+// HealthRef exposes direct references to an NPC's current and maximum health.
+type HealthRef struct {
+	Current *float64
+	Max     *float64
+}
+
+// Fraction returns the remaining share of maximum health. It reports false
+// when either reference is missing or the maximum is not positive.
+func (r HealthRef) Fraction() (float64, bool) {
+	if r.Current == nil || r.Max == nil || *r.Max <= 0 {
+		return 0, false
+	}
+	return *r.Current / *r.Max, true
+}
+
 // FacingAdapter wraps callbacks for reading and mutating an NPC's facing.
 type FacingAdapter struct {
 	Get func() string
@@ -71,11 +86,14 @@ type NPC struct {
 	AIConfigID uint16
 	AIState    *uint8
 	Position   PositionRef
+	Health     HealthRef
 	Facing     FacingAdapter
 	Waypoints  *[]Vec2
 	Home       *Vec2
 	Blackboard *Blackboard
 	Hooks      NPCHooks
+	// OwnerID names the player a summoned NPC follows, if any.
+	OwnerID string
 }
 
 // Player mirrors the subset of player state required by the AI executor.
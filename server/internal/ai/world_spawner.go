@@ -11,8 +11,9 @@ type WorldNPCSpawner struct {
 	ConfigFunc       func() worldpkg.Config
 	DimensionsFunc   func() (float64, float64)
 	SubsystemRNGFunc func(label string) *rand.Rand
-	SpawnGoblinFunc  func(x, y float64, waypoints []worldpkg.Vec2, goldQty, potionQty int)
+	SpawnGoblinFunc  func(x, y float64, waypoints []worldpkg.Vec2)
 	SpawnRatFunc     func(x, y float64)
+	SpawnBossFunc    func(x, y float64)
 }
 
 // Config returns the configured world settings or the defaults when unavailable.
@@ -43,11 +44,11 @@ func (s WorldNPCSpawner) SubsystemRNG(label string) *rand.Rand {
 }
 
 // SpawnGoblinAt delegates goblin spawning to the configured callback when present.
-func (s WorldNPCSpawner) SpawnGoblinAt(x, y float64, waypoints []worldpkg.Vec2, goldQty, potionQty int) {
+func (s WorldNPCSpawner) SpawnGoblinAt(x, y float64, waypoints []worldpkg.Vec2) {
 	if s.SpawnGoblinFunc == nil {
 		return
 	}
-	s.SpawnGoblinFunc(x, y, waypoints, goldQty, potionQty)
+	s.SpawnGoblinFunc(x, y, waypoints)
 }
 
 // SpawnRatAt delegates rat spawning to the configured callback when present.
@@ -58,7 +59,16 @@ func (s WorldNPCSpawner) SpawnRatAt(x, y float64) {
 	s.SpawnRatFunc(x, y)
 }
 
-// SeedInitialNPCs mirrors the legacy spawn layout for goblins and rats.
+// SpawnBossAt delegates boss spawning to the configured callback when present.
+func (s WorldNPCSpawner) SpawnBossAt(x, y float64) {
+	if s.SpawnBossFunc == nil {
+		return
+	}
+	s.SpawnBossFunc(x, y)
+}
+
+// SeedInitialNPCs mirrors the legacy spawn layout for goblins and rats, then
+// places the configured bosses.
 func SeedInitialNPCs(spawner WorldNPCSpawner) {
 	worldpkg.SeedInitialNPCs(spawner)
 }
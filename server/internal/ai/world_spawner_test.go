@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"reflect"
 	"testing"
 
 	worldpkg "mine-and-die/server/internal/world"
@@ -10,7 +11,7 @@ func TestWorldNPCSpawner_Defaults(t *testing.T) {
 	var spawner WorldNPCSpawner
 
 	cfg := spawner.Config()
-	if cfg != worldpkg.DefaultConfig() {
+	if !reflect.DeepEqual(cfg, worldpkg.DefaultConfig()) {
 		t.Fatalf("expected default config, got %+v", cfg)
 	}
 
@@ -32,18 +33,14 @@ func TestWorldNPCSpawner_Defaults(t *testing.T) {
 func TestWorldNPCSpawner_SpawnCallbacks(t *testing.T) {
 	var (
 		goblinX, goblinY float64
-		goblinGold       int
-		goblinPotion     int
 		goblinWaypoints  []worldpkg.Vec2
 		ratX, ratY       float64
 	)
 
 	spawner := WorldNPCSpawner{
-		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2, goldQty, potionQty int) {
+		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2) {
 			goblinX = x
 			goblinY = y
-			goblinGold = goldQty
-			goblinPotion = potionQty
 			goblinWaypoints = append([]worldpkg.Vec2(nil), waypoints...)
 		},
 		SpawnRatFunc: func(x, y float64) {
@@ -53,15 +50,12 @@ func TestWorldNPCSpawner_SpawnCallbacks(t *testing.T) {
 	}
 
 	waypoints := []worldpkg.Vec2{{X: 10, Y: 20}, {X: 30, Y: 40}}
-	spawner.SpawnGoblinAt(5, 6, waypoints, 7, 8)
+	spawner.SpawnGoblinAt(5, 6, waypoints)
 	spawner.SpawnRatAt(11, 12)
 
 	if goblinX != 5 || goblinY != 6 {
 		t.Fatalf("unexpected goblin spawn position %.1f, %.1f", goblinX, goblinY)
 	}
-	if goblinGold != 7 || goblinPotion != 8 {
-		t.Fatalf("unexpected goblin inventory %d gold, %d potions", goblinGold, goblinPotion)
-	}
 	if len(goblinWaypoints) != len(waypoints) {
 		t.Fatalf("expected %d waypoints, got %d", len(waypoints), len(goblinWaypoints))
 	}
@@ -83,7 +77,7 @@ func TestSeedInitialNPCs_DelegatesToCallbacks(t *testing.T) {
 			cfg.RatCount = 1
 			return cfg
 		},
-		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2, goldQty, potionQty int) {
+		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2) {
 			goblinCount++
 		},
 		SpawnRatFunc: func(x, y float64) {
@@ -100,3 +94,29 @@ func TestSeedInitialNPCs_DelegatesToCallbacks(t *testing.T) {
 		t.Fatalf("expected 1 rat spawn, got %d", ratCount)
 	}
 }
+
+func TestSeedInitialNPCs_SpawnsConfiguredBosses(t *testing.T) {
+	var bosses []worldpkg.Vec2
+	spawner := WorldNPCSpawner{
+		ConfigFunc: func() worldpkg.Config {
+			cfg := worldpkg.DefaultConfig()
+			cfg.NPCs = true
+			cfg.GoblinCount = 0
+			cfg.RatCount = 0
+			cfg.BossCount = 2
+			return cfg
+		},
+		SpawnBossFunc: func(x, y float64) {
+			bosses = append(bosses, worldpkg.Vec2{X: x, Y: y})
+		},
+	}
+
+	SeedInitialNPCs(spawner)
+
+	if len(bosses) != 2 {
+		t.Fatalf("expected 2 boss spawns, got %d", len(bosses))
+	}
+	if bosses[0] == bosses[1] {
+		t.Fatalf("expected bosses at distinct ring positions, got %+v", bosses)
+	}
+}
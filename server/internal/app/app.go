@@ -2,17 +2,21 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	server "mine-and-die/server"
 	servernet "mine-and-die/server/internal/net"
 	"mine-and-die/server/internal/observability"
 	"mine-and-die/server/internal/telemetry"
+	worldpkg "mine-and-die/server/internal/world"
 	"mine-and-die/server/logging"
 	loggingSinks "mine-and-die/server/logging/sinks"
 )
@@ -59,6 +63,57 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 	}
 
+	if raw := os.Getenv("TICK_RATE_HZ"); raw != "" {
+		if value, err := strconv.Atoi(raw); err == nil && value > 0 {
+			hubCfg.TickRate = value
+		} else {
+			telemetryLogger.Printf("invalid TICK_RATE_HZ=%q", raw)
+		}
+	}
+
+	if raw := os.Getenv("MAX_WORLD_SIZE"); raw != "" {
+		if value, err := strconv.ParseFloat(raw, 64); err == nil && value > 0 {
+			hubCfg.MaxWorldSize = value
+		} else {
+			telemetryLogger.Printf("invalid MAX_WORLD_SIZE=%q", raw)
+		}
+	}
+
+	if raw := os.Getenv("AI_DECISION_BUDGET"); raw != "" {
+		if value, err := strconv.Atoi(raw); err == nil && value > 0 {
+			hubCfg.AIDecisionBudget = value
+		} else {
+			telemetryLogger.Printf("invalid AI_DECISION_BUDGET=%q", raw)
+		}
+	}
+
+	if raw := os.Getenv("IDLE_KICK_AFTER"); raw != "" {
+		if value, err := time.ParseDuration(raw); err == nil && value > 0 {
+			hubCfg.IdleKickAfter = value
+		} else {
+			telemetryLogger.Printf("invalid IDLE_KICK_AFTER=%q", raw)
+		}
+	}
+
+	if raw := os.Getenv("LAG_COMPENSATION_TICKS"); raw != "" {
+		if value, err := strconv.Atoi(raw); err == nil && value >= 0 {
+			hubCfg.LagCompensationTicks = value
+		} else {
+			telemetryLogger.Printf("invalid LAG_COMPENSATION_TICKS=%q", raw)
+		}
+	}
+
+	if path := os.Getenv("EFFECT_CATALOG_PATH"); path != "" {
+		if err := server.ValidateEffectCatalog(path); err != nil {
+			return fmt.Errorf("invalid EFFECT_CATALOG_PATH=%q: %w", path, err)
+		}
+		hubCfg.EffectCatalogPath = path
+	}
+
+	if raw := os.Getenv("CONSOLE_OPERATORS"); raw != "" {
+		hubCfg.ConsoleOperators = strings.Split(raw, ",")
+	}
+
 	hubCfg.Logger = telemetryLogger
 
 	observabilityCfg := cfg.Observability
@@ -70,16 +125,48 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 	}
 
-	hub := server.NewHubWithConfig(hubCfg, router)
-	stop := make(chan struct{})
-	go hub.RunSimulation(stop)
-	defer close(stop)
+	maxRooms := 0
+	if raw := os.Getenv("MAX_ROOMS"); raw != "" {
+		if value, err := strconv.Atoi(raw); err == nil && value > 0 {
+			maxRooms = value
+		} else {
+			telemetryLogger.Printf("invalid MAX_ROOMS=%q", raw)
+		}
+	}
+
+	rooms := server.NewRoomManager(hubCfg, maxRooms, router)
+	defer rooms.Close()
+	if raw := os.Getenv("ROOM_IDLE_TIMEOUT"); raw != "" {
+		if value, err := time.ParseDuration(raw); err == nil && value > 0 {
+			rooms.SetIdleTimeout(value)
+		} else {
+			telemetryLogger.Printf("invalid ROOM_IDLE_TIMEOUT=%q", raw)
+		}
+	}
+	stopReaper := make(chan struct{})
+	defer close(stopReaper)
+	go rooms.ReapIdleRooms(time.Minute, stopReaper)
+	if path := os.Getenv("ROOM_PRESETS_FILE"); path != "" {
+		if err := loadRoomPresets(rooms, path); err != nil {
+			return err
+		}
+	}
+	hub, err := rooms.Room(server.DefaultRoom)
+	if err != nil {
+		return fmt.Errorf("failed to create default room: %w", err)
+	}
+	if path := os.Getenv("WORLD_CONFIG_WATCH_FILE"); path != "" {
+		stopWatch := make(chan struct{})
+		defer close(stopWatch)
+		go hub.WatchWorldConfig(path, time.Second, stopWatch)
+	}
 
 	clientDir := filepath.Clean(filepath.Join("..", "client"))
 	handler := servernet.NewHTTPHandler(hub, servernet.HTTPHandlerConfig{
 		ClientDir:     clientDir,
 		Logger:        telemetryLogger,
 		Observability: observabilityCfg,
+		Rooms:         rooms,
 	})
 
 	srv := &http.Server{Addr: ":8080", Handler: handler}
@@ -90,3 +177,22 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 	return nil
 }
+
+// loadRoomPresets registers the presets in a JSON file mapping preset names to
+// world configurations, alongside the built-in ones.
+func loadRoomPresets(rooms *server.RoomManager, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read room presets %s: %w", path, err)
+	}
+	var presets map[string]worldpkg.Config
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return fmt.Errorf("failed to parse room presets %s: %w", path, err)
+	}
+	for name, preset := range presets {
+		if err := rooms.RegisterPreset(name, preset); err != nil {
+			return fmt.Errorf("invalid room preset in %s: %w", path, err)
+		}
+	}
+	return nil
+}
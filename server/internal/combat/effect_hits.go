@@ -14,6 +14,14 @@ const (
 	EffectTypeBloodSplatter = effectcontract.EffectIDBloodSplatter
 	EffectTypeBurningTick   = effectcontract.EffectIDBurningTick
 	EffectTypeBurningVisual = effectcontract.EffectIDBurningVisual
+	EffectTypeBeam          = effectcontract.EffectIDBeam
+	EffectTypeHeal          = effectcontract.EffectIDHeal
+	EffectTypeHealBurst     = effectcontract.EffectIDHealBurst
+	EffectTypeExplosion     = effectcontract.EffectIDExplosion
+	EffectTypeFirePatch     = effectcontract.EffectIDFirePatch
+	EffectTypeFirebomb      = effectcontract.EffectIDFirebomb
+	EffectTypeSeeker        = effectcontract.EffectIDSeeker
+	EffectTypeRicochet      = effectcontract.EffectIDRicochet
 )
 
 // Status effect identifiers applied by combat behaviors.
@@ -88,6 +96,24 @@ type EffectHitDispatcherConfig struct {
 	RecordDefeatTelemetry    func(effect EffectRef, target ActorRef, statusEffect string)
 	DropAllInventory         func(target ActorRef, reason string)
 	ApplyStatusEffect        func(effect EffectRef, target ActorRef, statusEffect string, now time.Time)
+	// RollCritical decides whether a damaging hit lands as a critical and
+	// returns the multiplier to scale it by.
+	RollCritical func(effect EffectRef, target ActorRef) (multiplier float64, critical bool)
+	// AmplifyDamage returns the multiplier the effect owner's damage bonus
+	// applies to everything it deals.
+	AmplifyDamage func(effect EffectRef, target ActorRef) float64
+	// AttenuateDamage returns the multiplier an area effect's falloff applies
+	// at the target's distance from the impact centre.
+	AttenuateDamage func(effect EffectRef, target ActorRef) float64
+	// ResistDamage returns the multiplier the target's resistances apply to
+	// the effect's damage type.
+	ResistDamage func(effect EffectRef, target ActorRef) float64
+	// AbsorbDamage soaks damage into the target's absorb pool before it
+	// reaches health and returns the portion left over.
+	AbsorbDamage func(effect EffectRef, target ActorRef, damage float64) (remaining float64)
+	// ApplyLifesteal heals the effect's owner for a share of the damage
+	// that reached the target's health.
+	ApplyLifesteal func(effect EffectRef, target ActorRef, damage float64)
 }
 
 type effectBehavior func(d *effectDispatcher, effect EffectRef, target ActorRef, now time.Time)
@@ -137,6 +163,14 @@ func newEffectBehaviors() map[string]effectBehavior {
 		EffectTypeAttack:      healthDeltaBehavior("healthDelta", 0),
 		EffectTypeFireball:    damageAndStatusEffectBehavior("healthDelta", 0, StatusEffectBurning),
 		EffectTypeBurningTick: healthDeltaBehavior("healthDelta", 0),
+		EffectTypeBeam:        healthDeltaBehavior("healthDelta", 0),
+		EffectTypeHeal:        healthDeltaBehavior("healthDelta", 0),
+		EffectTypeHealBurst:   healthDeltaBehavior("healthDelta", 0),
+		EffectTypeExplosion:   healthDeltaBehavior("healthDelta", 0),
+		EffectTypeFirePatch:   healthDeltaBehavior("healthDelta", 0),
+		EffectTypeFirebomb:    damageAndStatusEffectBehavior("healthDelta", 0, StatusEffectBurning),
+		EffectTypeSeeker:      healthDeltaBehavior("healthDelta", 0),
+		EffectTypeRicochet:    healthDeltaBehavior("healthDelta", 0),
 	}
 }
 
@@ -154,6 +188,35 @@ func healthDeltaBehavior(param string, fallback float64) effectBehavior {
 		if delta == 0 || target.Actor.ID == "" {
 			return
 		}
+		if delta < 0 && d.cfg.RollCritical != nil {
+			if multiplier, critical := d.cfg.RollCritical(eff, target); critical && multiplier > 0 {
+				delta *= multiplier
+			}
+		}
+		if delta < 0 && d.cfg.AmplifyDamage != nil {
+			delta *= d.cfg.AmplifyDamage(eff, target)
+			if delta == 0 {
+				return
+			}
+		}
+		if delta < 0 && d.cfg.AttenuateDamage != nil {
+			delta *= d.cfg.AttenuateDamage(eff, target)
+			if delta == 0 {
+				return
+			}
+		}
+		if delta < 0 && d.cfg.ResistDamage != nil {
+			delta *= d.cfg.ResistDamage(eff, target)
+			if delta == 0 {
+				return
+			}
+		}
+		if delta < 0 && d.cfg.AbsorbDamage != nil {
+			delta = -d.cfg.AbsorbDamage(eff, target, -delta)
+			if delta >= 0 {
+				return
+			}
+		}
 
 		max := target.Actor.MaxHealth
 		if max <= 0 && target.Actor.Kind != ActorKindGeneric {
@@ -219,6 +282,10 @@ func healthDeltaBehavior(param string, fallback float64) effectBehavior {
 			d.cfg.RecordDamageTelemetry(eff, target, -delta, next, eff.Effect.StatusEffect)
 		}
 
+		if d.cfg.ApplyLifesteal != nil && actualDelta < 0 {
+			d.cfg.ApplyLifesteal(eff, target, -actualDelta)
+		}
+
 		if next > 0 {
 			return
 		}
@@ -0,0 +1,70 @@
+package combat
+
+import "time"
+
+// MeleeComboConfig escalates consecutive melee swings. Each swing landed within
+// Window of the previous one advances the chain; the Length-th swing is the
+// finisher, dealing FinisherMultiplier times the base damage and widening to a
+// FinisherArc degree cone when that is wider than the configured swing. The
+// chain restarts after the finisher or once the window lapses. A zero Window
+// or a Length below two disables combos.
+type MeleeComboConfig struct {
+	Window             time.Duration
+	Length             int
+	FinisherMultiplier float64
+	FinisherArc        float64
+}
+
+// Enabled reports whether the configuration escalates swings at all.
+func (cfg MeleeComboConfig) Enabled() bool {
+	return cfg.Window > 0 && cfg.Length > 1
+}
+
+// Escalate returns the intent configuration for a swing at the given 1-based
+// chain step. Only the finisher differs from the base swing.
+func (cfg MeleeComboConfig) Escalate(intent MeleeIntentConfig, step int) MeleeIntentConfig {
+	if !cfg.Enabled() || step <= 0 {
+		return intent
+	}
+	intent.ComboStep = step
+	if step < cfg.Length {
+		return intent
+	}
+	if cfg.FinisherMultiplier > 0 {
+		intent.Damage *= cfg.FinisherMultiplier
+	}
+	if cfg.FinisherArc > intent.Geometry.Arc {
+		intent.Geometry.Arc = cfg.FinisherArc
+	}
+	return intent
+}
+
+// MeleeComboState tracks where an actor sits in its combo chain.
+type MeleeComboState struct {
+	Step      int
+	LastSwing time.Time
+}
+
+// Advance records a swing at now and returns its 1-based position in the
+// chain, restarting when the previous swing is older than the window or
+// completed the chain.
+func (s *MeleeComboState) Advance(cfg MeleeComboConfig, now time.Time) int {
+	if s == nil || !cfg.Enabled() {
+		return 0
+	}
+	if s.Expired(cfg, now) || s.Step >= cfg.Length {
+		s.Step = 0
+	}
+	s.Step++
+	s.LastSwing = now
+	return s.Step
+}
+
+// Expired reports whether the chain has lapsed and the next swing would
+// start over.
+func (s *MeleeComboState) Expired(cfg MeleeComboConfig, now time.Time) bool {
+	if s == nil || s.Step == 0 {
+		return true
+	}
+	return now.Sub(s.LastSwing) > cfg.Window
+}
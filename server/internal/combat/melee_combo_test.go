@@ -0,0 +1,101 @@
+package combat
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+func TestMeleeComboEscalatesFinisherAndResetsAfterWindow(t *testing.T) {
+	intentCfg := MeleeIntentConfig{
+		Geometry: MeleeAttackGeometryConfig{
+			PlayerHalf:    20,
+			Reach:         MeleeAttackReach,
+			Width:         MeleeAttackWidth,
+			DefaultFacing: "down",
+		},
+		TileSize: 40,
+		Damage:   MeleeAttackDamage,
+		Duration: MeleeAttackDuration,
+		QuantizeCoord: func(value float64) int {
+			return int(math.Round(value * effectcontract.CoordScale))
+		},
+		DurationToTicks: func(time.Duration) int { return 3 },
+	}
+	combo := MeleeComboConfig{
+		Window:             600 * time.Millisecond,
+		Length:             3,
+		FinisherMultiplier: 2,
+		FinisherArc:        180,
+	}
+
+	swing := func(state *MeleeComboState, now time.Time) effectcontract.EffectIntent {
+		t.Helper()
+		intent, ok := StageMeleeIntent(MeleeAbilityTriggerConfig{
+			AbilityGate: func(actorID string, _ time.Time) (MeleeIntentOwner, bool) {
+				return MeleeIntentOwner{ID: actorID, X: 200, Y: 180, Facing: "down"}, true
+			},
+			IntentConfig: intentCfg,
+			Combo:        combo,
+			ComboState:   func(string) *MeleeComboState { return state },
+		}, "player-1", now)
+		if !ok {
+			t.Fatalf("expected melee intent to be staged")
+		}
+		return intent
+	}
+
+	start := time.Unix(10, 0)
+	base := int(-MeleeAttackDamage)
+
+	rapid := &MeleeComboState{}
+	var finisher effectcontract.EffectIntent
+	for i := 0; i < 3; i++ {
+		intent := swing(rapid, start.Add(time.Duration(i)*MeleeAttackCooldown))
+		if got := intent.Params["combo"]; got != i+1 {
+			t.Fatalf("swing %d: expected combo step %d, got %d", i+1, i+1, got)
+		}
+		if i < 2 {
+			if got := intent.Params["healthDelta"]; got != base {
+				t.Fatalf("swing %d: expected base healthDelta %d, got %d", i+1, base, got)
+			}
+			if intent.Geometry.Shape != effectcontract.GeometryShapeRect {
+				t.Fatalf("swing %d: expected box swing, got %v", i+1, intent.Geometry.Shape)
+			}
+		}
+		finisher = intent
+	}
+	if got := finisher.Params["healthDelta"]; got != 2*base {
+		t.Fatalf("expected finisher healthDelta %d, got %d", 2*base, got)
+	}
+	if finisher.Geometry.Shape != effectcontract.GeometryShapeArc || finisher.Geometry.Arc != 180 {
+		t.Fatalf("expected finisher to widen into a 180 degree arc, got %+v", finisher.Geometry)
+	}
+
+	delayed := &MeleeComboState{}
+	swing(delayed, start)
+	swing(delayed, start.Add(MeleeAttackCooldown))
+	late := swing(delayed, start.Add(MeleeAttackCooldown+combo.Window+time.Millisecond))
+	if got := late.Params["combo"]; got != 1 {
+		t.Fatalf("expected delayed swing to restart the chain, got step %d", got)
+	}
+	if got := late.Params["healthDelta"]; got != base {
+		t.Fatalf("expected delayed swing to deal base healthDelta %d, got %d", base, got)
+	}
+	if late.Geometry.Shape != effectcontract.GeometryShapeRect {
+		t.Fatalf("expected delayed swing to keep the box, got %v", late.Geometry.Shape)
+	}
+}
+
+func TestMeleeComboDisabledLeavesIntentUntouched(t *testing.T) {
+	state := &MeleeComboState{}
+	if step := state.Advance(MeleeComboConfig{}, time.Unix(0, 0)); step != 0 {
+		t.Fatalf("expected disabled combo to report step 0, got %d", step)
+	}
+	cfg := MeleeIntentConfig{Damage: MeleeAttackDamage}
+	if got := (MeleeComboConfig{}).Escalate(cfg, 3); got.Damage != cfg.Damage || got.ComboStep != 0 {
+		t.Fatalf("expected disabled combo to leave intent untouched, got %+v", got)
+	}
+}
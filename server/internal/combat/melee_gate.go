@@ -1,6 +1,9 @@
 package combat
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // MeleeAbilityGate provides gating for melee ability triggers. Callers pass in
 // the actor identifier and current wall-clock time; the gate consults the
@@ -15,24 +18,27 @@ type ProjectileAbilityGate func(actorID string, now time.Time) (ProjectileIntent
 // MeleeAbilityGateConfig bundles the dependencies required to reproduce the
 // legacy melee ability gating semantics without importing the server package.
 type MeleeAbilityGateConfig struct {
-	AbilityID   string
-	Cooldown    time.Duration
-	LookupOwner func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
+	AbilityID         string
+	Cooldown          time.Duration
+	CooldownReduction func(actorID string) float64
+	LookupOwner       func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
 }
 
 // ProjectileAbilityGateConfig carries the dependencies required to reproduce
 // the legacy projectile ability gating semantics.
 type ProjectileAbilityGateConfig struct {
-	AbilityID   string
-	Cooldown    time.Duration
-	LookupOwner func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
+	AbilityID         string
+	Cooldown          time.Duration
+	CooldownReduction func(actorID string) float64
+	LookupOwner       func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
 }
 
 type abilityGateConfig[T any] struct {
-	AbilityID    string
-	Cooldown     time.Duration
-	LookupOwner  func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
-	ConvertOwner func(*AbilityActor) (T, bool)
+	AbilityID         string
+	Cooldown          time.Duration
+	CooldownReduction func(actorID string) float64
+	LookupOwner       func(actorID string) (*AbilityActor, *map[string]time.Time, bool)
+	ConvertOwner      func(*AbilityActor) (T, bool)
 }
 
 // ReadyCooldown mirrors the legacy cooldown bookkeeping: it lazily allocates
@@ -56,6 +62,19 @@ func ReadyCooldown(cooldowns *map[string]time.Time, ability string, cooldown tim
 	return true
 }
 
+// ReducedCooldown shortens cooldown by the given fraction. Fractions outside
+// [0, 1] are clamped so a reduction never lengthens a cooldown or drives it
+// negative; stat formulas apply the gameplay cap before it reaches here.
+func ReducedCooldown(cooldown time.Duration, reduction float64) time.Duration {
+	if cooldown <= 0 || reduction <= 0 || math.IsNaN(reduction) {
+		return cooldown
+	}
+	if reduction >= 1 {
+		return 0
+	}
+	return time.Duration(float64(cooldown) * (1 - reduction))
+}
+
 func newAbilityGate[T any](cfg abilityGateConfig[T]) func(actorID string, now time.Time) (T, bool) {
 	var zero T
 	if cfg.LookupOwner == nil || cfg.ConvertOwner == nil || cfg.AbilityID == "" {
@@ -73,7 +92,11 @@ func newAbilityGate[T any](cfg abilityGateConfig[T]) func(actorID string, now ti
 		if !ok {
 			return zero, false
 		}
-		if !ReadyCooldown(cooldowns, cfg.AbilityID, cfg.Cooldown, now) {
+		cooldown := cfg.Cooldown
+		if cfg.CooldownReduction != nil {
+			cooldown = ReducedCooldown(cooldown, cfg.CooldownReduction(actorID))
+		}
+		if !ReadyCooldown(cooldowns, cfg.AbilityID, cooldown, now) {
 			return zero, false
 		}
 		return owner, true
@@ -84,10 +107,11 @@ func newAbilityGate[T any](cfg abilityGateConfig[T]) func(actorID string, now ti
 // ability gating semantics using the provided configuration.
 func NewMeleeAbilityGate(cfg MeleeAbilityGateConfig) MeleeAbilityGate {
 	gate := newAbilityGate[MeleeIntentOwner](abilityGateConfig[MeleeIntentOwner]{
-		AbilityID:    cfg.AbilityID,
-		Cooldown:     cfg.Cooldown,
-		LookupOwner:  cfg.LookupOwner,
-		ConvertOwner: NewMeleeIntentOwnerFromActor,
+		AbilityID:         cfg.AbilityID,
+		Cooldown:          cfg.Cooldown,
+		CooldownReduction: cfg.CooldownReduction,
+		LookupOwner:       cfg.LookupOwner,
+		ConvertOwner:      NewMeleeIntentOwnerFromActor,
 	})
 	if gate == nil {
 		return nil
@@ -99,10 +123,11 @@ func NewMeleeAbilityGate(cfg MeleeAbilityGateConfig) MeleeAbilityGate {
 // projectile ability gating semantics using the provided configuration.
 func NewProjectileAbilityGate(cfg ProjectileAbilityGateConfig) ProjectileAbilityGate {
 	gate := newAbilityGate[ProjectileIntentOwner](abilityGateConfig[ProjectileIntentOwner]{
-		AbilityID:    cfg.AbilityID,
-		Cooldown:     cfg.Cooldown,
-		LookupOwner:  cfg.LookupOwner,
-		ConvertOwner: NewProjectileIntentOwnerFromActor,
+		AbilityID:         cfg.AbilityID,
+		Cooldown:          cfg.Cooldown,
+		CooldownReduction: cfg.CooldownReduction,
+		LookupOwner:       cfg.LookupOwner,
+		ConvertOwner:      NewProjectileIntentOwnerFromActor,
 	})
 	if gate == nil {
 		return nil
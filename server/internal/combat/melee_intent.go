@@ -23,10 +23,16 @@ const (
 // MeleeAttackGeometryConfig carries the spatial parameters required to build the
 // melee attack rectangle relative to the owner.
 type MeleeAttackGeometryConfig struct {
-	PlayerHalf    float64
+	PlayerHalf float64
+	// SpawnOffset is how far from the owner's centre, along its facing, the
+	// hitbox starts. Zero starts it at the owner's edge, PlayerHalf away.
+	SpawnOffset   float64
 	Reach         float64
 	Width         float64
 	DefaultFacing string
+	// Arc swings a cone of this many degrees centred on the facing instead of
+	// the box. Zero keeps the box.
+	Arc float64
 }
 
 // MeleeIntentConfig bundles the dependencies required to reproduce the legacy
@@ -38,6 +44,9 @@ type MeleeIntentConfig struct {
 	Duration        time.Duration
 	QuantizeCoord   func(float64) int
 	DurationToTicks func(time.Duration) int
+	// ComboStep tags the intent with its position in a melee combo chain.
+	// Zero leaves the intent untagged.
+	ComboStep int
 }
 
 // MeleeIntentOwner captures the minimal actor metadata required to stage a
@@ -86,6 +95,22 @@ func NewMeleeIntent(cfg MeleeIntentConfig, owner MeleeIntentOwner) (effectcontra
 		"width":       int(math.Round(cfg.Geometry.Width)),
 	}
 
+	if arc := cfg.Geometry.Arc; arc > 0 {
+		radius := cfg.Geometry.nearEdge() + cfg.Geometry.Reach
+		geometry = effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeArc,
+			Width:  quantizeWorldCoord(radius * 2),
+			Height: quantizeWorldCoord(radius * 2),
+			Radius: quantizeWorldCoord(radius),
+			Arc:    int(math.Round(math.Min(arc, 360))),
+			Facing: MeleeFacingDegrees(facing),
+		}
+		params["arc"] = geometry.Arc
+	}
+	if cfg.ComboStep > 0 {
+		params["combo"] = cfg.ComboStep
+	}
+
 	intent := effectcontract.EffectIntent{
 		EntryID:       EffectTypeAttack,
 		TypeID:        EffectTypeAttack,
@@ -103,7 +128,7 @@ func NewMeleeIntent(cfg MeleeIntentConfig, owner MeleeIntentOwner) (effectcontra
 func MeleeAttackRectangle(cfg MeleeAttackGeometryConfig, x, y float64, facing string) (float64, float64, float64, float64) {
 	reach := cfg.Reach
 	thickness := cfg.Width
-	half := cfg.PlayerHalf
+	half := cfg.nearEdge()
 
 	if facing == "" {
 		facing = cfg.DefaultFacing
@@ -122,3 +147,26 @@ func MeleeAttackRectangle(cfg MeleeAttackGeometryConfig, x, y float64, facing st
 		return x - thickness/2, y + half, thickness, reach
 	}
 }
+
+// nearEdge reports how far from the owner's centre the hitbox starts.
+func (cfg MeleeAttackGeometryConfig) nearEdge() float64 {
+	if cfg.SpawnOffset > 0 {
+		return cfg.SpawnOffset
+	}
+	return cfg.PlayerHalf
+}
+
+// MeleeFacingDegrees converts a facing into the screen-space angle used by arc
+// geometry: 0 points right and angles grow clockwise, so "down" is 90.
+func MeleeFacingDegrees(facing string) int {
+	switch facing {
+	case "right":
+		return 0
+	case "left":
+		return 180
+	case "up":
+		return 270
+	default:
+		return 90
+	}
+}
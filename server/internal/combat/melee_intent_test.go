@@ -117,3 +117,12 @@ func TestMeleeAttackRectangleFacing(t *testing.T) {
 		})
 	}
 }
+
+func TestMeleeAttackRectangleSpawnOffset(t *testing.T) {
+	cfg := MeleeAttackGeometryConfig{PlayerHalf: 20, SpawnOffset: 30, Reach: 56, Width: 40, DefaultFacing: "down"}
+
+	gotX, gotY, gotW, gotH := MeleeAttackRectangle(cfg, 200, 180, "right")
+	if gotX != 230 || gotY != 160 || gotW != 56 || gotH != 40 {
+		t.Fatalf("expected the hitbox to start at the spawn offset, got (%v,%v,%v,%v)", gotX, gotY, gotW, gotH)
+	}
+}
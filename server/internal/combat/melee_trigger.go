@@ -11,6 +11,10 @@ import (
 type MeleeAbilityTriggerConfig struct {
 	AbilityGate  MeleeAbilityGate
 	IntentConfig MeleeIntentConfig
+	// Combo escalates consecutive swings; ComboState resolves the actor's
+	// chain. Combos stay off when either is unset.
+	Combo      MeleeComboConfig
+	ComboState func(actorID string) *MeleeComboState
 }
 
 // StageMeleeIntent applies the provided melee ability gate and intent
@@ -27,5 +31,11 @@ func StageMeleeIntent(cfg MeleeAbilityTriggerConfig, actorID string, now time.Ti
 		return effectcontract.EffectIntent{}, false
 	}
 
-	return NewMeleeIntent(cfg.IntentConfig, owner)
+	intentCfg := cfg.IntentConfig
+	if cfg.Combo.Enabled() && cfg.ComboState != nil {
+		step := cfg.ComboState(actorID).Advance(cfg.Combo, now)
+		intentCfg = cfg.Combo.Escalate(intentCfg, step)
+	}
+
+	return NewMeleeIntent(intentCfg, owner)
 }
@@ -4,6 +4,7 @@ import (
 	"math"
 
 	internaleffects "mine-and-die/server/internal/effects"
+	state "mine-and-die/server/internal/world/state"
 )
 
 // ProjectileAdvanceConfig bundles the adapters required to advance a legacy
@@ -17,9 +18,15 @@ type ProjectileAdvanceConfig struct {
 
 	WorldWidth  float64
 	WorldHeight float64
+	// WrapBounds carries projectiles across the world edge to the opposite
+	// side instead of expiring them.
+	WrapBounds bool
 
 	ComputeArea        func() Rectangle
 	AnyObstacleOverlap func(Rectangle) bool
+	// LocateTarget reports the position of a living actor so homing
+	// projectiles can steer toward their lock.
+	LocateTarget func(id string) (x, y float64, ok bool)
 
 	SetPosition       func(x, y float64)
 	SetRemainingRange func(remaining float64)
@@ -74,6 +81,26 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 		return result
 	}
 
+	if internaleffects.ParabolicMotion(&effect.Instance) {
+		if advanceArcProjectile(cfg, effect, projectile, template) {
+			if cfg.ComputeArea != nil {
+				overlapCfg := cfg.OverlapConfig
+				overlapCfg.Projectile = projectile
+				overlapCfg.Area = cfg.ComputeArea()
+				result.OverlapResult = ResolveProjectileOverlaps(overlapCfg)
+			}
+			stopWithOptions(ProjectileStopOptions{TriggerImpact: true})
+			result.Stopped = true
+			result.StoppedForImpact = true
+		}
+		return result
+	}
+
+	if template.TravelMode.Homing && cfg.Delta > 0 {
+		steerHomingProjectile(cfg, effect, projectile, template)
+	}
+
+	startX, startY := effect.X, effect.Y
 	if template.TravelMode.StraightLine && template.Speed > 0 && cfg.Delta > 0 {
 		distance := template.Speed * cfg.Delta
 		if projectile.RemainingRange > 0 && distance > projectile.RemainingRange {
@@ -83,6 +110,10 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 			if cfg.SetPosition != nil {
 				newX := effect.X + projectile.VelocityUnitX*distance
 				newY := effect.Y + projectile.VelocityUnitY*distance
+				if cfg.WrapBounds {
+					newX = state.WrapCoordinate(newX, cfg.WorldWidth)
+					newY = state.WrapCoordinate(newY, cfg.WorldHeight)
+				}
 				cfg.SetPosition(newX, newY)
 			}
 			if projectile.RemainingRange > 0 {
@@ -105,7 +136,7 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 		return result
 	}
 
-	if cfg.WorldWidth > 0 || cfg.WorldHeight > 0 {
+	if !cfg.WrapBounds && (cfg.WorldWidth > 0 || cfg.WorldHeight > 0) {
 		if effect.X < 0 || effect.Y < 0 ||
 			(cfg.WorldWidth > 0 && effect.X+effect.Width > cfg.WorldWidth) ||
 			(cfg.WorldHeight > 0 && effect.Y+effect.Height > cfg.WorldHeight) {
@@ -121,6 +152,13 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 		area = cfg.ComputeArea()
 	}
 
+	if cfg.AnyObstacleOverlap != nil && cfg.AnyObstacleOverlap(area) && projectile.BouncesRemaining > 0 {
+		bounceProjectile(cfg, effect, projectile, startX, startY)
+		if cfg.ComputeArea != nil {
+			area = cfg.ComputeArea()
+		}
+	}
+
 	if cfg.AnyObstacleOverlap != nil && cfg.AnyObstacleOverlap(area) {
 		stopWithOptions(ProjectileStopOptions{TriggerImpact: true})
 		result.Stopped = true
@@ -139,7 +177,7 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 		result.Stopped = true
 	}
 
-	if result.OverlapResult.HitsApplied > 0 && template != nil {
+	if result.OverlapResult.HitsApplied > 0 && !result.OverlapResult.Deflected && template != nil {
 		if spec := template.ImpactRules.ExplodeOnImpact; spec != nil {
 			if cfg.AreaEffectSpawn != nil {
 				spawnCfg := *cfg.AreaEffectSpawn
@@ -154,3 +192,92 @@ func AdvanceProjectile(cfg ProjectileAdvanceConfig) ProjectileAdvanceResult {
 
 	return result
 }
+
+// bounceProjectile reflects the projectile off the obstacle it just entered and
+// returns it to where the tick started. The axis whose motion alone causes the
+// overlap is flipped; corners that block both axes or neither flip both.
+func bounceProjectile(cfg ProjectileAdvanceConfig, effect *internaleffects.State, projectile *internaleffects.ProjectileState, startX, startY float64) {
+	blockedX := cfg.AnyObstacleOverlap(Rectangle{X: effect.X, Y: startY, Width: effect.Width, Height: effect.Height})
+	blockedY := cfg.AnyObstacleOverlap(Rectangle{X: startX, Y: effect.Y, Width: effect.Width, Height: effect.Height})
+	if blockedX || !blockedY {
+		projectile.VelocityUnitX = -projectile.VelocityUnitX
+	}
+	if blockedY || !blockedX {
+		projectile.VelocityUnitY = -projectile.VelocityUnitY
+	}
+	projectile.BouncesRemaining--
+	if cfg.SetPosition != nil {
+		cfg.SetPosition(startX, startY)
+	}
+}
+
+// steerHomingProjectile rotates the projectile heading toward its locked
+// target, limited by the template turn rate. The lock is released when the
+// target can no longer be located or has moved out of homing range.
+func steerHomingProjectile(cfg ProjectileAdvanceConfig, effect *internaleffects.State, projectile *internaleffects.ProjectileState, template *internaleffects.ProjectileTemplate) {
+	if projectile.HomingTargetID == "" {
+		return
+	}
+	var targetX, targetY float64
+	ok := false
+	if cfg.LocateTarget != nil {
+		targetX, targetY, ok = cfg.LocateTarget(projectile.HomingTargetID)
+	}
+	if !ok {
+		projectile.HomingTargetID = ""
+		return
+	}
+
+	dx := targetX - (effect.X + effect.Width/2)
+	dy := targetY - (effect.Y + effect.Height/2)
+	distance := math.Hypot(dx, dy)
+	if limit := template.TravelMode.HomingRange; limit > 0 && distance > limit {
+		projectile.HomingTargetID = ""
+		return
+	}
+	if distance == 0 {
+		return
+	}
+
+	heading := math.Atan2(projectile.VelocityUnitY, projectile.VelocityUnitX)
+	turn := math.Remainder(math.Atan2(dy, dx)-heading, 2*math.Pi)
+	if maxTurn := template.TravelMode.TurnRate * cfg.Delta; math.Abs(turn) > maxTurn {
+		turn = math.Copysign(maxTurn, turn)
+	}
+	heading += turn
+	projectile.VelocityUnitX = math.Cos(heading)
+	projectile.VelocityUnitY = math.Sin(heading)
+}
+
+// advanceArcProjectile moves an arcing projectile along its ground track toward
+// the landing point and lifts it on a parabola peaking mid-flight. Airborne
+// projectiles skip obstacle and actor checks. It reports true once the
+// projectile touches down, after snapping it onto the target point, so the
+// caller can hit whatever stands there.
+func advanceArcProjectile(cfg ProjectileAdvanceConfig, effect *internaleffects.State, projectile *internaleffects.ProjectileState, template *internaleffects.ProjectileTemplate) bool {
+	if projectile.RemainingRange > 0 && template.Speed > 0 && cfg.Delta > 0 {
+		distance := math.Min(template.Speed*cfg.Delta, projectile.RemainingRange)
+		projectile.RemainingRange -= distance
+		if projectile.RemainingRange < 1e-9 {
+			projectile.RemainingRange = 0
+		}
+		if cfg.SetRemainingRange != nil {
+			cfg.SetRemainingRange(projectile.RemainingRange)
+		}
+		if projectile.RemainingRange > 0 && cfg.SetPosition != nil {
+			cfg.SetPosition(effect.X+projectile.VelocityUnitX*distance, effect.Y+projectile.VelocityUnitY*distance)
+		}
+	}
+
+	if projectile.RemainingRange > 0 && projectile.ArcDistance > 0 {
+		progress := 1 - projectile.RemainingRange/projectile.ArcDistance
+		projectile.Altitude = 4 * template.TravelMode.ArcHeight * progress * (1 - progress)
+		return false
+	}
+
+	projectile.Altitude = 0
+	if cfg.SetPosition != nil {
+		cfg.SetPosition(projectile.ArcTargetX-effect.Width/2, projectile.ArcTargetY-effect.Height/2)
+	}
+	return true
+}
@@ -1,9 +1,11 @@
 package combat
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	effectcontract "mine-and-die/server/effects/contract"
 	internaleffects "mine-and-die/server/internal/effects"
 )
 
@@ -275,6 +277,357 @@ func TestAdvanceProjectileStopsOnExpiry(t *testing.T) {
 	}
 }
 
+func TestAdvanceProjectileWrapsAcrossWorldEdge(t *testing.T) {
+	effect := &internaleffects.State{
+		X:      98,
+		Y:      50,
+		Width:  2,
+		Height: 2,
+		Projectile: &internaleffects.ProjectileState{
+			VelocityUnitX:  1,
+			VelocityUnitY:  0,
+			RemainingRange: 50,
+			Template: &internaleffects.ProjectileTemplate{
+				Speed:       10,
+				MaxDistance: 50,
+				TravelMode:  internaleffects.TravelModeConfig{StraightLine: true},
+			},
+		},
+	}
+
+	stops := 0
+	cfg := ProjectileAdvanceConfig{
+		Effect:      effect,
+		Delta:       0.5,
+		WorldWidth:  100,
+		WorldHeight: 100,
+		WrapBounds:  true,
+		ComputeArea: func() Rectangle { return Rectangle{} },
+		SetPosition: func(x, y float64) {
+			effect.X = x
+			effect.Y = y
+		},
+		Stop: ProjectileStopConfig{
+			Effect: effect,
+			RecordEffectEnd: func(string) {
+				stops++
+			},
+		},
+	}
+
+	result := AdvanceProjectile(cfg)
+
+	if result.Stopped || stops != 0 {
+		t.Fatalf("expected wrapped projectile to keep travelling, got %+v", result)
+	}
+	if math.Abs(effect.X-3) > 1e-9 || effect.Y != 50 {
+		t.Fatalf("expected projectile to wrap to (3,50), got (%.2f,%.2f)", effect.X, effect.Y)
+	}
+}
+
+func TestAdvanceProjectileArcLandsOnTarget(t *testing.T) {
+	effect := &internaleffects.State{
+		X:      9,
+		Y:      9,
+		Width:  2,
+		Height: 2,
+		Instance: effectcontract.EffectInstance{
+			Definition: &effectcontract.EffectDefinition{Motion: effectcontract.MotionKindParabolic},
+		},
+		Projectile: &internaleffects.ProjectileState{
+			VelocityUnitX:  1,
+			VelocityUnitY:  0,
+			RemainingRange: 30,
+			ArcTargetX:     40,
+			ArcTargetY:     10,
+			ArcDistance:    30,
+			Template: &internaleffects.ProjectileTemplate{
+				Speed:       40,
+				MaxDistance: 100,
+				TravelMode:  internaleffects.TravelModeConfig{StraightLine: true, ArcHeight: 20},
+				ImpactRules: internaleffects.ImpactRuleConfig{
+					ExplodeOnImpact: &internaleffects.ExplosionSpec{
+						EffectType: "grenade-blast",
+						Radius:     4,
+						Duration:   time.Second,
+					},
+				},
+			},
+		},
+	}
+
+	var explosion *internaleffects.State
+	spawnCfg := internaleffects.AreaEffectSpawnConfig{
+		AllocateID: func() string { return "blast" },
+		Register: func(registered *internaleffects.State) bool {
+			explosion = registered
+			return true
+		},
+	}
+	var reasons, hits []string
+	cfg := ProjectileAdvanceConfig{
+		Effect: effect,
+		Delta:  0.25,
+		OverlapConfig: ProjectileOverlapResolutionConfig{
+			VisitNPCs: func(visit ProjectileOverlapVisitor) {
+				for _, target := range []ProjectileOverlapTarget{
+					{ID: "under-flight", X: 20, Y: 10, Radius: 4},
+					{ID: "at-landing", X: 40, Y: 10, Radius: 4},
+				} {
+					if !visit(target) {
+						return
+					}
+				}
+			},
+			OnNPCHit: func(target ProjectileOverlapTarget) {
+				hits = append(hits, target.ID)
+			},
+		},
+		WorldWidth:  100,
+		WorldHeight: 100,
+		ComputeArea: func() Rectangle {
+			return Rectangle{X: effect.X, Y: effect.Y, Width: effect.Width, Height: effect.Height}
+		},
+		AnyObstacleOverlap: func(Rectangle) bool { return true },
+		SetPosition: func(x, y float64) {
+			effect.X = x
+			effect.Y = y
+		},
+		Stop: ProjectileStopConfig{
+			Effect:          effect,
+			AreaEffectSpawn: &spawnCfg,
+			RecordEffectEnd: func(reason string) {
+				reasons = append(reasons, reason)
+			},
+		},
+		AreaEffectSpawn: &spawnCfg,
+	}
+
+	first := AdvanceProjectile(cfg)
+	if first.Stopped {
+		t.Fatalf("expected airborne projectile to fly over obstacles, got %+v", first)
+	}
+	if effect.Projectile.Altitude <= 0 {
+		t.Fatalf("expected projectile to climb mid-flight, got altitude %.2f", effect.Projectile.Altitude)
+	}
+
+	var result ProjectileAdvanceResult
+	for i := 0; i < 10 && !result.Stopped; i++ {
+		result = AdvanceProjectile(cfg)
+	}
+
+	if !result.Stopped || !result.StoppedForImpact {
+		t.Fatalf("expected arc projectile to land with an impact, got %+v", result)
+	}
+	if len(reasons) != 1 || reasons[0] != "impact" {
+		t.Fatalf("expected a single impact stop, got %v", reasons)
+	}
+	centerX := effect.X + effect.Width/2
+	centerY := effect.Y + effect.Height/2
+	if math.Abs(centerX-40) > 1e-9 || math.Abs(centerY-10) > 1e-9 {
+		t.Fatalf("expected projectile to land at (40,10), got (%.2f,%.2f)", centerX, centerY)
+	}
+	if effect.Projectile.Altitude != 0 {
+		t.Fatalf("expected landed projectile to rest on the ground, got altitude %.2f", effect.Projectile.Altitude)
+	}
+	if len(hits) != 1 || hits[0] != "at-landing" {
+		t.Fatalf("expected only the actor at the landing point to be hit, got %v", hits)
+	}
+	if explosion == nil {
+		t.Fatalf("expected landing to spawn the impact explosion")
+	}
+	blastX := explosion.X + explosion.Width/2
+	blastY := explosion.Y + explosion.Height/2
+	if math.Abs(blastX-40) > 1e-9 || math.Abs(blastY-10) > 1e-9 {
+		t.Fatalf("expected explosion centred on (40,10), got (%.2f,%.2f)", blastX, blastY)
+	}
+}
+
+func TestAdvanceProjectileHomingCurvesOntoTarget(t *testing.T) {
+	effect := &internaleffects.State{
+		X:      9,
+		Y:      49,
+		Width:  2,
+		Height: 2,
+		Owner:  "caster",
+		Projectile: &internaleffects.ProjectileState{
+			VelocityUnitX:  1,
+			VelocityUnitY:  0,
+			RemainingRange: 200,
+			HomingTargetID: "target",
+			Template: &internaleffects.ProjectileTemplate{
+				Speed:       40,
+				MaxDistance: 200,
+				TravelMode: internaleffects.TravelModeConfig{
+					StraightLine: true,
+					Homing:       true,
+					TurnRate:     math.Pi,
+				},
+				ImpactRules: internaleffects.ImpactRuleConfig{StopOnHit: true},
+			},
+		},
+	}
+
+	const targetX, targetY = 40.0, 90.0
+	var hits []string
+	cfg := ProjectileAdvanceConfig{
+		Effect:      effect,
+		Delta:       0.1,
+		WorldWidth:  200,
+		WorldHeight: 200,
+		ComputeArea: func() Rectangle {
+			return Rectangle{X: effect.X, Y: effect.Y, Width: effect.Width, Height: effect.Height}
+		},
+		LocateTarget: func(id string) (float64, float64, bool) {
+			return targetX, targetY, id == "target"
+		},
+		SetPosition: func(x, y float64) {
+			effect.X = x
+			effect.Y = y
+		},
+		Stop: ProjectileStopConfig{Effect: effect},
+		OverlapConfig: ProjectileOverlapResolutionConfig{
+			Impact:  ProjectileImpactRules{StopOnHit: true},
+			OwnerID: effect.Owner,
+			VisitPlayers: func(visitor ProjectileOverlapVisitor) {
+				visitor(ProjectileOverlapTarget{ID: "target", X: targetX, Y: targetY, Radius: 4})
+			},
+			OnPlayerHit: func(target ProjectileOverlapTarget) {
+				hits = append(hits, target.ID)
+			},
+		},
+	}
+
+	var result ProjectileAdvanceResult
+	for i := 0; i < 40 && !result.Stopped; i++ {
+		result = AdvanceProjectile(cfg)
+	}
+
+	if len(hits) != 1 || hits[0] != "target" {
+		t.Fatalf("expected homing projectile to curve onto the target, got hits %v at (%.2f,%.2f)", hits, effect.X, effect.Y)
+	}
+	if effect.Projectile.VelocityUnitY <= 0 {
+		t.Fatalf("expected projectile to have turned toward the target, heading (%.2f,%.2f)", effect.Projectile.VelocityUnitX, effect.Projectile.VelocityUnitY)
+	}
+}
+
+func TestAdvanceProjectileHomingDropsLockWhenTargetLost(t *testing.T) {
+	effect := &internaleffects.State{
+		Width:  2,
+		Height: 2,
+		Projectile: &internaleffects.ProjectileState{
+			VelocityUnitX:  1,
+			RemainingRange: 100,
+			HomingTargetID: "target",
+			Template: &internaleffects.ProjectileTemplate{
+				Speed:       10,
+				MaxDistance: 100,
+				TravelMode: internaleffects.TravelModeConfig{
+					StraightLine: true,
+					Homing:       true,
+					TurnRate:     math.Pi,
+				},
+			},
+		},
+	}
+
+	cfg := ProjectileAdvanceConfig{
+		Effect: effect,
+		Delta:  0.1,
+		LocateTarget: func(string) (float64, float64, bool) {
+			return 0, 0, false
+		},
+		SetPosition: func(x, y float64) {
+			effect.X = x
+			effect.Y = y
+		},
+	}
+
+	AdvanceProjectile(cfg)
+
+	if effect.Projectile.HomingTargetID != "" {
+		t.Fatalf("expected lost target to release the lock, still locked on %q", effect.Projectile.HomingTargetID)
+	}
+	if effect.Projectile.VelocityUnitX != 1 || effect.Projectile.VelocityUnitY != 0 {
+		t.Fatalf("expected projectile to keep its heading, got (%.2f,%.2f)", effect.Projectile.VelocityUnitX, effect.Projectile.VelocityUnitY)
+	}
+}
+
+func TestAdvanceProjectileBouncesOffWallsUntilExhausted(t *testing.T) {
+	effect := &internaleffects.State{
+		X:      10,
+		Y:      10,
+		Width:  2,
+		Height: 2,
+		Projectile: &internaleffects.ProjectileState{
+			VelocityUnitX:    1,
+			VelocityUnitY:    0,
+			RemainingRange:   500,
+			BouncesRemaining: 1,
+			Template: &internaleffects.ProjectileTemplate{
+				Speed:       40,
+				MaxDistance: 500,
+				TravelMode:  internaleffects.TravelModeConfig{StraightLine: true, Bounces: 1},
+			},
+		},
+	}
+
+	// A corridor walled off on both sides: wall hits anything left of x=2 or
+	// right of x=30.
+	wall := func(rect Rectangle) bool {
+		return rect.X < 2 || rect.X+rect.Width > 30
+	}
+	var reasons []string
+	cfg := ProjectileAdvanceConfig{
+		Effect:      effect,
+		Delta:       0.25,
+		WorldWidth:  100,
+		WorldHeight: 100,
+		ComputeArea: func() Rectangle {
+			return Rectangle{X: effect.X, Y: effect.Y, Width: effect.Width, Height: effect.Height}
+		},
+		AnyObstacleOverlap: wall,
+		SetPosition: func(x, y float64) {
+			effect.X = x
+			effect.Y = y
+		},
+		Stop: ProjectileStopConfig{
+			Effect: effect,
+			RecordEffectEnd: func(reason string) {
+				reasons = append(reasons, reason)
+			},
+		},
+	}
+
+	bounced := false
+	var result ProjectileAdvanceResult
+	for i := 0; i < 20 && !result.Stopped; i++ {
+		result = AdvanceProjectile(cfg)
+		if !result.Stopped && effect.Projectile.BouncesRemaining == 0 && !bounced {
+			bounced = true
+			if effect.Projectile.VelocityUnitX != -1 || effect.Projectile.VelocityUnitY != 0 {
+				t.Fatalf("expected bounce to reverse horizontal heading, got (%.2f,%.2f)", effect.Projectile.VelocityUnitX, effect.Projectile.VelocityUnitY)
+			}
+			if wall(Rectangle{X: effect.X, Y: effect.Y, Width: effect.Width, Height: effect.Height}) {
+				t.Fatalf("expected bounced projectile to leave the wall, got (%.2f,%.2f)", effect.X, effect.Y)
+			}
+		}
+	}
+
+	if !bounced {
+		t.Fatalf("expected projectile to bounce off the first wall")
+	}
+	if !result.Stopped || !result.StoppedForImpact {
+		t.Fatalf("expected projectile to end on the second wall once bounces ran out, got %+v", result)
+	}
+	if len(reasons) != 1 || reasons[0] != "impact" {
+		t.Fatalf("expected a single impact stop, got %v", reasons)
+	}
+	if effect.X > 16 {
+		t.Fatalf("expected projectile to end against the left wall, got x=%.2f", effect.X)
+	}
+}
+
 func TestAdvanceProjectileStopsOnObstacle(t *testing.T) {
 	effect := &internaleffects.State{
 		X:      1,
@@ -0,0 +1,39 @@
+package combat
+
+import (
+	"math"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// aimSnapComponent is the smallest unit-vector component an aimed shot keeps.
+// Smaller components snap to zero, so shots within roughly seven degrees of an
+// axis fly straight along it and no component quantizes to a value the
+// projectile spawn would read as a legacy whole-unit direction.
+const aimSnapComponent = 2.0 / effectcontract.CoordScale
+
+// NormalizeAim validates a client-supplied aim vector and returns the unit
+// direction a projectile should travel along, rounded to the contract's
+// coordinate precision. Zero-length or non-finite vectors report false so the
+// caller falls back to the owner's facing.
+func NormalizeAim(x, y float64) (float64, float64, bool) {
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return 0, 0, false
+	}
+	length := math.Hypot(x, y)
+	if length < 1e-6 {
+		return 0, 0, false
+	}
+	x /= length
+	y /= length
+	if math.Abs(x) < aimSnapComponent {
+		x = 0
+	}
+	if math.Abs(y) < aimSnapComponent {
+		y = 0
+	}
+	x = math.Round(x*effectcontract.CoordScale) / effectcontract.CoordScale
+	y = math.Round(y*effectcontract.CoordScale) / effectcontract.CoordScale
+	length = math.Hypot(x, y)
+	return x / length, y / length, true
+}
@@ -0,0 +1,39 @@
+package combat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeAimRejectsDegenerateVectors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		x, y float64
+	}{
+		{name: "zero", x: 0, y: 0},
+		{name: "nan", x: math.NaN(), y: 1},
+		{name: "inf", x: math.Inf(1), y: 0},
+	} {
+		if _, _, ok := NormalizeAim(tc.x, tc.y); ok {
+			t.Fatalf("%s: expected aim (%v, %v) to be rejected", tc.name, tc.x, tc.y)
+		}
+	}
+}
+
+func TestNormalizeAimSnapsNearCardinalShots(t *testing.T) {
+	x, y, ok := NormalizeAim(10, 0.5)
+	if !ok || x != 1 || y != 0 {
+		t.Fatalf("expected near-horizontal aim to snap to (1, 0), got (%v, %v) ok=%v", x, y, ok)
+	}
+
+	x, y, ok = NormalizeAim(3, 4)
+	if !ok {
+		t.Fatal("expected diagonal aim to be accepted")
+	}
+	if math.Abs(math.Hypot(x, y)-1) > 1e-9 {
+		t.Fatalf("expected unit direction, got (%v, %v)", x, y)
+	}
+	if math.Abs(math.Atan2(y, x)-math.Atan2(4, 3)) > 0.05 {
+		t.Fatalf("expected direction close to the aim, got (%v, %v)", x, y)
+	}
+}
@@ -23,6 +23,10 @@ type ProjectileIntentOwner struct {
 	X      float64
 	Y      float64
 	Facing string
+	// AimX/AimY is an optional precise aim vector that overrides Facing when
+	// it normalizes to a valid direction.
+	AimX float64
+	AimY float64
 }
 
 // ProjectileIntentCollisionShape mirrors the legacy projectile collision shape
@@ -64,6 +68,10 @@ func NewProjectileIntent(cfg ProjectileIntentConfig, owner ProjectileIntentOwner
 	if dirX == 0 && dirY == 0 {
 		dirX, dirY = 0, 1
 	}
+	aimX, aimY, aimed := NormalizeAim(owner.AimX, owner.AimY)
+	if aimed {
+		dirX, dirY = aimX, aimY
+	}
 
 	spawnRadius := sanitizeSpawnRadius(tpl.SpawnRadius)
 	spawnOffset := tpl.SpawnOffset
@@ -108,8 +116,15 @@ func NewProjectileIntent(cfg ProjectileIntentConfig, owner ProjectileIntentOwner
 	if params == nil {
 		params = make(map[string]int)
 	}
-	params["dx"] = int(math.Round(dirX))
-	params["dy"] = int(math.Round(dirY))
+	if aimed {
+		// Fractional directions travel quantized; the spawn decodes any
+		// component beyond a whole unit back through CoordScale.
+		params["dx"] = cfg.QuantizeCoord(dirX)
+		params["dy"] = cfg.QuantizeCoord(dirY)
+	} else {
+		params["dx"] = int(math.Round(dirX))
+		params["dy"] = int(math.Round(dirY))
+	}
 	if _, ok := params["radius"]; !ok {
 		params["radius"] = int(math.Round(spawnRadius))
 	}
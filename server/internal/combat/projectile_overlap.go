@@ -47,6 +47,12 @@ type ProjectileOverlapResolutionConfig struct {
 
 	OnPlayerHit func(target ProjectileOverlapTarget)
 	OnNPCHit    func(target ProjectileOverlapTarget)
+
+	// Deflects reports whether a target turns the projectile away instead of
+	// taking the hit. A deflected projectile registers no hit and stops;
+	// OnDeflect runs in place of the hit callback.
+	Deflects  func(target ProjectileOverlapTarget) bool
+	OnDeflect func(target ProjectileOverlapTarget)
 }
 
 // ProjectileOverlapResolutionResult reports the outcome of resolving projectile
@@ -54,6 +60,7 @@ type ProjectileOverlapResolutionConfig struct {
 type ProjectileOverlapResolutionResult struct {
 	HitsApplied int
 	ShouldStop  bool
+	Deflected   bool
 }
 
 // ResolveProjectileOverlaps scans the provided player and NPC iterators,
@@ -81,6 +88,14 @@ func ResolveProjectileOverlaps(cfg ProjectileOverlapResolutionConfig) Projectile
 		if !CircleRectOverlap(target.X, target.Y, target.Radius, cfg.Area) {
 			return true
 		}
+		if _, already := projectile.HitActors[target.ID]; !already && cfg.Deflects != nil && cfg.Deflects(target) {
+			if cfg.OnDeflect != nil {
+				cfg.OnDeflect(target)
+			}
+			result.Deflected = true
+			result.ShouldStop = true
+			return false
+		}
 		if !projectile.MarkHit(target.ID) {
 			return true
 		}
@@ -277,3 +277,43 @@ func TestResolveProjectileOverlapsNoTelemetryWhenNoHits(t *testing.T) {
 		t.Fatalf("expected no telemetry when no hits, got %d", recordCount)
 	}
 }
+
+func TestResolveProjectileOverlapsDeflectStopsWithoutHit(t *testing.T) {
+	projectile := &internaleffects.ProjectileState{}
+	area := Rectangle{X: 0, Y: 0, Width: 10, Height: 10}
+
+	var hits, deflected []string
+	cfg := ProjectileOverlapResolutionConfig{
+		Projectile: projectile,
+		OwnerID:    "owner",
+		Area:       area,
+		VisitPlayers: func(visitor ProjectileOverlapVisitor) {
+			for _, id := range []string{"parrier", "behind"} {
+				if !visitor(ProjectileOverlapTarget{ID: id, X: 5, Y: 5, Radius: 1}) {
+					return
+				}
+			}
+		},
+		OnPlayerHit: func(target ProjectileOverlapTarget) {
+			hits = append(hits, target.ID)
+		},
+		Deflects: func(target ProjectileOverlapTarget) bool {
+			return target.ID == "parrier"
+		},
+		OnDeflect: func(target ProjectileOverlapTarget) {
+			deflected = append(deflected, target.ID)
+		},
+	}
+
+	result := ResolveProjectileOverlaps(cfg)
+
+	if !result.Deflected || !result.ShouldStop {
+		t.Fatalf("expected deflection to stop the projectile, got %+v", result)
+	}
+	if result.HitsApplied != 0 || projectile.HitCount != 0 || len(hits) != 0 {
+		t.Fatalf("expected no hits after deflection, got %d applied and callbacks %v", result.HitsApplied, hits)
+	}
+	if !reflect.DeepEqual(deflected, []string{"parrier"}) {
+		t.Fatalf("expected one deflect callback for the parrier, got %v", deflected)
+	}
+}
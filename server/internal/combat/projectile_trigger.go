@@ -12,6 +12,9 @@ type ProjectileAbilityTriggerConfig struct {
 	AbilityGate  ProjectileAbilityGate
 	IntentConfig ProjectileIntentConfig
 	Template     ProjectileIntentTemplate
+	// AimX/AimY carries the optional aim vector from the action command.
+	AimX float64
+	AimY float64
 }
 
 // StageProjectileIntent applies the provided projectile ability gate and
@@ -31,5 +34,7 @@ func StageProjectileIntent(cfg ProjectileAbilityTriggerConfig, actorID string, n
 		return effectcontract.EffectIntent{}, false
 	}
 
+	owner.AimX = cfg.AimX
+	owner.AimY = cfg.AimY
 	return NewProjectileIntent(cfg.IntentConfig, owner, cfg.Template)
 }
@@ -26,6 +26,12 @@ type WorldEffectHitDispatcherConfig struct {
 
 	DropAllInventory  func(target ActorRef, reason string)
 	ApplyStatusEffect func(effect EffectRef, target ActorRef, statusEffect string, now time.Time)
+	RollCritical      func(effect EffectRef, target ActorRef) (multiplier float64, critical bool)
+	AmplifyDamage     func(effect EffectRef, target ActorRef) float64
+	AttenuateDamage   func(effect EffectRef, target ActorRef) float64
+	ResistDamage      func(effect EffectRef, target ActorRef) float64
+	AbsorbDamage      func(effect EffectRef, target ActorRef, damage float64) (remaining float64)
+	ApplyLifesteal    func(effect EffectRef, target ActorRef, damage float64)
 }
 
 // WorldActorAdapter captures the metadata required to adapt legacy world actor
@@ -61,6 +67,12 @@ type LegacyWorldEffectHitAdapterConfig struct {
 
 	DropAllInventory  func(actor WorldActorAdapter, reason string)
 	ApplyStatusEffect func(effect *internaleffects.State, actor WorldActorAdapter, statusEffect string, now time.Time)
+	RollCritical      func(effect *internaleffects.State, targetID string) (multiplier float64, critical bool)
+	AmplifyDamage     func(effect *internaleffects.State, targetID string) float64
+	AttenuateDamage   func(effect *internaleffects.State, targetID string) float64
+	ResistDamage      func(effect *internaleffects.State, targetID string) float64
+	AbsorbDamage      func(targetID string, damage float64) (remaining float64)
+	ApplyLifesteal    func(effect *internaleffects.State, targetID string, damage float64)
 }
 
 // NewLegacyWorldEffectHitAdapter constructs the world-scoped dispatcher using
@@ -179,6 +191,62 @@ func NewLegacyWorldEffectHitAdapter(cfg LegacyWorldEffectHitAdapterConfig) Effec
 			}
 			cfg.ApplyStatusEffect(state, adapter, statusEffect, now)
 		},
+		RollCritical: func(effect EffectRef, target ActorRef) (float64, bool) {
+			if cfg.RollCritical == nil || target.Actor.ID == "" {
+				return 1, false
+			}
+			state, _ := effect.Raw.(*internaleffects.State)
+			if state == nil {
+				return 1, false
+			}
+			return cfg.RollCritical(state, target.Actor.ID)
+		},
+		AmplifyDamage: func(effect EffectRef, target ActorRef) float64 {
+			if cfg.AmplifyDamage == nil || target.Actor.ID == "" {
+				return 1
+			}
+			state, _ := effect.Raw.(*internaleffects.State)
+			if state == nil {
+				return 1
+			}
+			return cfg.AmplifyDamage(state, target.Actor.ID)
+		},
+		AttenuateDamage: func(effect EffectRef, target ActorRef) float64 {
+			if cfg.AttenuateDamage == nil || target.Actor.ID == "" {
+				return 1
+			}
+			state, _ := effect.Raw.(*internaleffects.State)
+			if state == nil {
+				return 1
+			}
+			return cfg.AttenuateDamage(state, target.Actor.ID)
+		},
+		ResistDamage: func(effect EffectRef, target ActorRef) float64 {
+			if cfg.ResistDamage == nil || target.Actor.ID == "" {
+				return 1
+			}
+			state, _ := effect.Raw.(*internaleffects.State)
+			if state == nil {
+				return 1
+			}
+			return cfg.ResistDamage(state, target.Actor.ID)
+		},
+		AbsorbDamage: func(effect EffectRef, target ActorRef, damage float64) float64 {
+			if cfg.AbsorbDamage == nil || target.Actor.ID == "" {
+				return damage
+			}
+			return cfg.AbsorbDamage(target.Actor.ID, damage)
+		},
+		ApplyLifesteal: func(effect EffectRef, target ActorRef, damage float64) {
+			if cfg.ApplyLifesteal == nil || target.Actor.ID == "" {
+				return
+			}
+			state, _ := effect.Raw.(*internaleffects.State)
+			if state == nil {
+				return
+			}
+			cfg.ApplyLifesteal(state, target.Actor.ID, damage)
+		},
 	}
 
 	return NewWorldEffectHitDispatcher(dispatcherCfg)
@@ -201,6 +269,12 @@ func NewWorldEffectHitDispatcher(cfg WorldEffectHitDispatcherConfig) EffectHitCa
 		RecordDefeatTelemetry:    cfg.RecordDefeatTelemetry,
 		DropAllInventory:         cfg.DropAllInventory,
 		ApplyStatusEffect:        cfg.ApplyStatusEffect,
+		RollCritical:             cfg.RollCritical,
+		AmplifyDamage:            cfg.AmplifyDamage,
+		AttenuateDamage:          cfg.AttenuateDamage,
+		ResistDamage:             cfg.ResistDamage,
+		AbsorbDamage:             cfg.AbsorbDamage,
+		ApplyLifesteal:           cfg.ApplyLifesteal,
 	})
 	if dispatcher == nil {
 		return nil
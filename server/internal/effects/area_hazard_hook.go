@@ -0,0 +1,83 @@
+package effects
+
+import (
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// AreaHazardOwner captures where a hazard is placed.
+type AreaHazardOwner struct {
+	X float64
+	Y float64
+}
+
+// AreaHazardHookConfig bundles the dependencies required to resolve lingering
+// hazards such as fire patches. ResolveHits decides which actors standing in
+// the hazard are hurt and applies one pulse to each of them.
+type AreaHazardHookConfig struct {
+	TileSize           float64
+	DefaultRadius      float64
+	DefaultHealthDelta float64
+	LookupOwner        func(actorID string) *AreaHazardOwner
+	ResolveHits        func(effect *State, now time.Time)
+}
+
+// AreaHazardHook returns the spawn and tick handlers for hazards. The spawn
+// handler pins the hazard where its placer stood; it stays there after the
+// placer moves. The tick handler runs on the instance's tick cadence, reports
+// the footprint as the square bounding the radius, and resolves one pulse.
+func AreaHazardHook(cfg AreaHazardHookConfig) HookSet {
+	return HookSet{
+		OnSpawn: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, _ time.Time) {
+			if instance == nil || cfg.LookupOwner == nil || instance.OwnerActorID == "" {
+				return
+			}
+			owner := cfg.LookupOwner(instance.OwnerActorID)
+			if owner == nil {
+				return
+			}
+			motion := instance.DeliveryState.Motion
+			motion.PositionX = QuantizeWorldCoord(owner.X, cfg.TileSize)
+			motion.PositionY = QuantizeWorldCoord(owner.Y, cfg.TileSize)
+			instance.DeliveryState.Motion = motion
+		},
+		OnTick: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, now time.Time) {
+			if instance == nil || cfg.ResolveHits == nil {
+				return
+			}
+
+			radius := DequantizeWorldCoord(instance.DeliveryState.Geometry.Radius, cfg.TileSize)
+			if radius <= 0 {
+				radius = cfg.DefaultRadius
+			}
+
+			params := IntMapToFloat64(instance.BehaviorState.Extra)
+			if params == nil {
+				params = make(map[string]float64)
+			}
+			if _, ok := params["healthDelta"]; !ok {
+				params["healthDelta"] = cfg.DefaultHealthDelta
+			}
+			params["radius"] = radius
+
+			centerX := DequantizeWorldCoord(instance.DeliveryState.Motion.PositionX, cfg.TileSize)
+			centerY := DequantizeWorldCoord(instance.DeliveryState.Motion.PositionY, cfg.TileSize)
+
+			effect := &State{
+				ID:                 instance.ID,
+				Type:               instance.DefinitionID,
+				Owner:              instance.OwnerActorID,
+				Start:              now.UnixMilli(),
+				X:                  centerX - radius,
+				Y:                  centerY - radius,
+				Width:              radius * 2,
+				Height:             radius * 2,
+				Params:             params,
+				Instance:           *instance,
+				TelemetrySpawnTick: instance.StartTick,
+			}
+			cfg.ResolveHits(effect, now)
+		},
+	}
+}
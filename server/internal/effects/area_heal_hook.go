@@ -0,0 +1,75 @@
+package effects
+
+import (
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// AreaHealOwner captures where a group heal is centred.
+type AreaHealOwner struct {
+	X float64
+	Y float64
+}
+
+// AreaHealHookConfig bundles the dependencies required to resolve contract
+// group heals. ResolveHits decides which actors inside the burst are allies
+// and applies the heal to each of them.
+type AreaHealHookConfig struct {
+	TileSize           float64
+	DefaultRadius      float64
+	DefaultHealthDelta float64
+	LookupOwner        func(actorID string) *AreaHealOwner
+	ResolveHits        func(effect *State, now time.Time)
+}
+
+// AreaHealSpawnHook returns the spawn handler that centres a heal burst on its
+// caster and resolves it once. The burst reports its footprint as the square
+// bounding the radius so the actor filter can work from the effect alone.
+func AreaHealSpawnHook(cfg AreaHealHookConfig) HookSet {
+	return HookSet{
+		OnSpawn: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, now time.Time) {
+			if instance == nil || cfg.LookupOwner == nil || cfg.ResolveHits == nil || instance.OwnerActorID == "" {
+				return
+			}
+			owner := cfg.LookupOwner(instance.OwnerActorID)
+			if owner == nil {
+				return
+			}
+
+			radius := DequantizeWorldCoord(instance.DeliveryState.Geometry.Radius, cfg.TileSize)
+			if radius <= 0 {
+				radius = cfg.DefaultRadius
+			}
+
+			params := IntMapToFloat64(instance.BehaviorState.Extra)
+			if params == nil {
+				params = make(map[string]float64)
+			}
+			if _, ok := params["healthDelta"]; !ok {
+				params["healthDelta"] = cfg.DefaultHealthDelta
+			}
+			params["radius"] = radius
+
+			motion := instance.DeliveryState.Motion
+			motion.PositionX = QuantizeWorldCoord(owner.X, cfg.TileSize)
+			motion.PositionY = QuantizeWorldCoord(owner.Y, cfg.TileSize)
+			instance.DeliveryState.Motion = motion
+
+			effect := &State{
+				ID:                 instance.ID,
+				Type:               instance.DefinitionID,
+				Owner:              instance.OwnerActorID,
+				Start:              now.UnixMilli(),
+				X:                  owner.X - radius,
+				Y:                  owner.Y - radius,
+				Width:              radius * 2,
+				Height:             radius * 2,
+				Params:             params,
+				Instance:           *instance,
+				TelemetrySpawnTick: instance.StartTick,
+			}
+			cfg.ResolveHits(effect, now)
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package effects
+
+import (
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// AreaPullOwner captures where a gravity well is centred when it spawns.
+type AreaPullOwner struct {
+	X float64
+	Y float64
+}
+
+// AreaPullHookConfig bundles the dependencies required to resolve contract
+// gravity wells. ResolvePull decides which actors inside the well are pulled
+// and moves each of them, resolving the step against obstacles.
+type AreaPullHookConfig struct {
+	TileSize      float64
+	DefaultRadius float64
+	DefaultPull   float64
+	LookupOwner   func(actorID string) *AreaPullOwner
+	ResolvePull   func(effect *State, now time.Time)
+}
+
+// AreaPullHook returns the spawn and tick handlers for gravity wells. The
+// spawn handler pins the well's centre on where its caster stood; the centre
+// stays put afterwards even if the caster moves. The tick handler reports the
+// footprint as the square bounding the radius and applies one tick of pull.
+func AreaPullHook(cfg AreaPullHookConfig) HookSet {
+	return HookSet{
+		OnSpawn: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, _ time.Time) {
+			if instance == nil || cfg.LookupOwner == nil || instance.OwnerActorID == "" {
+				return
+			}
+			owner := cfg.LookupOwner(instance.OwnerActorID)
+			if owner == nil {
+				return
+			}
+			motion := instance.DeliveryState.Motion
+			motion.PositionX = QuantizeWorldCoord(owner.X, cfg.TileSize)
+			motion.PositionY = QuantizeWorldCoord(owner.Y, cfg.TileSize)
+			instance.DeliveryState.Motion = motion
+		},
+		OnTick: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, now time.Time) {
+			if instance == nil || cfg.ResolvePull == nil {
+				return
+			}
+
+			radius := DequantizeWorldCoord(instance.DeliveryState.Geometry.Radius, cfg.TileSize)
+			if radius <= 0 {
+				radius = cfg.DefaultRadius
+			}
+
+			params := IntMapToFloat64(instance.BehaviorState.Extra)
+			if params == nil {
+				params = make(map[string]float64)
+			}
+			if _, ok := params["pull"]; !ok {
+				params["pull"] = cfg.DefaultPull
+			}
+			params["radius"] = radius
+
+			centerX := DequantizeWorldCoord(instance.DeliveryState.Motion.PositionX, cfg.TileSize)
+			centerY := DequantizeWorldCoord(instance.DeliveryState.Motion.PositionY, cfg.TileSize)
+
+			effect := &State{
+				ID:                 instance.ID,
+				Type:               instance.DefinitionID,
+				Owner:              instance.OwnerActorID,
+				Start:              now.UnixMilli(),
+				X:                  centerX - radius,
+				Y:                  centerY - radius,
+				Width:              radius * 2,
+				Height:             radius * 2,
+				Params:             params,
+				Instance:           *instance,
+				TelemetrySpawnTick: instance.StartTick,
+			}
+			cfg.ResolvePull(effect, now)
+		},
+	}
+}
@@ -0,0 +1,110 @@
+package effects
+
+import (
+	"math"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// BeamOwner captures the caster position and the unit aim vector a beam is
+// fired along.
+type BeamOwner struct {
+	X    float64
+	Y    float64
+	DirX float64
+	DirY float64
+}
+
+// BeamSegment describes the line a beam covers on a given tick, from the
+// caster to the first blocked point or the end of its range.
+type BeamSegment struct {
+	StartX float64
+	StartY float64
+	EndX   float64
+	EndY   float64
+}
+
+// BeamHookConfig bundles the dependencies required to resolve contract beam
+// instances. Trace clips the beam against the world geometry and ResolveHit
+// applies the tick's damage to whatever the clipped segment touches first.
+type BeamHookConfig struct {
+	TileSize      float64
+	DefaultRange  float64
+	DefaultDamage float64
+	LookupOwner   func(actorID string) *BeamOwner
+	Trace         func(fromX, fromY, toX, toY float64) (float64, float64)
+	ResolveHit    func(effect *State, segment BeamSegment, now time.Time)
+}
+
+// BeamTickHook returns the tick handler that re-anchors a beam on its caster
+// every tick and resolves damage along the traced line. Beams have no travel
+// time, so the manager's spawn-tick OnTick call delivers the first hit.
+func BeamTickHook(cfg BeamHookConfig) HookSet {
+	return HookSet{
+		OnTick: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, now time.Time) {
+			if instance == nil || cfg.LookupOwner == nil || instance.OwnerActorID == "" {
+				return
+			}
+			owner := cfg.LookupOwner(instance.OwnerActorID)
+			if owner == nil {
+				return
+			}
+
+			effect, segment := beamEffectFromInstance(cfg, instance, owner, now)
+			if cfg.ResolveHit != nil {
+				cfg.ResolveHit(effect, segment, now)
+			}
+		},
+	}
+}
+
+func beamEffectFromInstance(cfg BeamHookConfig, instance *effectcontract.EffectInstance, owner *BeamOwner, now time.Time) (*State, BeamSegment) {
+	reach := DequantizeWorldCoord(instance.DeliveryState.Geometry.Length, cfg.TileSize)
+	if reach <= 0 {
+		reach = cfg.DefaultRange
+	}
+
+	segment := BeamSegment{
+		StartX: owner.X,
+		StartY: owner.Y,
+		EndX:   owner.X + owner.DirX*reach,
+		EndY:   owner.Y + owner.DirY*reach,
+	}
+	if cfg.Trace != nil {
+		segment.EndX, segment.EndY = cfg.Trace(segment.StartX, segment.StartY, segment.EndX, segment.EndY)
+	}
+
+	motion := instance.DeliveryState.Motion
+	motion.PositionX = QuantizeWorldCoord(segment.StartX, cfg.TileSize)
+	motion.PositionY = QuantizeWorldCoord(segment.StartY, cfg.TileSize)
+	motion.VelocityX = 0
+	motion.VelocityY = 0
+	motion.TravelledLength = QuantizeWorldCoord(math.Hypot(segment.EndX-segment.StartX, segment.EndY-segment.StartY), cfg.TileSize)
+	motion.TargetX = QuantizeWorldCoord(segment.EndX, cfg.TileSize)
+	motion.TargetY = QuantizeWorldCoord(segment.EndY, cfg.TileSize)
+	instance.DeliveryState.Motion = motion
+
+	params := IntMapToFloat64(instance.BehaviorState.Extra)
+	if params == nil {
+		params = make(map[string]float64)
+	}
+	if _, ok := params["healthDelta"]; !ok {
+		params["healthDelta"] = -cfg.DefaultDamage
+	}
+
+	effect := &State{
+		ID:                 instance.ID,
+		Type:               instance.DefinitionID,
+		Owner:              instance.OwnerActorID,
+		Start:              now.UnixMilli(),
+		X:                  math.Min(segment.StartX, segment.EndX),
+		Y:                  math.Min(segment.StartY, segment.EndY),
+		Width:              math.Abs(segment.EndX - segment.StartX),
+		Height:             math.Abs(segment.EndY - segment.StartY),
+		Params:             params,
+		Instance:           *instance,
+		TelemetrySpawnTick: instance.StartTick,
+	}
+	return effect, segment
+}
@@ -1,5 +1,7 @@
 package effects
 
+// bloodSplatterColorPalette mirrors the `colors` block of the blood-splatter
+// catalog entry so broadcasts can omit it from each spawn.
 var bloodSplatterColorPalette = []string{"#7a0e12", "#4a090b"}
 
 // NewBloodSplatterParams returns the default blood decal configuration values
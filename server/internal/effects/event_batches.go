@@ -89,9 +89,11 @@ func cloneTypedEffectSpawnEvents(events []simpatches.EffectSpawnEvent) []simpatc
 	clones := make([]simpatches.EffectSpawnEvent, len(events))
 	for i, evt := range events {
 		clones[i] = simpatches.EffectSpawnEvent{
-			Tick:     evt.Tick,
-			Seq:      evt.Seq,
-			Instance: cloneEffectInstance(evt.Instance),
+			Tick:          evt.Tick,
+			Seq:           evt.Seq,
+			Instance:      cloneEffectInstance(evt.Instance),
+			CatalogParams: evt.CatalogParams,
+			CatalogColors: evt.CatalogColors,
 		}
 	}
 	return clones
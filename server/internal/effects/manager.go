@@ -8,6 +8,7 @@ type (
 	HookSet       = worldeffects.HookSet
 	ManagerConfig = worldeffects.ManagerConfig
 	Manager       = worldeffects.Manager
+	Checkpoint    = worldeffects.Checkpoint
 )
 
 var (
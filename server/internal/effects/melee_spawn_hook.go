@@ -25,6 +25,14 @@ type MeleeImpactArea struct {
 	Y      float64
 	Width  float64
 	Height float64
+
+	// Arc is the swing angle in degrees for arc geometry, zero for boxes. The
+	// cone is centred on Facing degrees and reaches Radius from the origin.
+	Arc     float64
+	Facing  float64
+	Radius  float64
+	OriginX float64
+	OriginY float64
 }
 
 // MeleeSpawnHookConfig bundles the dependencies required to translate contract
@@ -139,5 +147,12 @@ func meleeEffectFromInstance(cfg MeleeSpawnHookConfig, instance *effectcontract.
 	}
 
 	area := MeleeImpactArea{X: rectX, Y: rectY, Width: width, Height: height}
+	if geom.Shape == effectcontract.GeometryShapeArc && geom.Arc > 0 {
+		area.Arc = float64(geom.Arc)
+		area.Facing = float64(geom.Facing)
+		area.Radius = DequantizeWorldCoord(geom.Radius, cfg.TileSize)
+		area.OriginX = owner.X
+		area.OriginY = owner.Y
+	}
 	return effect, area
 }
@@ -87,6 +87,11 @@ func SpawnContractProjectileFromInstance(cfg ProjectileSpawnConfig) *State {
 			dirX, dirY = 0, 1
 		}
 	}
+	if length := math.Hypot(dirX, dirY); length > 0 && length != 1 {
+		// Quantized aim vectors decode slightly off unit length.
+		dirX /= length
+		dirY /= length
+	}
 
 	geometry := instance.DeliveryState.Geometry
 	tileSize := cfg.TileSize
@@ -145,31 +150,96 @@ func SpawnContractProjectileFromInstance(cfg ProjectileSpawnConfig) *State {
 		remainingRange = 0
 	}
 
+	projectile := &ProjectileState{
+		Template:       tpl,
+		VelocityUnitX:  dirX,
+		VelocityUnitY:  dirY,
+		RemainingRange: remainingRange,
+	}
+	if ParabolicMotion(instance) {
+		planArcFlight(projectile, instance, params, centerX, centerY, tileSize)
+	}
+	if tpl.TravelMode.Homing {
+		projectile.HomingTargetID = instance.DeliveryState.AttachedActorID
+	}
+	if tpl.TravelMode.Bounces > 0 {
+		projectile.BouncesRemaining = tpl.TravelMode.Bounces
+		if raw, ok := instance.BehaviorState.Extra["bouncesRemaining"]; ok && raw >= 0 && raw < tpl.TravelMode.Bounces {
+			projectile.BouncesRemaining = raw
+		}
+	}
+
 	effect := &State{
-		ID:        instance.ID,
-		Type:      tpl.Type,
-		Owner:     instance.OwnerActorID,
-		Start:     cfg.Now.UnixMilli(),
-		Duration:  lifetime.Milliseconds(),
-		X:         centerX - width/2,
-		Y:         centerY - height/2,
-		Width:     width,
-		Height:    height,
-		Params:    params,
-		Instance:  *instance,
-		ExpiresAt: cfg.Now.Add(lifetime),
-		Projectile: &ProjectileState{
-			Template:       tpl,
-			VelocityUnitX:  dirX,
-			VelocityUnitY:  dirY,
-			RemainingRange: remainingRange,
-		},
+		ID:                 instance.ID,
+		Type:               tpl.Type,
+		Owner:              instance.OwnerActorID,
+		Start:              cfg.Now.UnixMilli(),
+		Duration:           lifetime.Milliseconds(),
+		X:                  centerX - width/2,
+		Y:                  centerY - height/2,
+		Width:              width,
+		Height:             height,
+		Params:             params,
+		Instance:           *instance,
+		ExpiresAt:          cfg.Now.Add(lifetime),
+		Projectile:         projectile,
 		ContractManaged:    true,
 		TelemetrySpawnTick: instance.StartTick,
 	}
 	return effect
 }
 
+// ParabolicMotion reports whether the instance's definition lobs it along a
+// parabola rather than flying it in a straight line.
+func ParabolicMotion(instance *effectcontract.EffectInstance) bool {
+	return instance != nil && instance.Definition != nil && instance.Definition.Motion == effectcontract.MotionKindParabolic
+}
+
+// planArcFlight resolves the landing point of an arcing projectile. A resynced
+// instance keeps the flight recorded in its motion state; fresh spawns land on
+// the "targetX"/"targetY" params when provided and otherwise at full range along
+// the launch direction. Targets beyond the template range are pulled in.
+func planArcFlight(projectile *ProjectileState, instance *effectcontract.EffectInstance, params map[string]float64, originX, originY, tileSize float64) {
+	motion := instance.DeliveryState.Motion
+	if motion.ArcDistance > 0 {
+		projectile.ArcTargetX = DequantizeWorldCoord(motion.TargetX, tileSize)
+		projectile.ArcTargetY = DequantizeWorldCoord(motion.TargetY, tileSize)
+		projectile.ArcDistance = DequantizeWorldCoord(motion.ArcDistance, tileSize)
+		projectile.Altitude = DequantizeWorldCoord(motion.Altitude, tileSize)
+		return
+	}
+
+	maxRange := projectile.Template.MaxDistance
+	targetX := originX + projectile.VelocityUnitX*maxRange
+	targetY := originY + projectile.VelocityUnitY*maxRange
+	tx, okX := params["targetX"]
+	ty, okY := params["targetY"]
+	if okX && okY {
+		targetX, targetY = tx, ty
+	}
+
+	dx := targetX - originX
+	dy := targetY - originY
+	distance := math.Hypot(dx, dy)
+	if distance > 0 {
+		projectile.VelocityUnitX = dx / distance
+		projectile.VelocityUnitY = dy / distance
+		params["dx"] = projectile.VelocityUnitX
+		params["dy"] = projectile.VelocityUnitY
+	}
+	if maxRange > 0 && distance > maxRange {
+		distance = maxRange
+		targetX = originX + projectile.VelocityUnitX*distance
+		targetY = originY + projectile.VelocityUnitY*distance
+	}
+
+	projectile.ArcTargetX = targetX
+	projectile.ArcTargetY = targetY
+	projectile.ArcDistance = distance
+	projectile.RemainingRange = distance
+	params["remainingRange"] = distance
+}
+
 // MergeParams returns a new map that contains the provided base parameters
 // overridden by the supplied overrides.
 func MergeParams(base, overrides map[string]float64) map[string]float64 {
@@ -263,25 +333,19 @@ func TicksToDuration(ticks int, tickRate int) time.Duration {
 // units before quantization so callers can round-trip geometry using the shared
 // tile size.
 func QuantizeWorldCoord(value float64, tileSize float64) int {
-	if tileSize <= 0 {
-		tileSize = 40
-	}
-	return quantizeCoord(value / tileSize)
+	return effectcontract.QuantizeWorldCoord(value, tileSize)
 }
 
 // DequantizeWorldCoord converts quantized geometry coordinates back into world
 // units using the provided tile size.
 func DequantizeWorldCoord(value int, tileSize float64) float64 {
-	if tileSize <= 0 {
-		tileSize = 40
-	}
-	return dequantizeCoord(value) * tileSize
+	return effectcontract.DequantizeWorldCoord(value, tileSize)
 }
 
 func quantizeCoord(value float64) int {
-	return int(math.Round(value * effectcontract.CoordScale))
+	return effectcontract.QuantizeCoord(value)
 }
 
 func dequantizeCoord(value int) float64 {
-	return float64(value) / effectcontract.CoordScale
+	return effectcontract.DequantizeCoord(value)
 }
@@ -51,6 +51,10 @@ func SyncContractProjectileInstance(cfg ContractProjectileSyncConfig) {
 		instance.BehaviorState.Extra["dy"] = dy
 		instance.Params["dy"] = dy
 
+		if tpl := proj.Template; tpl != nil && tpl.TravelMode.Bounces > 0 {
+			instance.BehaviorState.Extra["bouncesRemaining"] = proj.BouncesRemaining
+		}
+
 		if tpl := proj.Template; tpl != nil && tpl.MaxDistance > 0 {
 			distance := int(math.Round(tpl.MaxDistance))
 			instance.BehaviorState.Extra["range"] = distance
@@ -88,6 +92,17 @@ func SyncContractProjectileInstance(cfg ContractProjectileSyncConfig) {
 		}
 		motion.VelocityX = quantizeProjectileVelocity(proj.VelocityUnitX*speed, cfg.TickRate)
 		motion.VelocityY = quantizeProjectileVelocity(proj.VelocityUnitY*speed, cfg.TickRate)
+		if ParabolicMotion(instance) {
+			motion.TargetX = QuantizeWorldCoord(proj.ArcTargetX, tileSize)
+			motion.TargetY = QuantizeWorldCoord(proj.ArcTargetY, tileSize)
+			motion.ArcDistance = QuantizeWorldCoord(proj.ArcDistance, tileSize)
+			motion.Altitude = QuantizeWorldCoord(proj.Altitude, tileSize)
+			motion.RangeRemaining = QuantizeWorldCoord(proj.RemainingRange, tileSize)
+			motion.TravelledLength = QuantizeWorldCoord(proj.ArcDistance-proj.RemainingRange, tileSize)
+		}
+		if tpl := proj.Template; tpl != nil && tpl.TravelMode.Homing {
+			instance.DeliveryState.AttachedActorID = proj.HomingTargetID
+		}
 	}
 	instance.DeliveryState.Motion = motion
 	instance.DeliveryState.Geometry = geometry
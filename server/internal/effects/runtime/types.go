@@ -67,6 +67,20 @@ type CollisionShapeConfig struct {
 // TravelModeConfig captures the legacy projectile motion configuration.
 type TravelModeConfig struct {
 	StraightLine bool
+	// ArcHeight is the peak altitude, in world units, of projectiles whose
+	// definition uses parabolic motion. Those projectiles are lobbed toward a
+	// landing point, fly over actors and obstacles, and resolve their impact
+	// where they land.
+	ArcHeight float64
+	// Homing steers the projectile toward its locked target by at most
+	// TurnRate radians per second. The lock drops, leaving the projectile on
+	// its current heading, when the target dies or strays beyond HomingRange.
+	Homing      bool
+	TurnRate    float64
+	HomingRange float64
+	// Bounces is how many times the projectile reflects off obstacles before
+	// the next obstacle contact ends it.
+	Bounces int
 }
 
 // ImpactRuleConfig encodes legacy projectile impact policies.
@@ -95,9 +109,21 @@ type ProjectileState struct {
 	VelocityUnitX  float64
 	VelocityUnitY  float64
 	RemainingRange float64
-	HitCount       int
-	ExpiryResolved bool
-	HitActors      map[string]struct{}
+	// ArcTargetX/ArcTargetY is the landing point of an arcing projectile,
+	// ArcDistance the ground distance from launch to landing, and Altitude the
+	// current height above the ground track.
+	ArcTargetX  float64
+	ArcTargetY  float64
+	ArcDistance float64
+	Altitude    float64
+	// HomingTargetID is the actor a homing projectile is locked onto; empty
+	// once the lock is lost.
+	HomingTargetID string
+	// BouncesRemaining counts the obstacle reflections left before impact.
+	BouncesRemaining int
+	HitCount         int
+	ExpiryResolved   bool
+	HitActors        map[string]struct{}
 }
 
 // MarkHit records that the projectile has struck the provided actor ID.
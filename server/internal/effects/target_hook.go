@@ -0,0 +1,51 @@
+package effects
+
+import (
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// TargetHookConfig bundles the dependencies required to resolve contract
+// effects delivered straight to a single named actor. ResolveHit applies the
+// effect to that actor when it still exists.
+type TargetHookConfig struct {
+	DefaultHealthDelta float64
+	ResolveHit         func(effect *State, targetID string, now time.Time)
+}
+
+// TargetSpawnHook returns the spawn handler for targeted heals. The instance
+// carries its target in the attached actor ID, so the hit never searches the
+// world for nearby actors and can only land on that one entity.
+func TargetSpawnHook(cfg TargetHookConfig) HookSet {
+	return HookSet{
+		OnSpawn: func(_ Runtime, instance *effectcontract.EffectInstance, _ effectcontract.Tick, now time.Time) {
+			if instance == nil || cfg.ResolveHit == nil {
+				return
+			}
+			targetID := instance.DeliveryState.AttachedActorID
+			if targetID == "" {
+				return
+			}
+
+			params := IntMapToFloat64(instance.BehaviorState.Extra)
+			if params == nil {
+				params = make(map[string]float64)
+			}
+			if _, ok := params["healthDelta"]; !ok {
+				params["healthDelta"] = cfg.DefaultHealthDelta
+			}
+
+			effect := &State{
+				ID:                 instance.ID,
+				Type:               instance.DefinitionID,
+				Owner:              instance.OwnerActorID,
+				Start:              now.UnixMilli(),
+				Params:             params,
+				Instance:           *instance,
+				TelemetrySpawnTick: instance.StartTick,
+			}
+			cfg.ResolveHit(effect, targetID, now)
+		},
+	}
+}
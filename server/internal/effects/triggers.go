@@ -15,6 +15,10 @@ type Trigger struct {
 	Height   float64            `json:"height,omitempty"`
 	Params   map[string]float64 `json:"params,omitempty"`
 	Colors   []string           `json:"colors,omitempty"`
+	// SourceEffectID and TargetID identify hit triggers so repeated overlaps
+	// of the same effect and target within a tick collapse to one trigger.
+	SourceEffectID string `json:"sourceEffectId,omitempty"`
+	TargetID       string `json:"targetId,omitempty"`
 }
 
 // SimEffectTriggersFromLegacy converts legacy effect triggers into their
@@ -37,6 +41,9 @@ func SimEffectTriggersFromLegacy(triggers []Trigger) []sim.EffectTrigger {
 			Height:   trigger.Height,
 			Params:   cloneFloatMap(trigger.Params),
 			Colors:   cloneStringSlice(trigger.Colors),
+
+			SourceEffectID: trigger.SourceEffectID,
+			TargetID:       trigger.TargetID,
 		}
 	}
 	return converted
@@ -62,7 +69,36 @@ func LegacyEffectTriggersFromSim(triggers []sim.EffectTrigger) []Trigger {
 			Height:   trigger.Height,
 			Params:   cloneFloatMap(trigger.Params),
 			Colors:   cloneStringSlice(trigger.Colors),
+
+			SourceEffectID: trigger.SourceEffectID,
+			TargetID:       trigger.TargetID,
 		}
 	}
 	return converted
 }
+
+// DedupeTriggers drops repeat hit triggers for the same (source effect,
+// target) pair, keeping the first occurrence. Triggers without both keys are
+// never considered duplicates. The input slice is filtered in place.
+func DedupeTriggers(triggers []Trigger) []Trigger {
+	if len(triggers) < 2 {
+		return triggers
+	}
+	type hitKey struct {
+		effectID string
+		targetID string
+	}
+	seen := make(map[hitKey]struct{}, len(triggers))
+	kept := triggers[:0]
+	for _, trigger := range triggers {
+		if trigger.SourceEffectID != "" && trigger.TargetID != "" {
+			key := hitKey{effectID: trigger.SourceEffectID, targetID: trigger.TargetID}
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+		}
+		kept = append(kept, trigger)
+	}
+	return kept
+}
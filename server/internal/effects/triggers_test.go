@@ -66,3 +66,28 @@ func TestEffectTriggerConversionsHandleEmpty(t *testing.T) {
 		t.Fatal("expected empty sim slice to return nil")
 	}
 }
+
+func TestDedupeTriggersCollapsesRepeatHits(t *testing.T) {
+	triggers := []Trigger{
+		{ID: "t1", Type: "hit", SourceEffectID: "effect-1", TargetID: "npc-1"},
+		{ID: "t2", Type: "hit", SourceEffectID: "effect-1", TargetID: "npc-2"},
+		{ID: "t3", Type: "hit", SourceEffectID: "effect-1", TargetID: "npc-1"},
+		{ID: "t4", Type: "spark"},
+		{ID: "t5", Type: "spark"},
+	}
+
+	deduped := DedupeTriggers(triggers)
+	ids := make([]string, 0, len(deduped))
+	for _, trigger := range deduped {
+		ids = append(ids, trigger.ID)
+	}
+	want := []string{"t1", "t2", "t4", "t5"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+}
@@ -20,11 +20,24 @@ type GroundTileKey struct {
 	Y int
 }
 
-// GroundItemState tracks a ground item along with its tile metadata.
+// GroundItemState tracks a ground item along with its tile metadata. Loot
+// dropped by a defeated actor can be reserved for its killer: while the
+// reservation holds, only OwnerID may collect the stack.
 type GroundItemState struct {
 	GroundItem
-	Tile    GroundTileKey
-	Version uint64
+	Tile           GroundTileKey
+	Version        uint64
+	OwnerID        string
+	OwnerUntilTick uint64
+}
+
+// LockedFor reports whether the stack is reserved for someone other than
+// actorID at the provided tick.
+func (item *GroundItemState) LockedFor(actorID string, tick uint64) bool {
+	if item == nil || item.OwnerID == "" || item.OwnerID == actorID {
+		return false
+	}
+	return tick < item.OwnerUntilTick
 }
 
 // Actor captures the minimal actor metadata required for ground item placement.
@@ -326,6 +339,8 @@ const (
 	PickupFailureReasonOutOfRange = "out_of_range"
 	// PickupFailureReasonInventoryError indicates the inventory mutation failed.
 	PickupFailureReasonInventoryError = "inventory_error"
+	// PickupFailureReasonLootLocked indicates the nearest stack is still reserved for another actor.
+	PickupFailureReasonLootLocked = "loot_locked"
 )
 
 const (
@@ -0,0 +1,53 @@
+package items
+
+import "math/rand"
+
+// LootEntry describes a single possible drop in a loot table.
+type LootEntry struct {
+	Type        string  `json:"type"`
+	MinQuantity int     `json:"minQuantity"`
+	MaxQuantity int     `json:"maxQuantity"`
+	Chance      float64 `json:"chance"`
+}
+
+// LootTable lists the entries rolled when an NPC is defeated. Entries are
+// evaluated in order so a given RNG stream always produces the same drops.
+type LootTable []LootEntry
+
+// RollLoot evaluates every entry in the table against rng and returns the
+// stacks that dropped. Entries with a chance of 1 or more always drop and do
+// not consume a chance roll; quantity ranges only consume a roll when
+// MaxQuantity exceeds MinQuantity.
+func RollLoot(rng *rand.Rand, table LootTable) []ItemStack {
+	if len(table) == 0 {
+		return nil
+	}
+
+	var drops []ItemStack
+	for _, entry := range table {
+		if entry.Type == "" || entry.Chance <= 0 {
+			continue
+		}
+		minQty := entry.MinQuantity
+		if minQty < 1 {
+			minQty = 1
+		}
+		maxQty := entry.MaxQuantity
+		if maxQty < minQty {
+			maxQty = minQty
+		}
+
+		if entry.Chance < 1 {
+			if rng == nil || rng.Float64() >= entry.Chance {
+				continue
+			}
+		}
+
+		qty := minQty
+		if maxQty > minQty && rng != nil {
+			qty += rng.Intn(maxQty - minQty + 1)
+		}
+		drops = append(drops, ItemStack{Type: entry.Type, Quantity: qty})
+	}
+	return drops
+}
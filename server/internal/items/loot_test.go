@@ -0,0 +1,41 @@
+package items
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRollLootSkipsZeroChanceAndClampsQuantities(t *testing.T) {
+	table := LootTable{
+		{Type: "gold", MinQuantity: 0, MaxQuantity: 0, Chance: 1},
+		{Type: "never", MinQuantity: 1, MaxQuantity: 1, Chance: 0},
+		{Type: "", MinQuantity: 1, MaxQuantity: 1, Chance: 1},
+	}
+
+	drops := RollLoot(rand.New(rand.NewSource(1)), table)
+	want := []ItemStack{{Type: "gold", Quantity: 1}}
+	if !reflect.DeepEqual(drops, want) {
+		t.Fatalf("expected %v, got %v", want, drops)
+	}
+}
+
+func TestRollLootDeterministicForSeed(t *testing.T) {
+	table := LootTable{
+		{Type: "gold", MinQuantity: 3, MaxQuantity: 9, Chance: 0.75},
+		{Type: "potion", MinQuantity: 1, MaxQuantity: 2, Chance: 0.25},
+	}
+
+	for seed := int64(0); seed < 16; seed++ {
+		first := RollLoot(rand.New(rand.NewSource(seed)), table)
+		second := RollLoot(rand.New(rand.NewSource(seed)), table)
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("seed %d: expected identical drops, got %v and %v", seed, first, second)
+		}
+		for _, drop := range first {
+			if drop.Type == "gold" && (drop.Quantity < 3 || drop.Quantity > 9) {
+				t.Fatalf("seed %d: gold quantity %d out of range", seed, drop.Quantity)
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package journal
+
+// patchKey identifies the state slot a patch overwrites.
+type patchKey struct {
+	kind     PatchKind
+	entityID string
+}
+
+// CoalescePatches collapses repeated patches for the same (kind, entity) pair
+// down to the final one. Every patch kind carries the full replacement value
+// for its slot, so earlier entries are redundant once a later one exists.
+// Survivors keep the relative order of their last occurrence.
+func CoalescePatches(patches []Patch) []Patch {
+	if len(patches) < 2 {
+		return patches
+	}
+
+	last := make(map[patchKey]int, len(patches))
+	for i, patch := range patches {
+		last[patchKey{kind: patch.Kind, entityID: patch.EntityID}] = i
+	}
+	if len(last) == len(patches) {
+		return patches
+	}
+
+	coalesced := make([]Patch, 0, len(last))
+	for i, patch := range patches {
+		if last[patchKey{kind: patch.Kind, entityID: patch.EntityID}] != i {
+			continue
+		}
+		coalesced = append(coalesced, patch)
+	}
+	return coalesced
+}
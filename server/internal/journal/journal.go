@@ -29,6 +29,8 @@ const (
 	PatchPlayerInventory = simpaches.PatchPlayerInventory
 	// PatchPlayerEquipment updates a player's equipment loadout.
 	PatchPlayerEquipment = simpaches.PatchPlayerEquipment
+	// PatchPlayerAbsorb updates a player's absorb pool.
+	PatchPlayerAbsorb = simpaches.PatchPlayerAbsorb
 	// PatchPlayerRemoved signals that a player has been removed from the world.
 	PatchPlayerRemoved = simpaches.PatchPlayerRemoved
 
@@ -52,6 +54,13 @@ const (
 	PatchGroundItemPos = simpaches.PatchGroundItemPos
 	// PatchGroundItemQty updates a ground item's quantity.
 	PatchGroundItemQty = simpaches.PatchGroundItemQty
+
+	// PatchCorpseAdded announces a new corpse with its full contents.
+	PatchCorpseAdded = simpaches.PatchCorpseAdded
+	// PatchCorpseContents updates the slots left in a corpse.
+	PatchCorpseContents = simpaches.PatchCorpseContents
+	// PatchCorpseRemoved signals that a corpse was emptied or expired.
+	PatchCorpseRemoved = simpaches.PatchCorpseRemoved
 )
 
 // Patch represents a diff entry that can be applied to the client state.
@@ -93,6 +102,12 @@ type PlayerHealthPayload = simpaches.PlayerHealthPayload
 // NPCHealthPayload captures the health for an NPC patch.
 type NPCHealthPayload = simpaches.NPCHealthPayload
 
+// AbsorbPayload captures the remaining absorb pool for an entity patch.
+type AbsorbPayload = simpaches.AbsorbPayload
+
+// PlayerAbsorbPayload captures the absorb pool for a player patch.
+type PlayerAbsorbPayload = simpaches.PlayerAbsorbPayload
+
 // InventoryPayload captures the inventory slots for an entity patch.
 type InventoryPayload = simpaches.InventoryPayload
 
@@ -325,6 +340,8 @@ func (j *Journal) PurgeEntity(entityID string) {
 }
 
 // DrainPatches returns all staged patches and clears the in-memory slice.
+// Redundant patches for the same (kind, entity) pair are coalesced so only the
+// final state is broadcast.
 func (j *Journal) DrainPatches() []Patch {
 	j.mu.Lock()
 	defer j.mu.Unlock()
@@ -334,11 +351,11 @@ func (j *Journal) DrainPatches() []Patch {
 	drained := make([]Patch, len(j.patches))
 	copy(drained, j.patches)
 	j.patches = j.patches[:0]
-	return drained
+	return CoalescePatches(drained)
 }
 
-// SnapshotPatches returns a copy of the staged patches without clearing the
-// journal.
+// SnapshotPatches returns a coalesced copy of the staged patches without
+// clearing the journal.
 func (j *Journal) SnapshotPatches() []Patch {
 	j.mu.RLock()
 	defer j.mu.RUnlock()
@@ -347,7 +364,7 @@ func (j *Journal) SnapshotPatches() []Patch {
 	}
 	snapshot := make([]Patch, len(j.patches))
 	copy(snapshot, j.patches)
-	return snapshot
+	return CoalescePatches(snapshot)
 }
 
 // RestorePatches prepends the provided patches back into the journal. It is
@@ -660,9 +677,11 @@ func CloneEffectSpawnEvents(events []effectcontract.EffectSpawnEvent) []effectco
 	clones := make([]effectcontract.EffectSpawnEvent, len(events))
 	for i, evt := range events {
 		clones[i] = effectcontract.EffectSpawnEvent{
-			Tick:     evt.Tick,
-			Seq:      evt.Seq,
-			Instance: CloneEffectInstance(evt.Instance),
+			Tick:          evt.Tick,
+			Seq:           evt.Seq,
+			Instance:      CloneEffectInstance(evt.Instance),
+			CatalogParams: evt.CatalogParams,
+			CatalogColors: evt.CatalogColors,
 		}
 	}
 	return clones
@@ -776,14 +795,18 @@ func CloneGeometry(src effectcontract.EffectGeometry) effectcontract.EffectGeome
 // intentionally minimal for now so future diffs can expand it without touching
 // the broadcast layer again.
 type Keyframe struct {
-	Tick        uint64
-	Sequence    uint64
-	Players     any
-	NPCs        any
-	Obstacles   any
-	GroundItems any
-	Config      any
-	RecordedAt  time.Time
+	Tick           uint64
+	Sequence       uint64
+	Players        any
+	NPCs           any
+	Obstacles      any
+	GroundItems    any
+	Corpses        any
+	ScheduledTasks any
+	RNG            any
+	ActiveEffects  []effectcontract.EffectSpawnEvent
+	Config         any
+	RecordedAt     time.Time
 }
 
 type KeyframeEviction struct {
@@ -425,3 +425,30 @@ func TestJournalKeyframeBySequenceCopiesConfig(t *testing.T) {
 		t.Fatalf("expected keyframe config to remain unchanged, got %#v want %#v", typedAgain, expected)
 	}
 }
+
+func TestCoalescePatchesKeepsLastPerKindAndEntity(t *testing.T) {
+	patches := []Patch{
+		{Kind: PatchPlayerPos, EntityID: "player-1", Payload: PlayerPosPayload{X: 1, Y: 1}},
+		{Kind: PatchPlayerPos, EntityID: "player-2", Payload: PlayerPosPayload{X: 9, Y: 9}},
+		{Kind: PatchPlayerFacing, EntityID: "player-1", Payload: PlayerFacingPayload{Facing: "left"}},
+		{Kind: PatchPlayerPos, EntityID: "player-1", Payload: PlayerPosPayload{X: 2, Y: 3}},
+	}
+
+	got := CoalescePatches(patches)
+	want := []Patch{
+		{Kind: PatchPlayerPos, EntityID: "player-2", Payload: PlayerPosPayload{X: 9, Y: 9}},
+		{Kind: PatchPlayerFacing, EntityID: "player-1", Payload: PlayerFacingPayload{Facing: "left"}},
+		{Kind: PatchPlayerPos, EntityID: "player-1", Payload: PlayerPosPayload{X: 2, Y: 3}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected coalesced patches:\n got %#v\nwant %#v", got, want)
+	}
+
+	j := New(0, 0)
+	for _, patch := range patches {
+		j.AppendPatch(patch)
+	}
+	if drained := j.DrainPatches(); !reflect.DeepEqual(drained, want) {
+		t.Fatalf("expected drain to coalesce patches, got %#v", drained)
+	}
+}
@@ -2,10 +2,12 @@ package net
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	nethttp "net/http"
 	"net/http/pprof"
+	"sort"
 	"time"
 
 	"mine-and-die/server"
@@ -21,6 +23,9 @@ type HTTPHandlerConfig struct {
 	ClientDir     string
 	Logger        telemetry.Logger
 	Observability observability.Config
+	// Rooms, when set, lets /join and /ws attach to a room named by ?room=.
+	// Requests without a room use the hub passed to NewHTTPHandler.
+	Rooms *server.RoomManager
 }
 
 func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
@@ -44,13 +49,15 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 			ServerTime int64  `json:"serverTime"`
 			Players    any    `json:"players"`
 			TickRate   int    `json:"tickRate"`
+			Paused     bool   `json:"paused"`
 			Heartbeat  int64  `json:"heartbeatMillis"`
 			Telemetry  any    `json:"telemetry"`
 		}{
 			Status:     "ok",
 			ServerTime: time.Now().UnixMilli(),
 			Players:    hub.DiagnosticsSnapshot(),
-			TickRate:   server.TickRate(),
+			TickRate:   hub.TickRate(),
+			Paused:     hub.Paused(),
 			Heartbeat:  server.HeartbeatInterval().Milliseconds(),
 			Telemetry:  hub.TelemetrySnapshot(),
 		}
@@ -65,6 +72,18 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 		w.Write(data)
 	})
 
+	mux.HandleFunc("/metrics", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodGet {
+			httpError(w, "method not allowed", nethttp.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := hub.WriteMetrics(w); err != nil {
+			telemetryLogger.Printf("failed to write metrics: %v", err)
+		}
+	})
+
 	mux.HandleFunc("/world/reset", func(w nethttp.ResponseWriter, r *nethttp.Request) {
 		if r.Method != nethttp.MethodPost {
 			httpError(w, "method not allowed", nethttp.StatusMethodNotAllowed)
@@ -74,17 +93,27 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 		cfg := hub.CurrentConfig()
 
 		type resetRequest struct {
-			Obstacles      *bool   `json:"obstacles"`
-			ObstaclesCount *int    `json:"obstaclesCount"`
-			GoldMines      *bool   `json:"goldMines"`
-			GoldMineCount  *int    `json:"goldMineCount"`
-			NPCs           *bool   `json:"npcs"`
-			GoblinCount    *int    `json:"goblinCount"`
-			RatCount       *int    `json:"ratCount"`
-			NPCCount       *int    `json:"npcCount"`
-			Lava           *bool   `json:"lava"`
-			LavaCount      *int    `json:"lavaCount"`
-			Seed           *string `json:"seed"`
+			Obstacles      *bool                 `json:"obstacles"`
+			ObstaclesCount *int                  `json:"obstaclesCount"`
+			GoldMines      *bool                 `json:"goldMines"`
+			GoldMineCount  *int                  `json:"goldMineCount"`
+			NPCs           *bool                 `json:"npcs"`
+			GoblinCount    *int                  `json:"goblinCount"`
+			RatCount       *int                  `json:"ratCount"`
+			NPCCount       *int                  `json:"npcCount"`
+			NPCWeights     map[string]float64    `json:"npcWeights"`
+			Lava           *bool                 `json:"lava"`
+			LavaCount      *int                  `json:"lavaCount"`
+			Stash          *bool                 `json:"stash"`
+			Wrap           *bool                 `json:"wrap"`
+			Width          *float64              `json:"width"`
+			Height         *float64              `json:"height"`
+			Seed           *string               `json:"seed"`
+			PatrolRoutes   *[]server.PatrolRoute `json:"patrolRoutes"`
+			Waves          *server.WaveConfig    `json:"waves"`
+			Difficulty     *float64              `json:"difficulty"`
+			LootBags       *bool                 `json:"lootBags"`
+			BossCount      *int                  `json:"bossCount"`
 		}
 
 		if r.Body != nil {
@@ -118,21 +147,43 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 			}
 			if req.NPCCount != nil {
 				cfg.NPCCount = *req.NPCCount
-				if req.GoblinCount == nil && req.RatCount == nil {
-					goblins := cfg.NPCCount
-					if goblins > 2 {
-						goblins = 2
-					}
-					if goblins < 0 {
-						goblins = 0
-					}
-					cfg.GoblinCount = goblins
-					rats := cfg.NPCCount - goblins
-					if rats < 0 {
-						rats = 0
-					}
-					cfg.RatCount = rats
+			} else if len(req.NPCWeights) > 0 {
+				// Weights without a count redistribute the current population.
+				cfg.NPCCount = cfg.Normalized().NPCCount
+			}
+			if len(req.NPCWeights) > 0 {
+				types := make([]string, 0, len(req.NPCWeights))
+				for npcType := range req.NPCWeights {
+					types = append(types, npcType)
+				}
+				sort.Strings(types)
+				weights := make([]server.NPCSpawnWeight, 0, len(types))
+				for _, npcType := range types {
+					weights = append(weights, server.NPCSpawnWeight{Type: server.NPCType(npcType), Weight: req.NPCWeights[npcType]})
+				}
+				if req.Seed != nil {
+					cfg.Seed = *req.Seed
+				}
+				weighted, err := cfg.WithNPCWeights(weights)
+				if err != nil {
+					httpError(w, err.Error(), nethttp.StatusBadRequest)
+					return
 				}
+				cfg = weighted
+			} else if req.NPCCount != nil && req.GoblinCount == nil && req.RatCount == nil {
+				goblins := cfg.NPCCount
+				if goblins > 2 {
+					goblins = 2
+				}
+				if goblins < 0 {
+					goblins = 0
+				}
+				cfg.GoblinCount = goblins
+				rats := cfg.NPCCount - goblins
+				if rats < 0 {
+					rats = 0
+				}
+				cfg.RatCount = rats
 			}
 			if req.Lava != nil {
 				cfg.Lava = *req.Lava
@@ -140,11 +191,43 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 			if req.LavaCount != nil {
 				cfg.LavaCount = *req.LavaCount
 			}
+			if req.Stash != nil {
+				cfg.Stash = *req.Stash
+			}
+			if req.Wrap != nil {
+				cfg.Wrap = *req.Wrap
+			}
+			if req.PatrolRoutes != nil {
+				cfg.PatrolRoutes = *req.PatrolRoutes
+			}
+			if req.Waves != nil {
+				cfg.Waves = req.Waves
+			}
+			if req.Difficulty != nil {
+				cfg.Difficulty = *req.Difficulty
+			}
+			if req.LootBags != nil {
+				cfg.LootBags = *req.LootBags
+			}
+			if req.BossCount != nil {
+				cfg.BossCount = *req.BossCount
+			}
+			if req.Width != nil {
+				cfg.Width = *req.Width
+			}
+			if req.Height != nil {
+				cfg.Height = *req.Height
+			}
 			if req.Seed != nil {
 				cfg.Seed = *req.Seed
 			}
 		}
 
+		if err := hub.ValidateWorldConfig(cfg); err != nil {
+			httpError(w, err.Error(), nethttp.StatusBadRequest)
+			return
+		}
+
 		cfg = cfg.Normalized()
 
 		players, npcs := hub.ResetWorld(cfg)
@@ -169,13 +252,72 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 		w.Write(data)
 	})
 
+	mux.HandleFunc("/world/dump", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodGet {
+			httpError(w, "method not allowed", nethttp.StatusMethodNotAllowed)
+			return
+		}
+
+		data, err := hub.DumpWorld()
+		if err != nil {
+			httpError(w, "failed to encode", nethttp.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+
+	mux.HandleFunc("/world/load", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodPost {
+			httpError(w, "method not allowed", nethttp.StatusMethodNotAllowed)
+			return
+		}
+		if r.Body == nil {
+			httpError(w, "invalid payload", nethttp.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			httpError(w, "invalid payload", nethttp.StatusBadRequest)
+			return
+		}
+		if err := hub.LoadWorldDump(data); err != nil {
+			httpError(w, err.Error(), nethttp.StatusBadRequest)
+			return
+		}
+		hub.BroadcastState(nil, nil, nil, nil)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
 	mux.HandleFunc("/join", func(w nethttp.ResponseWriter, r *nethttp.Request) {
 		if r.Method != nethttp.MethodPost {
 			httpError(w, "method not allowed", nethttp.StatusMethodNotAllowed)
 			return
 		}
 
-		join := hub.Join()
+		target := hub
+		if name := r.URL.Query().Get("room"); name != "" && cfg.Rooms != nil {
+			resolved, err := cfg.Rooms.RoomWithPreset(name, r.URL.Query().Get("preset"))
+			if err != nil {
+				switch {
+				case errors.Is(err, server.ErrInvalidRoomName), errors.Is(err, server.ErrUnknownRoomPreset):
+					httpError(w, err.Error(), nethttp.StatusBadRequest)
+				case errors.Is(err, server.ErrRoomPresetConflict):
+					httpError(w, err.Error(), nethttp.StatusConflict)
+				default:
+					httpError(w, err.Error(), nethttp.StatusServiceUnavailable)
+				}
+				return
+			}
+			target = resolved
+		}
+
+		join := target.Join()
 		data, err := proto.EncodeJoinResponse(join)
 		if err != nil {
 			httpError(w, "failed to encode", nethttp.StatusInternalServerError)
@@ -258,6 +400,7 @@ func NewHTTPHandler(hub *server.Hub, cfg HTTPHandlerConfig) nethttp.Handler {
 
 	wsHandler := ws.NewHandler(hub, ws.HandlerConfig{
 		Logger: telemetryLogger,
+		Rooms:  cfg.Rooms,
 	})
 	mux.HandleFunc("/ws", wsHandler.Handle)
 
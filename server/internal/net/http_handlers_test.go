@@ -6,6 +6,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -190,6 +193,133 @@ func TestDiagnosticsIncludesSubscriberQueueTelemetry(t *testing.T) {
 	}
 }
 
+func TestMetricsRendersPrometheusExposition(t *testing.T) {
+	hub := server.NewHubWithConfig(server.DefaultHubConfig())
+	hub.RecordTelemetryBroadcast(128, 4)
+	handler := NewHTTPHandler(hub, HTTPHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status 200 OK, got %d", resp.Code)
+	}
+	if contentType := resp.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Fatalf("expected Prometheus text content type, got %q", contentType)
+	}
+
+	sample := regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[^}]*\})? (\S+)$`)
+	families := make(map[string]string)
+	values := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(resp.Body.String()), "\n") {
+		if strings.HasPrefix(line, "# HELP ") {
+			continue
+		}
+		if strings.HasPrefix(line, "# TYPE ") {
+			fields := strings.Fields(line)
+			if len(fields) != 4 {
+				t.Fatalf("malformed TYPE line %q", line)
+			}
+			families[fields[2]] = fields[3]
+			continue
+		}
+		match := sample.FindStringSubmatch(line)
+		if match == nil {
+			t.Fatalf("malformed sample line %q", line)
+		}
+		if _, err := strconv.ParseFloat(match[3], 64); err != nil {
+			t.Fatalf("sample %q has non-numeric value: %v", line, err)
+		}
+		name := match[1]
+		family := name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if base := strings.TrimSuffix(name, suffix); base != name && families[base] == "histogram" {
+				family = base
+			}
+		}
+		if _, ok := families[family]; !ok {
+			t.Fatalf("sample %q appears before its TYPE declaration", line)
+		}
+		values[name+match[2]] = match[3]
+	}
+
+	expected := map[string]string{
+		"telemetry_broadcast_total":          "counter",
+		"telemetry_broadcast_bytes_total":    "counter",
+		"telemetry_command_drops_total":      "counter",
+		"telemetry_effects_active_gauge":     "gauge",
+		"telemetry_tick_duration_seconds":    "histogram",
+		"telemetry_broadcast_entities_total": "counter",
+	}
+	for name, kind := range expected {
+		if families[name] != kind {
+			t.Fatalf("expected %s to be exported as a %s, got %q", name, kind, families[name])
+		}
+	}
+	if values["telemetry_broadcast_total"] != "1" || values["telemetry_broadcast_bytes_total"] != "128" {
+		t.Fatalf("expected broadcast counters to reflect one 128 byte broadcast, got %v", values)
+	}
+	if _, ok := values[`telemetry_tick_duration_seconds_bucket{le="+Inf"}`]; !ok {
+		t.Fatalf("expected tick duration histogram to include a +Inf bucket")
+	}
+	if _, ok := values["telemetry_tick_duration_seconds_count"]; !ok {
+		t.Fatalf("expected tick duration histogram to include a count sample")
+	}
+}
+
+func TestWorldResetRejectsOversizedWorld(t *testing.T) {
+	cfg := server.DefaultHubConfig()
+	cfg.MaxWorldSize = 4000
+	hub := server.NewHubWithConfig(cfg)
+	before := hub.CurrentConfig()
+
+	handler := NewHTTPHandler(hub, HTTPHandlerConfig{})
+
+	req := httptest.NewRequest(http.MethodPost, "/world/reset", strings.NewReader(`{"width":100000,"height":100000}`))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for oversized world, got %d", resp.Code)
+	}
+	if body := resp.Body.String(); !strings.Contains(body, "width") || !strings.Contains(body, "4000") {
+		t.Fatalf("expected error to name the width limit, got %q", body)
+	}
+	if after := hub.CurrentConfig(); after.Width != before.Width || after.Height != before.Height {
+		t.Fatalf("expected world to keep %gx%g, got %gx%g", before.Width, before.Height, after.Width, after.Height)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/world/reset", strings.NewReader(`{"width":3200,"height":1600}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected in-range reset to succeed, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if after := hub.CurrentConfig(); after.Width != 3200 || after.Height != 1600 {
+		t.Fatalf("expected world resized to 3200x1600, got %gx%g", after.Width, after.Height)
+	}
+}
+
+func TestWorldResetWeightsWithoutCountRedistributeCurrentNPCs(t *testing.T) {
+	hub := server.NewHubWithConfig(server.DefaultHubConfig())
+	handler := NewHTTPHandler(hub, HTTPHandlerConfig{})
+
+	for _, body := range []string{`{"goblinCount":2,"ratCount":2}`, `{"npcWeights":{"rat":1}}`} {
+		req := httptest.NewRequest(http.MethodPost, "/world/reset", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		if resp.Code != http.StatusOK {
+			t.Fatalf("expected reset %s to succeed, got %d: %s", body, resp.Code, resp.Body.String())
+		}
+	}
+
+	if cfg := hub.CurrentConfig(); cfg.GoblinCount != 0 || cfg.RatCount != 4 {
+		t.Fatalf("expected the four existing NPCs to become rats, got %d goblins and %d rats", cfg.GoblinCount, cfg.RatCount)
+	}
+}
+
 func TestDiagnosticsReportsSubscriberQueueOverflow(t *testing.T) {
 	hub := server.NewHubWithConfig(server.DefaultHubConfig())
 	join := hub.Join()
@@ -359,3 +489,57 @@ func (c *diagnosticsBlockingSubscriberConn) allow(tokens int) {
 		}
 	}
 }
+
+func TestJoinAttachesToRequestedRoom(t *testing.T) {
+	rooms := server.NewRoomManager(server.DefaultHubConfig(), 0)
+	defer rooms.Close()
+	lobby, err := rooms.Room(server.DefaultRoom)
+	if err != nil {
+		t.Fatalf("failed to create default room: %v", err)
+	}
+	handler := NewHTTPHandler(lobby, HTTPHandlerConfig{Rooms: rooms})
+
+	req := httptest.NewRequest(http.MethodPost, "/join?room=arena", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected join into arena to succeed, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var join struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &join); err != nil {
+		t.Fatalf("failed to decode join response: %v", err)
+	}
+
+	arena, ok := rooms.Lookup("arena")
+	if !ok {
+		t.Fatalf("expected join to create the arena room")
+	}
+	if !arena.HasPlayer(join.ID) {
+		t.Fatalf("expected %s to join arena", join.ID)
+	}
+	if lobby.HasPlayer(join.ID) {
+		t.Fatalf("expected %s to stay out of the default room", join.ID)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/join?room=duel&preset=arena", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected join with the arena preset to succeed, got %d: %s", resp.Code, resp.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodPost, "/join?room=duel&preset=dungeon", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusConflict {
+		t.Fatalf("expected a conflicting preset to be rejected with 409, got %d", resp.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/join?room=bad%20name", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected invalid room name to be rejected with 400, got %d", resp.Code)
+	}
+}
@@ -40,6 +40,10 @@ func StageClientCommand(ctx CommandContext, playerID string, msg proto.ClientMes
 		}
 		switch command.Action.Name {
 		case effectcontract.EffectIDAttack, effectcontract.EffectIDFireball:
+		case server.ActionEmote:
+			if !server.IsEmote(command.Action.Emote) {
+				return zero, false, server.CommandRejectInvalidAction
+			}
 		default:
 			return zero, false, server.CommandRejectInvalidAction
 		}
@@ -153,3 +153,22 @@ func TestStageClientCommandHandlesNilEngine(t *testing.T) {
 		t.Fatalf("expected reason %q, got %q", sim.CommandRejectQueueFull, reason)
 	}
 }
+
+func TestStageClientCommandValidatesEmoteName(t *testing.T) {
+	engine := &fakeEngine{enqueueOK: true}
+	ctx := CommandContext{Engine: engine}
+
+	msg := proto.ClientMessage{Type: proto.TypeAction, Action: server.ActionEmote, Emote: "wave"}
+	cmd, ok, reason := StageClientCommand(ctx, "player-1", msg)
+	if !ok {
+		t.Fatalf("expected emote to be accepted, got reason %q", reason)
+	}
+	if cmd.Action == nil || cmd.Action.Emote != "wave" {
+		t.Fatalf("expected emote name to be carried on the action, got %+v", cmd.Action)
+	}
+
+	msg.Emote = "moonwalk"
+	if _, ok, reason := StageClientCommand(ctx, "player-1", msg); ok || reason != server.CommandRejectInvalidAction {
+		t.Fatalf("expected unknown emote to be rejected as invalid, got ok=%v reason=%q", ok, reason)
+	}
+}
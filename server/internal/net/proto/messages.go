@@ -21,6 +21,11 @@ const (
 	typeState         = "state"
 	typeKeyframe      = "keyframe"
 	typeKeyframeNack  = "keyframeNack"
+	typeChat          = "chat"
+	typeChatReject    = "chatReject"
+	typeName          = "name"
+	typeNameReject    = "nameReject"
+	typeLootResult    = "lootResult"
 )
 
 // Client message type identifiers.
@@ -33,6 +38,9 @@ const (
 	TypeConsole         = "console"
 	TypeKeyframeReq     = "keyframeRequest"
 	TypeKeyframeCadence = "keyframeCadence"
+	TypeChat            = "chat"
+	TypeName            = "name"
+	TypeLoot            = "loot"
 )
 
 // Exported aliases for outbound message type identifiers.
@@ -110,21 +118,30 @@ func EncodeKeyframeNack(msg keyframeNack) ([]byte, error) {
 
 // ClientMessage captures an inbound websocket message from the client.
 type ClientMessage struct {
-	Ver              int     `json:"ver,omitempty"`
-	Type             string  `json:"type"`
-	DX               float64 `json:"dx"`
-	DY               float64 `json:"dy"`
-	Facing           string  `json:"facing"`
-	X                float64 `json:"x"`
-	Y                float64 `json:"y"`
-	SentAt           int64   `json:"sentAt"`
-	Action           string  `json:"action"`
-	Cmd              string  `json:"cmd"`
-	Qty              int     `json:"qty"`
-	Ack              *uint64 `json:"ack"`
-	KeyframeSeq      *uint64 `json:"keyframeSeq"`
-	KeyframeInterval *int    `json:"keyframeInterval,omitempty"`
-	CommandSeq       *uint64 `json:"seq,omitempty"`
+	Ver              int           `json:"ver,omitempty"`
+	Type             string        `json:"type"`
+	DX               float64       `json:"dx"`
+	DY               float64       `json:"dy"`
+	Facing           string        `json:"facing"`
+	X                float64       `json:"x"`
+	Y                float64       `json:"y"`
+	SentAt           int64         `json:"sentAt"`
+	Action           string        `json:"action"`
+	AimX             float64       `json:"aimX,omitempty"`
+	AimY             float64       `json:"aimY,omitempty"`
+	TargetID         string        `json:"targetId,omitempty"`
+	Cmd              string        `json:"cmd"`
+	Qty              int           `json:"qty"`
+	Items            []ConsoleItem `json:"items,omitempty"`
+	Ack              *uint64       `json:"ack"`
+	KeyframeSeq      *uint64       `json:"keyframeSeq"`
+	KeyframeInterval *int          `json:"keyframeInterval,omitempty"`
+	CommandSeq       *uint64       `json:"seq,omitempty"`
+	Text             string        `json:"text,omitempty"`
+	Emote            string        `json:"emote,omitempty"`
+	Name             string        `json:"name,omitempty"`
+	CorpseID         string        `json:"corpseId,omitempty"`
+	Slot             int           `json:"slot,omitempty"`
 }
 
 // DecodeClientMessage converts raw websocket payloads into a structured message.
@@ -173,7 +190,11 @@ func ClientCommand(msg ClientMessage) (sim.Command, bool) {
 		return sim.Command{
 			Type: sim.CommandAction,
 			Action: &sim.ActionCommand{
-				Name: msg.Action,
+				Name:     msg.Action,
+				AimX:     msg.AimX,
+				AimY:     msg.AimY,
+				TargetID: msg.TargetID,
+				Emote:    msg.Emote,
 			},
 		}, true
 	default:
@@ -279,6 +300,25 @@ type ConsoleAck struct {
 	Qty     int
 	StackID string
 	Slot    string
+	Effects []ConsoleEffect
+}
+
+// ConsoleEffect describes one active effect instance reported by the
+// list_effects console command.
+type ConsoleEffect struct {
+	ID             string  `json:"id"`
+	Type           string  `json:"type"`
+	Owner          string  `json:"owner,omitempty"`
+	TicksRemaining int     `json:"ticksRemaining"`
+	X              float64 `json:"x"`
+	Y              float64 `json:"y"`
+}
+
+// ConsoleItem is one item type and quantity requested by the bulk_drop
+// console command.
+type ConsoleItem struct {
+	Type string `json:"type"`
+	Qty  int    `json:"qty"`
 }
 
 // NewConsoleAck constructs a baseline acknowledgement for the given command.
@@ -289,14 +329,15 @@ func NewConsoleAck(cmd string) ConsoleAck {
 // EncodeConsoleAck renders a console command acknowledgement payload.
 func EncodeConsoleAck(msg ConsoleAck) ([]byte, error) {
 	frame := struct {
-		Ver     int    `json:"ver"`
-		Type    string `json:"type"`
-		Cmd     string `json:"cmd"`
-		Status  string `json:"status"`
-		Reason  string `json:"reason,omitempty"`
-		Qty     int    `json:"qty,omitempty"`
-		StackID string `json:"stackId,omitempty"`
-		Slot    string `json:"slot,omitempty"`
+		Ver     int             `json:"ver"`
+		Type    string          `json:"type"`
+		Cmd     string          `json:"cmd"`
+		Status  string          `json:"status"`
+		Reason  string          `json:"reason,omitempty"`
+		Qty     int             `json:"qty,omitempty"`
+		StackID string          `json:"stackId,omitempty"`
+		Slot    string          `json:"slot,omitempty"`
+		Effects []ConsoleEffect `json:"effects,omitempty"`
 	}{
 		Ver:     Version,
 		Type:    typeConsoleAck,
@@ -306,6 +347,112 @@ func EncodeConsoleAck(msg ConsoleAck) ([]byte, error) {
 		Qty:     msg.Qty,
 		StackID: msg.StackID,
 		Slot:    msg.Slot,
+		Effects: msg.Effects,
+	}
+	return json.Marshal(frame)
+}
+
+// ChatMessage is a chat line relayed to subscribers.
+type ChatMessage struct {
+	From string
+	Text string
+	Tick uint64
+}
+
+// EncodeChat renders a chat message payload.
+func EncodeChat(msg ChatMessage) ([]byte, error) {
+	frame := struct {
+		Ver  int    `json:"ver"`
+		Type string `json:"type"`
+		From string `json:"from"`
+		Text string `json:"text"`
+		Tick uint64 `json:"t"`
+	}{
+		Ver:  Version,
+		Type: typeChat,
+		From: msg.From,
+		Text: msg.Text,
+		Tick: msg.Tick,
+	}
+	return json.Marshal(frame)
+}
+
+// EncodeChatReject tells the sender why its chat message was not delivered.
+func EncodeChatReject(reason string) ([]byte, error) {
+	frame := struct {
+		Ver    int    `json:"ver"`
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}{
+		Ver:    Version,
+		Type:   typeChatReject,
+		Reason: reason,
+	}
+	return json.Marshal(frame)
+}
+
+// EncodeName renders the display name currently set for a player.
+func EncodeName(playerID, name string) ([]byte, error) {
+	frame := struct {
+		Ver  int    `json:"ver"`
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}{
+		Ver:  Version,
+		Type: typeName,
+		ID:   playerID,
+		Name: name,
+	}
+	return json.Marshal(frame)
+}
+
+// EncodeNameReject tells the player why a display name was refused.
+func EncodeNameReject(name, reason string) ([]byte, error) {
+	frame := struct {
+		Ver    int    `json:"ver"`
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	}{
+		Ver:    Version,
+		Type:   typeNameReject,
+		Name:   name,
+		Reason: reason,
+	}
+	return json.Marshal(frame)
+}
+
+// LootResult reports the outcome of a loot request against a corpse.
+type LootResult struct {
+	CorpseID string
+	Slot     int
+	Status   string
+	Reason   string
+	Item     string
+	Qty      int
+}
+
+// EncodeLootResult renders a loot result payload.
+func EncodeLootResult(msg LootResult) ([]byte, error) {
+	frame := struct {
+		Ver      int    `json:"ver"`
+		Type     string `json:"type"`
+		CorpseID string `json:"corpseId,omitempty"`
+		Slot     int    `json:"slot"`
+		Status   string `json:"status"`
+		Reason   string `json:"reason,omitempty"`
+		Item     string `json:"item,omitempty"`
+		Qty      int    `json:"qty,omitempty"`
+	}{
+		Ver:      Version,
+		Type:     typeLootResult,
+		CorpseID: msg.CorpseID,
+		Slot:     msg.Slot,
+		Status:   msg.Status,
+		Reason:   msg.Reason,
+		Item:     msg.Item,
+		Qty:      msg.Qty,
 	}
 	return json.Marshal(frame)
 }
@@ -322,7 +469,9 @@ type StateSnapshotV1 struct {
 	EffectUpdates    []simpatches.EffectUpdateEvent  `json:"effect_update,omitempty"`
 	EffectEnds       []simpatches.EffectEndEvent     `json:"effect_ended,omitempty"`
 	EffectSeqCursors map[string]simpatches.EffectSeq `json:"effect_seq_cursors,omitempty"`
+	ActiveEffects    []simpatches.EffectSpawnEvent   `json:"activeEffects,omitempty"`
 	GroundItems      []itemspkg.GroundItem           `json:"groundItems,omitempty"`
+	Corpses          []sim.Corpse                    `json:"corpses,omitempty"`
 	Patches          []simpatches.Patch              `json:"patches"`
 	Tick             uint64                          `json:"t"`
 	Sequence         uint64                          `json:"sequence"`
@@ -331,6 +480,14 @@ type StateSnapshotV1 struct {
 	Config           sim.WorldConfig                 `json:"config"`
 	Resync           bool                            `json:"resync,omitempty"`
 	KeyframeInterval int                             `json:"keyframeInterval,omitempty"`
+	InterestEnter    []string                        `json:"interestEnter,omitempty"`
+	InterestLeave    []string                        `json:"interestLeave,omitempty"`
+	// InterestPlayers, InterestNPCs and InterestGroundItems carry the full
+	// state of entities listed in InterestEnter so the client can rebuild
+	// them without waiting for a keyframe.
+	InterestPlayers     []sim.Player          `json:"interestPlayers,omitempty"`
+	InterestNPCs        []sim.NPC             `json:"interestNpcs,omitempty"`
+	InterestGroundItems []itemspkg.GroundItem `json:"interestGroundItems,omitempty"`
 }
 
 // ProtoStateSnapshot tags the struct as a websocket snapshot payload.
@@ -355,11 +512,13 @@ type JoinResponseV1 struct {
 	Obstacles         []sim.Obstacle        `json:"obstacles"`
 	EffectTriggers    []sim.EffectTrigger   `json:"effectTriggers,omitempty"`
 	GroundItems       []itemspkg.GroundItem `json:"groundItems,omitempty"`
+	Corpses           []sim.Corpse          `json:"corpses,omitempty"`
 	Patches           []simpatches.Patch    `json:"patches,omitempty"`
 	Config            sim.WorldConfig       `json:"config"`
 	Resync            bool                  `json:"resync"`
 	KeyframeInterval  int                   `json:"keyframeInterval,omitempty"`
 	EffectCatalogHash string                `json:"effectCatalogHash"`
+	ReconnectToken    string                `json:"reconnectToken,omitempty"`
 }
 
 // ProtoJoinResponse tags the struct as a websocket join response payload.
@@ -382,7 +541,11 @@ type KeyframeSnapshotV1 struct {
 	NPCs        []sim.NPC             `json:"npcs"`
 	Obstacles   []sim.Obstacle        `json:"obstacles"`
 	GroundItems []itemspkg.GroundItem `json:"groundItems"`
-	Config      sim.WorldConfig       `json:"config"`
+	Corpses     []sim.Corpse          `json:"corpses,omitempty"`
+	// ActiveEffects lists the effects alive at the keyframe tick as
+	// spawn-equivalent events.
+	ActiveEffects []simpatches.EffectSpawnEvent `json:"activeEffects,omitempty"`
+	Config        sim.WorldConfig               `json:"config"`
 }
 
 // ProtoKeyframeSnapshot tags the struct as a websocket keyframe payload.
@@ -0,0 +1,45 @@
+package ws
+
+// commandSeqWindow remembers which of the 64 command sequences up to the
+// newest acknowledged one have been seen, so a stale sequence can be told
+// apart as a duplicate or as one that arrived out of order. Sequences older
+// than the window count as duplicates.
+type commandSeqWindow struct {
+	newest uint64
+	// seen has bit i set when sequence newest-i was acknowledged.
+	seen uint64
+}
+
+// observe marks seq as acknowledged.
+func (w *commandSeqWindow) observe(seq uint64) {
+	if seq == 0 {
+		return
+	}
+	if seq > w.newest {
+		shift := seq - w.newest
+		if shift >= 64 {
+			w.seen = 0
+		} else {
+			w.seen <<= shift
+		}
+		w.seen |= 1
+		w.newest = seq
+		return
+	}
+	if offset := w.newest - seq; offset < 64 {
+		w.seen |= 1 << offset
+	}
+}
+
+// seenBefore reports whether seq, which must not be newer than the newest
+// observed sequence, was already acknowledged.
+func (w *commandSeqWindow) seenBefore(seq uint64) bool {
+	if seq > w.newest {
+		return false
+	}
+	offset := w.newest - seq
+	if offset >= 64 {
+		return true
+	}
+	return w.seen&(1<<offset) != 0
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	nethttp "net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -13,20 +14,26 @@ import (
 	"mine-and-die/server/internal/net/proto"
 	"mine-and-die/server/internal/sim"
 	"mine-and-die/server/internal/telemetry"
+	loggingnetwork "mine-and-die/server/logging/network"
 )
 
 type subscription interface {
 	Write(data []byte) error
 	LastCommandSeq() uint64
 	StoreLastCommandSeq(seq uint64)
+	QueueCommandAck(ack proto.CommandAck)
 }
 
 type HandlerConfig struct {
 	Logger telemetry.Logger
+	// Rooms, when set, lets clients pick a room with ?room=. The handler's
+	// hub serves connections that do not name one.
+	Rooms *server.RoomManager
 }
 
 type Handler struct {
 	hub      *server.Hub
+	rooms    *server.RoomManager
 	logger   telemetry.Logger
 	upgrader websocket.Upgrader
 }
@@ -56,6 +63,17 @@ func (c *websocketConn) Close() error {
 	return c.conn.Close()
 }
 
+// droppedWrite reports whether err is a timed-out write the hub dropped while
+// keeping the slow client connected, logging it if so. Any other write error
+// means the connection is gone.
+func (h *Handler) droppedWrite(playerID string, err error) bool {
+	if !errors.Is(err, server.ErrSubscriberWriteDropped) {
+		return false
+	}
+	h.logger.Printf("dropped write to slow client %s: %v", playerID, err)
+	return true
+}
+
 func NewHandler(hub *server.Hub, cfg HandlerConfig) *Handler {
 	logger := cfg.Logger
 	if logger == nil {
@@ -72,16 +90,45 @@ func NewHandler(hub *server.Hub, cfg HandlerConfig) *Handler {
 
 	return &Handler{
 		hub:      hub,
+		rooms:    cfg.Rooms,
 		logger:   logger,
 		upgrader: upgrader,
 	}
 }
 
 func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
+	hub := h.hub
+	if name := r.URL.Query().Get("room"); name != "" && h.rooms != nil {
+		resolved, ok := h.rooms.Lookup(name)
+		if !ok {
+			nethttp.Error(w, "unknown room", nethttp.StatusNotFound)
+			return
+		}
+		hub = resolved
+	}
+
+	// Spectators stream state without a player entity, so the id and token
+	// lookups are skipped and a spectator ID is allocated instead.
+	spectator, _ := strconv.ParseBool(r.URL.Query().Get("spectator"))
+	subscribe := hub.Subscribe
+
 	playerID := r.URL.Query().Get("id")
-	if playerID == "" {
-		nethttp.Error(w, "missing id", nethttp.StatusBadRequest)
-		return
+	if spectator {
+		playerID = hub.NextSpectatorID()
+		subscribe = hub.SubscribeSpectator
+	} else {
+		if token := r.URL.Query().Get("token"); token != "" {
+			resumed, err := hub.ResumeSession(token)
+			if err != nil {
+				nethttp.Error(w, err.Error(), nethttp.StatusGone)
+				return
+			}
+			playerID = resumed
+		}
+		if playerID == "" {
+			nethttp.Error(w, "missing id", nethttp.StatusBadRequest)
+			return
+		}
 	}
 
 	conn, err := h.upgrader.Upgrade(w, r, nil)
@@ -91,7 +138,7 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 	}
 
 	wrappedConn := &websocketConn{conn: conn}
-	sub, snapshotPlayers, snapshotNPCs, snapshotGroundItems, ok := h.hub.Subscribe(playerID, wrappedConn)
+	sub, snapshotPlayers, snapshotNPCs, snapshotGroundItems, ok := subscribe(playerID, wrappedConn)
 	if !ok {
 		message := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unknown player")
 		conn.WriteMessage(websocket.CloseMessage, message)
@@ -100,42 +147,44 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 	}
 
 	session := subscription(sub)
+	batchAcks := hub.BatchCommandAcks()
 
-	data, entities, err := h.hub.MarshalState(snapshotPlayers, snapshotNPCs, nil, snapshotGroundItems, false, true)
+	data, entities, err := hub.MarshalState(snapshotPlayers, snapshotNPCs, nil, snapshotGroundItems, false, true)
 	if err != nil {
 		h.logger.Printf("failed to marshal initial state for %s: %v", playerID, err)
-		players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+		players, npcs := hub.DisconnectSubscriber(playerID, sub)
 		if players != nil {
-			h.hub.ForceKeyframe()
-			h.hub.BroadcastState(players, npcs, nil, nil)
+			hub.ForceKeyframe()
+			hub.BroadcastState(players, npcs, nil, nil)
 		}
 		return
 	}
 
-	if err := session.Write(data); err != nil {
-		players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+	if err := session.Write(data); err != nil && !h.droppedWrite(playerID, err) {
+		players, npcs := hub.DisconnectSubscriber(playerID, sub)
 		if players != nil {
-			h.hub.ForceKeyframe()
-			h.hub.BroadcastState(players, npcs, nil, nil)
+			hub.ForceKeyframe()
+			hub.BroadcastState(players, npcs, nil, nil)
 		}
 		return
 	}
-	h.hub.RecordTelemetryBroadcast(len(data), entities)
+	hub.RecordTelemetryBroadcast(len(data), entities)
 
 	intakeCtx := intake.CommandContext{
-		Engine:    h.hub.Engine(),
-		HasPlayer: h.hub.HasPlayer,
-		Tick:      h.hub.Tick,
-		Now:       h.hub.Now,
+		Engine:    hub.Engine(),
+		HasPlayer: hub.HasPlayer,
+		Tick:      hub.Tick,
+		Now:       hub.Now,
 	}
+	var seqs commandSeqWindow
 
 	for {
 		_, payload, err := conn.ReadMessage()
 		if err != nil {
-			players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+			players, npcs := hub.DisconnectSubscriber(playerID, sub)
 			if players != nil {
-				h.hub.ForceKeyframe()
-				h.hub.BroadcastState(players, npcs, nil, nil)
+				hub.ForceKeyframe()
+				hub.BroadcastState(players, npcs, nil, nil)
 			}
 			return
 		}
@@ -147,7 +196,7 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 		}
 
 		if msg.Ack != nil {
-			h.hub.RecordAck(playerID, *msg.Ack)
+			hub.RecordAck(playerID, *msg.Ack)
 		}
 
 		normalizedSeq := uint64(0)
@@ -160,11 +209,11 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 				h.logger.Printf("failed to marshal response for %s: %v", playerID, err)
 				return true
 			}
-			if err := session.Write(data); err != nil {
-				players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+			if err := session.Write(data); err != nil && !h.droppedWrite(playerID, err) {
+				players, npcs := hub.DisconnectSubscriber(playerID, sub)
 				if players != nil {
-					h.hub.ForceKeyframe()
-					h.hub.BroadcastState(players, npcs, nil, nil)
+					hub.ForceKeyframe()
+					hub.BroadcastState(players, npcs, nil, nil)
 				}
 				return false
 			}
@@ -176,9 +225,23 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 				return true
 			}
 			ack := proto.CommandAck{Seq: normalizedSeq}
+			if batchAcks {
+				session.QueueCommandAck(ack)
+				return true
+			}
 			return writeMessage(proto.EncodeCommandAck(ack))
 		}
 
+		// acknowledgeCommandSeq records an acknowledged sequence, reporting
+		// a gap when it skips past sequences that never arrived.
+		acknowledgeCommandSeq := func() {
+			if last := session.LastCommandSeq(); last > 0 && normalizedSeq > last+1 {
+				hub.ReportCommandSeqAnomaly(playerID, loggingnetwork.CommandSeqGap, last, normalizedSeq)
+			}
+			seqs.observe(normalizedSeq)
+			session.StoreLastCommandSeq(normalizedSeq)
+		}
+
 		sendCommandAck := func(cmd sim.Command) bool {
 			if normalizedSeq == 0 {
 				return true
@@ -187,10 +250,15 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 			if cmd.OriginTick > 0 {
 				ack.Tick = cmd.OriginTick
 			}
+			if batchAcks {
+				session.QueueCommandAck(ack)
+				acknowledgeCommandSeq()
+				return true
+			}
 			if !writeMessage(proto.EncodeCommandAck(ack)) {
 				return false
 			}
-			session.StoreLastCommandSeq(normalizedSeq)
+			acknowledgeCommandSeq()
 			return true
 		}
 
@@ -210,8 +278,22 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 
 		switch msg.Type {
 		case proto.TypeInput, proto.TypePath, proto.TypeCancelPath, proto.TypeAction:
+			if spectator {
+				if !sendCommandReject(server.CommandRejectSpectator, false) {
+					return
+				}
+				continue
+			}
 			if normalizedSeq > 0 {
+				// Stale sequences are acknowledged again but never staged, so
+				// a command that arrives after a newer one cannot overwrite it.
 				if last := session.LastCommandSeq(); last > 0 && normalizedSeq <= last {
+					kind := loggingnetwork.CommandSeqDuplicate
+					if !seqs.seenBefore(normalizedSeq) {
+						kind = loggingnetwork.CommandSeqReordered
+						seqs.observe(normalizedSeq)
+					}
+					hub.ReportCommandSeqAnomaly(playerID, kind, last, normalizedSeq)
 					if !sendDuplicateAck() {
 						return
 					}
@@ -261,7 +343,7 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 		switch msg.Type {
 		case proto.TypeHeartbeat:
 			now := time.Now()
-			rtt, ok := h.hub.UpdateHeartbeat(playerID, now, msg.SentAt)
+			rtt, ok := hub.UpdateHeartbeat(playerID, now, msg.SentAt)
 			if !ok {
 				continue
 			}
@@ -275,18 +357,64 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 				return
 			}
 		case proto.TypeConsole:
-			ack, handled := h.hub.HandleConsoleCommand(playerID, msg.Cmd, msg.Qty)
+			if spectator {
+				continue
+			}
+			var ack proto.ConsoleAck
+			handled := true
+			if msg.Cmd == "bulk_drop" {
+				ack = hub.HandleBulkDrop(playerID, msg.Items)
+			} else {
+				ack, handled = hub.HandleConsoleCommand(playerID, msg.Cmd, msg.Qty)
+			}
 			if !handled {
 				continue
 			}
 			if !writeMessage(proto.EncodeConsoleAck(ack)) {
 				return
 			}
+		case proto.TypeLoot:
+			if spectator {
+				continue
+			}
+			if !writeMessage(proto.EncodeLootResult(hub.HandleCorpseLoot(playerID, msg.CorpseID, msg.Slot))) {
+				return
+			}
+		case proto.TypeChat:
+			reason, ok := hub.HandleChat(playerID, sub, msg.Text)
+			if ok {
+				continue
+			}
+			if !writeMessage(proto.EncodeChatReject(reason)) {
+				return
+			}
+		case proto.TypeName:
+			// An empty name queries the current display name.
+			if msg.Name == "" {
+				name, ok := hub.DisplayName(playerID)
+				if !ok {
+					continue
+				}
+				if !writeMessage(proto.EncodeName(playerID, name)) {
+					return
+				}
+				continue
+			}
+			name, reason, ok := hub.SetDisplayName(playerID, msg.Name)
+			if !ok {
+				if !writeMessage(proto.EncodeNameReject(msg.Name, reason)) {
+					return
+				}
+				continue
+			}
+			if !writeMessage(proto.EncodeName(playerID, name)) {
+				return
+			}
 		case proto.TypeKeyframeReq:
 			if msg.KeyframeSeq == nil {
 				continue
 			}
-			snapshot, nack, ok := h.hub.HandleKeyframeRequest(playerID, sub, *msg.KeyframeSeq)
+			snapshot, nack, ok := hub.HandleKeyframeRequest(playerID, sub, *msg.KeyframeSeq)
 			if !ok {
 				continue
 			}
@@ -296,11 +424,11 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 					h.logger.Printf("failed to marshal keyframe for %s: %v", playerID, err)
 					continue
 				}
-				if err := session.Write(data); err != nil {
-					players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+				if err := session.Write(data); err != nil && !h.droppedWrite(playerID, err) {
+					players, npcs := hub.DisconnectSubscriber(playerID, sub)
 					if players != nil {
-						h.hub.ForceKeyframe()
-						h.hub.BroadcastState(players, npcs, nil, nil)
+						hub.ForceKeyframe()
+						hub.BroadcastState(players, npcs, nil, nil)
 					}
 					return
 				}
@@ -311,20 +439,23 @@ func (h *Handler) Handle(w nethttp.ResponseWriter, r *nethttp.Request) {
 				h.logger.Printf("failed to marshal keyframe for %s: %v", playerID, err)
 				continue
 			}
-			if err := session.Write(data); err != nil {
-				players, npcs := h.hub.DisconnectSubscriber(playerID, sub)
+			if err := session.Write(data); err != nil && !h.droppedWrite(playerID, err) {
+				players, npcs := hub.DisconnectSubscriber(playerID, sub)
 				if players != nil {
-					h.hub.ForceKeyframe()
-					h.hub.BroadcastState(players, npcs, nil, nil)
+					hub.ForceKeyframe()
+					hub.BroadcastState(players, npcs, nil, nil)
 				}
 				return
 			}
 		case proto.TypeKeyframeCadence:
+			if spectator {
+				continue
+			}
 			requested := 0
 			if msg.KeyframeInterval != nil {
 				requested = *msg.KeyframeInterval
 			}
-			applied := h.hub.SetKeyframeInterval(requested)
+			applied := hub.SetKeyframeInterval(requested)
 			h.logger.Printf("[keyframe] player=%s requested cadence=%d", playerID, applied)
 		case proto.TypeInput, proto.TypePath, proto.TypeCancelPath, proto.TypeAction:
 			// Command messages without valid payloads were already ignored.
@@ -1,16 +1,23 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 
 	"mine-and-die/server"
 	"mine-and-die/server/internal/net/proto"
+	"mine-and-die/server/internal/sim"
+	"mine-and-die/server/logging"
+	loggingnetwork "mine-and-die/server/logging/network"
 )
 
 func TestHandleSubscribeInitialStateUsesSharedGroundItems(t *testing.T) {
@@ -194,3 +201,248 @@ func assertGroundItemPayload(t *testing.T, payload []byte, expectedID string, ex
 		t.Fatalf("expected qty %d, got %d", expectedQty, int(qtyValue))
 	}
 }
+
+func TestHandleBatchesCommandAcksUntilBroadcast(t *testing.T) {
+	cfg := server.DefaultHubConfig()
+	cfg.BatchCommandAcks = true
+	hub := server.NewHubWithConfig(cfg)
+	join := hub.Join()
+
+	handler := NewHandler(hub, HandlerConfig{})
+	srv := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	t.Cleanup(srv.Close)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(websocketURL(t, srv.URL, join.ID), nil)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		t.Fatalf("failed to open websocket connection: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+		if resp != nil {
+			resp.Body.Close()
+		}
+	})
+
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatalf("failed to read initial state: %v", err)
+	}
+
+	for seq := uint64(1); seq <= 3; seq++ {
+		input := map[string]any{"type": proto.TypeInput, "dx": 1, "dy": 0, "facing": "right", "seq": seq}
+		if err := conn.WriteJSON(input); err != nil {
+			t.Fatalf("failed to send input %d: %v", seq, err)
+		}
+	}
+	if err := conn.WriteJSON(map[string]any{"type": proto.TypeHeartbeat, "sentAt": 1}); err != nil {
+		t.Fatalf("failed to send heartbeat: %v", err)
+	}
+
+	// The heartbeat reply is written after every input was staged, so any
+	// unbatched ack would have arrived first.
+	if msgType := readFrameType(t, conn); msgType != proto.TypeHeartbeat {
+		t.Fatalf("expected heartbeat before any command ack, got %q", msgType)
+	}
+
+	hub.BroadcastState(nil, nil, nil, nil)
+
+	var ack struct {
+		Type string `json:"type"`
+		Seq  uint64 `json:"seq"`
+	}
+	_, payload, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read batched ack: %v", err)
+	}
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		t.Fatalf("failed to decode batched ack: %v", err)
+	}
+	if ack.Type != "commandAck" || ack.Seq != 3 {
+		t.Fatalf("expected a single commandAck for seq 3, got %s", payload)
+	}
+	if msgType := readFrameType(t, conn); msgType != proto.TypeState {
+		t.Fatalf("expected state broadcast after batched ack, got %q", msgType)
+	}
+}
+
+func TestHandleSpectatorStreamsStateButRejectsCommands(t *testing.T) {
+	hub := server.NewHubWithConfig(server.DefaultHubConfig())
+	join := hub.Join()
+
+	handler := NewHandler(hub, HandlerConfig{})
+	srv := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	t.Cleanup(srv.Close)
+
+	parsed, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server url: %v", err)
+	}
+	parsed.Scheme = "ws"
+	parsed.RawQuery = "spectator=1"
+	conn, resp, err := websocket.DefaultDialer.Dial(parsed.String(), nil)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		t.Fatalf("failed to open spectator websocket: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+		if resp != nil {
+			resp.Body.Close()
+		}
+	})
+
+	if msgType := readFrameType(t, conn); msgType != proto.TypeState {
+		t.Fatalf("expected initial state for spectator, got %q", msgType)
+	}
+
+	input := map[string]any{"type": proto.TypeInput, "dx": 1, "dy": 0, "facing": "right", "seq": 1}
+	if err := conn.WriteJSON(input); err != nil {
+		t.Fatalf("failed to send spectator input: %v", err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	var reject struct {
+		Type   string `json:"type"`
+		Seq    uint64 `json:"seq"`
+		Reason string `json:"reason"`
+	}
+	if err := conn.ReadJSON(&reject); err != nil {
+		t.Fatalf("failed to read command reject: %v", err)
+	}
+	if reject.Type != "commandReject" || reject.Seq != 1 || reject.Reason != server.CommandRejectSpectator {
+		t.Fatalf("expected spectator input to be rejected, got %+v", reject)
+	}
+
+	hub.BroadcastState(nil, nil, nil, nil)
+	if msgType := readFrameType(t, conn); msgType != proto.TypeState {
+		t.Fatalf("expected spectator to receive broadcast state, got %q", msgType)
+	}
+
+	if !hub.HasPlayer(join.ID) {
+		t.Fatalf("expected the joined player to be unaffected")
+	}
+	if hub.HasPlayer("spectator-1") {
+		t.Fatalf("expected spectator to have no player entity")
+	}
+}
+
+type commandSeqCapturePublisher struct {
+	mu       sync.Mutex
+	payloads []loggingnetwork.CommandSeqPayload
+}
+
+func (p *commandSeqCapturePublisher) Publish(_ context.Context, event logging.Event) {
+	if event.Type != loggingnetwork.EventCommandSeqAnomaly {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if payload, ok := event.Payload.(loggingnetwork.CommandSeqPayload); ok {
+		p.payloads = append(p.payloads, payload)
+	}
+}
+
+func TestHandleReorderedCommandSeqIsDroppedAndReported(t *testing.T) {
+	pub := &commandSeqCapturePublisher{}
+	hub := server.NewHubWithConfig(server.DefaultHubConfig(), pub)
+	join := hub.Join()
+
+	handler := NewHandler(hub, HandlerConfig{})
+	srv := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	t.Cleanup(srv.Close)
+
+	conn, resp, err := websocket.DefaultDialer.Dial(websocketURL(t, srv.URL, join.ID), nil)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		t.Fatalf("failed to open websocket connection: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+		if resp != nil {
+			resp.Body.Close()
+		}
+	})
+
+	if msgType := readFrameType(t, conn); msgType != proto.TypeState {
+		t.Fatalf("expected initial state, got %q", msgType)
+	}
+	hub.Engine().DrainCommands()
+
+	inputs := []struct {
+		seq    uint64
+		facing string
+	}{
+		{1, "right"},
+		{3, "down"},
+		{2, "left"},
+		{3, "down"},
+		{4, "up"},
+	}
+	for _, input := range inputs {
+		msg := map[string]any{"type": proto.TypeInput, "dx": 0, "dy": 0, "facing": input.facing, "seq": input.seq}
+		if err := conn.WriteJSON(msg); err != nil {
+			t.Fatalf("failed to send input %d: %v", input.seq, err)
+		}
+	}
+	if err := conn.WriteJSON(map[string]any{"type": proto.TypeHeartbeat, "sentAt": 1}); err != nil {
+		t.Fatalf("failed to send heartbeat: %v", err)
+	}
+	for i := 0; i < len(inputs); i++ {
+		if msgType := readFrameType(t, conn); msgType != "commandAck" {
+			t.Fatalf("expected every input to be acknowledged, got %q", msgType)
+		}
+	}
+	if msgType := readFrameType(t, conn); msgType != proto.TypeHeartbeat {
+		t.Fatalf("expected heartbeat after the acks, got %q", msgType)
+	}
+
+	var facings []sim.FacingDirection
+	for _, cmd := range hub.Engine().DrainCommands() {
+		if cmd.Move != nil {
+			facings = append(facings, cmd.Move.Facing)
+		}
+	}
+	if want := []sim.FacingDirection{"right", "down", "up"}; !reflect.DeepEqual(facings, want) {
+		t.Fatalf("expected the stale sequences to be dropped, staged %v want %v", facings, want)
+	}
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	want := []loggingnetwork.CommandSeqPayload{
+		{Kind: loggingnetwork.CommandSeqGap, Last: 1, Seq: 3},
+		{Kind: loggingnetwork.CommandSeqReordered, Last: 3, Seq: 2},
+		{Kind: loggingnetwork.CommandSeqDuplicate, Last: 3, Seq: 3},
+	}
+	if !reflect.DeepEqual(pub.payloads, want) {
+		t.Fatalf("unexpected command sequence events: got %+v want %+v", pub.payloads, want)
+	}
+}
+
+func readFrameType(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+	_, payload, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read websocket frame: %v", err)
+	}
+	var frame struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &frame); err != nil {
+		t.Fatalf("failed to decode websocket frame: %v", err)
+	}
+	return frame.Type
+}
@@ -0,0 +1,5 @@
+package sim
+
+// CollisionLayer is a bitmask of actor-separation layers mirrored from the
+// world state. Zero selects the default layer or, for masks, every layer.
+type CollisionLayer uint32
@@ -22,7 +22,13 @@ type MoveCommand struct {
 
 // ActionCommand identifies an ability or interaction trigger.
 type ActionCommand struct {
-	Name string `json:"name"`
+	Name string  `json:"name"`
+	AimX float64 `json:"aimX,omitempty"`
+	AimY float64 `json:"aimY,omitempty"`
+	// TargetID names the actor a targeted action applies to.
+	TargetID string `json:"targetId,omitempty"`
+	// Emote names the animation an emote action plays.
+	Emote string `json:"emote,omitempty"`
 }
 
 // PathCommand identifies a navigation target for A* pathfinding.
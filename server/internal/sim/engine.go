@@ -81,4 +81,8 @@ type LoopHooks struct {
 	// OnQueueWarning fires when the staged command count crosses warning
 	// thresholds. Callers can use this to emit backpressure logs.
 	OnQueueWarning func(length int)
+	// Paused reports whether the loop should skip advancing this tick.
+	// Commands keep buffering while paused and the skipped wall time is
+	// discarded so resuming does not trigger a catch-up burst.
+	Paused func() bool
 }
@@ -1,6 +1,10 @@
 package sim
 
-import "time"
+import (
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
 
 // Obstacle mirrors the legacy obstacle snapshot exposed via keyframes.
 type Obstacle struct {
@@ -24,21 +28,76 @@ type WorldConfig struct {
 	NPCCount       int     `json:"npcCount"`
 	Lava           bool    `json:"lava"`
 	LavaCount      int     `json:"lavaCount"`
+	Stash          bool    `json:"stash,omitempty"`
 	Seed           string  `json:"seed"`
 	Width          float64 `json:"width"`
 	Height         float64 `json:"height"`
+	Wrap           bool    `json:"wrap,omitempty"`
+	// PatrolRoutes mirrors the configured goblin patrol loops.
+	PatrolRoutes []PatrolRoute `json:"patrolRoutes,omitempty"`
+	// Waves mirrors the configured NPC respawn waves.
+	Waves *WaveConfig `json:"waves,omitempty"`
+	// Difficulty mirrors the NPC stat multiplier.
+	Difficulty float64 `json:"difficulty,omitempty"`
+	// LootBags mirrors whether defeated NPCs leave lootable corpses.
+	LootBags bool `json:"lootBags,omitempty"`
+	// BossCount mirrors how many boss NPCs the world seeds.
+	BossCount int `json:"bossCount,omitempty"`
+}
+
+// PatrolPoint is one stop on a configured patrol route.
+type PatrolPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// PatrolRoute mirrors a configured goblin patrol loop.
+type PatrolRoute struct {
+	Waypoints []PatrolPoint `json:"waypoints"`
+}
+
+// WaveConfig mirrors the configured NPC respawn waves.
+type WaveConfig struct {
+	DelaySeconds   float64 `json:"delaySeconds"`
+	GoblinsPerWave int     `json:"goblinsPerWave,omitempty"`
+	RatsPerWave    int     `json:"ratsPerWave,omitempty"`
+	MaxWaves       int     `json:"maxWaves,omitempty"`
+}
+
+// ScheduledTask is a world action deferred until a target tick. Tasks are
+// plain data so pending work survives in keyframes; the world maps Kind back to
+// the handler that runs it.
+type ScheduledTask struct {
+	ID      uint64             `json:"id"`
+	Tick    uint64             `json:"tick"`
+	Kind    string             `json:"kind"`
+	ActorID string             `json:"actorId,omitempty"`
+	Params  map[string]float64 `json:"params,omitempty"`
+}
+
+// RNGState is the position of the world's deterministic RNG stream: the seed
+// value the stream started from and the number of values drawn since.
+type RNGState struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
 }
 
 // Keyframe captures the immutable state snapshot stored in the journal.
 type Keyframe struct {
-	Tick        uint64       `json:"tick"`
-	Sequence    uint64       `json:"sequence"`
-	Players     []Player     `json:"players,omitempty"`
-	NPCs        []NPC        `json:"npcs,omitempty"`
-	Obstacles   []Obstacle   `json:"obstacles,omitempty"`
-	GroundItems []GroundItem `json:"groundItems,omitempty"`
-	Config      WorldConfig  `json:"config"`
-	RecordedAt  time.Time    `json:"recordedAt"`
+	Tick           uint64          `json:"tick"`
+	Sequence       uint64          `json:"sequence"`
+	Players        []Player        `json:"players,omitempty"`
+	NPCs           []NPC           `json:"npcs,omitempty"`
+	Obstacles      []Obstacle      `json:"obstacles,omitempty"`
+	GroundItems    []GroundItem    `json:"groundItems,omitempty"`
+	Corpses        []Corpse        `json:"corpses,omitempty"`
+	ScheduledTasks []ScheduledTask `json:"scheduledTasks,omitempty"`
+	RNG            *RNGState       `json:"rng,omitempty"`
+	// ActiveEffects holds spawn-equivalent events for effects alive at the
+	// keyframe tick so a resubscribing client sees in-flight effects.
+	ActiveEffects []effectcontract.EffectSpawnEvent `json:"activeEffects,omitempty"`
+	Config        WorldConfig                       `json:"config"`
+	RecordedAt    time.Time                         `json:"recordedAt"`
 }
 
 // KeyframeEviction describes a keyframe removed from the buffer and why it was dropped.
@@ -278,6 +278,10 @@ func (l *Loop) Run(stop <-chan struct{}) {
 			return
 		case <-ticker.C:
 			now := clock.Now()
+			if l.hooks.Paused != nil && l.hooks.Paused() {
+				last = now
+				continue
+			}
 			dt := now.Sub(last).Seconds()
 			clamped := false
 			if dt <= 0 {
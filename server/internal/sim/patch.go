@@ -10,6 +10,7 @@ const (
 	PatchPlayerHealth    PatchKind = "player_health"
 	PatchPlayerInventory PatchKind = "player_inventory"
 	PatchPlayerEquipment PatchKind = "player_equipment"
+	PatchPlayerAbsorb    PatchKind = "player_absorb"
 	PatchPlayerRemoved   PatchKind = "player_removed"
 
 	PatchNPCPos       PatchKind = "npc_pos"
@@ -23,6 +24,10 @@ const (
 
 	PatchGroundItemPos PatchKind = "ground_item_pos"
 	PatchGroundItemQty PatchKind = "ground_item_qty"
+
+	PatchCorpseAdded    PatchKind = "corpse_added"
+	PatchCorpseContents PatchKind = "corpse_contents"
+	PatchCorpseRemoved  PatchKind = "corpse_removed"
 )
 
 // Patch represents a diff entry that can be applied to the client state.
@@ -79,6 +84,14 @@ type PlayerHealthPayload = HealthPayload
 // NPCHealthPayload captures the health for an NPC patch.
 type NPCHealthPayload = HealthPayload
 
+// AbsorbPayload captures the remaining absorb pool for an entity patch.
+type AbsorbPayload struct {
+	Absorb float64 `json:"absorb"`
+}
+
+// PlayerAbsorbPayload captures the absorb pool for a player patch.
+type PlayerAbsorbPayload = AbsorbPayload
+
 // InventoryPayload captures the inventory slots for an entity patch.
 type InventoryPayload struct {
 	Slots []InventorySlot `json:"slots"`
@@ -110,3 +123,9 @@ type EffectParamsPayload struct {
 type GroundItemQtyPayload struct {
 	Qty int `json:"qty"`
 }
+
+// CorpseAddedPayload carries the full corpse for a corpse added patch.
+type CorpseAddedPayload = Corpse
+
+// CorpseContentsPayload captures the remaining slots for a corpse patch.
+type CorpseContentsPayload = InventoryPayload
@@ -103,6 +103,12 @@ func ApplyPlayers(base map[string]PlayerView, patches []sim.Patch) (map[string]P
 				return nil, fmt.Errorf("apply patches: unexpected payload %T for %q", patch.Payload, patch.Kind)
 			}
 			view.Player.Equipment = itemspkg.EquipmentFromSimSlots(payload.Slots)
+		case sim.PatchPlayerAbsorb:
+			payload, ok := payloadAsPlayerAbsorb(patch.Payload)
+			if !ok {
+				return nil, fmt.Errorf("apply patches: unexpected payload %T for %q", patch.Payload, patch.Kind)
+			}
+			view.Player.Absorb = payload.Absorb
 		default:
 			return nil, fmt.Errorf("apply patches: unsupported patch kind %q", patch.Kind)
 		}
@@ -155,6 +161,20 @@ func payloadAsPlayerIntent(value any) (sim.PlayerIntentPayload, bool) {
 	}
 }
 
+func payloadAsPlayerAbsorb(value any) (sim.PlayerAbsorbPayload, bool) {
+	switch v := value.(type) {
+	case sim.PlayerAbsorbPayload:
+		return v, true
+	case *sim.PlayerAbsorbPayload:
+		if v == nil {
+			return sim.PlayerAbsorbPayload{}, false
+		}
+		return *v, true
+	default:
+		return sim.PlayerAbsorbPayload{}, false
+	}
+}
+
 func payloadAsPlayerHealth(value any) (sim.PlayerHealthPayload, bool) {
 	switch v := value.(type) {
 	case sim.PlayerHealthPayload:
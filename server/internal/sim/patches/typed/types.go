@@ -15,6 +15,7 @@ const (
 	PatchPlayerHealth    = sim.PatchPlayerHealth
 	PatchPlayerInventory = sim.PatchPlayerInventory
 	PatchPlayerEquipment = sim.PatchPlayerEquipment
+	PatchPlayerAbsorb    = sim.PatchPlayerAbsorb
 	PatchPlayerRemoved   = sim.PatchPlayerRemoved
 
 	PatchNPCPos       = sim.PatchNPCPos
@@ -28,6 +29,10 @@ const (
 
 	PatchGroundItemPos = sim.PatchGroundItemPos
 	PatchGroundItemQty = sim.PatchGroundItemQty
+
+	PatchCorpseAdded    = sim.PatchCorpseAdded
+	PatchCorpseContents = sim.PatchCorpseContents
+	PatchCorpseRemoved  = sim.PatchCorpseRemoved
 )
 
 type Patch = sim.Patch
@@ -58,6 +63,10 @@ type NPCHealthPayload = sim.NPCHealthPayload
 
 type InventoryPayload = sim.InventoryPayload
 
+type AbsorbPayload = sim.AbsorbPayload
+
+type PlayerAbsorbPayload = sim.PlayerAbsorbPayload
+
 type PlayerInventoryPayload = sim.PlayerInventoryPayload
 
 type NPCInventoryPayload = sim.NPCInventoryPayload
@@ -4,19 +4,25 @@ import itemsapi "mine-and-die/server/internal/items/api"
 
 // Actor captures the shared state for any living entity in the world.
 type Actor struct {
-	ID        string          `json:"id"`
-	X         float64         `json:"x"`
-	Y         float64         `json:"y"`
-	Facing    FacingDirection `json:"facing"`
-	Health    float64         `json:"health"`
-	MaxHealth float64         `json:"maxHealth"`
-	Inventory Inventory       `json:"inventory"`
-	Equipment Equipment       `json:"equipment"`
+	ID             string          `json:"id"`
+	X              float64         `json:"x"`
+	Y              float64         `json:"y"`
+	Facing         FacingDirection `json:"facing"`
+	Health         float64         `json:"health"`
+	MaxHealth      float64         `json:"maxHealth"`
+	Absorb         float64         `json:"absorb,omitempty"`
+	Inventory      Inventory       `json:"inventory"`
+	Equipment      Equipment       `json:"equipment"`
+	CollisionLayer CollisionLayer  `json:"collisionLayer,omitempty"`
+	CollisionMask  CollisionLayer  `json:"collisionMask,omitempty"`
+	InCombat       bool            `json:"inCombat,omitempty"`
+	LastDamagedBy  string          `json:"lastDamagedBy,omitempty"`
 }
 
 // Player mirrors the actor state for human-controlled characters.
 type Player struct {
 	Actor
+	Name     string  `json:"name,omitempty"`
 	IntentDX float64 `json:"intentDX,omitempty"`
 	IntentDY float64 `json:"intentDY,omitempty"`
 }
@@ -25,8 +31,10 @@ type Player struct {
 type NPCType string
 
 const (
-	NPCTypeGoblin NPCType = "goblin"
-	NPCTypeRat    NPCType = "rat"
+	NPCTypeGoblin   NPCType = "goblin"
+	NPCTypeRat      NPCType = "rat"
+	NPCTypeBoss     NPCType = "boss"
+	NPCTypeFamiliar NPCType = "familiar"
 )
 
 // NPC describes an AI-controlled entity mirrored to the client.
@@ -49,16 +57,32 @@ type EffectTrigger struct {
 	Height   float64            `json:"height,omitempty"`
 	Params   map[string]float64 `json:"params,omitempty"`
 	Colors   []string           `json:"colors,omitempty"`
+	// SourceEffectID and TargetID identify hit triggers so repeated overlaps
+	// of the same effect and target within a tick collapse to one trigger.
+	SourceEffectID string `json:"sourceEffectId,omitempty"`
+	TargetID       string `json:"targetId,omitempty"`
 }
 
 // GroundItem mirrors the shared ground item stack exposed to callers.
 type GroundItem = itemsapi.GroundItem
 
+// Corpse is a lootable bag left where an NPC died. OwnerID names the player
+// it is reserved for until the loot lock lapses.
+type Corpse struct {
+	ID        string    `json:"id"`
+	NPCType   string    `json:"npcType"`
+	X         float64   `json:"x"`
+	Y         float64   `json:"y"`
+	Inventory Inventory `json:"inventory"`
+	OwnerID   string    `json:"ownerId,omitempty"`
+}
+
 // Snapshot captures the state exposed to non-simulation callers.
 type Snapshot struct {
 	Players        []Player        `json:"players,omitempty"`
 	NPCs           []NPC           `json:"npcs,omitempty"`
 	GroundItems    []GroundItem    `json:"groundItems,omitempty"`
+	Corpses        []Corpse        `json:"corpses,omitempty"`
 	EffectEvents   []EffectTrigger `json:"effectTriggers,omitempty"`
 	Obstacles      []Obstacle      `json:"obstacles,omitempty"`
 	AliveEffectIDs []string        `json:"aliveEffectIDs,omitempty"`
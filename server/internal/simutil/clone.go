@@ -85,6 +85,38 @@ func CloneObstacles(obstacles []sim.Obstacle) []sim.Obstacle {
 	return cloned
 }
 
+// CloneCorpses returns a deep copy of the provided corpse slice.
+func CloneCorpses(corpses []sim.Corpse) []sim.Corpse {
+	if len(corpses) == 0 {
+		return nil
+	}
+	cloned := make([]sim.Corpse, len(corpses))
+	for i, corpse := range corpses {
+		cloned[i] = corpse
+		cloned[i].Inventory = CloneInventory(corpse.Inventory)
+	}
+	return cloned
+}
+
+// CloneScheduledTasks returns a deep copy of the provided scheduled task slice.
+func CloneScheduledTasks(tasks []sim.ScheduledTask) []sim.ScheduledTask {
+	if len(tasks) == 0 {
+		return nil
+	}
+	cloned := make([]sim.ScheduledTask, len(tasks))
+	for i, task := range tasks {
+		cloned[i] = task
+		if task.Params != nil {
+			params := make(map[string]float64, len(task.Params))
+			for key, value := range task.Params {
+				params[key] = value
+			}
+			cloned[i].Params = params
+		}
+	}
+	return cloned
+}
+
 // ClonePatches returns a deep copy of the provided patch slice.
 func ClonePatches(patches []sim.Patch) []sim.Patch {
 	if len(patches) == 0 {
@@ -138,6 +170,14 @@ func ClonePatchPayload(payload any) any {
 		}
 		cloned := *value
 		return cloned
+	case sim.AbsorbPayload:
+		return value
+	case *sim.AbsorbPayload:
+		if value == nil {
+			return nil
+		}
+		cloned := *value
+		return cloned
 	case sim.InventoryPayload:
 		return itemspkg.SimInventoryPayloadFromSlots[sim.InventorySlot, sim.InventoryPayload](itemspkg.CloneInventorySlots(value.Slots))
 	case *sim.InventoryPayload:
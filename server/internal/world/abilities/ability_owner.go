@@ -90,17 +90,20 @@ func NewAbilityOwnerLookup[State any, Owner any](cfg AbilityOwnerLookupConfig[St
 // closure required to construct an ability gate without importing the combat
 // package. Callers pass the returned closure into their gate factory.
 type AbilityGateConfig[Owner any] struct {
-	AbilityID   string
-	Cooldown    time.Duration
-	LookupOwner func(actorID string) (*Owner, *map[string]time.Time, bool)
+	AbilityID         string
+	Cooldown          time.Duration
+	CooldownReduction func(actorID string) float64
+	LookupOwner       func(actorID string) (*Owner, *map[string]time.Time, bool)
 }
 
 // AbilityGateOptions bundles the ability metadata and lookup adapter required to
-// construct an ability gate configuration.
+// construct an ability gate configuration. CooldownReduction optionally reports
+// the fraction of Cooldown an actor skips.
 type AbilityGateOptions[State any, Owner any] struct {
-	AbilityID string
-	Cooldown  time.Duration
-	Lookup    AbilityOwnerLookup[State, Owner]
+	AbilityID         string
+	Cooldown          time.Duration
+	CooldownReduction func(actorID string) float64
+	Lookup            AbilityOwnerLookup[State, Owner]
 }
 
 // NewMeleeAbilityGateConfig constructs a melee ability gate configuration using
@@ -122,8 +125,9 @@ func newAbilityGateConfig[State any, Owner any](opts AbilityGateOptions[State, O
 
 	lookup := opts.Lookup
 	cfg := AbilityGateConfig[Owner]{
-		AbilityID: opts.AbilityID,
-		Cooldown:  opts.Cooldown,
+		AbilityID:         opts.AbilityID,
+		Cooldown:          opts.Cooldown,
+		CooldownReduction: opts.CooldownReduction,
 		LookupOwner: func(actorID string) (*Owner, *map[string]time.Time, bool) {
 			if lookup == nil {
 				return nil, nil, false
@@ -68,9 +68,10 @@ func bindMeleeAbilityGate(
 		AbilityGateOptions: opts,
 		Factory: func(cfg abilitiespkg.AbilityGateConfig[AbilityActorSnapshot]) (combat.MeleeAbilityGate, bool) {
 			constructed := combat.NewMeleeAbilityGate(combat.MeleeAbilityGateConfig{
-				AbilityID:   cfg.AbilityID,
-				Cooldown:    cfg.Cooldown,
-				LookupOwner: wrapAbilityOwnerLookup(cfg.LookupOwner),
+				AbilityID:         cfg.AbilityID,
+				Cooldown:          cfg.Cooldown,
+				CooldownReduction: cfg.CooldownReduction,
+				LookupOwner:       wrapAbilityOwnerLookup(cfg.LookupOwner),
 			})
 			if constructed == nil {
 				return nil, false
@@ -91,9 +92,10 @@ func bindProjectileAbilityGate(
 		AbilityGateOptions: opts,
 		Factory: func(cfg abilitiespkg.AbilityGateConfig[AbilityActorSnapshot]) (combat.ProjectileAbilityGate, bool) {
 			constructed := combat.NewProjectileAbilityGate(combat.ProjectileAbilityGateConfig{
-				AbilityID:   cfg.AbilityID,
-				Cooldown:    cfg.Cooldown,
-				LookupOwner: wrapAbilityOwnerLookup(cfg.LookupOwner),
+				AbilityID:         cfg.AbilityID,
+				Cooldown:          cfg.Cooldown,
+				CooldownReduction: cfg.CooldownReduction,
+				LookupOwner:       wrapAbilityOwnerLookup(cfg.LookupOwner),
 			})
 			if constructed == nil {
 				return nil, false
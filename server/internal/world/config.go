@@ -1,13 +1,33 @@
 package world
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
 
 const (
 	DefaultSeed   = "prototype"
 	DefaultWidth  = 100.0
 	DefaultHeight = 100.0
+
+	// MinDimension and MaxDimension bound the world width and height. The
+	// lower bound is a single navigation cell; the upper bound keeps the nav
+	// grid rebuilt on every reset to about a hundred thousand cells.
+	MinDimension = NavCellSize
+	MaxDimension = 10000.0
+
+	// DefaultCombatTimeoutSeconds is how long an actor stays in combat after
+	// its last damaging hit when the world does not override it.
+	DefaultCombatTimeoutSeconds = 5.0
 )
 
+// ErrWorldSizeOutOfRange reports a requested width or height outside the
+// allowed bounds.
+var ErrWorldSizeOutOfRange = errors.New("world size out of range")
+
 type Config struct {
 	Obstacles      bool    `json:"obstacles"`
 	ObstaclesCount int     `json:"obstaclesCount"`
@@ -19,9 +39,86 @@ type Config struct {
 	NPCCount       int     `json:"npcCount"`
 	Lava           bool    `json:"lava"`
 	LavaCount      int     `json:"lavaCount"`
+	Stash          bool    `json:"stash"`
 	Seed           string  `json:"seed"`
 	Width          float64 `json:"width"`
 	Height         float64 `json:"height"`
+	Wrap           bool    `json:"wrap"`
+	// PatrolRoutes pins seeded goblins, in spawn order, to fixed waypoint
+	// loops in place of the generated ones.
+	PatrolRoutes []PatrolRoute `json:"patrolRoutes,omitempty"`
+	// Waves respawns the seeded NPCs in growing waves once they have all
+	// been defeated. Nil leaves defeated NPCs gone for good.
+	Waves *WaveConfig `json:"waves,omitempty"`
+	// Difficulty scales the max health and damage of wild NPCs spawned in
+	// the world. Zero selects the baseline of 1.
+	Difficulty float64 `json:"difficulty,omitempty"`
+	// LootBags leaves a single lootable corpse holding a defeated NPC's
+	// inventory in place of scattered ground stacks.
+	LootBags bool `json:"lootBags,omitempty"`
+	// BossCount seeds that many boss NPCs on a ring around the centre of the
+	// map when NPCs are enabled.
+	BossCount int `json:"bossCount,omitempty"`
+
+	// Tunables below can be changed on a live world without a reset.
+
+	// LavaDamagePerSecond overrides the burning damage rate. Zero selects
+	// the LavaDamagePerSecond default.
+	LavaDamagePerSecond float64 `json:"lavaDamagePerSecond,omitempty"`
+	// RegenDelaySeconds is how long a player must go without dealing or
+	// taking damage before health regeneration starts. Zero disables
+	// out-of-combat regeneration.
+	RegenDelaySeconds float64 `json:"regenDelaySeconds,omitempty"`
+	// CombatTimeoutSeconds is how long an actor stays flagged in combat
+	// after its last damaging hit. Zero selects DefaultCombatTimeoutSeconds.
+	CombatTimeoutSeconds float64 `json:"combatTimeoutSeconds,omitempty"`
+}
+
+// PatrolPoint is one stop on a configured patrol route.
+type PatrolPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// PatrolRoute is a closed loop of waypoints walked by one seeded goblin while
+// it is not aggroed. The goblin spawns on the first waypoint.
+type PatrolRoute struct {
+	Waypoints []PatrolPoint `json:"waypoints"`
+}
+
+// Vec2s returns the route's waypoints as world vectors.
+func (r PatrolRoute) Vec2s() []Vec2 {
+	if len(r.Waypoints) == 0 {
+		return nil
+	}
+	points := make([]Vec2, len(r.Waypoints))
+	for i, point := range r.Waypoints {
+		points[i] = Vec2{X: point.X, Y: point.Y}
+	}
+	return points
+}
+
+// WaveConfig describes how defeated NPCs come back in waves. The seeded NPCs
+// form wave zero; wave n brings n times the per-wave increments on top of the
+// seeded goblin and rat counts.
+type WaveConfig struct {
+	// DelaySeconds is how long after the last wild NPC falls the next wave
+	// spawns.
+	DelaySeconds   float64 `json:"delaySeconds"`
+	GoblinsPerWave int     `json:"goblinsPerWave,omitempty"`
+	RatsPerWave    int     `json:"ratsPerWave,omitempty"`
+	// MaxWaves stops respawning after that many waves. Zero never stops.
+	MaxWaves int `json:"maxWaves,omitempty"`
+}
+
+// WaveComposition reports how many goblins and rats wave number wave spawns.
+func (cfg Config) WaveComposition(wave int) (goblins, rats int) {
+	goblins, rats = cfg.GoblinCount, cfg.RatCount
+	if cfg.Waves != nil && wave > 0 {
+		goblins += wave * cfg.Waves.GoblinsPerWave
+		rats += wave * cfg.Waves.RatsPerWave
+	}
+	return goblins, rats
 }
 
 func (cfg Config) normalized() Config {
@@ -45,18 +142,58 @@ func (cfg Config) normalized() Config {
 	if normalized.NPCCount < 0 {
 		normalized.NPCCount = 0
 	}
+	if normalized.BossCount < 0 {
+		normalized.BossCount = 0
+	}
 	if normalized.LavaCount < 0 {
 		normalized.LavaCount = 0
 	}
+	if normalized.Difficulty <= 0 || math.IsNaN(normalized.Difficulty) || math.IsInf(normalized.Difficulty, 0) {
+		normalized.Difficulty = 0
+	}
+	if normalized.LavaDamagePerSecond < 0 || math.IsNaN(normalized.LavaDamagePerSecond) || math.IsInf(normalized.LavaDamagePerSecond, 0) {
+		normalized.LavaDamagePerSecond = 0
+	}
+	if normalized.RegenDelaySeconds < 0 || math.IsNaN(normalized.RegenDelaySeconds) || math.IsInf(normalized.RegenDelaySeconds, 0) {
+		normalized.RegenDelaySeconds = 0
+	}
+	if normalized.CombatTimeoutSeconds < 0 || math.IsNaN(normalized.CombatTimeoutSeconds) || math.IsInf(normalized.CombatTimeoutSeconds, 0) {
+		normalized.CombatTimeoutSeconds = 0
+	}
 	totalSpecies := normalized.GoblinCount + normalized.RatCount
 	if totalSpecies > 0 {
 		normalized.NPCCount = totalSpecies
 	}
-	if normalized.Width <= 0 {
-		normalized.Width = DefaultWidth
+	normalized.Width = clampDimension(normalized.Width, DefaultWidth)
+	normalized.Height = clampDimension(normalized.Height, DefaultHeight)
+	if len(normalized.PatrolRoutes) > 0 {
+		routes := make([]PatrolRoute, 0, len(normalized.PatrolRoutes))
+		for _, route := range normalized.PatrolRoutes {
+			if len(route.Waypoints) == 0 {
+				continue
+			}
+			routes = append(routes, PatrolRoute{Waypoints: append([]PatrolPoint(nil), route.Waypoints...)})
+		}
+		normalized.PatrolRoutes = routes
+	}
+	if len(normalized.PatrolRoutes) == 0 {
+		normalized.PatrolRoutes = nil
 	}
-	if normalized.Height <= 0 {
-		normalized.Height = DefaultHeight
+	if normalized.Waves != nil {
+		waves := *normalized.Waves
+		if waves.DelaySeconds < 0 || math.IsNaN(waves.DelaySeconds) || math.IsInf(waves.DelaySeconds, 0) {
+			waves.DelaySeconds = 0
+		}
+		if waves.GoblinsPerWave < 0 {
+			waves.GoblinsPerWave = 0
+		}
+		if waves.RatsPerWave < 0 {
+			waves.RatsPerWave = 0
+		}
+		if waves.MaxWaves < 0 {
+			waves.MaxWaves = 0
+		}
+		normalized.Waves = &waves
 	}
 	return normalized
 }
@@ -65,6 +202,81 @@ func (cfg Config) Normalized() Config {
 	return cfg.normalized()
 }
 
+// DifficultyScale returns the NPC stat multiplier, falling back to 1 when the
+// difficulty is unset.
+func (cfg Config) DifficultyScale() float64 {
+	if cfg.Difficulty > 0 {
+		return cfg.Difficulty
+	}
+	return 1
+}
+
+// LavaDamageRate returns the burning damage applied per second, falling back
+// to LavaDamagePerSecond when the tunable is unset.
+func (cfg Config) LavaDamageRate() float64 {
+	if cfg.LavaDamagePerSecond > 0 {
+		return cfg.LavaDamagePerSecond
+	}
+	return LavaDamagePerSecond
+}
+
+// CombatTimeout returns how many seconds an actor stays in combat after its
+// last damaging hit, falling back to DefaultCombatTimeoutSeconds when the
+// tunable is unset.
+func (cfg Config) CombatTimeout() float64 {
+	if cfg.CombatTimeoutSeconds > 0 {
+		return cfg.CombatTimeoutSeconds
+	}
+	return DefaultCombatTimeoutSeconds
+}
+
+// WithTunables returns cfg with its tunable fields copied from src. The
+// structural fields that shape world generation are left untouched.
+func (cfg Config) WithTunables(src Config) Config {
+	result := cfg
+	result.LavaDamagePerSecond = src.LavaDamagePerSecond
+	result.RegenDelaySeconds = src.RegenDelaySeconds
+	result.CombatTimeoutSeconds = src.CombatTimeoutSeconds
+	return result
+}
+
+// SameStructure reports whether cfg and other generate the same world, i.e.
+// whether they differ only in tunables that can be applied without a reset.
+func (cfg Config) SameStructure(other Config) bool {
+	return reflect.DeepEqual(cfg.WithTunables(Config{}), other.WithTunables(Config{}))
+}
+
+// clampDimension falls back to the default for unset or invalid values and
+// pins everything else into [MinDimension, MaxDimension].
+func clampDimension(value, fallback float64) float64 {
+	if value <= 0 || math.IsNaN(value) {
+		return fallback
+	}
+	return math.Min(math.Max(value, MinDimension), MaxDimension)
+}
+
+// ValidateSize rejects a width or height outside [MinDimension, max] so
+// callers can refuse a request instead of silently clamping it. Unset
+// dimensions pass. A max outside (0, MaxDimension] selects MaxDimension.
+func (cfg Config) ValidateSize(max float64) error {
+	if max <= 0 || max > MaxDimension {
+		max = MaxDimension
+	}
+	dimensions := []struct {
+		name  string
+		value float64
+	}{{"width", cfg.Width}, {"height", cfg.Height}}
+	for _, dim := range dimensions {
+		if dim.value == 0 {
+			continue
+		}
+		if math.IsNaN(dim.value) || dim.value < MinDimension || dim.value > max {
+			return fmt.Errorf("%w: %s %g must be between %g and %g", ErrWorldSizeOutOfRange, dim.name, dim.value, MinDimension, max)
+		}
+	}
+	return nil
+}
+
 func DefaultConfig() Config {
 	return Config{
 		Obstacles:      false,
@@ -77,8 +289,10 @@ func DefaultConfig() Config {
 		NPCCount:       0,
 		Lava:           false,
 		LavaCount:      0,
+		Stash:          false,
 		Seed:           DefaultSeed,
 		Width:          DefaultWidth,
 		Height:         DefaultHeight,
+		Wrap:           false,
 	}
 }
@@ -39,7 +39,7 @@ func TestConstructLegacyRehydratesInternalState(t *testing.T) {
 	internalHarness := constructed.ConstructorHarness()
 	legacyHarness := requireLegacyHarness(t, legacy)
 
-	if internalHarness.Config != legacyHarness.Config {
+	if !reflect.DeepEqual(internalHarness.Config, legacyHarness.Config) {
 		t.Fatalf("expected legacy world config to match internal: %+v vs %+v", legacyHarness.Config, internalHarness.Config)
 	}
 	if internalHarness.Seed != legacyHarness.Seed {
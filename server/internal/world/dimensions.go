@@ -1,5 +1,11 @@
 package world
 
+import (
+	"math"
+
+	state "mine-and-die/server/internal/world/state"
+)
+
 func Width(cfg Config) float64 {
 	if cfg.Width > 0 {
 		return cfg.Width
@@ -17,3 +23,63 @@ func Height(cfg Config) float64 {
 func Dimensions(cfg Config) (float64, float64) {
 	return Width(cfg), Height(cfg)
 }
+
+// Bounds describes the playable area and whether its edges wrap around.
+type Bounds struct {
+	Width  float64
+	Height float64
+	Wrap   bool
+}
+
+// BoundsOf reports the configured playable area.
+func BoundsOf(cfg Config) Bounds {
+	return Bounds{Width: Width(cfg), Height: Height(cfg), Wrap: cfg.Wrap}
+}
+
+// ConfineX keeps an actor centre inside the horizontal bounds, clamping in the
+// default mode and wrapping to the opposite edge in toroidal mode.
+func (b Bounds) ConfineX(x float64) float64 {
+	if b.Wrap {
+		return WrapCoordinate(x, b.Width)
+	}
+	return Clamp(x, PlayerHalf, b.Width-PlayerHalf)
+}
+
+// ConfineY keeps an actor centre inside the vertical bounds, clamping in the
+// default mode and wrapping to the opposite edge in toroidal mode.
+func (b Bounds) ConfineY(y float64) float64 {
+	if b.Wrap {
+		return WrapCoordinate(y, b.Height)
+	}
+	return Clamp(y, PlayerHalf, b.Height-PlayerHalf)
+}
+
+// Delta returns the offset from one point to another. In toroidal mode the
+// shortest offset across the seam is used.
+func (b Bounds) Delta(fromX, fromY, toX, toY float64) (float64, float64) {
+	dx := toX - fromX
+	dy := toY - fromY
+	if b.Wrap {
+		dx = shortestWrappedOffset(dx, b.Width)
+		dy = shortestWrappedOffset(dy, b.Height)
+	}
+	return dx, dy
+}
+
+// WrapCoordinate maps value into [0, size).
+func WrapCoordinate(value, size float64) float64 {
+	return state.WrapCoordinate(value, size)
+}
+
+func shortestWrappedOffset(delta, size float64) float64 {
+	if size <= 0 {
+		return delta
+	}
+	delta = math.Mod(delta, size)
+	if delta > size/2 {
+		delta -= size
+	} else if delta < -size/2 {
+		delta += size
+	}
+	return delta
+}
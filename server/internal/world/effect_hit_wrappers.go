@@ -34,6 +34,7 @@ func NewWorldPlayerEffectHitCallback(cfg WorldPlayerEffectHitCallbackConfig) Eff
 type WorldNPCEffectHitCallbackConfig struct {
 	Dispatcher   EffectHitCallback
 	SpawnBlood   func(effect any, target any, now time.Time)
+	OnHit        func(effect any, target any, now time.Time)
 	IsAlive      func(target any) bool
 	HandleDefeat func(target any)
 }
@@ -54,6 +55,7 @@ func NewWorldNPCEffectHitCallback(cfg WorldNPCEffectHitCallbackConfig) EffectHit
 	npcCfg := EffectHitNPCConfig{
 		ApplyActorHit: applyActorHit,
 		SpawnBlood:    cfg.SpawnBlood,
+		OnHit:         cfg.OnHit,
 		IsAlive:       cfg.IsAlive,
 		HandleDefeat:  cfg.HandleDefeat,
 	}
@@ -48,6 +48,8 @@ type EffectHitNPCConfig struct {
 	ApplyActorHit EffectHitCallback
 	// SpawnBlood emits any contract-managed visuals associated with the hit.
 	SpawnBlood EffectHitCallback
+	// OnHit runs after the hit is applied and before any defeat handling.
+	OnHit EffectHitCallback
 	// IsAlive reports whether the NPC is currently alive.
 	IsAlive func(target any) bool
 	// HandleDefeat cleans up NPC state after the actor is defeated.
@@ -78,6 +80,10 @@ func EffectHitNPCCallback(cfg EffectHitNPCConfig) EffectHitCallback {
 
 		cfg.ApplyActorHit(effect, target, now)
 
+		if cfg.OnHit != nil {
+			cfg.OnHit(effect, target, now)
+		}
+
 		if !wasAlive || cfg.HandleDefeat == nil || cfg.IsAlive == nil {
 			return
 		}
@@ -312,6 +318,12 @@ type EffectHitCombatDispatcherConfig struct {
 	RecordEffectHitTelemetry func(effect *worldeffects.State, targetID string, actualDelta float64)
 	DropAllInventory         func(actor *state.ActorState, reason string)
 	ApplyStatusEffect        func(effect *worldeffects.State, actor *state.ActorState, status statuspkg.StatusEffectType, now time.Time)
+	RollCritical             func(effect *worldeffects.State, targetID string) (multiplier float64, critical bool)
+	AmplifyDamage            func(effect *worldeffects.State, targetID string) float64
+	AttenuateDamage          func(effect *worldeffects.State, targetID string) float64
+	ResistDamage             func(effect *worldeffects.State, targetID string) float64
+	AbsorbDamage             func(targetID string, damage float64) (remaining float64)
+	ApplyLifesteal           func(effect *worldeffects.State, targetID string, damage float64)
 
 	BuildLegacyAdapter LegacyEffectHitAdapterBuilder
 
@@ -346,6 +358,12 @@ type LegacyEffectHitAdapterConfig struct {
 
 	DropAllInventory  func(actor CombatActorData, reason string)
 	ApplyStatusEffect func(effect *worldeffects.State, actor CombatActorData, status statuspkg.StatusEffectType, now time.Time)
+	RollCritical      func(effect *worldeffects.State, targetID string) (multiplier float64, critical bool)
+	AmplifyDamage     func(effect *worldeffects.State, targetID string) float64
+	AttenuateDamage   func(effect *worldeffects.State, targetID string) float64
+	ResistDamage      func(effect *worldeffects.State, targetID string) float64
+	AbsorbDamage      func(targetID string, damage float64) (remaining float64)
+	ApplyLifesteal    func(effect *worldeffects.State, targetID string, damage float64)
 }
 
 // CombatActorKind identifies the classification of the target actor for hit
@@ -426,6 +444,12 @@ func NewEffectHitCombatDispatcher(cfg EffectHitCombatDispatcherConfig) EffectHit
 			}
 			cfg.ApplyStatusEffect(effect, actor.State, status, now)
 		},
+		RollCritical:    cfg.RollCritical,
+		AmplifyDamage:   cfg.AmplifyDamage,
+		AttenuateDamage: cfg.AttenuateDamage,
+		ResistDamage:    cfg.ResistDamage,
+		AbsorbDamage:    cfg.AbsorbDamage,
+		ApplyLifesteal:  cfg.ApplyLifesteal,
 		IsPlayer: func(id string) bool {
 			if cfg.IsPlayer == nil || id == "" {
 				return false
@@ -544,6 +568,37 @@ func defaultLegacyEffectHitAdapterBuilder() LegacyEffectHitAdapterBuilder {
 				data, _ := adapter.Raw.(CombatActorData)
 				adapterCfg.ApplyStatusEffect((*worldeffects.State)(effect), data, statuspkg.StatusEffectType(status), now)
 			},
+			RollCritical: func(effect *internaleffects.State, targetID string) (float64, bool) {
+				if adapterCfg.RollCritical == nil || effect == nil {
+					return 1, false
+				}
+				return adapterCfg.RollCritical((*worldeffects.State)(effect), targetID)
+			},
+			AmplifyDamage: func(effect *internaleffects.State, targetID string) float64 {
+				if adapterCfg.AmplifyDamage == nil || effect == nil {
+					return 1
+				}
+				return adapterCfg.AmplifyDamage((*worldeffects.State)(effect), targetID)
+			},
+			AttenuateDamage: func(effect *internaleffects.State, targetID string) float64 {
+				if adapterCfg.AttenuateDamage == nil || effect == nil {
+					return 1
+				}
+				return adapterCfg.AttenuateDamage((*worldeffects.State)(effect), targetID)
+			},
+			ResistDamage: func(effect *internaleffects.State, targetID string) float64 {
+				if adapterCfg.ResistDamage == nil || effect == nil {
+					return 1
+				}
+				return adapterCfg.ResistDamage((*worldeffects.State)(effect), targetID)
+			},
+			AbsorbDamage: adapterCfg.AbsorbDamage,
+			ApplyLifesteal: func(effect *internaleffects.State, targetID string, damage float64) {
+				if adapterCfg.ApplyLifesteal == nil || effect == nil {
+					return
+				}
+				adapterCfg.ApplyLifesteal((*worldeffects.State)(effect), targetID, damage)
+			},
 		}
 
 		combatCallback := combat.NewLegacyWorldEffectHitAdapter(combatCfg)
@@ -647,6 +702,7 @@ func newDamageTelemetryRecorder(publisher logging.Publisher, lookup func(id stri
 		}
 		if effect != nil {
 			payload.Ability = effect.Type
+			payload.Critical = effect.Params["crit"] > 0
 		}
 
 		loggingcombat.Damage(
@@ -184,7 +184,7 @@ func (w *World) buildEffectManagerCore() *worldeffects.Manager {
 	definitions := effectcontract.BuiltInDefinitions()
 
 	var resolver *effectcatalog.Resolver
-	if loaded, err := effectcatalog.Load(effectcontract.BuiltInRegistry, effectcatalog.DefaultPaths()...); err == nil {
+	if loaded, err := effectcatalog.Load(effectcontract.BuiltInRegistry, effectcatalog.PathsFor(w.effectCatalogPath)...); err == nil {
 		if defs := loaded.DefinitionsByContractID(); len(defs) > 0 {
 			for id, def := range defs {
 				if _, exists := definitions[id]; exists {
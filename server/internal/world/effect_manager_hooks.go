@@ -50,6 +50,72 @@ type BloodHookConfig struct {
 	RecordEffectSpawn func(effectType, category string)
 }
 
+// BeamHookConfig carries the lookups needed to resolve beam ticks. Trace clips
+// the beam at the first blocked point and ResolveHit applies the tick's damage.
+// The hook is skipped when either the owner lookup or the resolver is missing.
+type BeamHookConfig struct {
+	TileSize      float64
+	DefaultRange  float64
+	DefaultDamage float64
+
+	LookupOwner func(actorID string) *internaleffects.BeamOwner
+	Trace       func(fromX, fromY, toX, toY float64) (float64, float64)
+	ResolveHit  func(effect *worldeffects.State, segment internaleffects.BeamSegment, now time.Time)
+}
+
+// TargetHookConfig carries the resolver used by effects delivered to a single
+// named actor. The hook is skipped when ResolveHit is missing.
+type TargetHookConfig struct {
+	DefaultHealthDelta float64
+
+	ResolveHit func(effect *worldeffects.State, targetID string, now time.Time)
+}
+
+// AreaHealHookConfig carries the lookups needed to resolve group heals. The
+// hook is skipped when either the owner lookup or the resolver is missing.
+type AreaHealHookConfig struct {
+	TileSize           float64
+	DefaultRadius      float64
+	DefaultHealthDelta float64
+
+	LookupOwner func(actorID string) *internaleffects.AreaHealOwner
+	ResolveHits func(effect *worldeffects.State, now time.Time)
+}
+
+// AreaPullHookConfig carries the lookups needed to resolve gravity wells. The
+// hook is skipped when either the owner lookup or the resolver is missing.
+type AreaPullHookConfig struct {
+	TileSize      float64
+	DefaultRadius float64
+	DefaultPull   float64
+
+	LookupOwner func(actorID string) *internaleffects.AreaPullOwner
+	ResolvePull func(effect *worldeffects.State, now time.Time)
+}
+
+// AreaExplosionHookConfig carries the lookups needed to resolve explosions.
+// The hook is skipped when either the owner lookup or the resolver is missing.
+type AreaExplosionHookConfig struct {
+	TileSize           float64
+	DefaultRadius      float64
+	DefaultHealthDelta float64
+
+	LookupOwner func(actorID string) *internaleffects.AreaExplosionOwner
+	ResolveHits func(effect *worldeffects.State, now time.Time)
+}
+
+// AreaHazardHookConfig carries the lookups needed to resolve lingering
+// hazards. The hook is skipped when either the owner lookup or the resolver is
+// missing.
+type AreaHazardHookConfig struct {
+	TileSize           float64
+	DefaultRadius      float64
+	DefaultHealthDelta float64
+
+	LookupOwner func(actorID string) *internaleffects.AreaHazardOwner
+	ResolveHits func(effect *worldeffects.State, now time.Time)
+}
+
 // EffectManagerHooksConfig aggregates the optional hook configurations used to
 // build the effect manager registry. Individual hooks are only registered when
 // their configs provide the minimum required callbacks.
@@ -57,6 +123,12 @@ type EffectManagerHooksConfig struct {
 	Melee      MeleeHookConfig
 	Projectile ProjectileHookConfig
 	Blood      BloodHookConfig
+	Beam       BeamHookConfig
+	Target     TargetHookConfig
+	AreaHeal   AreaHealHookConfig
+	AreaPull   AreaPullHookConfig
+	Explosion  AreaExplosionHookConfig
+	Hazard     AreaHazardHookConfig
 }
 
 func BuildEffectManagerHooks(cfg EffectManagerHooksConfig) map[string]worldeffects.HookSet {
@@ -110,6 +182,76 @@ func buildEffectManagerHooks(cfg EffectManagerHooksConfig) map[string]worldeffec
 		}
 	}
 
+	if cfg.Beam.LookupOwner != nil && cfg.Beam.ResolveHit != nil {
+		hooks[effectcontract.HookBeamTick] = internaleffects.BeamTickHook(internaleffects.BeamHookConfig{
+			TileSize:      cfg.Beam.TileSize,
+			DefaultRange:  cfg.Beam.DefaultRange,
+			DefaultDamage: cfg.Beam.DefaultDamage,
+			LookupOwner:   cfg.Beam.LookupOwner,
+			Trace:         cfg.Beam.Trace,
+			ResolveHit: func(effect *internaleffects.State, segment internaleffects.BeamSegment, now time.Time) {
+				cfg.Beam.ResolveHit((*worldeffects.State)(effect), segment, now)
+			},
+		})
+	}
+
+	if cfg.Target.ResolveHit != nil {
+		hooks[effectcontract.HookTargetHeal] = internaleffects.TargetSpawnHook(internaleffects.TargetHookConfig{
+			DefaultHealthDelta: cfg.Target.DefaultHealthDelta,
+			ResolveHit: func(effect *internaleffects.State, targetID string, now time.Time) {
+				cfg.Target.ResolveHit((*worldeffects.State)(effect), targetID, now)
+			},
+		})
+	}
+
+	if cfg.AreaHeal.LookupOwner != nil && cfg.AreaHeal.ResolveHits != nil {
+		hooks[effectcontract.HookAreaHealBurst] = internaleffects.AreaHealSpawnHook(internaleffects.AreaHealHookConfig{
+			TileSize:           cfg.AreaHeal.TileSize,
+			DefaultRadius:      cfg.AreaHeal.DefaultRadius,
+			DefaultHealthDelta: cfg.AreaHeal.DefaultHealthDelta,
+			LookupOwner:        cfg.AreaHeal.LookupOwner,
+			ResolveHits: func(effect *internaleffects.State, now time.Time) {
+				cfg.AreaHeal.ResolveHits((*worldeffects.State)(effect), now)
+			},
+		})
+	}
+
+	if cfg.AreaPull.LookupOwner != nil && cfg.AreaPull.ResolvePull != nil {
+		hooks[effectcontract.HookAreaGravityPull] = internaleffects.AreaPullHook(internaleffects.AreaPullHookConfig{
+			TileSize:      cfg.AreaPull.TileSize,
+			DefaultRadius: cfg.AreaPull.DefaultRadius,
+			DefaultPull:   cfg.AreaPull.DefaultPull,
+			LookupOwner:   cfg.AreaPull.LookupOwner,
+			ResolvePull: func(effect *internaleffects.State, now time.Time) {
+				cfg.AreaPull.ResolvePull((*worldeffects.State)(effect), now)
+			},
+		})
+	}
+
+	if cfg.Explosion.LookupOwner != nil && cfg.Explosion.ResolveHits != nil {
+		hooks[effectcontract.HookAreaExplosion] = internaleffects.AreaExplosionSpawnHook(internaleffects.AreaExplosionHookConfig{
+			TileSize:           cfg.Explosion.TileSize,
+			DefaultRadius:      cfg.Explosion.DefaultRadius,
+			DefaultHealthDelta: cfg.Explosion.DefaultHealthDelta,
+			LookupOwner:        cfg.Explosion.LookupOwner,
+			ResolveHits: func(effect *internaleffects.State, now time.Time) {
+				cfg.Explosion.ResolveHits((*worldeffects.State)(effect), now)
+			},
+		})
+	}
+
+	if cfg.Hazard.LookupOwner != nil && cfg.Hazard.ResolveHits != nil {
+		hooks[effectcontract.HookAreaHazardPulse] = internaleffects.AreaHazardHook(internaleffects.AreaHazardHookConfig{
+			TileSize:           cfg.Hazard.TileSize,
+			DefaultRadius:      cfg.Hazard.DefaultRadius,
+			DefaultHealthDelta: cfg.Hazard.DefaultHealthDelta,
+			LookupOwner:        cfg.Hazard.LookupOwner,
+			ResolveHits: func(effect *internaleffects.State, now time.Time) {
+				cfg.Hazard.ResolveHits((*worldeffects.State)(effect), now)
+			},
+		})
+	}
+
 	return hooks
 }
 
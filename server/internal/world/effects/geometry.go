@@ -1,24 +1,17 @@
 package effects
 
 import (
-	"math"
 	"time"
 
 	effectcontract "mine-and-die/server/effects/contract"
 )
 
 func QuantizeWorldCoord(value float64, tileSize float64) int {
-	if tileSize <= 0 {
-		tileSize = 40
-	}
-	return int(math.Round((value / tileSize) * effectcontract.CoordScale))
+	return effectcontract.QuantizeWorldCoord(value, tileSize)
 }
 
 func DequantizeWorldCoord(value int, tileSize float64) float64 {
-	if tileSize <= 0 {
-		tileSize = 40
-	}
-	return (float64(value) / effectcontract.CoordScale) * tileSize
+	return effectcontract.DequantizeWorldCoord(value, tileSize)
 }
 
 func TicksToDuration(ticks int, tickRate int) time.Duration {
@@ -2,6 +2,9 @@ package effects
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	effectcatalog "mine-and-die/server/effects/catalog"
@@ -55,6 +58,7 @@ type Manager struct {
 	nextInstanceID    uint64
 	ownerMissing      func(string) bool
 	registry          func() Registry
+	cancelled         map[string]struct{}
 }
 
 func NewManager(cfg ManagerConfig) *Manager {
@@ -78,6 +82,7 @@ func NewManager(cfg ManagerConfig) *Manager {
 		seqByInstance: make(map[string]effectcontract.Seq),
 		ownerMissing:  cfg.OwnerMissing,
 		registry:      cfg.Registry,
+		cancelled:     make(map[string]struct{}),
 	}
 }
 
@@ -95,6 +100,26 @@ func (m *Manager) Hooks() map[string]HookSet {
 	return m.hooks
 }
 
+// RegisterHook adds a behavior hook that effect definitions can reference by
+// name from their OnSpawn and OnTick hooks. Names already taken, including the
+// built-in hooks, are rejected so a plugin cannot replace core behavior.
+func (m *Manager) RegisterHook(name string, hook HookSet) error {
+	if m == nil {
+		return fmt.Errorf("effect hook %q: nil manager", name)
+	}
+	if name == "" {
+		return fmt.Errorf("effect hook name is required")
+	}
+	if hook.OnSpawn == nil && hook.OnTick == nil {
+		return fmt.Errorf("effect hook %q has no callbacks", name)
+	}
+	if _, exists := m.hooks[name]; exists {
+		return fmt.Errorf("effect hook %q is already registered", name)
+	}
+	m.hooks[name] = hook
+	return nil
+}
+
 func (m *Manager) Instances() map[string]*effectcontract.EffectInstance {
 	if m == nil {
 		return nil
@@ -201,6 +226,144 @@ func (m *Manager) EnqueueIntent(intent effectcontract.EffectIntent) {
 	m.totalEnqueued++
 }
 
+// CancelOwned flags every live instance owned by the actor whose end policy
+// honours explicit cancellation. Flagged instances end on the next RunTick with
+// EndReasonCancelled before their tick hooks run. It returns the number of
+// instances flagged.
+func (m *Manager) CancelOwned(ownerID string) int {
+	if m == nil || ownerID == "" {
+		return 0
+	}
+	count := 0
+	for id, instance := range m.instances {
+		if instance == nil || instance.OwnerActorID != ownerID || !instance.End.Conditions.OnExplicitCancel {
+			continue
+		}
+		if _, flagged := m.cancelled[id]; !flagged {
+			m.cancelled[id] = struct{}{}
+			count++
+		}
+	}
+	return count
+}
+
+// ActiveSpawnEvents rebuilds a spawn event for every live instance that
+// replicates spawns, so a client joining mid-flight can materialise effects it
+// never saw spawn. Events carry the instance's current sequence without
+// advancing it, and instances already flagged for cancellation are skipped.
+// The result is ordered by instance ID.
+func (m *Manager) ActiveSpawnEvents() []effectcontract.EffectSpawnEvent {
+	if m == nil || len(m.instances) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(m.instances))
+	for id, instance := range m.instances {
+		if instance == nil || !instance.Replication.SendSpawn {
+			continue
+		}
+		if _, cancelled := m.cancelled[id]; cancelled {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Strings(ids)
+	events := make([]effectcontract.EffectSpawnEvent, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, effectcontract.EffectSpawnEvent{
+			Tick:     m.lastTickProcessed,
+			Seq:      m.seqByInstance[id],
+			Instance: m.cloneInstanceForSpawn(m.instances[id]),
+		})
+	}
+	return events
+}
+
+// InstanceCheckpoint pairs a live instance with the last sequence it emitted.
+type InstanceCheckpoint struct {
+	Instance effectcontract.EffectInstance `json:"instance"`
+	Seq      effectcontract.Seq            `json:"seq"`
+}
+
+// Checkpoint is the serializable form of the manager's contract state: live
+// instances, intents still waiting to drain, and the ID and tick counters.
+// Hook-owned runtime state is not included; hooks rebuild it from the
+// instance on the next tick.
+type Checkpoint struct {
+	LastTick       effectcontract.Tick           `json:"lastTick"`
+	NextInstanceID uint64                        `json:"nextInstanceId"`
+	Instances      []InstanceCheckpoint          `json:"instances,omitempty"`
+	Intents        []effectcontract.EffectIntent `json:"intents,omitempty"`
+}
+
+// Checkpoint captures every live instance, ordered by ID, together with the
+// pending intent queue. Instances flagged for cancellation are left out.
+// Definitions are dropped from the copies and re-resolved on restore.
+func (m *Manager) Checkpoint() Checkpoint {
+	if m == nil {
+		return Checkpoint{}
+	}
+	checkpoint := Checkpoint{
+		LastTick:       m.lastTickProcessed,
+		NextInstanceID: m.nextInstanceID,
+		Intents:        append([]effectcontract.EffectIntent(nil), m.intentQueue...),
+	}
+	ids := make([]string, 0, len(m.instances))
+	for id, instance := range m.instances {
+		if instance == nil {
+			continue
+		}
+		if _, cancelled := m.cancelled[id]; cancelled {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		instance := m.cloneInstanceForSpawn(m.instances[id])
+		instance.Definition = nil
+		checkpoint.Instances = append(checkpoint.Instances, InstanceCheckpoint{
+			Instance: instance,
+			Seq:      m.seqByInstance[id],
+		})
+	}
+	return checkpoint
+}
+
+// RestoreCheckpoint replaces the manager's instances and intent queue with
+// the checkpointed ones. OnSpawn hooks are not invoked again.
+func (m *Manager) RestoreCheckpoint(checkpoint Checkpoint) {
+	if m == nil {
+		return
+	}
+	for id := range m.instances {
+		if value := m.InstanceState(id); value != nil {
+			if effect, ok := value.(*State); ok {
+				UnregisterEffect(m.Registry(), effect)
+			}
+		}
+		m.ClearInstanceState(id)
+	}
+	m.instances = make(map[string]*effectcontract.EffectInstance, len(checkpoint.Instances))
+	m.seqByInstance = make(map[string]effectcontract.Seq, len(checkpoint.Instances))
+	m.cancelled = make(map[string]struct{})
+	for _, entry := range checkpoint.Instances {
+		if entry.Instance.ID == "" {
+			continue
+		}
+		instance := m.cloneInstanceForSpawn(&entry.Instance)
+		instance.Definition = m.definitions[instance.DefinitionID]
+		m.instances[instance.ID] = &instance
+		m.seqByInstance[instance.ID] = entry.Seq
+	}
+	m.ResetPendingIntents()
+	m.intentQueue = append(m.intentQueue, checkpoint.Intents...)
+	m.lastTickProcessed = checkpoint.LastTick
+	m.nextInstanceID = checkpoint.NextInstanceID
+}
+
 func (m *Manager) RunTick(tick effectcontract.Tick, now time.Time, emit func(effectcontract.EffectLifecycleEvent)) {
 	if m == nil {
 		return
@@ -249,8 +412,17 @@ func (m *Manager) RunTick(tick effectcontract.Tick, now time.Time, emit func(eff
 	}
 
 	ended := make([]string, 0)
-	for _, instance := range m.instances {
-		if instance == nil {
+	for _, instance := range m.instancesInSpawnOrder() {
+		if _, cancelled := m.cancelled[instance.ID]; cancelled {
+			if instance.Replication.SendEnd && emit != nil {
+				emit(effectcontract.EffectEndEvent{
+					Tick:   tick,
+					Seq:    m.nextSequenceFor(instance.ID),
+					ID:     instance.ID,
+					Reason: effectcontract.EndReasonCancelled,
+				})
+			}
+			ended = append(ended, instance.ID)
 			continue
 		}
 		shouldTick := m.shouldInvokeOnTick(instance)
@@ -293,12 +465,55 @@ func (m *Manager) RunTick(tick effectcontract.Tick, now time.Time, emit func(eff
 		delete(m.instances, id)
 		delete(m.seqByInstance, id)
 		m.ClearInstanceState(id)
+		delete(m.cancelled, id)
+	}
+}
+
+// instanceIDPrefix precedes the spawn sequence number in every instance ID.
+const instanceIDPrefix = "contract-effect-"
+
+// instancesInSpawnOrder returns the live instances ordered by the sequence they
+// were spawned in. Ticking them in this order makes simultaneous hits on one
+// actor apply the same way every run, so whoever fired first lands the killing
+// blow.
+func (m *Manager) instancesInSpawnOrder() []*effectcontract.EffectInstance {
+	ordered := make([]*effectcontract.EffectInstance, 0, len(m.instances))
+	for _, instance := range m.instances {
+		if instance != nil {
+			ordered = append(ordered, instance)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return spawnOrderLess(ordered[i].ID, ordered[j].ID)
+	})
+	return ordered
+}
+
+// spawnOrderLess orders instance IDs by their spawn sequence. IDs that do not
+// carry one sort after those that do, by plain string comparison.
+func spawnOrderLess(a, b string) bool {
+	seqA, okA := instanceSpawnSeq(a)
+	seqB, okB := instanceSpawnSeq(b)
+	if okA && okB && seqA != seqB {
+		return seqA < seqB
+	}
+	if okA != okB {
+		return okA
+	}
+	return a < b
+}
+
+func instanceSpawnSeq(id string) (uint64, bool) {
+	if !strings.HasPrefix(id, instanceIDPrefix) {
+		return 0, false
 	}
+	seq, err := strconv.ParseUint(strings.TrimPrefix(id, instanceIDPrefix), 10, 64)
+	return seq, err == nil
 }
 
 func (m *Manager) instantiateIntent(intent effectcontract.EffectIntent, tick effectcontract.Tick) *effectcontract.EffectInstance {
 	m.nextInstanceID++
-	id := fmt.Sprintf("contract-effect-%d", m.nextInstanceID)
+	id := fmt.Sprintf("%s%d", instanceIDPrefix, m.nextInstanceID)
 	geometry := intent.Geometry
 	if geometry.Variants != nil {
 		geometry.Variants = copyIntMap(geometry.Variants)
@@ -331,8 +546,11 @@ func (m *Manager) instantiateIntent(intent effectcontract.EffectIntent, tick eff
 		deliveryKind = effectcontract.DeliveryKindArea
 	}
 	follow := effectcontract.FollowNone
-	if deliveryKind == effectcontract.DeliveryKindTarget {
+	switch deliveryKind {
+	case effectcontract.DeliveryKindTarget:
 		follow = effectcontract.FollowTarget
+	case effectcontract.DeliveryKindBeam:
+		follow = effectcontract.FollowOwner
 	}
 	ticksRemaining := intent.DurationTicks
 	if ticksRemaining <= 0 && definition != nil && endPolicy.Kind == effectcontract.EndDuration {
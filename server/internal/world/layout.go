@@ -18,9 +18,12 @@ const (
 
 	GoldOreMinSize = 56.0
 	GoldOreMaxSize = 96.0
+
+	StashSize = 32.0
 )
 
 const (
 	ObstacleTypeGoldOre = "gold-ore"
 	ObstacleTypeLava    = "lava"
+	ObstacleTypeStash   = "stash"
 )
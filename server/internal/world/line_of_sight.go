@@ -0,0 +1,71 @@
+package world
+
+import "math"
+
+// LineOfSightRequest captures the inputs required to trace a straight line
+// across the navigation grid built from the world obstacles.
+type LineOfSightRequest struct {
+	From      Vec2
+	To        Vec2
+	Width     float64
+	Height    float64
+	Wrap      bool
+	Obstacles []Obstacle
+}
+
+// TraceLineOfSight walks the navigation grid from From towards To and returns
+// the point where the line first enters a blocked cell. When the whole line is
+// clear the destination is returned alongside false.
+func TraceLineOfSight(req LineOfSightRequest) (Vec2, bool) {
+	grid := newNavGridInBounds(req.Obstacles, Bounds{Width: req.Width, Height: req.Height, Wrap: req.Wrap})
+	if grid == nil {
+		return req.To, false
+	}
+	return grid.traceLine(req.From, req.To)
+}
+
+// traceLine rasterises the segment cell by cell, visiting every cell the line
+// passes through in order. The starting cell is never treated as blocked so
+// actors hugging a wall can still see out of it.
+func (g *navGrid) traceLine(from, to Vec2) (Vec2, bool) {
+	col, row, ok := g.locate(from.X, from.Y)
+	if !ok {
+		return from, true
+	}
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+
+	stepCol, tMaxX, tDeltaX := traceAxis(from.X, dx, float64(col), g.cellSize)
+	stepRow, tMaxY, tDeltaY := traceAxis(from.Y, dy, float64(row), g.cellSize)
+
+	for {
+		t := math.Min(tMaxX, tMaxY)
+		if t >= 1 {
+			return to, false
+		}
+		if tMaxX < tMaxY {
+			col, row, ok = g.neighbor(col, row, stepCol, 0)
+			tMaxX += tDeltaX
+		} else {
+			col, row, ok = g.neighbor(col, row, 0, stepRow)
+			tMaxY += tDeltaY
+		}
+		if !ok || !g.walkable[g.index(col, row)] {
+			return Vec2{X: from.X + dx*t, Y: from.Y + dy*t}, true
+		}
+	}
+}
+
+// traceAxis reports the step direction along one axis, the line parameter at
+// which the first cell boundary is crossed, and the parameter distance between
+// successive boundaries.
+func traceAxis(origin, delta, cell, cellSize float64) (int, float64, float64) {
+	switch {
+	case delta > 0:
+		return 1, ((cell+1)*cellSize - origin) / delta, cellSize / delta
+	case delta < 0:
+		return -1, (cell*cellSize - origin) / delta, -cellSize / delta
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
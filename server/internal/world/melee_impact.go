@@ -1,6 +1,9 @@
 package world
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // MeleeActorVisitor iterates over actors participating in melee collision
 // resolution. Callers must provide a function that invokes the visitor for each
@@ -21,6 +24,7 @@ type ResolveMeleeImpactConfig struct {
 	Tick       uint64
 	Now        time.Time
 	Area       Obstacle
+	Cone       MeleeCone
 	Obstacles  []Obstacle
 
 	ForEachPlayer MeleeActorVisitor
@@ -91,7 +95,7 @@ func ResolveMeleeImpact(cfg ResolveMeleeImpactConfig) {
 			if id == cfg.ActorID {
 				return
 			}
-			if !CircleRectOverlap(x, y, PlayerHalf, cfg.Area) {
+			if !cfg.strikes(x, y) {
 				return
 			}
 
@@ -108,7 +112,7 @@ func ResolveMeleeImpact(cfg ResolveMeleeImpactConfig) {
 			if id == cfg.ActorID {
 				return
 			}
-			if !CircleRectOverlap(x, y, PlayerHalf, cfg.Area) {
+			if !cfg.strikes(x, y) {
 				return
 			}
 
@@ -127,3 +131,40 @@ func ResolveMeleeImpact(cfg ResolveMeleeImpactConfig) {
 		cfg.RecordAttackOverlap(cfg.ActorID, cfg.Tick, cfg.EffectType, hitPlayers, hitNPCs)
 	}
 }
+
+// MeleeCone describes an arc swing centred on Facing degrees (screen space, 0
+// pointing right) spanning Arc degrees out to Radius from the origin.
+type MeleeCone struct {
+	OriginX float64
+	OriginY float64
+	Radius  float64
+	Facing  float64
+	Arc     float64
+}
+
+// Overlaps reports whether a circular body at (x, y) touches the cone. The arc
+// is widened by the angle the body subtends so grazing targets are struck.
+func (c MeleeCone) Overlaps(x, y, radius float64) bool {
+	dx := x - c.OriginX
+	dy := y - c.OriginY
+	distance := math.Hypot(dx, dy)
+	if distance > c.Radius+radius {
+		return false
+	}
+	if distance <= radius {
+		return true
+	}
+	angle := math.Atan2(dy, dx) * 180 / math.Pi
+	offset := math.Abs(math.Remainder(angle-c.Facing, 360))
+	slack := math.Asin(math.Min(1, radius/distance)) * 180 / math.Pi
+	return offset <= c.Arc/2+slack
+}
+
+// strikes reports whether an actor centred at (x, y) is inside the swing,
+// using the cone for arc swings and the box otherwise.
+func (cfg ResolveMeleeImpactConfig) strikes(x, y float64) bool {
+	if cfg.Cone.Arc > 0 {
+		return cfg.Cone.Overlaps(x, y, PlayerHalf)
+	}
+	return CircleRectOverlap(x, y, PlayerHalf, cfg.Area)
+}
@@ -1,6 +1,10 @@
 package world
 
-import "math"
+import (
+	"math"
+
+	state "mine-and-die/server/internal/world/state"
+)
 
 // MovementActor captures the minimal mutable state required to move an actor
 // while resolving obstacle collisions.
@@ -9,12 +13,22 @@ type MovementActor struct {
 	Y       float64
 	IntentX float64
 	IntentY float64
+	// Layer and Mask gate actor-actor separation; zero values collide with
+	// everyone on the default layer.
+	Layer state.CollisionLayer
+	Mask  state.CollisionLayer
 }
 
 // MoveActorWithObstacles advances an actor while clamping speed, bounds, and
 // blocking obstacles. Callers should pass the desired movement speed in units
 // per second.
 func MoveActorWithObstacles(state *MovementActor, dt float64, obstacles []Obstacle, width, height, speed float64) {
+	MoveActorInBounds(state, dt, obstacles, Bounds{Width: width, Height: height}, speed)
+}
+
+// MoveActorInBounds advances an actor like MoveActorWithObstacles but confines
+// the result according to bounds, so toroidal worlds wrap instead of clamp.
+func MoveActorInBounds(state *MovementActor, dt float64, obstacles []Obstacle, bounds Bounds, speed float64) {
 	if state == nil {
 		return
 	}
@@ -30,24 +44,33 @@ func MoveActorWithObstacles(state *MovementActor, dt float64, obstacles []Obstac
 	deltaX := dx * speed * dt
 	deltaY := dy * speed * dt
 
-	newX := Clamp(state.X+deltaX, PlayerHalf, width-PlayerHalf)
+	// Obstacle stops run on the unwrapped proposal so a wrap never makes an
+	// actor appear to jump past a wall.
+	proposedX := bounds.ConfineX(state.X + deltaX)
+	proposedY := bounds.ConfineY(state.Y + deltaY)
+	if bounds.Wrap {
+		proposedX = state.X + deltaX
+		proposedY = state.Y + deltaY
+	}
+
+	newX := bounds.ConfineX(proposedX)
 	if deltaX != 0 {
-		newX = resolveAxisMoveX(state.X, state.Y, newX, deltaX, obstacles, width)
+		newX = resolveAxisMoveX(state.X, state.Y, proposedX, deltaX, obstacles, bounds)
 	}
 
-	newY := Clamp(state.Y+deltaY, PlayerHalf, height-PlayerHalf)
+	newY := bounds.ConfineY(proposedY)
 	if deltaY != 0 {
-		newY = resolveAxisMoveY(newX, state.Y, newY, deltaY, obstacles, height)
+		newY = resolveAxisMoveY(newX, state.Y, proposedY, deltaY, obstacles, bounds)
 	}
 
 	state.X = newX
 	state.Y = newY
 
-	ResolveObstaclePenetration(state, obstacles, width, height)
+	ResolveObstaclePenetrationInBounds(state, obstacles, bounds)
 }
 
 // resolveAxisMoveX applies horizontal movement while stopping at obstacle edges.
-func resolveAxisMoveX(oldX, oldY, proposedX, deltaX float64, obstacles []Obstacle, width float64) float64 {
+func resolveAxisMoveX(oldX, oldY, proposedX, deltaX float64, obstacles []Obstacle, bounds Bounds) float64 {
 	newX := proposedX
 	for _, obs := range obstacles {
 		if obs.Type == ObstacleTypeLava {
@@ -71,11 +94,11 @@ func resolveAxisMoveX(oldX, oldY, proposedX, deltaX float64, obstacles []Obstacl
 			}
 		}
 	}
-	return Clamp(newX, PlayerHalf, width-PlayerHalf)
+	return bounds.ConfineX(newX)
 }
 
 // resolveAxisMoveY applies vertical movement while stopping at obstacle edges.
-func resolveAxisMoveY(oldX, oldY, proposedY, deltaY float64, obstacles []Obstacle, height float64) float64 {
+func resolveAxisMoveY(oldX, oldY, proposedY, deltaY float64, obstacles []Obstacle, bounds Bounds) float64 {
 	newY := proposedY
 	for _, obs := range obstacles {
 		if obs.Type == ObstacleTypeLava {
@@ -99,11 +122,17 @@ func resolveAxisMoveY(oldX, oldY, proposedY, deltaY float64, obstacles []Obstacl
 			}
 		}
 	}
-	return Clamp(newY, PlayerHalf, height-PlayerHalf)
+	return bounds.ConfineY(newY)
 }
 
 // ResolveObstaclePenetration nudges an actor out of overlapping obstacles.
 func ResolveObstaclePenetration(state *MovementActor, obstacles []Obstacle, width, height float64) {
+	ResolveObstaclePenetrationInBounds(state, obstacles, Bounds{Width: width, Height: height})
+}
+
+// ResolveObstaclePenetrationInBounds nudges an actor out of overlapping
+// obstacles and confines it according to bounds.
+func ResolveObstaclePenetrationInBounds(state *MovementActor, obstacles []Obstacle, bounds Bounds) {
 	if state == nil {
 		return
 	}
@@ -163,14 +192,20 @@ func ResolveObstaclePenetration(state *MovementActor, obstacles []Obstacle, widt
 			}
 		}
 
-		state.X = Clamp(state.X, PlayerHalf, width-PlayerHalf)
-		state.Y = Clamp(state.Y, PlayerHalf, height-PlayerHalf)
+		state.X = bounds.ConfineX(state.X)
+		state.Y = bounds.ConfineY(state.Y)
 	}
 }
 
 // ResolveActorCollisions separates overlapping actors while respecting
 // obstacles and world boundaries.
 func ResolveActorCollisions(actors []*MovementActor, obstacles []Obstacle, width, height float64) {
+	ResolveActorCollisionsInBounds(actors, obstacles, Bounds{Width: width, Height: height})
+}
+
+// ResolveActorCollisionsInBounds separates overlapping actors, measuring their
+// distance across the seam when bounds wrap.
+func ResolveActorCollisionsInBounds(actors []*MovementActor, obstacles []Obstacle, bounds Bounds) {
 	if len(actors) < 2 {
 		return
 	}
@@ -188,9 +223,11 @@ func ResolveActorCollisions(actors []*MovementActor, obstacles []Obstacle, width
 				if p2 == nil {
 					continue
 				}
+				if !state.CollisionLayersInteract(p1.Layer, p1.Mask, p2.Layer, p2.Mask) {
+					continue
+				}
 
-				dx := p2.X - p1.X
-				dy := p2.Y - p1.Y
+				dx, dy := bounds.Delta(p1.X, p1.Y, p2.X, p2.Y)
 				distSq := dx*dx + dy*dy
 				minDist := PlayerHalf * 2
 
@@ -216,13 +253,13 @@ func ResolveActorCollisions(actors []*MovementActor, obstacles []Obstacle, width
 				p2.X += nx * overlap
 				p2.Y += ny * overlap
 
-				p1.X = Clamp(p1.X, PlayerHalf, width-PlayerHalf)
-				p1.Y = Clamp(p1.Y, PlayerHalf, height-PlayerHalf)
-				p2.X = Clamp(p2.X, PlayerHalf, width-PlayerHalf)
-				p2.Y = Clamp(p2.Y, PlayerHalf, height-PlayerHalf)
+				p1.X = bounds.ConfineX(p1.X)
+				p1.Y = bounds.ConfineY(p1.Y)
+				p2.X = bounds.ConfineX(p2.X)
+				p2.Y = bounds.ConfineY(p2.Y)
 
-				ResolveObstaclePenetration(p1, obstacles, width, height)
-				ResolveObstaclePenetration(p2, obstacles, width, height)
+				ResolveObstaclePenetrationInBounds(p1, obstacles, bounds)
+				ResolveObstaclePenetrationInBounds(p2, obstacles, bounds)
 
 				adjusted = true
 			}
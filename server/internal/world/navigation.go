@@ -37,9 +37,17 @@ type navGrid struct {
 	walkable   []bool
 	width      float64
 	height     float64
+	wrap       bool
 }
 
 func newNavGrid(obstacles []Obstacle, width, height float64) *navGrid {
+	return newNavGridInBounds(obstacles, Bounds{Width: width, Height: height})
+}
+
+// newNavGridInBounds builds the navigation grid for bounds. Toroidal grids keep
+// their edge cells walkable and connect them to the opposite side.
+func newNavGridInBounds(obstacles []Obstacle, bounds Bounds) *navGrid {
+	width, height := bounds.Width, bounds.Height
 	cols := int(math.Ceil(width / NavCellSize))
 	rows := int(math.Ceil(height / NavCellSize))
 	if cols <= 0 {
@@ -55,26 +63,17 @@ func newNavGrid(obstacles []Obstacle, width, height float64) *navGrid {
 		walkable: make([]bool, cols*rows),
 		width:    width,
 		height:   height,
+		wrap:     bounds.Wrap,
 	}
 
 	for row := 0; row < rows; row++ {
 		for col := 0; col < cols; col++ {
 			cx := (float64(col) + 0.5) * grid.cellSize
 			cy := (float64(row) + 0.5) * grid.cellSize
-			if cx < PlayerHalf || cx > width-PlayerHalf || cy < PlayerHalf || cy > height-PlayerHalf {
+			if !grid.wrap && (cx < PlayerHalf || cx > width-PlayerHalf || cy < PlayerHalf || cy > height-PlayerHalf) {
 				continue
 			}
-			blocked := false
-			for _, obs := range obstacles {
-				if obs.Type == ObstacleTypeLava {
-					continue
-				}
-				if CircleRectOverlap(cx, cy, PlayerHalf, obs) {
-					blocked = true
-					break
-				}
-			}
-			if !blocked {
+			if !navCellBlocked(obstacles, cx, cy) {
 				grid.walkable[row*cols+col] = true
 			}
 		}
@@ -83,10 +82,46 @@ func newNavGrid(obstacles []Obstacle, width, height float64) *navGrid {
 	return grid
 }
 
+// navCellBlocked reports whether a solid obstacle covers the navigation cell
+// centred on (cx, cy). Lava burns but never blocks movement.
+func navCellBlocked(obstacles []Obstacle, cx, cy float64) bool {
+	for _, obs := range obstacles {
+		if obs.Type == ObstacleTypeLava {
+			continue
+		}
+		if CircleRectOverlap(cx, cy, PlayerHalf, obs) {
+			return true
+		}
+	}
+	return false
+}
+
+// PathNodeBlocked reports whether the navigation cell holding node is now
+// covered by a solid obstacle, using the same test that marks grid cells
+// unwalkable. Path followers use it to notice obstacles placed after the path
+// was planned.
+func PathNodeBlocked(obstacles []Obstacle, node Vec2) bool {
+	cx := (math.Floor(node.X/NavCellSize) + 0.5) * NavCellSize
+	cy := (math.Floor(node.Y/NavCellSize) + 0.5) * NavCellSize
+	return navCellBlocked(obstacles, cx, cy)
+}
+
 func (g *navGrid) inBounds(col, row int) bool {
 	return g != nil && col >= 0 && row >= 0 && col < g.cols && row < g.rows
 }
 
+// neighbor resolves the cell offset by (dc, dr), wrapping across the seam on
+// toroidal grids.
+func (g *navGrid) neighbor(col, row, dc, dr int) (int, int, bool) {
+	nc := col + dc
+	nr := row + dr
+	if g != nil && g.wrap {
+		nc = ((nc % g.cols) + g.cols) % g.cols
+		nr = ((nr % g.rows) + g.rows) % g.rows
+	}
+	return nc, nr, g.inBounds(nc, nr)
+}
+
 func (g *navGrid) index(col, row int) int {
 	return row*g.cols + col
 }
@@ -109,11 +144,9 @@ func (g *navGrid) canTraverseDiagonal(current navPoint, delta navNeighbor, block
 	if g == nil || !delta.diagonal {
 		return true
 	}
-	horizCol := current.col + delta.col
-	horizRow := current.row
-	vertCol := current.col
-	vertRow := current.row + delta.row
-	if !g.inBounds(horizCol, horizRow) || !g.inBounds(vertCol, vertRow) {
+	horizCol, horizRow, horizOK := g.neighbor(current.col, current.row, delta.col, 0)
+	vertCol, vertRow, vertOK := g.neighbor(current.col, current.row, 0, delta.row)
+	if !horizOK || !vertOK {
 		return false
 	}
 	if !g.walkable[g.index(horizCol, horizRow)] || !g.walkable[g.index(vertCol, vertRow)] {
@@ -145,6 +178,10 @@ func (g *navGrid) locate(x, y float64) (int, int, bool) {
 	}
 	clampedX := Clamp(x, 0, maxX)
 	clampedY := Clamp(y, 0, maxY)
+	if g.wrap {
+		clampedX = Clamp(WrapCoordinate(x, g.width), 0, maxX)
+		clampedY = Clamp(WrapCoordinate(y, g.height), 0, maxY)
+	}
 	col := int(clampedX / g.cellSize)
 	row := int(clampedY / g.cellSize)
 	if !g.inBounds(col, row) {
@@ -178,12 +215,11 @@ func (g *navGrid) closestWalkable(col, row int, blocked map[int]struct{}) (int,
 			}
 		}
 		for _, delta := range navNeighborOffsets {
-			nc := current.col + delta.col
-			nr := current.row + delta.row
+			nc, nr, ok := g.neighbor(current.col, current.row, delta.col, delta.row)
 			if delta.diagonal && !g.canTraverseDiagonal(navPoint{col: current.col, row: current.row}, delta, blocked) {
 				continue
 			}
-			if !g.inBounds(nc, nr) {
+			if !ok {
 				continue
 			}
 			nIdx := g.index(nc, nr)
@@ -205,6 +241,10 @@ type navPoint struct {
 func (g *navGrid) heuristic(a, b navPoint) float64 {
 	dx := math.Abs(float64(a.col - b.col))
 	dy := math.Abs(float64(a.row - b.row))
+	if g != nil && g.wrap {
+		dx = math.Min(dx, float64(g.cols)-dx)
+		dy = math.Min(dy, float64(g.rows)-dy)
+	}
 	if dx > dy {
 		return dx + (math.Sqrt2-1)*dy
 	}
@@ -271,9 +311,8 @@ func (g *navGrid) astar(start, goal navPoint, blocked map[int]struct{}) ([]navPo
 			if delta.diagonal && !g.canTraverseDiagonal(current.point, delta, blocked) {
 				continue
 			}
-			nc := current.point.col + delta.col
-			nr := current.point.row + delta.row
-			if !g.inBounds(nc, nr) {
+			nc, nr, ok := g.neighbor(current.point.col, current.point.row, delta.col, delta.row)
+			if !ok {
 				continue
 			}
 			idx := g.index(nc, nr)
@@ -443,6 +482,7 @@ type ComputePathRequest struct {
 	Target    Vec2
 	Width     float64
 	Height    float64
+	Wrap      bool
 	Obstacles []Obstacle
 	Blockers  []Vec2
 }
@@ -450,13 +490,14 @@ type ComputePathRequest struct {
 // ComputePathFrom reproduces the legacy pathfinding helpers used by the world
 // package while remaining agnostic of the legacy world implementation.
 func ComputePathFrom(req ComputePathRequest) ([]Vec2, Vec2, bool) {
-	grid := newNavGrid(req.Obstacles, req.Width, req.Height)
+	bounds := Bounds{Width: req.Width, Height: req.Height, Wrap: req.Wrap}
+	grid := newNavGridInBounds(req.Obstacles, bounds)
 	if grid == nil {
 		return nil, Vec2{}, false
 	}
 	target := Vec2{
-		X: Clamp(req.Target.X, PlayerHalf, req.Width-PlayerHalf),
-		Y: Clamp(req.Target.Y, PlayerHalf, req.Height-PlayerHalf),
+		X: bounds.ConfineX(req.Target.X),
+		Y: bounds.ConfineY(req.Target.Y),
 	}
 	blocked := buildDynamicBlockers(grid, req.Blockers)
 	path, ok := grid.findPath(req.Start, target, blocked)
@@ -483,8 +524,8 @@ func ComputePathFrom(req ComputePathRequest) ([]Vec2, Vec2, bool) {
 	var bestGoal Vec2
 	for _, offset := range offsets {
 		alt := Vec2{
-			X: Clamp(target.X+offset.X, PlayerHalf, req.Width-PlayerHalf),
-			Y: Clamp(target.Y+offset.Y, PlayerHalf, req.Height-PlayerHalf),
+			X: bounds.ConfineX(target.X + offset.X),
+			Y: bounds.ConfineY(target.Y + offset.Y),
 		}
 		if math.Hypot(alt.X-target.X, alt.Y-target.Y) < 1 {
 			continue
@@ -81,3 +81,53 @@ func TestComputeNavigationPathMatchesManual(t *testing.T) {
 		})
 	}
 }
+
+func TestComputePathFromCrossesSeamWhenWrapping(t *testing.T) {
+	req := ComputePathRequest{
+		Start:  Vec2{X: 48, Y: 160},
+		Target: Vec2{X: 592, Y: 160},
+		Width:  640,
+		Height: 320,
+	}
+
+	clampPath, _, ok := ComputePathFrom(req)
+	if !ok {
+		t.Fatalf("expected clamped path to succeed")
+	}
+
+	req.Wrap = true
+	wrapPath, _, ok := ComputePathFrom(req)
+	if !ok {
+		t.Fatalf("expected wrapped path to succeed")
+	}
+	if len(wrapPath) >= len(clampPath) {
+		t.Fatalf("expected wrapped path to be shorter than %d nodes, got %d", len(clampPath), len(wrapPath))
+	}
+	bounds := Bounds{Width: req.Width, Height: req.Height, Wrap: true}
+	if dx, _ := bounds.Delta(req.Start.X, req.Start.Y, wrapPath[0].X, wrapPath[0].Y); dx >= 0 {
+		t.Fatalf("expected wrapped path to head left across the seam, first node %+v", wrapPath[0])
+	}
+}
+
+func TestTraceLineOfSightStopsAtFirstBlockedCell(t *testing.T) {
+	req := LineOfSightRequest{
+		From:      Vec2{X: 100, Y: 112},
+		To:        Vec2{X: 400, Y: 112},
+		Width:     640,
+		Height:    480,
+		Obstacles: []Obstacle{{ID: "wall", X: 256, Y: 96, Width: 32, Height: 32}},
+	}
+
+	end, blocked := TraceLineOfSight(req)
+	if !blocked {
+		t.Fatalf("expected the wall to block the line")
+	}
+	if end.Y != req.From.Y || end.X > 256 || end.X <= req.From.X {
+		t.Fatalf("expected the line to stop short of the wall, got %+v", end)
+	}
+
+	req.Obstacles = nil
+	if end, blocked := TraceLineOfSight(req); blocked || end != req.To {
+		t.Fatalf("expected a clear line to reach %+v, got %+v (blocked=%v)", req.To, end, blocked)
+	}
+}
@@ -29,6 +29,7 @@ type NPCPathController interface {
 	SetIntent(actorID string, dx, dy float64)
 	SetFacing(actorID string, facing string)
 	DeriveFacing(dx, dy float64, fallback string) string
+	Bounds() Bounds
 	ComputeNPCPath(actorID string, target Vec2) ([]Vec2, Vec2, bool)
 }
 
@@ -76,6 +77,9 @@ func FollowNPCPath(actor *NPCPathActor, tick uint64, controller NPCPathControlle
 		node := path.Path[path.PathIndex]
 		dx := node.X - actor.X
 		dy := node.Y - actor.Y
+		if controller != nil {
+			dx, dy = controller.Bounds().Delta(actor.X, actor.Y, node.X, node.Y)
+		}
 		dist := math.Hypot(dx, dy)
 
 		limit := threshold
@@ -0,0 +1,97 @@
+package world
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	state "mine-and-die/server/internal/world/state"
+)
+
+// NPCSpawnWeight assigns a relative share of the configured NPCCount to an NPC type.
+type NPCSpawnWeight struct {
+	Type   state.NPCType
+	Weight float64
+}
+
+// DistributeNPCCount splits total across the weighted entries. Each type
+// receives the floor of its exact share; the leftover NPCs are handed out by
+// weighted draws on the fractional remainders, so every type lands within one
+// of its proportional share and the result only depends on rng.
+func DistributeNPCCount(total int, weights []NPCSpawnWeight, rng *rand.Rand) map[state.NPCType]int {
+	counts := make(map[state.NPCType]int, len(weights))
+	if total <= 0 {
+		return counts
+	}
+
+	sum := 0.0
+	for _, entry := range weights {
+		if entry.Weight > 0 {
+			sum += entry.Weight
+		}
+	}
+	if sum <= 0 {
+		return counts
+	}
+
+	type remainder struct {
+		npcType  state.NPCType
+		fraction float64
+	}
+	remainders := make([]remainder, 0, len(weights))
+	assigned := 0
+	for _, entry := range weights {
+		if entry.Weight <= 0 {
+			continue
+		}
+		exact := float64(total) * entry.Weight / sum
+		whole := int(math.Floor(exact))
+		counts[entry.Type] += whole
+		assigned += whole
+		if fraction := exact - float64(whole); fraction > 0 {
+			remainders = append(remainders, remainder{npcType: entry.Type, fraction: fraction})
+		}
+	}
+
+	for leftover := total - assigned; leftover > 0 && len(remainders) > 0; leftover-- {
+		pool := 0.0
+		for _, r := range remainders {
+			pool += r.fraction
+		}
+		pick := len(remainders) - 1
+		target := RandomFloat(rng) * pool
+		for i, r := range remainders {
+			if target < r.fraction {
+				pick = i
+				break
+			}
+			target -= r.fraction
+		}
+		counts[remainders[pick].npcType]++
+		remainders = append(remainders[:pick], remainders[pick+1:]...)
+	}
+
+	return counts
+}
+
+// WithNPCWeights derives GoblinCount and RatCount from NPCCount using the
+// provided weights. The leftover distribution is seeded from cfg.Seed so the
+// same seed and weights always yield the same population.
+func (cfg Config) WithNPCWeights(weights []NPCSpawnWeight) (Config, error) {
+	for _, entry := range weights {
+		switch entry.Type {
+		case state.NPCTypeGoblin, state.NPCTypeRat:
+		default:
+			return cfg, fmt.Errorf("unknown npc type %q", entry.Type)
+		}
+		if entry.Weight < 0 {
+			return cfg, fmt.Errorf("negative weight for npc type %q", entry.Type)
+		}
+	}
+
+	seed := cfg.normalized().Seed
+	counts := DistributeNPCCount(cfg.NPCCount, weights, NewDeterministicRNG(seed, "npcs.weights"))
+	cfg.GoblinCount = counts[state.NPCTypeGoblin]
+	cfg.RatCount = counts[state.NPCTypeRat]
+	return cfg, nil
+}
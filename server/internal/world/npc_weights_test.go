@@ -0,0 +1,35 @@
+package world
+
+import (
+	"testing"
+
+	state "mine-and-die/server/internal/world/state"
+)
+
+func TestDistributeNPCCountStaysWithinOneOfShare(t *testing.T) {
+	weights := []NPCSpawnWeight{
+		{Type: state.NPCTypeGoblin, Weight: 2},
+		{Type: state.NPCTypeRat, Weight: 5},
+	}
+
+	for total := 0; total <= 40; total++ {
+		counts := DistributeNPCCount(total, weights, NewDeterministicRNG("weights", "npcs.weights"))
+		if sum := counts[state.NPCTypeGoblin] + counts[state.NPCTypeRat]; sum != total {
+			t.Fatalf("total %d: expected counts to sum to total, got %v", total, counts)
+		}
+		exact := float64(total) * 2 / 7
+		if got := float64(counts[state.NPCTypeGoblin]); got < exact-1 || got > exact+1 {
+			t.Fatalf("total %d: goblin count %v strays from share %.2f", total, got, exact)
+		}
+	}
+}
+
+func TestDistributeNPCCountIgnoresNonPositiveWeights(t *testing.T) {
+	counts := DistributeNPCCount(5, []NPCSpawnWeight{
+		{Type: state.NPCTypeGoblin, Weight: 0},
+		{Type: state.NPCTypeRat, Weight: 1},
+	}, NewDeterministicRNG("weights", "npcs.weights"))
+	if counts[state.NPCTypeGoblin] != 0 || counts[state.NPCTypeRat] != 5 {
+		t.Fatalf("expected all NPCs to be rats, got %v", counts)
+	}
+}
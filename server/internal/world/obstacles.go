@@ -117,6 +117,11 @@ func GenerateObstacles(gen ObstacleGenerator, count int) []Obstacle {
 		obstacles = append(obstacles, lavaPools...)
 	}
 
+	if cfg.Stash {
+		stashes := generateStashes(worldW, worldH, obstacles)
+		obstacles = append(obstacles, stashes...)
+	}
+
 	return obstacles
 }
 
@@ -248,3 +253,28 @@ func generateLavaPools(count int, existing []Obstacle) []Obstacle {
 	}
 	return pools
 }
+
+// generateStashes places the bank stash beside the player spawn so freshly
+// joined characters can reach it without crossing the map.
+func generateStashes(worldW, worldH float64, existing []Obstacle) []Obstacle {
+	maxX := worldW - StashSize
+	maxY := worldH - StashSize
+	if maxX < 0 || maxY < 0 {
+		return nil
+	}
+
+	candidate := Obstacle{
+		ID:     "stash-1",
+		Type:   ObstacleTypeStash,
+		X:      Clamp(DefaultSpawnX+PlayerSpawnSafeRadius/2, 0, maxX),
+		Y:      Clamp(DefaultSpawnY-StashSize/2, 0, maxY),
+		Width:  StashSize,
+		Height: StashSize,
+	}
+	for _, obs := range existing {
+		if ObstaclesOverlap(candidate, obs, PlayerHalf) {
+			return nil
+		}
+	}
+	return []Obstacle{candidate}
+}
@@ -28,8 +28,10 @@ type PlayerPathController interface {
 	SetIntent(actorID string, dx, dy float64)
 	SetFacing(actorID string, facing string)
 	DeriveFacing(dx, dy float64, fallback string) string
-	Dimensions() (float64, float64)
+	Bounds() Bounds
 	ComputePlayerPath(actorID string, target Vec2) ([]Vec2, Vec2, bool)
+	// NodeBlocked reports whether an obstacle now covers a path node.
+	NodeBlocked(node Vec2) bool
 }
 
 // AdvancePlayerPaths walks each actor and advances their navigation state.
@@ -80,6 +82,9 @@ func FollowPlayerPath(actor *PlayerPathActor, tick uint64, controller PlayerPath
 		node := path.Path[path.PathIndex]
 		dx := node.X - actor.X
 		dy := node.Y - actor.Y
+		if controller != nil {
+			dx, dy = controller.Bounds().Delta(actor.X, actor.Y, node.X, node.Y)
+		}
 		dist := math.Hypot(dx, dy)
 
 		limit := threshold
@@ -98,6 +103,16 @@ func FollowPlayerPath(actor *PlayerPathActor, tick uint64, controller PlayerPath
 			continue
 		}
 
+		// An obstacle placed across the path after it was planned blocks the
+		// next waypoint; stop and route around it instead of pushing into it.
+		if controller != nil && controller.NodeBlocked(node) {
+			controller.SetIntent(actor.ID, 0, 0)
+			if tick >= path.PathRecalcTick && RecalculatePlayerPath(actor, tick, controller) {
+				FollowPlayerPath(actor, tick, controller)
+			}
+			return
+		}
+
 		if path.PathLastDistance == 0 || dist+0.1 < path.PathLastDistance {
 			path.PathLastDistance = dist
 			path.PathStallTicks = 0
@@ -162,17 +177,17 @@ func ClearPlayerPath(actor *PlayerPathActor) {
 }
 
 // EnsurePlayerPath computes and installs a navigation path to the requested
-// target, clamping to the playable area and recording recalc metadata when the
+// target, confining it to the playable area and recording recalc metadata when the
 // request fails.
 func EnsurePlayerPath(actor *PlayerPathActor, target Vec2, tick uint64, controller PlayerPathController) bool {
 	if actor == nil || actor.Path == nil || controller == nil {
 		return false
 	}
 
-	width, height := controller.Dimensions()
+	bounds := controller.Bounds()
 	actor.Path.PathTarget = Vec2{
-		X: Clamp(target.X, PlayerHalf, width-PlayerHalf),
-		Y: Clamp(target.Y, PlayerHalf, height-PlayerHalf),
+		X: bounds.ConfineX(target.X),
+		Y: bounds.ConfineY(target.Y),
 	}
 
 	path, goal, ok := controller.ComputePlayerPath(actor.ID, actor.Path.PathTarget)
@@ -42,10 +42,12 @@ type LegacyProjectileStepAdvanceConfig struct {
 
 	WorldWidth  float64
 	WorldHeight float64
+	WrapBounds  bool
 
 	ComputeArea        func() Obstacle
 	AnyObstacleOverlap func(Obstacle) bool
 	SetPosition        func(x, y float64)
+	LocateActor        func(id string) (x, y float64, ok bool)
 
 	StopBindings   ProjectileStopConfig
 	BindStopConfig func(ProjectileStopConfig, any, time.Time) any
@@ -70,9 +72,11 @@ type LegacyProjectileStepConfig struct {
 	HasProjectile func(effect any) bool
 
 	Dimensions         func() (float64, float64)
+	WrapBounds         bool
 	ComputeArea        func(effect any) Obstacle
 	AnyObstacleOverlap func(Obstacle) bool
 	SetPosition        func(effect any, x, y float64)
+	LocateActor        func(id string) (x, y float64, ok bool)
 
 	StopAdapter         ProjectileStopAdapter
 	BindStopConfig      func(ProjectileStopConfig, any, time.Time) any
@@ -115,6 +119,7 @@ func AdvanceLegacyProjectile(cfg LegacyProjectileStepConfig) LegacyProjectileSte
 		Now:         cfg.Now,
 		WorldWidth:  worldWidth,
 		WorldHeight: worldHeight,
+		WrapBounds:  cfg.WrapBounds,
 		ComputeArea: func() Obstacle {
 			if cfg.ComputeArea == nil {
 				return Obstacle{}
@@ -133,6 +138,7 @@ func AdvanceLegacyProjectile(cfg LegacyProjectileStepConfig) LegacyProjectileSte
 			}
 			cfg.SetPosition(cfg.Effect, x, y)
 		},
+		LocateActor:         cfg.LocateActor,
 		StopBindings:        stopBindings,
 		BindStopConfig:      cfg.BindStopConfig,
 		RecordAttackOverlap: cfg.RecordAttackOverlap,
@@ -4,6 +4,8 @@ import (
 	"hash/fnv"
 	"math"
 	"math/rand"
+
+	"mine-and-die/server/internal/sim"
 )
 
 const centralSpawnRegionRatio = 0.5
@@ -25,6 +27,54 @@ func NewDeterministicRNG(rootSeed, label string) *rand.Rand {
 	return rand.New(rand.NewSource(seedValue))
 }
 
+// RNGState is the serializable position of a deterministic RNG stream.
+type RNGState = sim.RNGState
+
+// RNGSource is a math/rand source that counts the values drawn from it so the
+// stream position can be captured and restored. It yields exactly the values
+// of rand.NewSource for the same seed.
+type RNGSource struct {
+	seed  int64
+	draws uint64
+	src   rand.Source64
+}
+
+// NewRNGSource returns a counting source seeded with seed.
+func NewRNGSource(seed int64) *RNGSource {
+	return &RNGSource{seed: seed, src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *RNGSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *RNGSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *RNGSource) Seed(seed int64) {
+	s.seed = seed
+	s.draws = 0
+	s.src.Seed(seed)
+}
+
+// State reports the seed and the number of values drawn since seeding.
+func (s *RNGSource) State() RNGState {
+	return RNGState{Seed: s.seed, Draws: s.draws}
+}
+
+// Restore reseeds the source and replays draws until it reaches the captured
+// position. Replay cost is linear in the number of draws.
+func (s *RNGSource) Restore(state RNGState) {
+	s.Seed(state.Seed)
+	for s.draws < state.Draws {
+		s.src.Uint64()
+		s.draws++
+	}
+}
+
 func RandomFloat(rng *rand.Rand) float64 {
 	if rng == nil {
 		return rand.New(rand.NewSource(DeterministicSeedValue(DefaultSeed, "world"))).Float64()
@@ -1,17 +1,26 @@
 package world
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+)
 
 // NPCSpawner exposes the legacy world surface required for deterministic NPC seeding.
 type NPCSpawner interface {
 	Config() Config
 	Dimensions() (float64, float64)
 	SubsystemRNG(label string) *rand.Rand
-	SpawnGoblinAt(x, y float64, waypoints []Vec2, goldQty, potionQty int)
+	SpawnGoblinAt(x, y float64, waypoints []Vec2)
 	SpawnRatAt(x, y float64)
+	SpawnBossAt(x, y float64)
 }
 
-// SeedInitialNPCs mirrors the legacy spawn layout for goblins and rats.
+// bossRingRadius is how far from the centre of the map seeded bosses stand
+// guard.
+const bossRingRadius = 360.0
+
+// SeedInitialNPCs mirrors the legacy spawn layout for goblins and rats, then
+// places the configured bosses.
 func SeedInitialNPCs(spawner NPCSpawner) {
 	if spawner == nil {
 		return
@@ -22,12 +31,18 @@ func SeedInitialNPCs(spawner NPCSpawner) {
 		return
 	}
 
+	SpawnBosses(spawner, cfg.BossCount)
+
 	goblinTarget := cfg.GoblinCount
 	ratTarget := cfg.RatCount
 	if goblinTarget <= 0 && ratTarget <= 0 {
 		return
 	}
 
+	if len(cfg.PatrolRoutes) > 0 {
+		spawner = &patrolRouteSpawner{NPCSpawner: spawner, routes: cfg.PatrolRoutes}
+	}
+
 	centerX := DefaultSpawnX
 	centerY := DefaultSpawnY
 
@@ -39,7 +54,7 @@ func SeedInitialNPCs(spawner NPCSpawner) {
 			{X: centerX + patrolOffset, Y: centerY - patrolOffset},
 			{X: centerX + patrolOffset, Y: centerY + patrolOffset},
 			{X: centerX - patrolOffset, Y: centerY + patrolOffset},
-		}, 12, 1)
+		})
 		goblinsSpawned++
 	}
 	if goblinTarget >= 2 {
@@ -52,7 +67,7 @@ func SeedInitialNPCs(spawner NPCSpawner) {
 			{X: topLeftX + width, Y: topLeftY},
 			{X: topLeftX + width, Y: topLeftY + height},
 			{X: topLeftX, Y: topLeftY + height},
-		}, 8, 1)
+		})
 		goblinsSpawned++
 	}
 	extraGoblins := goblinTarget - goblinsSpawned
@@ -71,6 +86,24 @@ func SeedInitialNPCs(spawner NPCSpawner) {
 	}
 }
 
+// SpawnBosses spaces count bosses evenly on a ring around the centre of the
+// map, starting due north, kept inside the world bounds.
+func SpawnBosses(spawner NPCSpawner, count int) {
+	if spawner == nil || count <= 0 {
+		return
+	}
+
+	width, height := spawner.Dimensions()
+	centerX := width / 2
+	centerY := height / 2
+	for i := 0; i < count; i++ {
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(count)
+		x := Clamp(centerX+math.Cos(angle)*bossRingRadius, PlayerHalf, width-PlayerHalf)
+		y := Clamp(centerY+math.Sin(angle)*bossRingRadius, PlayerHalf, height-PlayerHalf)
+		spawner.SpawnBossAt(x, y)
+	}
+}
+
 // SpawnExtraGoblins distributes extra goblins around the map perimeter.
 func SpawnExtraGoblins(spawner NPCSpawner, count int) {
 	if spawner == nil || count <= 0 {
@@ -133,7 +166,7 @@ func SpawnExtraGoblins(spawner NPCSpawner, count int) {
 			{X: topLeftX, Y: bottomY},
 		}
 
-		spawner.SpawnGoblinAt(topLeftX, topLeftY, waypoints, 10, 1)
+		spawner.SpawnGoblinAt(topLeftX, topLeftY, waypoints)
 	}
 }
 
@@ -164,3 +197,22 @@ func SpawnExtraRats(spawner NPCSpawner, count int) {
 		spawner.SpawnRatAt(x, y)
 	}
 }
+
+// patrolRouteSpawner hands the configured patrol routes to goblins in spawn
+// order. Goblins spawned after the routes run out keep their generated loops.
+type patrolRouteSpawner struct {
+	NPCSpawner
+	routes  []PatrolRoute
+	goblins int
+}
+
+func (s *patrolRouteSpawner) SpawnGoblinAt(x, y float64, waypoints []Vec2) {
+	if s.goblins < len(s.routes) {
+		if route := s.routes[s.goblins].Vec2s(); len(route) > 0 {
+			x, y = route[0].X, route[0].Y
+			waypoints = route
+		}
+	}
+	s.goblins++
+	s.NPCSpawner.SpawnGoblinAt(x, y, waypoints)
+}
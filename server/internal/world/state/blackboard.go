@@ -19,6 +19,8 @@ type Blackboard struct {
 	StuckCounter      uint8
 	TargetActorID     string
 	ChaseUntil        uint64
+	TauntedBy         string
+	TauntUntil        uint64
 	PauseTicks        uint64
 	PatrolSpeed       float64
 	StuckEpsilon      float64
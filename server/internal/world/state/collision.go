@@ -0,0 +1,40 @@
+package state
+
+// CollisionLayer is a bitmask of actor-separation layers. An actor occupies
+// the layers in its CollisionLayer and is pushed apart only from actors whose
+// layer appears in its CollisionMask (and vice versa). Zero values select the
+// defaults so existing actors keep separating from everyone.
+type CollisionLayer uint32
+
+const (
+	// CollisionLayerDefault is the layer occupied by actors that leave their
+	// layer unset.
+	CollisionLayerDefault CollisionLayer = 1 << iota
+	// CollisionLayerGhost is for actors that drift through the default layer.
+	CollisionLayerGhost
+	// CollisionLayerFriendly is for allied units that should not shove each
+	// other when their mask excludes it.
+	CollisionLayerFriendly
+
+	// CollisionLayerAll matches every layer and is the mask used when unset.
+	CollisionLayerAll CollisionLayer = ^CollisionLayer(0)
+)
+
+// EffectiveCollision resolves unset layer and mask values to their defaults.
+func EffectiveCollision(layer, mask CollisionLayer) (CollisionLayer, CollisionLayer) {
+	if layer == 0 {
+		layer = CollisionLayerDefault
+	}
+	if mask == 0 {
+		mask = CollisionLayerAll
+	}
+	return layer, mask
+}
+
+// CollisionLayersInteract reports whether two actors should be separated.
+// Both sides must accept the other's layer.
+func CollisionLayersInteract(layerA, maskA, layerB, maskB CollisionLayer) bool {
+	layerA, maskA = EffectiveCollision(layerA, maskA)
+	layerB, maskB = EffectiveCollision(layerB, maskB)
+	return layerA&maskB != 0 && layerB&maskA != 0
+}
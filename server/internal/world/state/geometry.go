@@ -1,7 +1,22 @@
 package state
 
+import "math"
+
 // Vec2 represents a 2D point used across player and NPC state.
 type Vec2 struct {
 	X float64
 	Y float64
 }
+
+// WrapCoordinate maps value into [0, size). A non-positive size leaves value
+// unchanged.
+func WrapCoordinate(value, size float64) float64 {
+	if size <= 0 {
+		return value
+	}
+	wrapped := math.Mod(value, size)
+	if wrapped < 0 {
+		wrapped += size
+	}
+	return wrapped
+}
@@ -37,7 +37,11 @@ func (inv Inventory) Clone() Inventory {
 	return Inventory{Slots: slots}
 }
 
-// AddStack merges stackable items and returns the slot index that was affected.
+// AddStack merges stackable items into the slots holding the same item type
+// and fungibility key, and returns the first slot index that was affected.
+// Slots restored without a fungibility key take the definition's key so they
+// still merge. Types with a MaxStack fill existing slots up to the cap and
+// spill the overflow into new slots of at most MaxStack each.
 func (inv *Inventory) AddStack(stack ItemStack) (int, error) {
 	if stack.Quantity <= 0 {
 		return -1, fmt.Errorf("quantity must be positive, got %d", stack.Quantity)
@@ -54,19 +58,55 @@ func (inv *Inventory) AddStack(stack ItemStack) (int, error) {
 		return -1, fmt.Errorf("fungibility key %q does not match definition %q", stack.FungibilityKey, def.FungibilityKey)
 	}
 
-	if def.Stackable {
-		for i := range inv.Slots {
-			if inv.Slots[i].Item.FungibilityKey != stack.FungibilityKey {
-				continue
-			}
-			inv.Slots[i].Item.Quantity += stack.Quantity
-			return inv.Slots[i].Slot, nil
+	if !def.Stackable {
+		slot := InventorySlot{Slot: len(inv.Slots), Item: stack}
+		inv.Slots = append(inv.Slots, slot)
+		return slot.Slot, nil
+	}
+
+	first := -1
+	remaining := stack.Quantity
+	for i := range inv.Slots {
+		item := &inv.Slots[i].Item
+		if item.Type != stack.Type {
+			continue
+		}
+		if item.FungibilityKey == "" {
+			item.FungibilityKey = def.FungibilityKey
+		}
+		if item.FungibilityKey != stack.FungibilityKey {
+			continue
+		}
+		take := remaining
+		if def.MaxStack > 0 {
+			take = min(take, def.MaxStack-item.Quantity)
+		}
+		if take <= 0 {
+			continue
+		}
+		item.Quantity += take
+		remaining -= take
+		if first < 0 {
+			first = inv.Slots[i].Slot
+		}
+		if remaining == 0 {
+			return first, nil
 		}
 	}
 
-	slot := InventorySlot{Slot: len(inv.Slots), Item: stack}
-	inv.Slots = append(inv.Slots, slot)
-	return slot.Slot, nil
+	for remaining > 0 {
+		quantity := remaining
+		if def.MaxStack > 0 {
+			quantity = min(quantity, def.MaxStack)
+		}
+		slot := InventorySlot{Slot: len(inv.Slots), Item: ItemStack{Type: stack.Type, FungibilityKey: stack.FungibilityKey, Quantity: quantity}}
+		inv.Slots = append(inv.Slots, slot)
+		remaining -= quantity
+		if first < 0 {
+			first = slot.Slot
+		}
+	}
+	return first, nil
 }
 
 // MoveSlot reorders an item to a new index while preserving slot metadata.
@@ -14,6 +14,9 @@ const (
 	ItemTypeRefinedOre    ItemType = "refined_ore"
 )
 
+// MaxPotionStack is how many healing potions fit in one inventory slot.
+const MaxPotionStack = 10
+
 var itemCatalog = buildItemCatalog()
 
 func buildItemCatalog() map[ItemType]ItemDefinition {
@@ -34,6 +37,7 @@ func buildItemCatalog() map[ItemType]ItemDefinition {
 			Class:     ItemClassConsumable,
 			Tier:      1,
 			Stackable: true,
+			MaxStack:  MaxPotionStack,
 			Actions:   []ItemAction{ItemActionConsume},
 			Modifiers: []ItemModifier{
 				{Type: "heal_flat", Magnitude: 25},
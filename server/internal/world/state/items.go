@@ -135,6 +135,7 @@ type ItemDefinition struct {
 	Class          ItemClass      `json:"class"`
 	Tier           int            `json:"tier"`
 	Stackable      bool           `json:"stackable"`
+	MaxStack       int            `json:"max_stack,omitempty"`
 	FungibilityKey string         `json:"fungibility_key"`
 	EquipSlot      EquipSlot      `json:"equip_slot,omitempty"`
 	Actions        []ItemAction   `json:"actions"`
@@ -152,6 +153,7 @@ type ItemDefinitionParams struct {
 	Class        ItemClass
 	Tier         int
 	Stackable    bool
+	MaxStack     int // items per inventory slot for stackable types; zero is unlimited
 	EquipSlot    EquipSlot
 	Actions      []ItemAction
 	Modifiers    []ItemModifier
@@ -208,6 +210,14 @@ func NewItemDefinition(params ItemDefinitionParams) (ItemDefinition, error) {
 		return modifiers[i].Type < modifiers[j].Type
 	})
 
+	if params.MaxStack < 0 {
+		return ItemDefinition{}, fmt.Errorf("max stack must not be negative, got %d", params.MaxStack)
+	}
+	maxStack := params.MaxStack
+	if !params.Stackable {
+		maxStack = 0
+	}
+
 	recycleValue := params.RecycleValue
 	if recycleValue <= 0 {
 		recycleValue = defaultRecycleValue
@@ -220,6 +230,7 @@ func NewItemDefinition(params ItemDefinitionParams) (ItemDefinition, error) {
 		Class:          params.Class,
 		Tier:           params.Tier,
 		Stackable:      params.Stackable,
+		MaxStack:       maxStack,
 		FungibilityKey: key,
 		EquipSlot:      equipSlot,
 		Actions:        actionSet,
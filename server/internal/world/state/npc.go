@@ -10,8 +10,10 @@ import (
 type NPCType string
 
 const (
-	NPCTypeGoblin NPCType = "goblin"
-	NPCTypeRat    NPCType = "rat"
+	NPCTypeGoblin   NPCType = "goblin"
+	NPCTypeRat      NPCType = "rat"
+	NPCTypeBoss     NPCType = "boss"
+	NPCTypeFamiliar NPCType = "familiar"
 )
 
 // NPC describes an AI-controlled entity mirrored to the client.
@@ -34,6 +36,12 @@ type NPCState struct {
 	Home             Vec2
 	Cooldowns        map[string]time.Time
 	Version          uint64
+	// Phase counts the health-threshold phases the NPC has entered.
+	Phase uint8
+	// OwnerID names the player that summoned the NPC, if any.
+	OwnerID string
+	// DespawnTick is the tick at which a summoned NPC vanishes.
+	DespawnTick uint64
 }
 
 // Snapshot returns a sanitized NPC snapshot for serialization.
@@ -15,13 +15,23 @@ type Actor struct {
 	Facing    FacingDirection `json:"facing"`
 	Health    float64         `json:"health"`
 	MaxHealth float64         `json:"maxHealth"`
+	Absorb    float64         `json:"absorb,omitempty"`
 	Inventory Inventory       `json:"inventory"`
 	Equipment Equipment       `json:"equipment"`
+	// CollisionLayer and CollisionMask select which actors this one is
+	// separated from; see CollisionLayersInteract.
+	CollisionLayer CollisionLayer `json:"collisionLayer,omitempty"`
+	CollisionMask  CollisionLayer `json:"collisionMask,omitempty"`
+	// InCombat reports whether the actor dealt or took damage within the
+	// world's combat timeout; LastDamagedBy names who last hurt it then.
+	InCombat      bool   `json:"inCombat,omitempty"`
+	LastDamagedBy string `json:"lastDamagedBy,omitempty"`
 }
 
 // Player mirrors the actor state for human-controlled characters.
 type Player struct {
 	Actor
+	Name string `json:"name,omitempty"`
 }
 
 type FacingDirection string
@@ -102,6 +112,8 @@ type ActorState struct {
 	IntentX       float64
 	IntentY       float64
 	StatusEffects map[StatusEffectType]*StatusEffectInstance
+	// LastCombatTick is the last tick the actor dealt or took damage.
+	LastCombatTick uint64
 }
 
 type PlayerPathState struct {
@@ -158,9 +170,27 @@ type PlayerState struct {
 	Cooldowns     map[string]time.Time
 	Path          PlayerPathState
 	Version       uint64
+	// AbsorbExpiresAt is when the remaining Absorb pool lapses.
+	AbsorbExpiresAt time.Time
+	// DisplayName is the validated name clients render for the player.
+	DisplayName string
+	// Session accumulates activity since the player joined.
+	Session SessionStats
+}
+
+// SessionStats counts a player's activity for post-match scoreboards. It lives
+// on the player state, so it survives reconnects and starts over when a new
+// player joins.
+type SessionStats struct {
+	DamageDealt      float64 `json:"damageDealt"`
+	DamageTaken      float64 `json:"damageTaken"`
+	Kills            int     `json:"kills"`
+	Assists          int     `json:"assists"`
+	GoldCollected    int     `json:"goldCollected"`
+	DistanceTraveled float64 `json:"distanceTraveled"`
 }
 
 // Snapshot returns a sanitized player snapshot for serialization.
 func (s *PlayerState) Snapshot() Player {
-	return Player{Actor: s.SnapshotActor()}
+	return Player{Actor: s.SnapshotActor(), Name: s.DisplayName}
 }
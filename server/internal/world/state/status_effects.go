@@ -11,6 +11,7 @@ type StatusEffectInstance struct {
 	ExpiresAt      time.Time
 	NextTick       time.Time
 	LastTick       time.Time
+	Stacks         int
 	attachedEffect any
 	actor          *ActorState
 }
@@ -2,6 +2,20 @@ package status
 
 import "time"
 
+// StackPolicy decides what reapplying a status effect does while an instance
+// of it is still active on the actor.
+type StackPolicy string
+
+const (
+	// StackRefresh resets the expiry without changing the instance. An empty
+	// policy behaves the same way.
+	StackRefresh StackPolicy = "refresh"
+	// StackAdd refreshes the expiry and increments the instance's stack count.
+	StackAdd StackPolicy = "add"
+	// StackIgnore leaves the active instance untouched.
+	StackIgnore StackPolicy = "ignore"
+)
+
 // ApplyStatusEffectDefinition describes the runtime behavior for a single
 // status effect when applying it to an actor. The legacy world wrapper provides
 // closures that already capture its dependencies so the shared helper can
@@ -10,6 +24,7 @@ type ApplyStatusEffectDefinition struct {
 	Duration     time.Duration
 	TickInterval time.Duration
 	InitialTick  bool
+	Stacking     StackPolicy
 
 	State any
 
@@ -48,6 +63,9 @@ type StatusEffectInstanceHandle struct {
 	NextTick    func() time.Time
 	SetLastTick func(time.Time)
 
+	SetStacks func(int)
+	Stacks    func() int
+
 	Attachment StatusEffectInstanceAttachment
 }
 
@@ -86,7 +104,10 @@ type StatusEffectDefinition struct {
 }
 
 const (
-	StatusEffectBurning StatusEffectType = "burning"
+	StatusEffectBurning   StatusEffectType = "burning"
+	StatusEffectParrying  StatusEffectType = "parrying"
+	StatusEffectHasted    StatusEffectType = "hasted"
+	StatusEffectStealthed StatusEffectType = "stealthed"
 )
 
 // StatusEffectType implements state.StatusEffectDefinitionView so shared state
@@ -103,7 +124,19 @@ func (def *StatusEffectDefinition) StatusEffectType() string {
 // registered along with the callbacks required to drive their runtime
 // behaviour.
 type StatusEffectDefinitionsConfig struct {
-	Burning BurningStatusEffectDefinitionConfig
+	Burning   BurningStatusEffectDefinitionConfig
+	Parrying  TimedStatusEffectDefinitionConfig
+	Hasted    TimedStatusEffectDefinitionConfig
+	Stealthed TimedStatusEffectDefinitionConfig
+}
+
+// TimedStatusEffectDefinitionConfig describes a status effect that has no tick
+// or visual behaviour of its own. It only marks the actor until it expires, so
+// other systems can check for it.
+type TimedStatusEffectDefinitionConfig struct {
+	Type     string
+	Duration time.Duration
+	Stacking StackPolicy
 }
 
 // BurningStatusEffectDefinitionConfig carries the configuration required to
@@ -114,6 +147,7 @@ type BurningStatusEffectDefinitionConfig struct {
 	Duration     time.Duration
 	TickInterval time.Duration
 	InitialTick  bool
+	Stacking     StackPolicy
 
 	OnApply  func(StatusEffectApplyRuntime)
 	OnTick   func(StatusEffectTickRuntime)
@@ -132,10 +166,27 @@ func NewStatusEffectDefinitions(cfg StatusEffectDefinitionsConfig) map[string]Ap
 	if cfg.Burning.Type != "" {
 		defs[cfg.Burning.Type] = newBurningStatusEffectDefinition(cfg.Burning)
 	}
+	if cfg.Parrying.Type != "" {
+		defs[cfg.Parrying.Type] = newTimedStatusEffectDefinition(cfg.Parrying)
+	}
+	if cfg.Hasted.Type != "" {
+		defs[cfg.Hasted.Type] = newTimedStatusEffectDefinition(cfg.Hasted)
+	}
+	if cfg.Stealthed.Type != "" {
+		defs[cfg.Stealthed.Type] = newTimedStatusEffectDefinition(cfg.Stealthed)
+	}
 
 	return defs
 }
 
+func newTimedStatusEffectDefinition(cfg TimedStatusEffectDefinitionConfig) ApplyStatusEffectDefinition {
+	return ApplyStatusEffectDefinition{
+		Duration: cfg.Duration,
+		Stacking: cfg.Stacking,
+		State:    &StatusEffectDefinition{Type: cfg.Type},
+	}
+}
+
 func newBurningStatusEffectDefinition(cfg BurningStatusEffectDefinitionConfig) ApplyStatusEffectDefinition {
 	state := &StatusEffectDefinition{
 		Type:         cfg.Type,
@@ -167,6 +218,7 @@ func newBurningStatusEffectDefinition(cfg BurningStatusEffectDefinitionConfig) A
 		Duration:     cfg.Duration,
 		TickInterval: cfg.TickInterval,
 		InitialTick:  cfg.InitialTick,
+		Stacking:     cfg.Stacking,
 		State:        state,
 	}
 
@@ -197,13 +249,17 @@ type ApplyStatusEffectConfig struct {
 	NewInstance      func() StatusEffectInstanceHandle
 	StoreInstance    func(StatusEffectInstanceHandle)
 
-	RecordApplied func(time.Duration)
+	RecordApplied   func(time.Duration)
+	RecordRefreshed func(time.Duration)
+	RecordTick      func(time.Time)
 }
 
 // ApplyStatusEffect applies or refreshes the requested status effect using the
 // provided adapters. The helper mirrors the legacy behavior by performing
 // initial tick handling, attachment bookkeeping, and telemetry logging when the
-// effect is newly applied.
+// effect is newly applied. Reapplying an active effect follows the
+// definition's stacking policy and reports the refresh. An initial tick is
+// reported after the application so log readers see apply before tick.
 func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 	if cfg.Type == "" || cfg.LookupDefinition == nil {
 		return false
@@ -222,6 +278,13 @@ func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 	}
 
 	if inst, exists := cfg.FindInstance(); exists {
+		if def.Stacking == StackIgnore {
+			return false
+		}
+		if def.Stacking == StackAdd && inst.SetStacks != nil && inst.Stacks != nil {
+			inst.SetStacks(inst.Stacks() + 1)
+		}
+
 		if inst.SetSourceID != nil {
 			inst.SetSourceID(cfg.SourceID)
 		}
@@ -247,6 +310,10 @@ func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 			inst.Attachment.Extend(expiresAt)
 		}
 
+		if cfg.RecordRefreshed != nil {
+			cfg.RecordRefreshed(def.Duration)
+		}
+
 		return false
 	}
 
@@ -254,6 +321,9 @@ func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 	if inst.SetSourceID != nil {
 		inst.SetSourceID(cfg.SourceID)
 	}
+	if inst.SetStacks != nil {
+		inst.SetStacks(1)
+	}
 	if inst.SetAppliedAt != nil {
 		inst.SetAppliedAt(cfg.Now)
 	}
@@ -281,7 +351,8 @@ func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 		def.OnApply(inst, cfg.Now)
 	}
 
-	if def.InitialTick && def.OnTick != nil {
+	initialTick := def.InitialTick && def.OnTick != nil
+	if initialTick {
 		def.OnTick(inst, cfg.Now)
 		if inst.SetLastTick != nil {
 			inst.SetLastTick(cfg.Now)
@@ -298,6 +369,9 @@ func ApplyStatusEffect(cfg ApplyStatusEffectConfig) bool {
 	if cfg.RecordApplied != nil {
 		cfg.RecordApplied(def.Duration)
 	}
+	if initialTick && cfg.RecordTick != nil {
+		cfg.RecordTick(cfg.Now)
+	}
 
 	return true
 }
@@ -345,6 +419,8 @@ type AdvanceActorStatusEffectsConfig struct {
 	Remove          func(key string)
 
 	RecordEffectEnd func(any)
+	RecordTick      func(key string, instance any, at time.Time)
+	RecordExpired   func(key string, instance any, at time.Time)
 }
 
 // AdvanceStatusEffectsActorIterator enumerates actors that have status effect
@@ -421,6 +497,9 @@ func AdvanceActorStatusEffects(cfg AdvanceActorStatusEffectsConfig) {
 				if instCfg.SetLastTick != nil {
 					instCfg.SetLastTick(tickAt)
 				}
+				if cfg.RecordTick != nil {
+					cfg.RecordTick(key, instance, tickAt)
+				}
 
 				nextTick = nextTick.Add(interval)
 				if instCfg.SetNextTick != nil {
@@ -455,6 +534,9 @@ func AdvanceActorStatusEffects(cfg AdvanceActorStatusEffectsConfig) {
 					instCfg.Attachment.Clear()
 				}
 			}
+			if cfg.RecordExpired != nil {
+				cfg.RecordExpired(key, instance, cfg.Now)
+			}
 
 			cfg.Remove(key)
 			return
@@ -38,6 +38,7 @@ type applyInstanceStub struct {
 	expiresAt  time.Time
 	nextTick   time.Time
 	lastTick   time.Time
+	stacks     int
 }
 
 type applyAttachmentStub struct {
@@ -93,6 +94,18 @@ func newApplyInstanceHandle(inst *applyInstanceStub, attachment *applyAttachment
 			}
 			inst.lastTick = at
 		},
+		SetStacks: func(value int) {
+			if inst == nil {
+				return
+			}
+			inst.stacks = value
+		},
+		Stacks: func() int {
+			if inst == nil {
+				return 0
+			}
+			return inst.stacks
+		},
 		Attachment: StatusEffectInstanceAttachment{
 			SetStatus: func(effectType string) {
 				if attachment == nil {
@@ -338,6 +351,91 @@ func TestApplyStatusEffectRefreshesExistingInstance(t *testing.T) {
 	}
 }
 
+func reapplyStatusEffect(t *testing.T, now time.Time, stacking StackPolicy, instance *applyInstanceStub, applies *int) bool {
+	t.Helper()
+	return ApplyStatusEffect(ApplyStatusEffectConfig{
+		Now:      now,
+		Type:     "buff",
+		SourceID: "caster",
+		LookupDefinition: func() (ApplyStatusEffectDefinition, bool) {
+			return ApplyStatusEffectDefinition{
+				Duration: 2 * time.Second,
+				Stacking: stacking,
+				OnApply: func(StatusEffectInstanceHandle, time.Time) {
+					*applies++
+				},
+			}, true
+		},
+		FindInstance: func() (StatusEffectInstanceHandle, bool) {
+			if instance.stacks == 0 {
+				return StatusEffectInstanceHandle{}, false
+			}
+			return newApplyInstanceHandle(instance, nil), true
+		},
+		NewInstance: func() StatusEffectInstanceHandle {
+			return newApplyInstanceHandle(instance, nil)
+		},
+		StoreInstance: func(StatusEffectInstanceHandle) {},
+	})
+}
+
+func TestApplyStatusEffectRefreshPolicyExtendsWithoutStacking(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	instance := &applyInstanceStub{}
+	applies := 0
+
+	if !reapplyStatusEffect(t, start, StackRefresh, instance, &applies) {
+		t.Fatalf("expected first application to create the instance")
+	}
+	later := start.Add(time.Second)
+	if reapplyStatusEffect(t, later, StackRefresh, instance, &applies) {
+		t.Fatalf("expected reapplication to refresh rather than create")
+	}
+
+	if want := later.Add(2 * time.Second); !instance.expiresAt.Equal(want) {
+		t.Fatalf("expected refresh to extend expiry to %v, got %v", want, instance.expiresAt)
+	}
+	if instance.stacks != 1 {
+		t.Fatalf("expected refresh to keep a single stack, got %d", instance.stacks)
+	}
+	if applies != 1 {
+		t.Fatalf("expected OnApply to run once, got %d", applies)
+	}
+}
+
+func TestApplyStatusEffectAddPolicyIncrementsStacks(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	instance := &applyInstanceStub{}
+	applies := 0
+
+	for i := 0; i < 3; i++ {
+		reapplyStatusEffect(t, start.Add(time.Duration(i)*time.Second), StackAdd, instance, &applies)
+	}
+
+	if instance.stacks != 3 {
+		t.Fatalf("expected three stacks, got %d", instance.stacks)
+	}
+	if want := start.Add(4 * time.Second); !instance.expiresAt.Equal(want) {
+		t.Fatalf("expected expiry refreshed to %v, got %v", want, instance.expiresAt)
+	}
+}
+
+func TestApplyStatusEffectIgnorePolicyLeavesInstanceUntouched(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	instance := &applyInstanceStub{}
+	applies := 0
+
+	reapplyStatusEffect(t, start, StackIgnore, instance, &applies)
+	reapplyStatusEffect(t, start.Add(time.Second), StackIgnore, instance, &applies)
+
+	if want := start.Add(2 * time.Second); !instance.expiresAt.Equal(want) {
+		t.Fatalf("expected ignored reapplication to keep expiry %v, got %v", want, instance.expiresAt)
+	}
+	if instance.stacks != 1 {
+		t.Fatalf("expected a single stack, got %d", instance.stacks)
+	}
+}
+
 func TestApplyStatusEffectSkipsWhenDefinitionMissing(t *testing.T) {
 	called := false
 	applied := ApplyStatusEffect(ApplyStatusEffectConfig{
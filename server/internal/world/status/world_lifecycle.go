@@ -19,6 +19,9 @@ type BurningLifecycleConfig struct {
 	VisualEffectType string
 	VisualFootprint  float64
 	DamagePerSecond  float64
+	// DamageRate, when set, is read on every tick in place of
+	// DamagePerSecond so the rate can be retuned while burns are active.
+	DamageRate func() float64
 
 	BuildContractVisualIntent func(BurningContractVisualConfig) (effectcontract.EffectIntent, bool)
 	EnqueueIntent             func(effectcontract.EffectIntent)
@@ -161,7 +164,14 @@ func newBurningLifecycleApplyHook(cfg BurningLifecycleConfig, state *StatusEffec
 
 func newBurningLifecycleTickHook(cfg BurningLifecycleConfig, state *StatusEffectDefinition) func(StatusEffectTickRuntime) {
 	return func(rt StatusEffectTickRuntime) {
-		if cfg.ApplyDamage == nil || cfg.DamagePerSecond <= 0 {
+		if cfg.ApplyDamage == nil {
+			return
+		}
+		rate := cfg.DamagePerSecond
+		if cfg.DamageRate != nil {
+			rate = cfg.DamageRate()
+		}
+		if rate <= 0 {
 			return
 		}
 
@@ -190,7 +200,7 @@ func newBurningLifecycleTickHook(cfg BurningLifecycleConfig, state *StatusEffect
 			return
 		}
 
-		damage := cfg.DamagePerSecond * interval.Seconds()
+		damage := rate * interval.Seconds()
 		if damage <= 0 {
 			return
 		}
@@ -70,14 +70,7 @@ func (w *World) ApplyStatusEffect(target *state.ActorState, cond statuspkg.Statu
 			if w == nil {
 				return
 			}
-			actorRef := logging.EntityRef{}
-			if source != "" {
-				actorRef = w.entityRef(source)
-			}
-			targetRef := logging.EntityRef{}
-			if target != nil {
-				targetRef = w.entityRef(target.ID)
-			}
+			actorRef, targetRef := w.statusEffectRefs(source, target.ID)
 			payload := loggingstatuseffects.AppliedPayload{StatusEffect: string(cond), SourceID: source}
 			if duration > 0 {
 				payload.DurationMs = duration.Milliseconds()
@@ -92,9 +85,57 @@ func (w *World) ApplyStatusEffect(target *state.ActorState, cond statuspkg.Statu
 				nil,
 			)
 		},
+		RecordRefreshed: func(duration time.Duration) {
+			if w == nil {
+				return
+			}
+			actorRef, targetRef := w.statusEffectRefs(source, target.ID)
+			payload := loggingstatuseffects.RefreshedPayload{StatusEffect: string(cond), SourceID: source}
+			if duration > 0 {
+				payload.DurationMs = duration.Milliseconds()
+			}
+			loggingstatuseffects.Refreshed(
+				context.Background(),
+				w.publisher,
+				w.currentTick(),
+				actorRef,
+				targetRef,
+				payload,
+				nil,
+			)
+		},
+		RecordTick: func(time.Time) {
+			if w == nil {
+				return
+			}
+			actorRef, targetRef := w.statusEffectRefs(source, target.ID)
+			loggingstatuseffects.Ticked(
+				context.Background(),
+				w.publisher,
+				w.currentTick(),
+				actorRef,
+				targetRef,
+				loggingstatuseffects.TickedPayload{StatusEffect: string(cond), SourceID: source},
+				nil,
+			)
+		},
 	})
 }
 
+// statusEffectRefs builds the log references for a status effect event: the
+// actor is whoever applied it, when known, and the target is the recipient.
+func (w *World) statusEffectRefs(sourceID, targetID string) (logging.EntityRef, logging.EntityRef) {
+	actorRef := logging.EntityRef{}
+	if sourceID != "" {
+		actorRef = w.entityRef(sourceID)
+	}
+	targetRef := logging.EntityRef{}
+	if targetID != "" {
+		targetRef = w.entityRef(targetID)
+	}
+	return actorRef, targetRef
+}
+
 func newStatusEffectInstanceHandle(inst *state.StatusEffectInstance, actor *state.ActorState) statuspkg.StatusEffectInstanceHandle {
 	if inst != nil {
 		inst.SetActorState(actor)
@@ -173,6 +214,18 @@ func newStatusEffectInstanceHandle(inst *state.StatusEffectInstance, actor *stat
 			}
 			inst.LastTick = at
 		},
+		SetStacks: func(value int) {
+			if inst == nil {
+				return
+			}
+			inst.Stacks = value
+		},
+		Stacks: func() int {
+			if inst == nil {
+				return 0
+			}
+			return inst.Stacks
+		},
 		Attachment: statuspkg.StatusEffectInstanceAttachment{
 			SetStatus: func(effectType string) {
 				if inst == nil || effectType == "" {
@@ -37,6 +37,7 @@ func (w *World) configureStatusEffects() {
 			Duration:     BurningStatusEffectDuration,
 			TickInterval: BurningTickInterval,
 			InitialTick:  true,
+			Stacking:     statuspkg.StackRefresh,
 			Lifecycle: &statuspkg.BurningLifecycleConfig{
 				StatusEffect:              statuspkg.StatusEffectBurning,
 				TickInterval:              BurningTickInterval,
@@ -50,6 +51,9 @@ func (w *World) configureStatusEffects() {
 				CurrentTick: func() effectcontract.Tick {
 					return effectcontract.Tick(int64(w.currentTick()))
 				},
+				DamageRate: func() float64 {
+					return w.config.LavaDamageRate()
+				},
 				ApplyDamage: w.applyBurningStatusDamage,
 			},
 		},
@@ -16,6 +16,7 @@ import (
 	state "mine-and-die/server/internal/world/state"
 	statuspkg "mine-and-die/server/internal/world/status"
 	"mine-and-die/server/logging"
+	stats "mine-and-die/server/stats"
 )
 
 const (
@@ -35,6 +36,11 @@ type Deps struct {
 	JournalRetention func() (int, time.Duration)
 	JournalTelemetry journalpkg.Telemetry
 	OnConstructed    func(*World)
+	// TickRate is the simulation frequency in hertz; zero selects TickRate.
+	TickRate int
+	// EffectCatalogPath loads the effect catalog from this file instead of
+	// the default location. Empty keeps the default.
+	EffectCatalogPath string
 }
 
 // World owns the deterministic RNG root and configuration for the simulation.
@@ -45,6 +51,9 @@ type World struct {
 	publisher  logging.Publisher
 	rngFactory RNGFactory
 	rng        *rand.Rand
+	// rngSource backs rng when the default factory is in use so the stream
+	// position can be captured; it is nil for injected factories.
+	rngSource *RNGSource
 
 	players map[string]*state.PlayerState
 	npcs    map[string]*state.NPCState
@@ -54,6 +63,7 @@ type World struct {
 	effectsIndex            *worldeffects.SpatialIndex
 	effectsRegistry         worldeffects.Registry
 	effectManager           *EffectManager
+	effectCatalogPath       string
 	effectTelemetry         EffectTelemetry
 	effectTriggers          []internaleffects.Trigger
 	effectHitDispatcher     EffectHitCallback
@@ -102,7 +112,6 @@ func New(cfg Config, deps Deps) (*World, error) {
 		seed:                    seed,
 		publisher:               publisher,
 		rngFactory:              factory,
-		rng:                     factory(seed, "world"),
 		players:                 make(map[string]*state.PlayerState),
 		npcs:                    make(map[string]*state.NPCState),
 		effects:                 make([]*worldeffects.State, 0),
@@ -112,6 +121,14 @@ func New(cfg Config, deps Deps) (*World, error) {
 		groundItemsByTile:       make(map[itemspkg.GroundTileKey]map[string]*itemspkg.GroundItemState),
 		statusEffectDefinitions: make(map[string]statuspkg.ApplyStatusEffectDefinition),
 		journal:                 journalpkg.New(capacity, maxAge),
+		effectCatalogPath:       deps.EffectCatalogPath,
+	}
+
+	if deps.RNG == nil {
+		world.rngSource = NewRNGSource(DeterministicSeedValue(seed, "world"))
+		world.rng = rand.New(world.rngSource)
+	} else {
+		world.rng = factory(seed, "world")
 	}
 
 	world.effectsRegistry = worldeffects.Registry{
@@ -207,6 +224,15 @@ func (w *World) NextEffectID() uint64 {
 	return w.nextEffectID
 }
 
+// SetNextEffectID restores the effect identifier seed, e.g. when a world is
+// rebuilt from a dump.
+func (w *World) SetNextEffectID(next uint64) {
+	if w == nil {
+		return
+	}
+	w.nextEffectID = next
+}
+
 // JournalState returns the journal backing the world.
 func (w *World) JournalState() journalpkg.Journal {
 	if w == nil {
@@ -318,7 +344,28 @@ func (w *World) AbilityGateOptions() (abilitiespkg.WorldAbilityGateOptions, bool
 		return abilitiespkg.WorldAbilityGateOptions{}, false
 	}
 	w.ensureAbilityOwnerAdapters()
-	return abilitiespkg.NewWorldAbilityGateOptions(w.abilityOwnerLookup)
+	options, ok := abilitiespkg.NewWorldAbilityGateOptions(w.abilityOwnerLookup)
+	if !ok {
+		return options, false
+	}
+	options.Melee.CooldownReduction = w.cooldownReduction
+	options.Projectile.CooldownReduction = w.cooldownReduction
+	return options, true
+}
+
+// cooldownReduction reports the fraction of every ability cooldown the actor
+// skips, read from its derived stats.
+func (w *World) cooldownReduction(actorID string) float64 {
+	if w == nil || actorID == "" {
+		return 0
+	}
+	if player, ok := w.players[actorID]; ok && player != nil {
+		return player.Stats.GetDerived(stats.DerivedCooldownReduction)
+	}
+	if npc, ok := w.npcs[actorID]; ok && npc != nil {
+		return npc.Stats.GetDerived(stats.DerivedCooldownReduction)
+	}
+	return 0
 }
 
 func (w *World) ensureAbilityOwnerAdapters() {
@@ -443,6 +490,15 @@ func (w *World) Config() Config {
 	return w.config
 }
 
+// ApplyTunables copies the tunable fields of cfg onto the live configuration
+// without touching anything generated from the structural fields.
+func (w *World) ApplyTunables(cfg Config) {
+	if w == nil {
+		return
+	}
+	w.config = w.config.WithTunables(cfg.Normalized())
+}
+
 // Seed reports the deterministic seed applied to the world RNG hierarchy.
 func (w *World) Seed() string {
 	if w == nil {
@@ -462,6 +518,25 @@ func (w *World) RNG() *rand.Rand {
 	return w.rng
 }
 
+// RNGState captures the position of the root RNG stream. It reports false
+// when the RNG came from an injected factory and cannot be captured.
+func (w *World) RNGState() (RNGState, bool) {
+	if w == nil || w.rngSource == nil {
+		return RNGState{}, false
+	}
+	return w.rngSource.State(), true
+}
+
+// RestoreRNGState rewinds or advances the root RNG to a captured position so
+// subsequent draws match the run it was captured from.
+func (w *World) RestoreRNGState(state RNGState) bool {
+	if w == nil || w.rngSource == nil {
+		return false
+	}
+	w.rngSource.Restore(state)
+	return true
+}
+
 // SubsystemRNG returns a deterministic RNG derived from the world seed.
 func (w *World) SubsystemRNG(label string) *rand.Rand {
 	if w == nil {
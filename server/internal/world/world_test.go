@@ -3,6 +3,7 @@ package world
 import (
 	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 
@@ -24,7 +25,7 @@ func TestNewNormalizesConfigAndSeedsRNG(t *testing.T) {
 	}
 
 	normalized := (Config{}).normalized()
-	if got := w.Config(); got != normalized {
+	if got := w.Config(); !reflect.DeepEqual(got, normalized) {
 		t.Fatalf("Config not normalized: got %+v want %+v", got, normalized)
 	}
 
@@ -73,6 +74,48 @@ func TestNewUsesInjectedRNGFactory(t *testing.T) {
 	}
 }
 
+func TestRestoreRNGStateReplaysSubsequentDraws(t *testing.T) {
+	original, err := New(Config{Seed: "resume"}, Deps{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	rng := original.RNG()
+	for i := 0; i < 17; i++ {
+		rng.Float64()
+	}
+	rng.Intn(10)
+
+	captured, ok := original.RNGState()
+	if !ok {
+		t.Fatalf("expected default RNG state to be capturable")
+	}
+	want := make([]float64, 8)
+	for i := range want {
+		want[i] = rng.Float64()
+	}
+
+	resumed, err := New(Config{Seed: "resume"}, Deps{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if !resumed.RestoreRNGState(captured) {
+		t.Fatalf("expected RNG state to restore")
+	}
+	for i, expected := range want {
+		if got := resumed.RNG().Float64(); got != expected {
+			t.Fatalf("draw %d after restore: got %v want %v", i, got, expected)
+		}
+	}
+
+	injected, err := New(Config{}, Deps{RNG: func(string, string) *rand.Rand { return rand.New(rand.NewSource(1)) }})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if _, ok := injected.RNGState(); ok {
+		t.Fatalf("expected injected RNG state to be reported as uncapturable")
+	}
+}
+
 func TestNewInitializesPlayerAndNPCState(t *testing.T) {
 	w, err := New(Config{}, Deps{})
 	if err != nil {
@@ -36,6 +36,60 @@ func TestInventoryAddStackMergesByFungibilityKey(t *testing.T) {
 	}
 }
 
+func TestInventoryAddStackMergesIntoSlotWithoutFungibilityKey(t *testing.T) {
+	inv := Inventory{Slots: []InventorySlot{
+		{Slot: 0, Item: ItemStack{Type: ItemTypeHealthPotion, Quantity: 1}},
+		{Slot: 1, Item: ItemStack{Type: ItemTypeGold, Quantity: 3}},
+	}}
+
+	slot, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 2})
+	if err != nil {
+		t.Fatalf("unexpected error merging stack: %v", err)
+	}
+	if slot != 1 || len(inv.Slots) != 2 {
+		t.Fatalf("expected gold to merge into slot 1, got slot %d with %d slots", slot, len(inv.Slots))
+	}
+	if got := inv.Slots[1].Item.Quantity; got != 5 {
+		t.Fatalf("expected merged quantity of 5, got %d", got)
+	}
+}
+
+func TestInventoryAddStackSpillsPastMaxStack(t *testing.T) {
+	inv := NewInventory()
+
+	if _, err := inv.AddStack(ItemStack{Type: ItemTypeHealthPotion, Quantity: 7}); err != nil {
+		t.Fatalf("unexpected error adding potions: %v", err)
+	}
+	slot, err := inv.AddStack(ItemStack{Type: ItemTypeHealthPotion, Quantity: 8})
+	if err != nil {
+		t.Fatalf("unexpected error adding potions past the cap: %v", err)
+	}
+	if slot != 0 {
+		t.Fatalf("expected the first affected slot to be the partial stack, got %d", slot)
+	}
+	if len(inv.Slots) != 2 {
+		t.Fatalf("expected overflow to spill into a second slot, got %+v", inv.Slots)
+	}
+	if got := inv.Slots[0].Item.Quantity; got != MaxPotionStack {
+		t.Fatalf("expected first potion slot filled to %d, got %d", MaxPotionStack, got)
+	}
+	if got := inv.Slots[1].Item.Quantity; got != 15-MaxPotionStack {
+		t.Fatalf("expected second potion slot to hold the overflow, got %d", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 1000}); err != nil {
+			t.Fatalf("unexpected error adding gold: %v", err)
+		}
+	}
+	if len(inv.Slots) != 3 {
+		t.Fatalf("expected uncapped gold to stay in one slot, got %+v", inv.Slots)
+	}
+	if got := inv.QuantityOf(ItemTypeGold); got != 2000 || inv.Slots[2].Item.Quantity != 2000 {
+		t.Fatalf("expected a single gold slot of 2000, got %+v", inv.Slots[2])
+	}
+}
+
 func TestInventoryAddStackRejectsMismatchedFungibilityKey(t *testing.T) {
 	inv := NewInventory()
 	if _, err := inv.AddStack(ItemStack{Type: ItemTypeGold, FungibilityKey: "custom", Quantity: 1}); err == nil {
@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"context"
+
+	"mine-and-die/server/logging"
+)
+
+const (
+	// EventMessage is emitted for every chat message delivered to subscribers.
+	EventMessage logging.EventType = "chat.message"
+	// EventRejected is emitted when a chat message fails validation or rate limiting.
+	EventRejected logging.EventType = "chat.rejected"
+)
+
+// MessagePayload records a delivered chat message for moderation.
+type MessagePayload struct {
+	Text       string `json:"text"`
+	Recipients int    `json:"recipients"`
+}
+
+// RejectedPayload records why a chat message was refused.
+type RejectedPayload struct {
+	Reason string `json:"reason"`
+	Length int    `json:"length"`
+}
+
+// Message publishes a delivered chat message.
+func Message(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload MessagePayload, extra map[string]any) {
+	if pub == nil {
+		return
+	}
+	event := logging.Event{
+		Type:     EventMessage,
+		Tick:     tick,
+		Actor:    actor,
+		Severity: logging.SeverityInfo,
+		Category: "chat",
+		Payload:  payload,
+		Extra:    extra,
+	}
+	pub.Publish(ctx, event)
+}
+
+// Rejected publishes a refused chat message.
+func Rejected(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload RejectedPayload, extra map[string]any) {
+	if pub == nil {
+		return
+	}
+	event := logging.Event{
+		Type:     EventRejected,
+		Tick:     tick,
+		Actor:    actor,
+		Severity: logging.SeverityWarn,
+		Category: "chat",
+		Payload:  payload,
+		Extra:    extra,
+	}
+	pub.Publish(ctx, event)
+}
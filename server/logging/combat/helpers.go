@@ -28,6 +28,7 @@ type DamagePayload struct {
 	Amount       float64 `json:"amount"`
 	TargetHealth float64 `json:"targetHealth"`
 	StatusEffect string  `json:"statusEffect,omitempty"`
+	Critical     bool    `json:"critical,omitempty"`
 }
 
 // DefeatPayload describes the context for a fatal blow.
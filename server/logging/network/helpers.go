@@ -11,6 +11,25 @@ const (
 	EventAckAdvanced logging.EventType = "network.ack_advanced"
 	// EventAckRegression is emitted when a client reports an older acknowledgement than previously recorded.
 	EventAckRegression logging.EventType = "network.ack_regression"
+	// EventKeyframeNack is emitted when a keyframe request cannot be served.
+	EventKeyframeNack logging.EventType = "network.keyframe_nack"
+	// EventCommandSeqAnomaly is emitted when a client's command sequence
+	// repeats, arrives out of order, or skips ahead.
+	EventCommandSeqAnomaly logging.EventType = "network.command_seq_anomaly"
+	// EventSlowClientDisconnected is emitted when a subscriber is dropped
+	// for timing out too many writes in a row.
+	EventSlowClientDisconnected logging.EventType = "network.slow_client_disconnected"
+)
+
+// Command sequence anomaly kinds reported in CommandSeqPayload.Kind.
+const (
+	// CommandSeqDuplicate marks a sequence that was already acknowledged.
+	CommandSeqDuplicate = "duplicate"
+	// CommandSeqReordered marks a sequence older than the newest
+	// acknowledged one that had not been seen before.
+	CommandSeqReordered = "reordered"
+	// CommandSeqGap marks a sequence that skipped past unseen ones.
+	CommandSeqGap = "gap"
 )
 
 // AckPayload captures acknowledgement progression details.
@@ -19,6 +38,31 @@ type AckPayload struct {
 	Ack      uint64 `json:"ack"`
 }
 
+// KeyframeNackPayload captures the requested sequence alongside the journal
+// window that was available when the request was refused.
+type KeyframeNackPayload struct {
+	Requested uint64 `json:"requested"`
+	Oldest    uint64 `json:"oldest"`
+	Newest    uint64 `json:"newest"`
+	Size      int    `json:"size"`
+	Reason    string `json:"reason"`
+}
+
+// CommandSeqPayload captures an out-of-line command sequence alongside the
+// newest sequence acknowledged before it arrived.
+type CommandSeqPayload struct {
+	Kind string `json:"kind"`
+	Last uint64 `json:"last"`
+	Seq  uint64 `json:"seq"`
+}
+
+// SlowClientPayload captures the write timeout streak that disconnected a
+// slow subscriber.
+type SlowClientPayload struct {
+	Failures       int   `json:"failures"`
+	WriteTimeoutMs int64 `json:"writeTimeoutMs"`
+}
+
 // AckAdvanced publishes a debug event when a client acknowledgement advances.
 func AckAdvanced(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload AckPayload, extra map[string]any) {
 	if pub == nil {
@@ -52,3 +96,56 @@ func AckRegression(ctx context.Context, pub logging.Publisher, tick uint64, acto
 	}
 	pub.Publish(ctx, event)
 }
+
+// KeyframeNack publishes a warning event when a keyframe request is refused.
+func KeyframeNack(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload KeyframeNackPayload, extra map[string]any) {
+	if pub == nil {
+		return
+	}
+	event := logging.Event{
+		Type:     EventKeyframeNack,
+		Tick:     tick,
+		Actor:    actor,
+		Severity: logging.SeverityWarn,
+		Category: "network",
+		Payload:  payload,
+		Extra:    extra,
+	}
+	pub.Publish(ctx, event)
+}
+
+// CommandSeqAnomaly publishes a warning event when a command sequence is
+// duplicated, reordered, or skips ahead.
+func CommandSeqAnomaly(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload CommandSeqPayload, extra map[string]any) {
+	if pub == nil {
+		return
+	}
+	event := logging.Event{
+		Type:     EventCommandSeqAnomaly,
+		Tick:     tick,
+		Actor:    actor,
+		Severity: logging.SeverityWarn,
+		Category: "network",
+		Payload:  payload,
+		Extra:    extra,
+	}
+	pub.Publish(ctx, event)
+}
+
+// SlowClientDisconnected publishes a warning event when a slow subscriber is
+// disconnected.
+func SlowClientDisconnected(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, payload SlowClientPayload, extra map[string]any) {
+	if pub == nil {
+		return
+	}
+	event := logging.Event{
+		Type:     EventSlowClientDisconnected,
+		Tick:     tick,
+		Actor:    actor,
+		Severity: logging.SeverityWarn,
+		Category: "network",
+		Payload:  payload,
+		Extra:    extra,
+	}
+	pub.Publish(ctx, event)
+}
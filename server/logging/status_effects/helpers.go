@@ -9,6 +9,13 @@ import (
 const (
 	// EventApplied is emitted when a status effect is applied to an actor.
 	EventApplied logging.EventType = "status_effects.applied"
+	// EventRefreshed is emitted when an active status effect is reapplied and
+	// its expiry pushed back.
+	EventRefreshed logging.EventType = "status_effects.refreshed"
+	// EventTicked is emitted each time a periodic status effect fires.
+	EventTicked logging.EventType = "status_effects.ticked"
+	// EventExpired is emitted when a status effect runs out and is removed.
+	EventExpired logging.EventType = "status_effects.expired"
 )
 
 // AppliedPayload captures details about a status effect application.
@@ -18,13 +25,51 @@ type AppliedPayload struct {
 	DurationMs   int64  `json:"durationMs,omitempty"`
 }
 
+// RefreshedPayload captures details about a status effect refresh.
+type RefreshedPayload struct {
+	StatusEffect string `json:"statusEffect"`
+	SourceID     string `json:"sourceId,omitempty"`
+	DurationMs   int64  `json:"durationMs,omitempty"`
+}
+
+// TickedPayload captures a single periodic status effect tick.
+type TickedPayload struct {
+	StatusEffect string `json:"statusEffect"`
+	SourceID     string `json:"sourceId,omitempty"`
+}
+
+// ExpiredPayload captures details about a status effect expiry.
+type ExpiredPayload struct {
+	StatusEffect string `json:"statusEffect"`
+	SourceID     string `json:"sourceId,omitempty"`
+}
+
 // Applied publishes a status effect application event.
 func Applied(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, target logging.EntityRef, payload AppliedPayload, extra map[string]any) {
+	publish(ctx, pub, EventApplied, tick, actor, target, payload, extra)
+}
+
+// Refreshed publishes a status effect refresh event.
+func Refreshed(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, target logging.EntityRef, payload RefreshedPayload, extra map[string]any) {
+	publish(ctx, pub, EventRefreshed, tick, actor, target, payload, extra)
+}
+
+// Ticked publishes a status effect tick event.
+func Ticked(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, target logging.EntityRef, payload TickedPayload, extra map[string]any) {
+	publish(ctx, pub, EventTicked, tick, actor, target, payload, extra)
+}
+
+// Expired publishes a status effect expiry event.
+func Expired(ctx context.Context, pub logging.Publisher, tick uint64, actor logging.EntityRef, target logging.EntityRef, payload ExpiredPayload, extra map[string]any) {
+	publish(ctx, pub, EventExpired, tick, actor, target, payload, extra)
+}
+
+func publish(ctx context.Context, pub logging.Publisher, eventType logging.EventType, tick uint64, actor logging.EntityRef, target logging.EntityRef, payload any, extra map[string]any) {
 	if pub == nil {
 		return
 	}
 	event := logging.Event{
-		Type:     EventApplied,
+		Type:     eventType,
 		Tick:     tick,
 		Actor:    actor,
 		Targets:  []logging.EntityRef{target},
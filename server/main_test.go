@@ -618,6 +618,44 @@ func TestAdvanceMovesAndClampsPlayers(t *testing.T) {
 	}
 }
 
+func TestAdvanceWrapsPlayersAcrossEdgesInWrapMode(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		hub, _ := newSeededTestHub("wrap-bounds", func(cfg *worldConfig) {
+			cfg.NPCs = false
+			cfg.GoblinCount = 0
+			cfg.RatCount = 0
+			cfg.NPCCount = 0
+			cfg.Wrap = wrap
+		})
+		now := time.Now()
+		width := hub.world.width()
+
+		runner := newTestPlayerState("runner")
+		runner.X = width - playerHalf - 5
+		runner.Y = 100
+		runner.LastHeartbeat = now
+		hub.world.AddPlayer(runner)
+		hub.world.SetIntent(runner.ID, 1, 0)
+
+		players, _, _, _, _ := hub.advance(now, 0.5)
+		moved := findPlayer(players, runner.ID)
+		if moved == nil {
+			t.Fatalf("wrap=%v: updated snapshot missing runner", wrap)
+		}
+
+		expectedX := width - playerHalf
+		if wrap {
+			expectedX = width - playerHalf - 5 + moveSpeed*0.5 - width
+		}
+		if math.Abs(moved.X-expectedX) > 1e-6 {
+			t.Fatalf("wrap=%v: expected runner X %.3f, got %.3f", wrap, expectedX, moved.X)
+		}
+		if wrap && moved.X > width/2 {
+			t.Fatalf("expected runner to reappear on the left edge, got X %.3f", moved.X)
+		}
+	}
+}
+
 func TestWorldRespectsConfiguredDimensions(t *testing.T) {
 	cfg := fullyFeaturedTestWorldConfig()
 	cfg.Width = 960
@@ -703,6 +741,46 @@ func TestEnsurePlayerPathProducesDiagonalWaypoint(t *testing.T) {
 	}
 }
 
+func TestHubTickRateScalesPerTickMovement(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.TickRate = 30
+	hub := NewHubWithConfig(cfg)
+	hub.ResetWorld(fullyFeaturedTestWorldConfig())
+	hub.world.obstacles = nil
+	if got := hub.TickRate(); got != 30 {
+		t.Fatalf("expected hub tick rate 30, got %d", got)
+	}
+
+	now := time.Now()
+	moverState := newTestPlayerState("mover")
+	moverState.X = 200
+	moverState.Y = 200
+	moverState.LastHeartbeat = now
+	hub.world.AddPlayer(moverState)
+	hub.world.SetIntent("mover", 1, 0)
+
+	players, _, _, _, _ := hub.advance(now, 0)
+	mover := findPlayer(players, "mover")
+	if mover == nil {
+		t.Fatalf("updated snapshot missing mover")
+	}
+	expected := 200 + moveSpeed/30
+	if math.Abs(mover.X-expected) > 1e-9 {
+		t.Fatalf("expected a 30 Hz tick to move to %.4f, got %.4f", expected, mover.X)
+	}
+}
+
+func TestHubTickRateRejectsNonPositiveValues(t *testing.T) {
+	for _, rate := range []int{0, -5} {
+		cfg := DefaultHubConfig()
+		cfg.TickRate = rate
+		hub := NewHubWithConfig(cfg)
+		if got := hub.TickRate(); got != tickRate {
+			t.Fatalf("expected tick rate %d to fall back to %d, got %d", rate, tickRate, got)
+		}
+	}
+}
+
 func TestAdvanceRemovesStalePlayers(t *testing.T) {
 	hub := newHubWithFullWorld()
 	hub.world.obstacles = nil
@@ -725,6 +803,97 @@ func TestAdvanceRemovesStalePlayers(t *testing.T) {
 	}
 }
 
+func TestPlayerDisconnectTimeoutOverrideOutlastsDefault(t *testing.T) {
+	hub := NewHubWithConfig(HubConfig{KeyframeInterval: 30, DisconnectAfter: 4 * time.Second})
+	hub.ResetWorld(fullyFeaturedTestWorldConfig())
+	hub.world.obstacles = nil
+
+	gap := time.Now().Add(-6 * time.Second)
+	defaultState := newTestPlayerState("default-timeout")
+	defaultState.LastHeartbeat = gap
+	hub.world.players[defaultState.ID] = defaultState
+	extendedState := newTestPlayerState("extended-timeout")
+	extendedState.LastHeartbeat = gap
+	hub.world.players[extendedState.ID] = extendedState
+	hub.SetPlayerDisconnectTimeout(extendedState.ID, 10*time.Second)
+
+	hub.advance(time.Now(), 0)
+
+	if _, ok := hub.world.players[defaultState.ID]; ok {
+		t.Fatalf("expected player on the hub timeout to be removed after the gap")
+	}
+	if _, ok := hub.world.players[extendedState.ID]; !ok {
+		t.Fatalf("expected player with an extended timeout to survive the gap")
+	}
+}
+
+func TestIdleKickRemovesHeartbeatingIdlePlayers(t *testing.T) {
+	cfg := DefaultHubConfig()
+	cfg.IdleKickAfter = 30 * time.Second
+	hub := NewHubWithConfig(cfg)
+	hub.ResetWorld(fullyFeaturedTestWorldConfig())
+	hub.world.obstacles = nil
+
+	idle := hub.Join()
+	active := hub.Join()
+
+	hub.mu.Lock()
+	past := time.Now().Add(-time.Minute)
+	for _, id := range []string{idle.ID, active.ID} {
+		hub.world.players[id].LastInput = past
+		hub.world.players[id].LastHeartbeat = time.Now()
+	}
+	hub.mu.Unlock()
+
+	if _, ok, reason := hub.UpdateIntent(active.ID, 1, 0, string(FacingRight)); !ok {
+		t.Fatalf("expected active player's intent to be accepted, got %q", reason)
+	}
+	if _, ok := hub.UpdateHeartbeat(idle.ID, time.Now(), time.Now().UnixMilli()); !ok {
+		t.Fatalf("expected idle player's heartbeat to be accepted")
+	}
+
+	runAdvance(hub, 1.0/float64(tickRate))
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := hub.world.players[idle.ID]; ok {
+		t.Fatalf("expected heartbeating but idle player to be kicked")
+	}
+	if _, ok := hub.world.players[active.ID]; !ok {
+		t.Fatalf("expected moving player to stay connected")
+	}
+}
+
+func TestAdvanceDropsStalePlayerInventoryWhenEnabled(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	hub.world.SetStaleInventoryPolicy(StaleInventoryDrop)
+	staleID := "stale-carrier"
+	staleState := newTestPlayerState(staleID)
+	staleState.X = 100
+	staleState.Y = 100
+	staleState.LastHeartbeat = time.Now().Add(-disconnectAfter - time.Second)
+	if _, err := staleState.Inventory.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 12}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+	hub.world.players[staleID] = staleState
+
+	hub.advance(time.Now(), 0)
+
+	if _, ok := hub.world.players[staleID]; ok {
+		t.Fatalf("stale player still in hub map")
+	}
+	dropped := 0
+	for _, item := range hub.world.GroundItemsSnapshot() {
+		if item.Type == string(ItemTypeGold) {
+			dropped += item.Qty
+		}
+	}
+	if dropped != 12 {
+		t.Fatalf("expected stale player's 12 gold on the ground, found %d", dropped)
+	}
+}
+
 func TestMeleeAttackCreatesEffectAndRespectsCooldown(t *testing.T) {
 	hub := newHubWithFullWorld()
 	attackerID := "attacker"
@@ -831,6 +1000,167 @@ func TestMeleeAttackDealsDamage(t *testing.T) {
 	hub.mu.Unlock()
 }
 
+func TestMeleeArcHitsTargetsFlankingTheFacing(t *testing.T) {
+	swing := func(arc float64) (ahead, flank float64) {
+		hub := newHubWithFullWorld()
+		hub.world.SetMeleeArc(arc)
+		now := time.Now()
+
+		attacker := newTestPlayerState("attacker")
+		attacker.X = 200
+		attacker.Y = 200
+		attacker.Facing = FacingRight
+		attacker.LastHeartbeat = now
+		attacker.Cooldowns = make(map[string]time.Time)
+		hub.world.players[attacker.ID] = attacker
+
+		front := newTestPlayerState("front")
+		front.X = 200 + playerHalf + meleeAttackReach/2
+		front.Y = 200
+		front.LastHeartbeat = now
+		hub.world.players[front.ID] = front
+
+		// Fifty degrees below the facing: outside the 40-wide box, inside a
+		// 120 degree arc.
+		angle := 50 * math.Pi / 180
+		side := newTestPlayerState("side")
+		side.X = 200 + 50*math.Cos(angle)
+		side.Y = 200 + 50*math.Sin(angle)
+		side.LastHeartbeat = now
+		hub.world.players[side.ID] = side
+
+		if _, ok, _ := hub.HandleAction(attacker.ID, effectTypeAttack); !ok {
+			t.Fatalf("expected melee attack to execute")
+		}
+		runAdvance(hub, 1.0/float64(tickRate))
+
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.world.players[front.ID].Health, hub.world.players[side.ID].Health
+	}
+
+	damaged := baselinePlayerMaxHealth - meleeAttackDamage
+
+	ahead, flank := swing(0)
+	if math.Abs(ahead-damaged) > 1e-6 {
+		t.Fatalf("expected box swing to hit the target ahead, health %.1f", ahead)
+	}
+	if flank != baselinePlayerMaxHealth {
+		t.Fatalf("expected box swing to miss the flanking target, health %.1f", flank)
+	}
+
+	ahead, flank = swing(120)
+	if math.Abs(ahead-damaged) > 1e-6 || math.Abs(flank-damaged) > 1e-6 {
+		t.Fatalf("expected arc swing to hit both targets, got ahead %.1f flank %.1f", ahead, flank)
+	}
+}
+
+func TestMeleeCriticalHitsApplyMultiplierWhenChanceIsPinned(t *testing.T) {
+	hub := newHubWithFullWorld()
+	now := time.Now()
+
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	hub.world.players[attacker.ID] = attacker
+
+	target := newTestPlayerState("target")
+	target.X = 200 + playerHalf + meleeAttackReach/2
+	target.Y = 200
+	target.LastHeartbeat = now
+	hub.world.players[target.ID] = target
+
+	delta := stats.NewStatDelta()
+	delta.Add[stats.StatPrecision] = 100
+	attacker.Stats.Apply(stats.CommandStatChange{
+		Layer:  stats.LayerPermanent,
+		Source: stats.SourceKey{Kind: stats.SourceKindProgression, ID: "crit-test"},
+		Delta:  delta,
+	})
+	attacker.Stats.Resolve(hub.world.currentTick)
+	if chance := attacker.Stats.GetDerived(stats.DerivedCritChance); chance != 1 {
+		t.Fatalf("expected precision to pin crit chance to 1, got %.2f", chance)
+	}
+	multiplier := attacker.Stats.GetDerived(stats.DerivedCritMultiplier)
+	if multiplier <= 1 {
+		t.Fatalf("expected crit multiplier above 1, got %.2f", multiplier)
+	}
+
+	expected := baselinePlayerMaxHealth
+	for swing := 0; swing < 3; swing++ {
+		hub.mu.Lock()
+		attacker.Cooldowns = make(map[string]time.Time)
+		hub.mu.Unlock()
+		if _, ok, _ := hub.HandleAction(attacker.ID, effectTypeAttack); !ok {
+			t.Fatalf("expected melee attack %d to execute", swing)
+		}
+		runAdvance(hub, 1.0/float64(tickRate))
+
+		expected -= meleeAttackDamage * multiplier
+		hub.mu.Lock()
+		health := hub.world.players[target.ID].Health
+		hub.mu.Unlock()
+		if math.Abs(health-expected) > 1e-6 {
+			t.Fatalf("expected swing %d to deal critical damage leaving %.1f health, got %.1f", swing, expected, health)
+		}
+	}
+}
+
+func TestMeleeComboEscalatesThirdSwingAndResetsWhenDelayed(t *testing.T) {
+	hub := newHubWithFullWorld()
+	combo := MeleeComboConfig{Window: time.Second, Length: 3, FinisherMultiplier: 2}
+	hub.world.SetMeleeCombo(combo)
+	now := time.Now()
+
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	hub.world.players[attacker.ID] = attacker
+
+	target := newTestPlayerState("target")
+	target.X = 200 + playerHalf + meleeAttackReach/2
+	target.Y = 200
+	target.LastHeartbeat = now
+	hub.world.players[target.ID] = target
+
+	swing := func(label string, delay bool, damage float64) {
+		t.Helper()
+		hub.mu.Lock()
+		attacker.Cooldowns = make(map[string]time.Time)
+		if state, ok := hub.world.meleeCombos[attacker.ID]; ok && delay {
+			state.LastSwing = state.LastSwing.Add(-2 * combo.Window)
+		}
+		before := hub.world.players[target.ID].Health
+		hub.mu.Unlock()
+
+		if _, ok, _ := hub.HandleAction(attacker.ID, effectTypeAttack); !ok {
+			t.Fatalf("expected %s swing to execute", label)
+		}
+		runAdvance(hub, 1.0/float64(tickRate))
+
+		hub.mu.Lock()
+		dealt := before - hub.world.players[target.ID].Health
+		hub.mu.Unlock()
+		if math.Abs(dealt-damage) > 1e-6 {
+			t.Fatalf("expected %s swing to deal %.1f damage, got %.1f", label, damage, dealt)
+		}
+	}
+
+	swing("first", false, meleeAttackDamage)
+	swing("second", false, meleeAttackDamage)
+	swing("rapid third", false, meleeAttackDamage*combo.FinisherMultiplier)
+
+	swing("fourth", false, meleeAttackDamage)
+	swing("fifth", false, meleeAttackDamage)
+	swing("delayed sixth", true, meleeAttackDamage)
+}
+
 func TestMeleeAttackCanDefeatGoblin(t *testing.T) {
 	hub := newHubWithFullWorld()
 
@@ -975,8 +1305,12 @@ func TestContractMeleeHitBroadcastsBloodEffect(t *testing.T) {
 			continue
 		}
 		foundBlood = true
-		if !slicesEqual(spawn.Instance.Colors, []string{"#7a0e12", "#4a090b"}) {
-			t.Fatalf("expected spawn colors %v, got %v", []string{"#7a0e12", "#4a090b"}, spawn.Instance.Colors)
+		if !spawn.CatalogColors || len(spawn.Instance.Colors) != 0 {
+			t.Fatalf("expected blood splatter colors to defer to the catalog, got %+v", spawn)
+		}
+		colors := expandEffectSpawn(t, hub.world.effectManager.Catalog(), spawn).Colors
+		if !slicesEqual(colors, []string{"#7a0e12", "#4a090b"}) {
+			t.Fatalf("expected catalog colors %v, got %v", []string{"#7a0e12", "#4a090b"}, colors)
 		}
 	}
 	if !foundBlood {
@@ -1252,6 +1586,49 @@ func TestPlayersSeparateWhenColliding(t *testing.T) {
 	}
 }
 
+func TestCollisionLayersLetNonCollidingActorsOverlap(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	now := time.Now()
+
+	place := func(id string, x, y float64, layer, mask CollisionLayer) {
+		state := newTestPlayerState(id)
+		state.X = x
+		state.Y = y
+		state.CollisionLayer = layer
+		state.CollisionMask = mask
+		state.LastHeartbeat = now
+		hub.world.AddPlayer(state)
+	}
+
+	place("walker", 300, 200, 0, 0)
+	place("ghost", 300+playerHalf/2, 200, CollisionLayerGhost, CollisionLayerGhost)
+	place("left", 500, 200, 0, 0)
+	place("right", 500+playerHalf/2, 200, 0, 0)
+
+	players, _, _, _, _ := hub.advance(now, 1)
+
+	walker := findPlayer(players, "walker")
+	ghost := findPlayer(players, "ghost")
+	left := findPlayer(players, "left")
+	right := findPlayer(players, "right")
+	if walker == nil || ghost == nil || left == nil || right == nil {
+		t.Fatalf("expected all players in snapshot")
+	}
+
+	if walker.X != 300 || ghost.X != 300+playerHalf/2 {
+		t.Fatalf("expected ghost and walker to overlap untouched, got walker %.2f ghost %.2f", walker.X, ghost.X)
+	}
+	if ghost.CollisionLayer != CollisionLayerGhost || ghost.CollisionMask != CollisionLayerGhost {
+		t.Fatalf("expected snapshot to carry ghost collision layers, got layer %d mask %d", ghost.CollisionLayer, ghost.CollisionMask)
+	}
+
+	distance := math.Hypot(right.X-left.X, right.Y-left.Y)
+	if distance+1e-6 < playerHalf*2 {
+		t.Fatalf("expected default-layer players separated by at least %.2f, got %.2f", playerHalf*2, distance)
+	}
+}
+
 func TestTriggerFireballCreatesProjectile(t *testing.T) {
 	hub := newHubWithFullWorld()
 	hub.world.obstacles = nil
@@ -1295,6 +1672,104 @@ func TestTriggerFireballCreatesProjectile(t *testing.T) {
 	}
 }
 
+func TestAimedFireballTravelsAlongAimVector(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	now := time.Now()
+
+	shooter := newTestPlayerState("aimer")
+	shooter.X = 200
+	shooter.Y = 200
+	shooter.Facing = FacingRight
+	shooter.LastHeartbeat = now
+	shooter.Cooldowns = make(map[string]time.Time)
+	hub.world.players[shooter.ID] = shooter
+
+	const aimDegrees = 30.0
+	aim := aimDegrees * math.Pi / 180
+	if _, ok, _ := hub.HandleAimedAction(shooter.ID, effectTypeFireball, math.Cos(aim), math.Sin(aim)); !ok {
+		t.Fatalf("expected aimed fireball action to be accepted")
+	}
+
+	dt := 1.0 / float64(tickRate)
+	runAdvance(hub, dt)
+
+	hub.mu.Lock()
+	if len(hub.world.effects) != 1 {
+		hub.mu.Unlock()
+		t.Fatalf("expected 1 effect in world, got %d", len(hub.world.effects))
+	}
+	eff := hub.world.effects[0]
+	startX, startY := eff.X+eff.Width/2, eff.Y+eff.Height/2
+	hub.mu.Unlock()
+
+	for i := 0; i < 3; i++ {
+		runAdvance(hub, dt)
+	}
+
+	hub.mu.Lock()
+	endX, endY := eff.X+eff.Width/2, eff.Y+eff.Height/2
+	hub.mu.Unlock()
+
+	heading := math.Atan2(endY-startY, endX-startX) * 180 / math.Pi
+	if math.Abs(heading-aimDegrees) > 2 {
+		t.Fatalf("expected fireball to travel at %.0f degrees, got %.2f", aimDegrees, heading)
+	}
+	launch := math.Atan2(startY-shooter.Y, startX-shooter.X) * 180 / math.Pi
+	if math.Abs(launch-aimDegrees) > 2 {
+		t.Fatalf("expected fireball to spawn along the aim vector, got %.2f degrees", launch)
+	}
+}
+
+func TestTimeScaleSlowsProjectileTravel(t *testing.T) {
+	travelled := func(t *testing.T, percent int) float64 {
+		t.Helper()
+		hub := newHubWithFullWorld()
+		hub.world.obstacles = nil
+		shooterID := "shooter"
+		now := time.Now()
+
+		shooterState := newTestPlayerState(shooterID)
+		shooterState.X = 200
+		shooterState.Y = 200
+		shooterState.Facing = FacingRight
+		shooterState.LastHeartbeat = now
+		shooterState.Cooldowns = make(map[string]time.Time)
+		hub.world.players[shooterID] = shooterState
+
+		ack, _ := hub.HandleConsoleCommand(shooterID, "time_scale", percent)
+		if ack.Status != "error" || ack.Reason != "forbidden" {
+			t.Fatalf("expected unprivileged time_scale to be rejected, got %+v", ack)
+		}
+		hub.SetConsolePrivilege(shooterID, true)
+		ack, _ = hub.HandleConsoleCommand(shooterID, "time_scale", percent)
+		if ack.Status != "ok" || ack.Qty != percent {
+			t.Fatalf("expected time_scale %d to apply, got %+v", percent, ack)
+		}
+
+		if _, ok, _ := hub.HandleAction(shooterID, effectTypeFireball); !ok {
+			t.Fatalf("expected fireball action to be recognized")
+		}
+		runAdvance(hub, 1.0/float64(tickRate))
+
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		if len(hub.world.effects) != 1 || hub.world.effects[0].Projectile == nil {
+			t.Fatalf("expected a single projectile in world, got %d effects", len(hub.world.effects))
+		}
+		return fireballRange - hub.world.effects[0].Projectile.RemainingRange
+	}
+
+	full := travelled(t, 100)
+	half := travelled(t, 50)
+	if full <= 0 {
+		t.Fatalf("expected projectile to travel at time-scale 1.0, got %.4f", full)
+	}
+	if math.Abs(half-full/2) > 1e-6 {
+		t.Fatalf("expected half-speed projectile to travel %.4f per tick, got %.4f", full/2, half)
+	}
+}
+
 func TestContractMeleeDefinitionsApplyDamage(t *testing.T) {
 	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
 	if world.effectManager == nil {
@@ -1368,6 +1843,43 @@ func TestContractMeleeSpawnPopulatesMotionCenter(t *testing.T) {
 	}
 }
 
+func TestDefinitionSpawnOffsetMovesMeleeMotionCenter(t *testing.T) {
+	motionCenterX := func(offset int) int {
+		world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+		world.obstacles = nil
+		if offset > 0 {
+			world.effectManager.Definitions()[effectTypeAttack].Geometry.SpawnOffset = offset
+		}
+
+		attacker := newTestPlayerState("offset-attacker")
+		attacker.X = 320
+		attacker.Y = 280
+		attacker.Facing = FacingRight
+		attacker.Cooldowns = make(map[string]time.Time)
+		world.AddPlayer(attacker)
+
+		commands := []Command{{ActorID: attacker.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeAttack}}}
+		world.Step(1, time.Unix(0, 0), 1.0/float64(tickRate), commands, nil)
+
+		batch := world.SnapshotEffectEvents()
+		if len(batch.Spawns) != 1 {
+			t.Fatalf("expected exactly one effect spawn, got %d", len(batch.Spawns))
+		}
+		return batch.Spawns[0].Instance.DeliveryState.Motion.PositionX
+	}
+
+	base := motionCenterX(0)
+	if expected := quantizeWorldCoord(320 + playerHalf + meleeAttackReach/2); base != expected {
+		t.Fatalf("expected default motion center %d, got %d", expected, base)
+	}
+
+	const offset = 30
+	shifted := motionCenterX(offset)
+	if expected := quantizeWorldCoord(320 + offset + meleeAttackReach/2); shifted != expected {
+		t.Fatalf("expected overridden motion center %d, got %d", expected, shifted)
+	}
+}
+
 func TestContractProjectileDefinitionsApplyDamage(t *testing.T) {
 	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
 	if world.effectManager == nil {
@@ -2159,41 +2671,645 @@ func TestContractSeqMonotonicAcrossTicks(t *testing.T) {
 	}
 }
 
-func TestFireballDealsDamageOnHit(t *testing.T) {
-	hub := newHubWithFullWorld()
-	hub.world.obstacles = nil
+func TestBeamDamagesFirstActorInLineEveryTick(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = []Obstacle{{ID: "cover", X: 584, Y: 280, Width: 32, Height: 32}}
+
+	const beamTicks = 3
+	place := func(id string, x, y float64, facing FacingDirection) *playerState {
+		player := newTestPlayerState(id)
+		player.X = x
+		player.Y = y
+		player.Facing = facing
+		world.players[id] = player
+		return player
+	}
+	place("caster", 200, 200, FacingRight)
+	victim := place("victim", 260, 200, FacingLeft)
+	bystander := place("bystander", 320, 200, FacingLeft)
+	place("sniper", 600, 200, FacingDown)
+	sheltered := place("sheltered", 600, 380, FacingUp)
+
+	for _, caster := range []string{"caster", "sniper"} {
+		world.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+			TypeID:        effectcontract.EffectIDBeam,
+			Delivery:      effectcontract.DeliveryKindBeam,
+			SourceActorID: caster,
+			Geometry:      effectcontract.EffectGeometry{Shape: effectcontract.GeometryShapeSegment},
+			DurationTicks: beamTicks,
+			Params:        map[string]int{"healthDelta": -5},
+		})
+	}
+
+	dt := 1.0 / float64(tickRate)
 	now := time.Now()
+	previous := victim.Health
+	for tick := uint64(1); tick <= beamTicks; tick++ {
+		world.Step(tick, now.Add(time.Duration(tick)*time.Millisecond), dt, nil, nil)
+		if victim.Health >= previous {
+			t.Fatalf("expected beam to damage the front actor on tick %d, health %.1f -> %.1f", tick, previous, victim.Health)
+		}
+		previous = victim.Health
+	}
 
-	shooterID := "caster"
-	targetID := "victim"
+	if bystander.Health != baselinePlayerMaxHealth {
+		t.Fatalf("expected actor behind the victim to be untouched, got health %.1f", bystander.Health)
+	}
+	if sheltered.Health != baselinePlayerMaxHealth {
+		t.Fatalf("expected actor behind cover to be untouched, got health %.1f", sheltered.Health)
+	}
 
-	shooterX := 200.0
-	shooterY := 200.0
-	travel := fireballSpeed / float64(tickRate)
-	spawnOffset := playerHalf + fireballSpawnGap + fireballSize/2
+	world.Step(beamTicks+1, now.Add(time.Second), dt, nil, nil)
+	if victim.Health != previous {
+		t.Fatalf("expected beam to stop after %d ticks, health %.1f -> %.1f", beamTicks, previous, victim.Health)
+	}
+}
 
-	casterState := newTestPlayerState(shooterID)
-	casterState.X = shooterX
-	casterState.Y = shooterY
-	casterState.Facing = FacingRight
-	casterState.LastHeartbeat = now
-	casterState.Cooldowns = make(map[string]time.Time)
-	hub.world.players[shooterID] = casterState
+func TestBeamActionChannelsAlongTheCastersFacing(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
 
-	victimState := newTestPlayerState(targetID)
-	victimState.X = shooterX + spawnOffset + travel/2
-	victimState.Y = shooterY
-	victimState.Facing = FacingLeft
-	victimState.LastHeartbeat = now
-	hub.world.players[targetID] = victimState
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Facing = FacingRight
+	world.players[caster.ID] = caster
 
-	if _, ok, _ := hub.HandleAction(shooterID, effectTypeFireball); !ok {
-		t.Fatalf("expected fireball to be created")
-	}
+	victim := newTestPlayerState("victim")
+	victim.X = 260
+	victim.Y = 200
+	world.players[victim.ID] = victim
 
+	collector := &effectEventCollector{}
 	dt := 1.0 / float64(tickRate)
-	step := time.Second / time.Duration(tickRate)
-	current := now
+	now := time.Unix(0, 0)
+	channel := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeBeam}}}
+	world.Step(1, now, dt, channel, collector.collect)
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected the beam action to spawn one beam, got %d", len(collector.spawns))
+	}
+	beam := world.effectManager.Instances()[collector.spawns[0].Instance.ID]
+	if beam == nil {
+		t.Fatalf("expected the beam to stay active while channelled")
+	}
+	motion := beam.DeliveryState.Motion
+	if want := quantizeWorldCoord(caster.X + beamDefaultRange); motion.TargetX != want || motion.TargetY != quantizeWorldCoord(caster.Y) {
+		t.Fatalf("expected the beam to publish its far end (%d, %d), got (%d, %d)", want, quantizeWorldCoord(caster.Y), motion.TargetX, motion.TargetY)
+	}
+
+	for tick := uint64(2); tick <= beamDuration+1; tick++ {
+		world.Step(tick, now.Add(time.Duration(tick)*time.Millisecond), dt, channel, collector.collect)
+	}
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected recasts inside the cooldown to be ignored, got %d spawns", len(collector.spawns))
+	}
+	if want := baselinePlayerMaxHealth - beamDuration*beamDefaultDamage; victim.Health != want {
+		t.Fatalf("expected %d ticks of beam damage, health %.1f, want %.1f", beamDuration, victim.Health, want)
+	}
+}
+
+func TestCancelActionEndsHeldBeamWithoutFurtherDamage(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Facing = FacingRight
+	world.players[caster.ID] = caster
+
+	victim := newTestPlayerState("victim")
+	victim.X = 260
+	victim.Y = 200
+	world.players[victim.ID] = victim
+
+	world.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		TypeID:        effectcontract.EffectIDBeam,
+		Delivery:      effectcontract.DeliveryKindBeam,
+		SourceActorID: caster.ID,
+		Geometry:      effectcontract.EffectGeometry{Shape: effectcontract.GeometryShapeSegment},
+		Params:        map[string]int{"healthDelta": -5},
+	})
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	world.Step(1, now, dt, nil, collector.collect)
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected beam spawn, got %d", len(collector.spawns))
+	}
+	channelled := victim.Health
+	if channelled >= baselinePlayerMaxHealth {
+		t.Fatalf("expected the beam to land before it is cancelled, health %.1f", channelled)
+	}
+
+	cancel := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: actionCancel}}}
+	world.Step(2, now.Add(time.Millisecond), dt, cancel, collector.collect)
+
+	if len(collector.ends) != 1 {
+		t.Fatalf("expected cancelled beam to emit one end event, got %d", len(collector.ends))
+	}
+	end := collector.ends[0]
+	if end.ID != collector.spawns[0].Instance.ID {
+		t.Fatalf("expected end for %q, got %q", collector.spawns[0].Instance.ID, end.ID)
+	}
+	if end.Reason != effectcontract.EndReasonCancelled {
+		t.Fatalf("expected cancel reason %q, got %q", effectcontract.EndReasonCancelled, end.Reason)
+	}
+	if len(world.effectManager.Instances()) != 0 {
+		t.Fatalf("expected cancelled beam to be removed, got %d instances", len(world.effectManager.Instances()))
+	}
+
+	world.Step(3, now.Add(2*time.Millisecond), dt, nil, collector.collect)
+	if victim.Health != channelled {
+		t.Fatalf("expected no damage after cancelling, health %.1f -> %.1f", channelled, victim.Health)
+	}
+}
+
+func TestTargetedHealRestoresNamedAllyWithinRange(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+
+	healer := newTestPlayerState("healer")
+	healer.X = 200
+	healer.Y = 200
+	world.players[healer.ID] = healer
+
+	ally := newTestPlayerState("ally")
+	ally.X = 200 + healRange/2
+	ally.Y = 200
+	ally.Health = baselinePlayerMaxHealth - 50
+	world.players[ally.ID] = ally
+
+	bystander := newTestPlayerState("bystander")
+	bystander.X = 200
+	bystander.Y = 210
+	bystander.Health = baselinePlayerMaxHealth - 50
+	world.players[bystander.ID] = bystander
+
+	distant := newTestPlayerState("distant")
+	distant.X = 200 + healRange*2
+	distant.Y = 200
+	distant.Health = baselinePlayerMaxHealth - 50
+	world.players[distant.ID] = distant
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	now := time.Unix(0, 0)
+	heal := []Command{{ActorID: healer.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeHeal, TargetID: ally.ID}}}
+	world.Step(1, now, dt, heal, collector.collect)
+
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected one heal spawn, got %d", len(collector.spawns))
+	}
+	if got := collector.spawns[0].Instance.DeliveryState.AttachedActorID; got != ally.ID {
+		t.Fatalf("expected heal attached to %q, got %q", ally.ID, got)
+	}
+	if want := baselinePlayerMaxHealth - 50 + healAmount; math.Abs(ally.Health-want) > 1e-6 {
+		t.Fatalf("expected ally health %.1f after heal, got %.1f", want, ally.Health)
+	}
+	if bystander.Health != baselinePlayerMaxHealth-50 {
+		t.Fatalf("expected heal to skip actors it was not aimed at, bystander health %.1f", bystander.Health)
+	}
+
+	outOfRange := []Command{{ActorID: healer.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeHeal, TargetID: distant.ID}}}
+	world.Step(2, now.Add(time.Millisecond), dt, outOfRange, collector.collect)
+
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected out-of-range heal to be rejected, got %d spawns", len(collector.spawns))
+	}
+	if distant.Health != baselinePlayerMaxHealth-50 {
+		t.Fatalf("expected out-of-range target to stay at %.1f, got %.1f", baselinePlayerMaxHealth-50, distant.Health)
+	}
+}
+
+func TestTargetedHealRejectsInvalidTargetsWhenQueued(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+
+	healer := newTestPlayerState("healer")
+	healer.X = 200
+	healer.Y = 200
+	hub.world.players[healer.ID] = healer
+
+	ally := newTestPlayerState("ally")
+	ally.X = 200 + healRange/2
+	ally.Y = 200
+	hub.world.players[ally.ID] = ally
+
+	distant := newTestPlayerState("distant")
+	distant.X = 200 + healRange*2
+	distant.Y = 200
+	hub.world.players[distant.ID] = distant
+
+	enemy := &npcState{
+		ActorState: actorState{Actor: Actor{ID: "enemy", X: 220, Y: 200, Health: 10, MaxHealth: 25, Inventory: NewInventory()}},
+		Stats:      stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:       NPCTypeGoblin,
+	}
+	hub.world.npcs[enemy.ID] = enemy
+
+	cases := []struct {
+		target string
+		reason string
+	}{
+		{target: ally.ID},
+		{target: ""},
+		{target: enemy.ID, reason: CommandRejectInvalidTarget},
+		{target: "missing", reason: CommandRejectInvalidTarget},
+		{target: distant.ID, reason: CommandRejectOutOfRange},
+	}
+	for _, tc := range cases {
+		_, ok, reason := hub.HandleTargetedAction(healer.ID, effectTypeHeal, tc.target)
+		if tc.reason == "" && !ok {
+			t.Fatalf("expected heal on %q to be queued, got %q", tc.target, reason)
+		}
+		if tc.reason != "" && (ok || reason != tc.reason) {
+			t.Fatalf("expected heal on %q to be rejected with %q, got ok=%t reason=%q", tc.target, tc.reason, ok, reason)
+		}
+	}
+}
+
+func TestTargetedHealIgnoresRecastsInsideCooldown(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+
+	healer := newTestPlayerState("healer")
+	healer.X = 200
+	healer.Y = 200
+	healer.Health = baselinePlayerMaxHealth - 50
+	world.players[healer.ID] = healer
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	start := time.Unix(0, 0)
+	heal := []Command{{ActorID: healer.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeHeal}}}
+	world.Step(1, start, dt, heal, collector.collect)
+	world.Step(2, start.Add(time.Second/time.Duration(tickRate)), dt, heal, collector.collect)
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected the recast inside the cooldown to do nothing, got %d spawns", len(collector.spawns))
+	}
+	world.Step(3, start.Add(healCooldown), dt, heal, collector.collect)
+	if len(collector.spawns) != 2 {
+		t.Fatalf("expected a heal once the cooldown elapsed, got %d spawns", len(collector.spawns))
+	}
+}
+
+func TestHealBurstRestoresAlliesAndSkipsEnemiesInRadius(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+
+	wounded := baselinePlayerMaxHealth - 50
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Health = wounded
+	world.players[caster.ID] = caster
+
+	ally := newTestPlayerState("ally")
+	ally.X = 200 + healBurstRadius/2
+	ally.Y = 200
+	ally.Health = wounded
+	world.players[ally.ID] = ally
+
+	farAlly := newTestPlayerState("far-ally")
+	farAlly.X = 200 + healBurstRadius*2
+	farAlly.Y = 200
+	farAlly.Health = wounded
+	world.players[farAlly.ID] = farAlly
+
+	enemy := &npcState{
+		ActorState: actorState{Actor: Actor{
+			ID:        "enemy",
+			X:         200,
+			Y:         200 + healBurstRadius/2,
+			Health:    10,
+			MaxHealth: 25,
+			Inventory: NewInventory(),
+		}},
+		Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:  NPCTypeGoblin,
+	}
+	world.npcs[enemy.ID] = enemy
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	burst := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeHealBurst}}}
+	world.Step(1, time.Unix(0, 0), dt, burst, collector.collect)
+
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected one heal burst spawn, got %d", len(collector.spawns))
+	}
+	healed := wounded + healBurstAmount
+	if math.Abs(caster.Health-healed) > 1e-6 {
+		t.Fatalf("expected caster health %.1f, got %.1f", healed, caster.Health)
+	}
+	if math.Abs(ally.Health-healed) > 1e-6 {
+		t.Fatalf("expected nearby ally health %.1f, got %.1f", healed, ally.Health)
+	}
+	if farAlly.Health != wounded {
+		t.Fatalf("expected ally outside the radius to stay at %.1f, got %.1f", wounded, farAlly.Health)
+	}
+	if enemy.Health != 10 {
+		t.Fatalf("expected enemy inside the radius to be ignored, health %.1f", enemy.Health)
+	}
+}
+
+func TestFirePatchPulsesEnemiesWalkingThroughAndSparesPlacer(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	placer := newTestPlayerState("placer")
+	placer.X = 200
+	placer.Y = 200
+	world.players[placer.ID] = placer
+	placerHealth := placer.Health
+
+	walker := &npcState{
+		ActorState: actorState{Actor: Actor{
+			ID:        "walker",
+			X:         120,
+			Y:         placer.Y + 30,
+			Health:    50,
+			MaxHealth: 50,
+			Inventory: NewInventory(),
+		}},
+		Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:  NPCTypeGoblin,
+	}
+	world.npcs[walker.ID] = walker
+
+	dt := 1.0 / float64(tickRate)
+	start := time.Unix(0, 0)
+	place := []Command{{ActorID: placer.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeFirePatch}}}
+	world.Step(1, start, dt, place, nil)
+
+	// Walk the goblin across the patch, then keep it clear of it.
+	pulses := 0
+	entered := false
+	last := walker.Health
+	for tick := uint64(2); tick <= 40; tick++ {
+		x := 120 + float64(tick-2)*8
+		if x > 320 {
+			x = 320
+		}
+		world.SetNPCPosition(walker.ID, x, walker.Y)
+		world.Step(tick, start.Add(time.Duration(tick)*time.Second/time.Duration(tickRate)), dt, nil, nil)
+		inside := math.Abs(walker.X-placer.X) <= 40
+		entered = entered || inside
+		if walker.Health == last {
+			continue
+		}
+		if !inside {
+			t.Fatalf("tick %d: walker took damage outside the patch at x=%.1f", tick, walker.X)
+		}
+		if math.Abs(last-walker.Health-firePatchDamage) > 1e-6 {
+			t.Fatalf("tick %d: expected a %.0f damage pulse, health went %.1f -> %.1f", tick, firePatchDamage, last, walker.Health)
+		}
+		pulses++
+		last = walker.Health
+	}
+	if !entered {
+		t.Fatalf("expected the walker to cross the patch")
+	}
+	if pulses < 2 {
+		t.Fatalf("expected periodic damage while crossing the patch, got %d pulses", pulses)
+	}
+	if placer.Health != placerHealth {
+		t.Fatalf("expected the placer standing in the patch to be unhurt, health %.1f -> %.1f", placerHealth, placer.Health)
+	}
+}
+
+func TestExplosionDamageFallsOffTowardTheEdge(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	world.players[caster.ID] = caster
+
+	goblinAt := func(id string, x float64) *npcState {
+		npc := &npcState{
+			ActorState: actorState{Actor: Actor{
+				ID:        id,
+				X:         x,
+				Y:         caster.Y,
+				Health:    50,
+				MaxHealth: 50,
+				Inventory: NewInventory(),
+			}},
+			Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+			Type:  NPCTypeGoblin,
+		}
+		world.npcs[id] = npc
+		return npc
+	}
+	near := goblinAt("goblin-near", caster.X-explosionRadius*0.4)
+	edge := goblinAt("goblin-edge", caster.X+explosionRadius*0.75)
+	outside := goblinAt("goblin-outside", caster.X+explosionRadius*1.5)
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	blast := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeExplosion}}}
+	world.Step(1, time.Unix(0, 0), dt, blast, collector.collect)
+
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected one explosion spawn, got %d", len(collector.spawns))
+	}
+	nearDamage := 50 - near.Health
+	edgeDamage := 50 - edge.Health
+	if nearDamage <= 0 {
+		t.Fatalf("expected the goblin near the centre to take damage")
+	}
+	if edgeDamage <= 0 || edgeDamage >= nearDamage {
+		t.Fatalf("expected the edge to take less damage than near the centre, got %.2f vs %.2f", edgeDamage, nearDamage)
+	}
+	// The explosion's linear curve keeps 60% of the damage at 40% of the
+	// radius and 25% at 75% of it.
+	if want := nearDamage * 0.25 / 0.6; math.Abs(edgeDamage-want) > 1e-6 {
+		t.Fatalf("expected linear falloff to deal %.2f at the edge, got %.2f", want, edgeDamage)
+	}
+	if outside.Health != 50 {
+		t.Fatalf("expected the goblin outside the radius to be untouched, health %.1f", outside.Health)
+	}
+	if got := damageFalloffScale(effectcontract.DamageFalloffQuadratic, 0.75); math.Abs(got-0.0625) > 1e-9 {
+		t.Fatalf("expected quadratic falloff to scale by 0.0625 at 75%% of the radius, got %v", got)
+	}
+}
+
+func TestExplosionSparesTargetsBehindWalls(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = []Obstacle{{ID: "wall", X: 240, Y: 120, Width: 20, Height: 160}}
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	world.players[caster.ID] = caster
+
+	goblinAt := func(id string, x float64) *npcState {
+		npc := &npcState{
+			ActorState: actorState{Actor: Actor{
+				ID:        id,
+				X:         x,
+				Y:         caster.Y,
+				Health:    50,
+				MaxHealth: 50,
+				Inventory: NewInventory(),
+			}},
+			Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+			Type:  NPCTypeGoblin,
+		}
+		world.npcs[id] = npc
+		return npc
+	}
+	open := goblinAt("goblin-open", caster.X-explosionRadius*0.8)
+	covered := goblinAt("goblin-covered", caster.X+explosionRadius*0.8)
+
+	dt := 1.0 / float64(tickRate)
+	blast := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeExplosion}}}
+	world.Step(1, time.Unix(0, 0), dt, blast, nil)
+
+	if open.Health >= 50 {
+		t.Fatalf("expected the goblin in the open to take damage, health %.1f", open.Health)
+	}
+	if covered.Health != 50 {
+		t.Fatalf("expected the wall to shield the goblin behind it, health %.1f", covered.Health)
+	}
+}
+
+func TestExplosionIgnoresRecastsInsideCooldown(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	world.players[caster.ID] = caster
+
+	collector := &effectEventCollector{}
+	dt := 1.0 / float64(tickRate)
+	start := time.Unix(0, 0)
+	blast := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeExplosion}}}
+	world.Step(1, start, dt, blast, collector.collect)
+	world.Step(2, start.Add(time.Second/time.Duration(tickRate)), dt, blast, collector.collect)
+	if len(collector.spawns) != 1 {
+		t.Fatalf("expected the recast inside the cooldown to do nothing, got %d spawns", len(collector.spawns))
+	}
+
+	world.Step(3, start.Add(explosionCooldown), dt, blast, collector.collect)
+	if len(collector.spawns) != 2 {
+		t.Fatalf("expected a cast once the cooldown elapsed, got %d spawns", len(collector.spawns))
+	}
+}
+
+func TestGravityWellPullsEnemiesTowardCentreWithoutCrossingWalls(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	// A wall sits between the well's centre and the enemy south of it.
+	world.obstacles = []Obstacle{{ID: "wall", X: 360, Y: 460, Width: 80, Height: 20}}
+
+	caster := newTestPlayerState("caster")
+	caster.X = 400
+	caster.Y = 400
+	world.players[caster.ID] = caster
+
+	ally := newTestPlayerState("ally")
+	ally.X = 400 - gravityWellRadius/2
+	ally.Y = 400
+	world.players[ally.ID] = ally
+
+	newEnemy := func(id string, x, y float64) *npcState {
+		enemy := &npcState{
+			ActorState: actorState{Actor: Actor{
+				ID:        id,
+				X:         x,
+				Y:         y,
+				Health:    25,
+				MaxHealth: 25,
+				Inventory: NewInventory(),
+			}},
+			Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+			Type:  NPCTypeGoblin,
+		}
+		world.npcs[id] = enemy
+		return enemy
+	}
+	open := newEnemy("open", 400+gravityWellRadius*3/4, 400)
+	walled := newEnemy("walled", 400, 540)
+	outside := newEnemy("outside", 400+gravityWellRadius*2, 400)
+
+	dt := 1.0 / float64(tickRate)
+	cast := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeGravityWell}}}
+	world.Step(1, time.Unix(0, 0), dt, cast, nil)
+
+	wallEdge := 460 + 20 + playerHalf
+	previous := math.Hypot(open.X-400, open.Y-400)
+	for tick := uint64(2); tick <= 6; tick++ {
+		world.Step(tick, time.Unix(0, 0).Add(time.Duration(tick)*time.Second/time.Duration(tickRate)), dt, nil, nil)
+		distance := math.Hypot(open.X-400, open.Y-400)
+		if distance >= previous {
+			t.Fatalf("tick %d: expected enemy in the open to drift toward the centre, distance went from %.2f to %.2f", tick, previous, distance)
+		}
+		if open.X < 400 {
+			t.Fatalf("tick %d: expected the pull to stop at the centre, enemy at x=%.2f", tick, open.X)
+		}
+		previous = distance
+		if walled.Y < wallEdge {
+			t.Fatalf("tick %d: expected the wall to stop the pull at y=%.2f, enemy at y=%.2f", tick, wallEdge, walled.Y)
+		}
+	}
+
+	if math.Abs(walled.Y-wallEdge) > 1e-6 || walled.X != 400 {
+		t.Fatalf("expected walled enemy to rest against the wall at (400, %.2f), got (%.2f, %.2f)", wallEdge, walled.X, walled.Y)
+	}
+	if outside.X != 400+gravityWellRadius*2 || outside.Y != 400 {
+		t.Fatalf("expected enemy outside the radius to stay put, got (%.2f, %.2f)", outside.X, outside.Y)
+	}
+	if ally.X != 400-gravityWellRadius/2 || ally.Y != 400 {
+		t.Fatalf("expected the caster's ally to be ignored, got (%.2f, %.2f)", ally.X, ally.Y)
+	}
+}
+
+func TestFireballDealsDamageOnHit(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	now := time.Now()
+
+	shooterID := "caster"
+	targetID := "victim"
+
+	shooterX := 200.0
+	shooterY := 200.0
+	travel := fireballSpeed / float64(tickRate)
+	spawnOffset := playerHalf + fireballSpawnGap + fireballSize/2
+
+	casterState := newTestPlayerState(shooterID)
+	casterState.X = shooterX
+	casterState.Y = shooterY
+	casterState.Facing = FacingRight
+	casterState.LastHeartbeat = now
+	casterState.Cooldowns = make(map[string]time.Time)
+	hub.world.players[shooterID] = casterState
+
+	victimState := newTestPlayerState(targetID)
+	victimState.X = shooterX + spawnOffset + travel/2
+	victimState.Y = shooterY
+	victimState.Facing = FacingLeft
+	victimState.LastHeartbeat = now
+	hub.world.players[targetID] = victimState
+
+	if _, ok, _ := hub.HandleAction(shooterID, effectTypeFireball); !ok {
+		t.Fatalf("expected fireball to be created")
+	}
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	current := now
 	for i := 0; i < 3; i++ {
 		_, _, _, _, _ = hub.advance(current, dt)
 		current = current.Add(step)
@@ -2217,6 +3333,266 @@ func TestFireballDealsDamageOnHit(t *testing.T) {
 	hub.mu.Unlock()
 }
 
+func TestParryReflectsFireballDamageToCaster(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	now := time.Now()
+
+	shooterX := 200.0
+	shooterY := 200.0
+	travel := fireballSpeed / float64(tickRate)
+	spawnOffset := playerHalf + fireballSpawnGap + fireballSize/2
+
+	casterState := newTestPlayerState("caster")
+	casterState.X = shooterX
+	casterState.Y = shooterY
+	casterState.Facing = FacingRight
+	casterState.LastHeartbeat = now
+	casterState.Cooldowns = make(map[string]time.Time)
+	hub.world.players[casterState.ID] = casterState
+
+	parrierState := newTestPlayerState("parrier")
+	parrierState.X = shooterX + spawnOffset + travel/2
+	parrierState.Y = shooterY
+	parrierState.Facing = FacingLeft
+	parrierState.LastHeartbeat = now
+	hub.world.players[parrierState.ID] = parrierState
+
+	if _, ok, reason := hub.HandleAction(parrierState.ID, actionParry); !ok {
+		t.Fatalf("expected parry to be accepted, got %q", reason)
+	}
+	if _, ok, _ := hub.HandleAction(casterState.ID, effectTypeFireball); !ok {
+		t.Fatalf("expected fireball to be created")
+	}
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	current := now
+	for i := 0; i < 3; i++ {
+		_, _, _, _, _ = hub.advance(current, dt)
+		current = current.Add(step)
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	parrier := hub.world.players[parrierState.ID]
+	caster := hub.world.players[casterState.ID]
+	if parrier.Health != baselinePlayerMaxHealth {
+		t.Fatalf("expected parrying player to take no damage, health %.1f", parrier.Health)
+	}
+	if parrier.StatusEffects[StatusEffectBurning] != nil {
+		t.Fatalf("expected parrying player not to be set on fire")
+	}
+	expected := baselinePlayerMaxHealth - fireballDamage - lavaDamagePerSecond*burningTickInterval.Seconds()
+	if math.Abs(caster.Health-expected) > 1e-6 {
+		t.Fatalf("expected reflected fireball to leave caster at %.1f, got %.1f", expected, caster.Health)
+	}
+	for _, eff := range hub.world.effects {
+		if eff != nil && eff.Type == effectTypeFireball {
+			t.Fatalf("expected parried fireball to be destroyed, found %s", eff.ID)
+		}
+	}
+}
+
+func TestHasteSpeedsUpMovementUntilItExpires(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	now := time.Now()
+
+	runnerState := newTestPlayerState("runner")
+	runnerState.X = 200
+	runnerState.Y = 200
+	runnerState.LastHeartbeat = now
+	hub.world.players[runnerState.ID] = runnerState
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	current := now
+	stride := func() float64 {
+		t.Helper()
+		if _, ok, reason := hub.UpdateIntent(runnerState.ID, 1, 0, string(FacingRight)); !ok {
+			t.Fatalf("expected intent to be accepted, got %q", reason)
+		}
+		before := hub.world.players[runnerState.ID].X
+		_, _, _, _, _ = hub.advance(current, dt)
+		current = current.Add(step)
+		return hub.world.players[runnerState.ID].X - before
+	}
+
+	baseline := stride()
+	if math.Abs(baseline-moveSpeed*dt) > 1e-6 {
+		t.Fatalf("expected baseline stride %.3f, got %.3f", moveSpeed*dt, baseline)
+	}
+
+	if _, ok, reason := hub.HandleAction(runnerState.ID, actionHaste); !ok {
+		t.Fatalf("expected haste to be accepted, got %q", reason)
+	}
+	// The action resolves after this tick's movement, so the boost shows up
+	// from the following tick.
+	stride()
+	hasted := stride()
+	if math.Abs(hasted-moveSpeed*hasteSpeedMultiplier*dt) > 1e-6 {
+		t.Fatalf("expected hasted stride %.3f, got %.3f", moveSpeed*hasteSpeedMultiplier*dt, hasted)
+	}
+	if hasted <= baseline {
+		t.Fatalf("expected hasted stride %.3f to beat baseline %.3f", hasted, baseline)
+	}
+
+	current = current.Add(hasteDuration)
+	if reverted := stride(); math.Abs(reverted-baseline) > 1e-6 {
+		t.Fatalf("expected stride to revert to %.3f after haste expired, got %.3f", baseline, reverted)
+	}
+	if _, ok := hub.world.players[runnerState.ID].StatusEffects[StatusEffectHasted]; ok {
+		t.Fatalf("expected the hasted status to be removed on expiry")
+	}
+}
+
+func TestSimultaneousLethalHitsCreditEarliestSpawnedEffect(t *testing.T) {
+	killerOf := func() string {
+		hub := newHubWithFullWorld()
+		hub.world.obstacles = nil
+		hub.world.npcs = make(map[string]*npcState)
+		now := time.Now()
+
+		gap := playerHalf + fireballSpawnGap + fireballSize/2 + fireballSpeed/float64(tickRate)/2
+		centerX := 400.0
+		for _, shooter := range []struct {
+			id     string
+			x      float64
+			facing FacingDirection
+		}{
+			{"shooter-a", centerX - gap, FacingRight},
+			{"shooter-b", centerX + gap, FacingLeft},
+		} {
+			state := newTestPlayerState(shooter.id)
+			state.X = shooter.x
+			state.Y = 300
+			state.Facing = shooter.facing
+			state.LastHeartbeat = now
+			state.Cooldowns = make(map[string]time.Time)
+			hub.world.players[shooter.id] = state
+		}
+		hub.world.npcs["target"] = &npcState{
+			ActorState: actorState{Actor: Actor{
+				ID:        "target",
+				X:         centerX,
+				Y:         300,
+				Health:    1,
+				MaxHealth: 25,
+				Inventory: NewInventory(),
+			}},
+			Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+			Type:  NPCTypeGoblin,
+		}
+
+		for _, id := range []string{"shooter-a", "shooter-b"} {
+			if _, ok, _ := hub.HandleAction(id, effectTypeFireball); !ok {
+				t.Fatalf("expected %s's fireball to be accepted", id)
+			}
+		}
+
+		dt := 1.0 / float64(tickRate)
+		step := time.Second / time.Duration(tickRate)
+		current := now
+		for i := 0; i < 3; i++ {
+			_, _, _, _, _ = hub.advance(current, dt)
+			current = current.Add(step)
+		}
+
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		if _, alive := hub.world.npcs["target"]; alive {
+			t.Fatalf("expected the target to die from the fireballs")
+		}
+		killer := ""
+		for _, id := range []string{"shooter-a", "shooter-b"} {
+			if hub.world.players[id].Session.Kills > 0 {
+				if killer != "" {
+					t.Fatalf("expected a single kill credit, both %s and %s scored", killer, id)
+				}
+				killer = id
+			}
+		}
+		return killer
+	}
+
+	for run := 0; run < 20; run++ {
+		if killer := killerOf(); killer != "shooter-a" {
+			t.Fatalf("run %d: expected the first fireball spawned to take the kill, credited %q", run, killer)
+		}
+	}
+}
+
+func TestFireResistanceReducesFireballAndBurningDamage(t *testing.T) {
+	healthAfterFireball := func(resistance float64) (float64, bool) {
+		hub := newHubWithFullWorld()
+		hub.world.obstacles = nil
+		now := time.Now()
+
+		spawnOffset := playerHalf + fireballSpawnGap + fireballSize/2
+		travel := fireballSpeed / float64(tickRate)
+
+		caster := newTestPlayerState("caster")
+		caster.X = 200
+		caster.Y = 200
+		caster.Facing = FacingRight
+		caster.LastHeartbeat = now
+		caster.Cooldowns = make(map[string]time.Time)
+		hub.world.players[caster.ID] = caster
+
+		victim := newTestPlayerState("victim")
+		victim.X = 200 + spawnOffset + travel/2
+		victim.Y = 200
+		victim.LastHeartbeat = now
+		if resistance != 0 {
+			delta := stats.NewStatDelta()
+			delta.Add[stats.StatResistFire] = resistance
+			victim.Stats.Apply(stats.CommandStatChange{
+				Layer:  stats.LayerPermanent,
+				Source: stats.SourceKey{Kind: stats.SourceKindProgression, ID: "fire-ward"},
+				Delta:  delta,
+			})
+			victim.Stats.Resolve(hub.world.currentTick)
+		}
+		hub.world.players[victim.ID] = victim
+
+		if _, ok, _ := hub.HandleAction(caster.ID, effectTypeFireball); !ok {
+			t.Fatalf("expected fireball to be created")
+		}
+
+		dt := 1.0 / float64(tickRate)
+		step := time.Second / time.Duration(tickRate)
+		current := now
+		for i := 0; i < 3; i++ {
+			_, _, _, _, _ = hub.advance(current, dt)
+			current = current.Add(step)
+		}
+
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		target := hub.world.players[victim.ID]
+		return target.Health, target.StatusEffects[StatusEffectBurning] != nil
+	}
+
+	fullDamage := fireballDamage + lavaDamagePerSecond*burningTickInterval.Seconds()
+
+	neutral, burning := healthAfterFireball(0)
+	if !burning {
+		t.Fatalf("expected neutral target to be burning")
+	}
+	if expected := baselinePlayerMaxHealth - fullDamage; math.Abs(neutral-expected) > 1e-6 {
+		t.Fatalf("expected neutral target health %.1f, got %.1f", expected, neutral)
+	}
+
+	resistant, burning := healthAfterFireball(50)
+	if !burning {
+		t.Fatalf("expected resistant target to still be burning")
+	}
+	if expected := baselinePlayerMaxHealth - fullDamage/2; math.Abs(resistant-expected) > 1e-6 {
+		t.Fatalf("expected fire-resistant target health %.1f, got %.1f", expected, resistant)
+	}
+}
+
 func TestHealthDeltaHealingClampsToMax(t *testing.T) {
 	hub := newHubWithFullWorld()
 	playerID := "patient"
@@ -2255,6 +3631,173 @@ func TestHealthDamageClampsToZero(t *testing.T) {
 	}
 }
 
+func TestAbsorbSoaksDamageBeforeHealth(t *testing.T) {
+	hub := newHubWithFullWorld()
+	now := time.Now()
+	state := newTestPlayerState("shielded")
+	state.X = 180
+	state.Y = 180
+	state.LastHeartbeat = now
+	hub.world.players[state.ID] = state
+
+	hub.world.GrantAbsorb(state.ID, 25, shieldDuration, now)
+	if state.Absorb != 25 {
+		t.Fatalf("expected absorb pool of 25, got %.1f", state.Absorb)
+	}
+
+	hit := func() {
+		blast := &effectState{Type: effectTypeAttack, Owner: "boom", Params: map[string]float64{"healthDelta": -10}}
+		hub.world.invokePlayerHitCallback(blast, state, now)
+	}
+
+	hit()
+	hit()
+	if state.Health != baselinePlayerMaxHealth {
+		t.Fatalf("expected absorb to protect health, got %.1f", state.Health)
+	}
+	if math.Abs(state.Absorb-5) > 1e-6 {
+		t.Fatalf("expected 5 absorb remaining, got %.1f", state.Absorb)
+	}
+
+	hit()
+	if state.Absorb != 0 {
+		t.Fatalf("expected absorb to be depleted, got %.1f", state.Absorb)
+	}
+	if expected := baselinePlayerMaxHealth - 5; math.Abs(state.Health-expected) > 1e-6 {
+		t.Fatalf("expected remainder to reach health %.1f, got %.1f", expected, state.Health)
+	}
+
+	var sawDepleted bool
+	for _, patch := range hub.world.snapshotPatchesLocked() {
+		if patch.Kind != PatchPlayerAbsorb || patch.EntityID != state.ID {
+			continue
+		}
+		if payload, ok := patch.Payload.(PlayerAbsorbPayload); ok && payload.Absorb == 0 {
+			sawDepleted = true
+		}
+	}
+	if !sawDepleted {
+		t.Fatalf("expected a player_absorb patch announcing the depleted pool")
+	}
+}
+
+func TestAbsorbExpiresAfterDuration(t *testing.T) {
+	hub := newHubWithFullWorld()
+	now := time.Now()
+	state := newTestPlayerState("fading")
+	state.LastHeartbeat = now
+	hub.world.players[state.ID] = state
+
+	hub.world.GrantAbsorb(state.ID, 25, shieldDuration, now)
+	hub.world.expireAbsorbs(now.Add(shieldDuration - time.Millisecond))
+	if state.Absorb != 25 {
+		t.Fatalf("expected absorb to persist before expiry, got %.1f", state.Absorb)
+	}
+	hub.world.expireAbsorbs(now.Add(shieldDuration))
+	if state.Absorb != 0 {
+		t.Fatalf("expected absorb to expire, got %.1f", state.Absorb)
+	}
+}
+
+func TestLifestealHealsAttackerForDamageDealt(t *testing.T) {
+	hub := newHubWithFullWorld()
+	now := time.Now()
+
+	attacker := newTestPlayerState("leech")
+	attacker.LastHeartbeat = now
+	delta := stats.NewStatDelta()
+	delta.Add[stats.StatLifesteal] = 50
+	attacker.Stats.Apply(stats.CommandStatChange{
+		Layer:  stats.LayerPermanent,
+		Source: stats.SourceKey{Kind: stats.SourceKindProgression, ID: "lifesteal-test"},
+		Delta:  delta,
+	})
+	attacker.Stats.Resolve(hub.world.currentTick)
+	hub.world.players[attacker.ID] = attacker
+
+	target := newTestPlayerState("donor")
+	target.LastHeartbeat = now
+	hub.world.players[target.ID] = target
+
+	strike := func(damage float64) {
+		eff := &effectState{Type: effectTypeAttack, Owner: attacker.ID, Params: map[string]float64{"healthDelta": -damage}}
+		hub.world.invokePlayerHitCallback(eff, target, now)
+	}
+
+	attacker.Health = 50
+	strike(20)
+	if math.Abs(attacker.Health-60) > 1e-6 {
+		t.Fatalf("expected attacker to heal half of 20 damage to 60, got %.1f", attacker.Health)
+	}
+
+	target.Health = 4
+	strike(20)
+	if math.Abs(attacker.Health-62) > 1e-6 {
+		t.Fatalf("expected lifesteal to follow the 4 damage actually dealt, got %.1f", attacker.Health)
+	}
+
+	target.Health = baselinePlayerMaxHealth
+	attacker.Health = baselinePlayerMaxHealth - 2
+	strike(20)
+	if attacker.Health != baselinePlayerMaxHealth {
+		t.Fatalf("expected lifesteal to clamp at max health %.1f, got %.1f", baselinePlayerMaxHealth, attacker.Health)
+	}
+}
+
+func TestCooldownReductionShortensMeleeCooldownUpToCap(t *testing.T) {
+	hub := newHubWithFullWorld()
+	start := time.Unix(0, 0)
+
+	addSwinger := func(id string, reduction float64) *playerState {
+		player := newTestPlayerState(id)
+		player.Cooldowns = make(map[string]time.Time)
+		if reduction > 0 {
+			delta := stats.NewStatDelta()
+			delta.Add[stats.StatCooldownReduction] = reduction
+			player.Stats.Apply(stats.CommandStatChange{
+				Layer:  stats.LayerEquipment,
+				Source: stats.SourceKey{Kind: stats.SourceKindEquipment, ID: "cooldown-test"},
+				Delta:  delta,
+			})
+			player.Stats.Resolve(hub.world.currentTick)
+		}
+		hub.world.players[player.ID] = player
+		return player
+	}
+	baseline := addSwinger("baseline", 0)
+	hasted := addSwinger("hasted", 25)
+	capped := addSwinger("capped", 90)
+
+	ready := func(player *playerState, at time.Duration) bool {
+		_, ok := hub.world.meleeAbilityGate(player.ID, start.Add(at))
+		return ok
+	}
+	for _, player := range []*playerState{baseline, hasted, capped} {
+		if !ready(player, 0) {
+			t.Fatalf("expected %s to swing immediately", player.ID)
+		}
+	}
+
+	reduced := meleeAttackCooldown * 3 / 4
+	if ready(baseline, reduced) {
+		t.Fatalf("expected baseline player to still be on cooldown after %s", reduced)
+	}
+	if !ready(hasted, reduced) {
+		t.Fatalf("expected 25%% cooldown reduction to allow a swing after %s", reduced)
+	}
+
+	floor := meleeAttackCooldown / 2
+	if ready(capped, floor-time.Millisecond) {
+		t.Fatalf("expected cooldown reduction to be capped at half the base cooldown")
+	}
+	if !ready(capped, floor) {
+		t.Fatalf("expected capped player to swing again after %s", floor)
+	}
+	if !ready(baseline, meleeAttackCooldown) {
+		t.Fatalf("expected baseline player to swing again after %s", meleeAttackCooldown)
+	}
+}
+
 func TestFireballExpiresOnObstacleCollision(t *testing.T) {
 	hub := newHubWithFullWorld()
 	now := time.Now()
@@ -2355,6 +3898,63 @@ func TestConsoleDropAndPickupSelf(t *testing.T) {
 	}
 }
 
+func TestConsolePickupGoldMergesIntoHeldStack(t *testing.T) {
+	hub := newHubWithFullWorld()
+	playerID := "player-auto-stack"
+	player := newTestPlayerState(playerID)
+	hub.world.AddPlayer(player)
+	if err := hub.world.MutateInventory(playerID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+
+	for i, qty := range []int{4, 9} {
+		dropper := newTestPlayerState(fmt.Sprintf("player-auto-stack-dropper-%d", i))
+		dropper.X = player.X
+		dropper.Y = player.Y
+		hub.world.AddPlayer(dropper)
+		if err := hub.world.MutateInventory(dropper.ID, func(inv *Inventory) error {
+			_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: qty})
+			return err
+		}); err != nil {
+			t.Fatalf("failed to seed dropper gold: %v", err)
+		}
+		if ack, _ := hub.HandleConsoleCommand(dropper.ID, "drop_gold", qty); ack.Status != "ok" {
+			t.Fatalf("expected drop of %d gold to succeed, got %+v", qty, ack)
+		}
+		hub.world.DrainPatches()
+
+		if ack, _ := hub.HandleConsoleCommand(playerID, "pickup_gold", 0); ack.Status != "ok" || ack.Qty != qty {
+			t.Fatalf("expected pickup of %d gold, got %+v", qty, ack)
+		}
+	}
+
+	if len(player.Inventory.Slots) != 1 {
+		t.Fatalf("expected picked up gold to merge into one slot, got %+v", player.Inventory.Slots)
+	}
+	if got := player.Inventory.Slots[0].Item.Quantity; got != 20 {
+		t.Fatalf("expected merged slot of 20 gold, got %d", got)
+	}
+
+	var payload *PlayerInventoryPayload
+	for _, patch := range hub.world.DrainPatches() {
+		if patch.Kind != PatchPlayerInventory || patch.EntityID != playerID {
+			continue
+		}
+		if p, ok := patch.Payload.(PlayerInventoryPayload); ok {
+			payload = &p
+		}
+	}
+	if payload == nil {
+		t.Fatalf("expected the pickup to emit an inventory patch")
+	}
+	if len(payload.Slots) != 1 || payload.Slots[0].Item.Quantity != 20 {
+		t.Fatalf("expected the inventory patch to carry the merged slot, got %+v", payload.Slots)
+	}
+}
+
 func TestConsolePickupTransfersBetweenPlayers(t *testing.T) {
 	hub := newHubWithFullWorld()
 	dropperID := "player-dropper"
@@ -2417,6 +4017,79 @@ func TestConsolePickupRaceHonoursFirstCollector(t *testing.T) {
 	}
 }
 
+func TestConsolePickupReservesDeathLootForKiller(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	hub.world.npcs = make(map[string]*npcState)
+	now := time.Now()
+
+	killer := newTestPlayerState("player-loot-killer")
+	killer.X = 200
+	killer.Y = 200
+	killer.Facing = FacingRight
+	killer.LastHeartbeat = now
+	killer.Cooldowns = make(map[string]time.Time)
+	hub.world.players[killer.ID] = killer
+
+	victim := &npcState{
+		ActorState: actorState{Actor: Actor{
+			ID:        "npc-loot-victim",
+			X:         killer.X + playerHalf + meleeAttackReach/2,
+			Y:         killer.Y,
+			Health:    meleeAttackDamage,
+			MaxHealth: 100,
+			Inventory: NewInventory(),
+		}},
+		Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:  NPCTypeGoblin,
+	}
+	if _, err := victim.Inventory.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 5}); err != nil {
+		t.Fatalf("failed to seed victim gold: %v", err)
+	}
+	hub.world.npcs[victim.ID] = victim
+
+	bystander := newTestPlayerState("player-loot-bystander")
+	bystander.X = victim.X
+	bystander.Y = victim.Y + 200
+	bystander.LastHeartbeat = now
+	hub.world.players[bystander.ID] = bystander
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	current := now
+	if _, ok, reason := hub.HandleAction(killer.ID, effectTypeAttack); !ok {
+		t.Fatalf("expected melee attack to be accepted, got %q", reason)
+	}
+	_, _, _, _, _ = hub.advance(current, dt)
+	current = current.Add(step)
+	if _, alive := hub.world.npcs[victim.ID]; alive {
+		t.Fatalf("expected the swing to defeat the victim, health %.1f", victim.Health)
+	}
+
+	hub.mu.Lock()
+	bystander.X = victim.X
+	bystander.Y = victim.Y
+	hub.mu.Unlock()
+
+	ack, _ := hub.HandleConsoleCommand(bystander.ID, "pickup_gold", 0)
+	if ack.Status != "error" || ack.Reason != itemspkg.PickupFailureReasonLootLocked {
+		t.Fatalf("expected loot_locked during the window, got %+v", ack)
+	}
+
+	for i := 0; i < int(lootLockWindow.Seconds()*float64(tickRate)); i++ {
+		_, _, _, _, _ = hub.advance(current, dt)
+		current = current.Add(step)
+	}
+
+	ack, _ = hub.HandleConsoleCommand(bystander.ID, "pickup_gold", 0)
+	if ack.Status != "ok" {
+		t.Fatalf("expected pickup to succeed once the window expired, got %+v", ack)
+	}
+	if gold := bystander.Inventory.QuantityOf(ItemTypeGold); gold < 5 {
+		t.Fatalf("expected the bystander to collect the victim's gold, got %d", gold)
+	}
+}
+
 func TestConsoleDropGoldBroadcastsGroundItemsFromSimEngine(t *testing.T) {
 	hub := newHubWithFullWorld()
 	player := newTestPlayerState("player-console-engine")
@@ -2690,15 +4363,16 @@ func TestDeathDropsNPCInventory(t *testing.T) {
 	if len(items) != 2 {
 		t.Fatalf("expected two ground drops for npc death, got %d", len(items))
 	}
+	// The carried stacks drop together with the default goblin loot.
 	totals := map[ItemType]int{}
 	for _, item := range items {
 		totals[ItemType(item.Type)] += item.Qty
 	}
-	if totals[ItemTypeGold] != 12 {
-		t.Fatalf("expected npc drop of 12 gold, got %d", totals[ItemTypeGold])
+	if totals[ItemTypeGold] != 22 {
+		t.Fatalf("expected npc drop of 12 carried and 10 looted gold, got %d", totals[ItemTypeGold])
 	}
-	if totals[ItemTypeHealthPotion] != 1 {
-		t.Fatalf("expected npc drop of 1 potion, got %d", totals[ItemTypeHealthPotion])
+	if totals[ItemTypeHealthPotion] != 2 {
+		t.Fatalf("expected npc drop of 1 carried and 1 looted potion, got %d", totals[ItemTypeHealthPotion])
 	}
 }
 
@@ -2718,8 +4392,8 @@ func TestRatDropsTailOnDeath(t *testing.T) {
 	if rat == nil {
 		t.Fatalf("expected rat to spawn")
 	}
-	if qty := rat.Inventory.QuantityOf(ItemTypeRatTail); qty != 1 {
-		t.Fatalf("expected rat to start with 1 tail, got %d", qty)
+	if qty := rat.Inventory.QuantityOf(ItemTypeRatTail); qty != 0 {
+		t.Fatalf("expected the tail to come from the loot table, rat spawned carrying %d", qty)
 	}
 
 	now := time.Now()
@@ -2821,6 +4495,55 @@ func TestConsolePickupOutOfRange(t *testing.T) {
 	}
 }
 
+func TestConsolePickupRadiusScalesWithReach(t *testing.T) {
+	hub := newHubWithFullWorld()
+	dropper := newTestPlayerState("player-reach-dropper")
+	baseline := newTestPlayerState("player-reach-baseline")
+	boosted := newTestPlayerState("player-reach-boosted")
+	hub.world.AddPlayer(dropper)
+	hub.world.AddPlayer(baseline)
+	hub.world.AddPlayer(boosted)
+	if err := hub.world.MutateInventory(dropper.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 4})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed dropper gold: %v", err)
+	}
+
+	if ack, _ := hub.HandleConsoleCommand(dropper.ID, "drop_gold", 4); ack.Status != "ok" {
+		t.Fatalf("expected drop to succeed, got %+v", ack)
+	}
+
+	delta := stats.NewStatDelta()
+	delta.Add[stats.StatSpeed] = 20
+
+	hub.mu.Lock()
+	baseline.X = dropper.X + groundPickupRadius*1.5
+	baseline.Y = dropper.Y
+	boosted.X = baseline.X
+	boosted.Y = baseline.Y
+	boosted.Stats.Apply(stats.CommandStatChange{
+		Layer:  stats.LayerTemporary,
+		Source: stats.SourceKey{Kind: stats.SourceKindTemporary, ID: "reach-test"},
+		Delta:  delta,
+	})
+	boosted.Stats.Resolve(hub.world.currentTick)
+	hub.mu.Unlock()
+
+	ack, _ := hub.HandleConsoleCommand(baseline.ID, "pickup_gold", 0)
+	if ack.Status != "error" || ack.Reason != "out_of_range" {
+		t.Fatalf("expected baseline pickup to be out_of_range, got %+v", ack)
+	}
+
+	ack, _ = hub.HandleConsoleCommand(boosted.ID, "pickup_gold", 0)
+	if ack.Status != "ok" || ack.Qty != 4 {
+		t.Fatalf("expected boosted pickup to collect 4 gold, got %+v", ack)
+	}
+	if qty := boosted.Inventory.QuantityOf(ItemTypeGold); qty != 4 {
+		t.Fatalf("expected boosted player to carry 4 gold, got %d", qty)
+	}
+}
+
 func TestMarshalStateCapturesResubscribeBaselinesFromSnapshot(t *testing.T) {
 	hub := newHub()
 	player := newTestPlayerState("resubscribe-baseline")
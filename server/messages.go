@@ -28,4 +28,6 @@ type diagnosticsPlayer struct {
 	LastHeartbeat int64  `json:"lastHeartbeat"`
 	RTTMillis     int64  `json:"rttMillis"`
 	LastAck       uint64 `json:"lastAck"`
+
+	Session SessionStats `json:"session"`
 }
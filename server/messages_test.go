@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	stdlog "log"
@@ -17,6 +18,8 @@ import (
 	"mine-and-die/server/internal/sim"
 	simutil "mine-and-die/server/internal/simutil"
 	worldpkg "mine-and-die/server/internal/world"
+	"mine-and-die/server/logging"
+	loggingnetwork "mine-and-die/server/logging/network"
 )
 
 type failingPayload struct{}
@@ -1229,6 +1232,59 @@ func TestHandleKeyframeRequestClonesActors(t *testing.T) {
 	}
 }
 
+type keyframeNackCapturePublisher struct {
+	events []logging.Event
+}
+
+func (p *keyframeNackCapturePublisher) Publish(_ context.Context, event logging.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestHandleKeyframeRequestPublishesNackWindow(t *testing.T) {
+	pub := &keyframeNackCapturePublisher{}
+	hub := NewHubWithConfig(DefaultHubConfig(), pub)
+	hub.world.SwapJournal(newJournal(2, 0))
+
+	adapter := hub.adapter
+	if adapter == nil {
+		t.Fatalf("expected hub adapter to be initialized")
+	}
+	for seq := uint64(1); seq <= 4; seq++ {
+		adapter.RecordKeyframe(sim.Keyframe{Sequence: seq, Tick: seq * 10})
+	}
+	pub.events = nil
+
+	_, nack, ok := hub.HandleKeyframeRequest("player-1", nil, 1)
+	if !ok || nack == nil {
+		t.Fatalf("expected evicted keyframe request to be nacked, got ok=%v nack=%+v", ok, nack)
+	}
+	if nack.Reason != "expired" {
+		t.Fatalf("expected expired nack, got %q", nack.Reason)
+	}
+
+	var nackEvents []logging.Event
+	for _, event := range pub.events {
+		if event.Type == loggingnetwork.EventKeyframeNack {
+			nackEvents = append(nackEvents, event)
+		}
+	}
+	if len(nackEvents) != 1 {
+		t.Fatalf("expected one keyframe nack event, got %d", len(nackEvents))
+	}
+	event := nackEvents[0]
+	if event.Actor.ID != "player-1" {
+		t.Fatalf("expected nack event for player-1, got %q", event.Actor.ID)
+	}
+	payload, ok := event.Payload.(loggingnetwork.KeyframeNackPayload)
+	if !ok {
+		t.Fatalf("expected keyframe nack payload, got %T", event.Payload)
+	}
+	want := loggingnetwork.KeyframeNackPayload{Requested: 1, Oldest: 3, Newest: 4, Size: 2, Reason: "expired"}
+	if payload != want {
+		t.Fatalf("unexpected nack payload: got %+v want %+v", payload, want)
+	}
+}
+
 func TestHandleKeyframeRequestClonesObstacles(t *testing.T) {
 	hub := newHub()
 	adapter := hub.adapter
@@ -1352,7 +1408,7 @@ func TestHubKeyframeCopiesConfig(t *testing.T) {
 		t.Fatalf("expected hub to return keyframe %d", frame.Sequence)
 	}
 
-	if snapshot.Config != expected {
+	if !reflect.DeepEqual(snapshot.Config, expected) {
 		t.Fatalf("unexpected keyframe config: got %#v want %#v", snapshot.Config, expected)
 	}
 
@@ -1364,7 +1420,7 @@ func TestHubKeyframeCopiesConfig(t *testing.T) {
 		t.Fatalf("expected hub to return keyframe %d on second lookup", frame.Sequence)
 	}
 
-	if again.Config != expected {
+	if !reflect.DeepEqual(again.Config, expected) {
 		t.Fatalf("expected keyframe config to remain unchanged, got %#v want %#v", again.Config, expected)
 	}
 
@@ -1428,7 +1484,7 @@ func TestHandleKeyframeRequestCopiesConfig(t *testing.T) {
 		t.Fatalf("expected ack response, got nack: %+v", nack)
 	}
 
-	if snapshot.Config != expected {
+	if !reflect.DeepEqual(snapshot.Config, expected) {
 		t.Fatalf("unexpected keyframe config: got %#v want %#v", snapshot.Config, expected)
 	}
 
@@ -1443,7 +1499,7 @@ func TestHandleKeyframeRequestCopiesConfig(t *testing.T) {
 		t.Fatalf("expected ack response on second fetch, got nack: %+v", nack)
 	}
 
-	if again.Config != expected {
+	if !reflect.DeepEqual(again.Config, expected) {
 		t.Fatalf("expected keyframe config to remain unchanged, got %#v want %#v", again.Config, expected)
 	}
 
@@ -1,9 +1,19 @@
 package server
 
-import worldpkg "mine-and-die/server/internal/world"
+import (
+	"math"
+
+	worldpkg "mine-and-die/server/internal/world"
+)
 
 // moveActorWithObstacles advances an actor while clamping speed, bounds, and walls.
 func moveActorWithObstacles(state *actorState, dt float64, obstacles []Obstacle, width, height float64) {
+	moveActorInBounds(state, dt, obstacles, worldpkg.Bounds{Width: width, Height: height}, moveSpeed)
+}
+
+// moveActorInBounds advances an actor at speed, clamping or wrapping at the
+// world edge according to bounds.
+func moveActorInBounds(state *actorState, dt float64, obstacles []Obstacle, bounds worldpkg.Bounds, speed float64) {
 	if state == nil {
 		return
 	}
@@ -14,25 +24,42 @@ func moveActorWithObstacles(state *actorState, dt float64, obstacles []Obstacle,
 		IntentX: state.IntentX,
 		IntentY: state.IntentY,
 	}
-	worldpkg.MoveActorWithObstacles(&movement, dt, obstacles, width, height, moveSpeed)
+	worldpkg.MoveActorInBounds(&movement, dt, obstacles, bounds, speed)
+	state.X = movement.X
+	state.Y = movement.Y
+}
+
+// displaceActorInBounds shifts an actor by up to (dx, dy) in one step, stopping
+// at obstacle edges and confining the result to bounds like regular movement.
+func displaceActorInBounds(state *actorState, dx, dy float64, obstacles []Obstacle, bounds worldpkg.Bounds) {
+	if state == nil {
+		return
+	}
+	distance := math.Hypot(dx, dy)
+	if distance == 0 {
+		return
+	}
+
+	movement := worldpkg.MovementActor{X: state.X, Y: state.Y, IntentX: dx, IntentY: dy}
+	worldpkg.MoveActorInBounds(&movement, 1, obstacles, bounds, distance)
 	state.X = movement.X
 	state.Y = movement.Y
 }
 
 // resolveObstaclePenetration nudges an actor out of overlapping obstacles.
-func resolveObstaclePenetration(state *actorState, obstacles []Obstacle, width, height float64) {
+func resolveObstaclePenetration(state *actorState, obstacles []Obstacle, bounds worldpkg.Bounds) {
 	if state == nil {
 		return
 	}
 
 	movement := worldpkg.MovementActor{X: state.X, Y: state.Y}
-	worldpkg.ResolveObstaclePenetration(&movement, obstacles, width, height)
+	worldpkg.ResolveObstaclePenetrationInBounds(&movement, obstacles, bounds)
 	state.X = movement.X
 	state.Y = movement.Y
 }
 
 // resolveActorCollisions separates overlapping actors while respecting walls.
-func resolveActorCollisions(actors []*actorState, obstacles []Obstacle, width, height float64) {
+func resolveActorCollisions(actors []*actorState, obstacles []Obstacle, bounds worldpkg.Bounds) {
 	if len(actors) < 2 {
 		return
 	}
@@ -43,11 +70,16 @@ func resolveActorCollisions(actors []*actorState, obstacles []Obstacle, width, h
 		if actor == nil {
 			continue
 		}
-		states[i] = worldpkg.MovementActor{X: actor.X, Y: actor.Y}
+		states[i] = worldpkg.MovementActor{
+			X:     actor.X,
+			Y:     actor.Y,
+			Layer: actor.CollisionLayer,
+			Mask:  actor.CollisionMask,
+		}
 		pointers[i] = &states[i]
 	}
 
-	worldpkg.ResolveActorCollisions(pointers, obstacles, width, height)
+	worldpkg.ResolveActorCollisionsInBounds(pointers, obstacles, bounds)
 
 	for i, actor := range actors {
 		if actor == nil {
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+
+	itemspkg "mine-and-die/server/internal/items"
+	logging "mine-and-die/server/logging"
+	loggingeconomy "mine-and-die/server/logging/economy"
+)
+
+type (
+	LootEntry = itemspkg.LootEntry
+	LootTable = itemspkg.LootTable
+)
+
+// DefaultNPCLootTable returns the loot rolled for an NPC type that has no
+// configured table: goblins carry gold and a health potion, rats a tail.
+func DefaultNPCLootTable(npcType NPCType) LootTable {
+	switch npcType {
+	case NPCTypeGoblin:
+		return LootTable{
+			{Type: string(ItemTypeGold), MinQuantity: 10, MaxQuantity: 10, Chance: 1},
+			{Type: string(ItemTypeHealthPotion), MinQuantity: 1, MaxQuantity: 1, Chance: 1},
+		}
+	case NPCTypeRat:
+		return LootTable{
+			{Type: string(ItemTypeRatTail), MinQuantity: 1, MaxQuantity: 1, Chance: 1},
+		}
+	default:
+		return nil
+	}
+}
+
+// SetNPCLootTables replaces the per-type loot tables rolled when NPCs die.
+// A configured table replaces that type's default, and an empty one turns
+// its drops off; other types keep DefaultNPCLootTable.
+func (w *World) SetNPCLootTables(tables map[NPCType]LootTable) {
+	if w == nil {
+		return
+	}
+	if len(tables) == 0 {
+		w.lootTables = nil
+		return
+	}
+	w.lootTables = make(map[NPCType]LootTable, len(tables))
+	for npcType, table := range tables {
+		w.lootTables[npcType] = append(LootTable(nil), table...)
+	}
+}
+
+// grantNPCLoot rolls the NPC's loot table with the world RNG and adds the
+// results to its inventory so the regular death drop scatters them.
+func (w *World) grantNPCLoot(npc *npcState) {
+	if w == nil || npc == nil {
+		return
+	}
+	table, ok := w.lootTables[npc.Type]
+	if !ok {
+		table = DefaultNPCLootTable(npc.Type)
+	}
+	if len(table) == 0 {
+		return
+	}
+
+	w.ensureRNG()
+	drops := itemspkg.RollLoot(w.rng, table)
+	for _, drop := range drops {
+		stack := ItemStack{Type: ItemType(drop.Type), Quantity: drop.Quantity}
+		if err := w.MutateNPCInventory(npc.ID, func(inv *Inventory) error {
+			_, addErr := inv.AddStack(stack)
+			return addErr
+		}); err != nil {
+			loggingeconomy.ItemGrantFailed(
+				context.Background(),
+				w.publisher,
+				w.currentTick,
+				logging.EntityRef{ID: npc.ID, Kind: logging.EntityKind("npc")},
+				loggingeconomy.ItemGrantFailedPayload{ItemType: drop.Type, Quantity: drop.Quantity, Reason: "npc_loot"},
+				map[string]any{"error": err.Error()},
+			)
+		}
+	}
+}
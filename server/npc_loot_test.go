@@ -0,0 +1,159 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func goblinLootTestWorld(t *testing.T, table LootTable) (*Hub, *npcState) {
+	t.Helper()
+	cfg := DefaultHubConfig()
+	cfg.NPCLootTables = map[NPCType]LootTable{NPCTypeGoblin: table}
+	hub := NewHubWithConfig(cfg)
+	hub.ResetWorld(fullyFeaturedTestWorldConfig())
+
+	hub.world.spawnGoblinAt(400, 400, nil)
+	goblin, ok := hub.world.npcs[fmt.Sprintf("npc-goblin-%d", hub.world.nextNPCID)]
+	if !ok {
+		t.Fatalf("expected spawned goblin")
+	}
+	return hub, goblin
+}
+
+func groundTotalsByType(w *World) map[ItemType]int {
+	totals := make(map[ItemType]int)
+	for _, item := range w.GroundItemsSnapshot() {
+		totals[ItemType(item.Type)] += item.Qty
+	}
+	return totals
+}
+
+func TestNPCLootTableGuaranteedDrops(t *testing.T) {
+	hub, goblin := goblinLootTestWorld(t, LootTable{
+		{Type: string(ItemTypeGold), MinQuantity: 7, MaxQuantity: 7, Chance: 1},
+		{Type: string(ItemTypeHealthPotion), MinQuantity: 2, MaxQuantity: 2, Chance: 1},
+	})
+	before := groundTotalsByType(hub.world)
+
+	hub.world.handleNPCDefeat(goblin)
+
+	if _, ok := hub.world.npcs[goblin.ID]; ok {
+		t.Fatalf("expected goblin to be removed after defeat")
+	}
+	after := groundTotalsByType(hub.world)
+	if got := after[ItemTypeGold] - before[ItemTypeGold]; got != 7 {
+		t.Fatalf("expected 7 gold from loot table, got %d", got)
+	}
+	if got := after[ItemTypeHealthPotion] - before[ItemTypeHealthPotion]; got != 2 {
+		t.Fatalf("expected 2 potions from loot table, got %d", got)
+	}
+	for itemType, qty := range after {
+		if itemType == ItemTypeGold || itemType == ItemTypeHealthPotion {
+			continue
+		}
+		if qty != before[itemType] {
+			t.Fatalf("unexpected %s drop from loot table", itemType)
+		}
+	}
+}
+
+func TestNPCDefaultLootTableAppliesWithoutConfiguredTable(t *testing.T) {
+	hub := NewHubWithConfig(DefaultHubConfig())
+	hub.ResetWorld(fullyFeaturedTestWorldConfig())
+	hub.world.spawnGoblinAt(400, 400, nil)
+	goblin := hub.world.npcs[fmt.Sprintf("npc-goblin-%d", hub.world.nextNPCID)]
+	if goblin == nil || len(goblin.Inventory.Slots) != 0 {
+		t.Fatalf("expected a goblin spawned with an empty inventory, got %+v", goblin)
+	}
+	before := groundTotalsByType(hub.world)
+
+	hub.world.handleNPCDefeat(goblin)
+
+	after := groundTotalsByType(hub.world)
+	if got := after[ItemTypeGold] - before[ItemTypeGold]; got != 10 {
+		t.Fatalf("expected 10 gold from the default goblin table, got %d", got)
+	}
+	if got := after[ItemTypeHealthPotion] - before[ItemTypeHealthPotion]; got != 1 {
+		t.Fatalf("expected 1 potion from the default goblin table, got %d", got)
+	}
+
+	silenced, quiet := goblinLootTestWorld(t, LootTable{})
+	before = groundTotalsByType(silenced.world)
+	silenced.world.handleNPCDefeat(quiet)
+	if after := groundTotalsByType(silenced.world); !reflect.DeepEqual(after, before) {
+		t.Fatalf("expected an empty configured table to turn goblin drops off, got %v from %v", after, before)
+	}
+}
+
+func TestNPCLootTableRollsAreReproducible(t *testing.T) {
+	table := LootTable{
+		{Type: string(ItemTypeGold), MinQuantity: 1, MaxQuantity: 50, Chance: 1},
+		{Type: string(ItemTypeHealthPotion), MinQuantity: 1, MaxQuantity: 3, Chance: 0.5},
+	}
+
+	roll := func() map[ItemType]int {
+		hub, goblin := goblinLootTestWorld(t, table)
+		before := groundTotalsByType(hub.world)
+		hub.world.handleNPCDefeat(goblin)
+		after := groundTotalsByType(hub.world)
+		for itemType, qty := range before {
+			after[itemType] -= qty
+			if after[itemType] == 0 {
+				delete(after, itemType)
+			}
+		}
+		return after
+	}
+
+	first := roll()
+	second := roll()
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("expected identical loot for identical seeds, got %v and %v", first, second)
+	}
+	if first[ItemTypeGold] < 1 || first[ItemTypeGold] > 50 {
+		t.Fatalf("expected gold within configured range, got %d", first[ItemTypeGold])
+	}
+}
+
+func TestSameTickNPCDefeatsDropReproducibleLoot(t *testing.T) {
+	table := LootTable{
+		{Type: string(ItemTypeGold), MinQuantity: 1, MaxQuantity: 50, Chance: 1},
+		{Type: string(ItemTypeHealthPotion), MinQuantity: 1, MaxQuantity: 3, Chance: 0.5},
+	}
+
+	roll := func() map[string]int {
+		hub, _ := goblinLootTestWorld(t, table)
+		for i := 0; i < 5; i++ {
+			hub.world.spawnGoblinAt(160+float64(i)*120, 160, nil)
+		}
+		before := make(map[string]int)
+		for _, item := range hub.world.GroundItemsSnapshot() {
+			before[fmt.Sprintf("%s@%.0f,%.0f", item.Type, item.X, item.Y)] += item.Qty
+		}
+		for _, npc := range hub.world.npcs {
+			if npc.Type == NPCTypeGoblin {
+				npc.Health = 0
+			}
+		}
+		hub.world.pruneDefeatedNPCs()
+		drops := make(map[string]int)
+		for _, item := range hub.world.GroundItemsSnapshot() {
+			key := fmt.Sprintf("%s@%.0f,%.0f", item.Type, item.X, item.Y)
+			if qty := item.Qty - before[key]; qty != 0 {
+				drops[key] += qty
+			}
+		}
+		return drops
+	}
+
+	first := roll()
+	if len(first) == 0 {
+		t.Fatalf("expected the defeated goblins to drop loot")
+	}
+	for i := 0; i < 10; i++ {
+		if next := roll(); !reflect.DeepEqual(first, next) {
+			t.Fatalf("expected identical same-tick drops for identical seeds, got %v and %v", first, next)
+		}
+	}
+}
@@ -33,6 +33,13 @@ func (c npcPathController) DeriveFacing(dx, dy float64, fallback string) string
 	return string(deriveFacing(dx, dy, FacingDirection(fallback)))
 }
 
+func (c npcPathController) Bounds() worldpkg.Bounds {
+	if c.world == nil {
+		return worldpkg.Bounds{}
+	}
+	return c.world.bounds()
+}
+
 func (c npcPathController) ComputeNPCPath(actorID string, target worldpkg.Vec2) ([]worldpkg.Vec2, worldpkg.Vec2, bool) {
 	if c.world == nil {
 		return nil, worldpkg.Vec2{}, false
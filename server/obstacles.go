@@ -11,6 +11,7 @@ type Obstacle = worldpkg.Obstacle
 const (
 	obstacleTypeGoldOre = worldpkg.ObstacleTypeGoldOre
 	obstacleTypeLava    = worldpkg.ObstacleTypeLava
+	obstacleTypeStash   = worldpkg.ObstacleTypeStash
 )
 
 // generateObstacles scatters blocking rectangles and ore deposits around the map.
@@ -28,6 +28,7 @@ const (
 	PatchPlayerHealth    = simpatches.PatchPlayerHealth
 	PatchPlayerInventory = simpatches.PatchPlayerInventory
 	PatchPlayerEquipment = simpatches.PatchPlayerEquipment
+	PatchPlayerAbsorb    = simpatches.PatchPlayerAbsorb
 	PatchPlayerRemoved   = simpatches.PatchPlayerRemoved
 
 	PatchNPCPos       = simpatches.PatchNPCPos
@@ -41,6 +42,10 @@ const (
 
 	PatchGroundItemPos = simpatches.PatchGroundItemPos
 	PatchGroundItemQty = simpatches.PatchGroundItemQty
+
+	PatchCorpseAdded    = simpatches.PatchCorpseAdded
+	PatchCorpseContents = simpatches.PatchCorpseContents
+	PatchCorpseRemoved  = simpatches.PatchCorpseRemoved
 )
 
 type Patch = simpatches.Patch
@@ -69,6 +74,10 @@ type PlayerHealthPayload = simpatches.PlayerHealthPayload
 
 type NPCHealthPayload = simpatches.NPCHealthPayload
 
+type AbsorbPayload = simpatches.AbsorbPayload
+
+type PlayerAbsorbPayload = simpatches.PlayerAbsorbPayload
+
 type InventoryPayload = simpatches.InventoryPayload
 
 type PlayerInventoryPayload = simpatches.PlayerInventoryPayload
@@ -28,6 +28,7 @@ func (w *World) computePathFrom(startX, startY float64, ignoreID string, target
 		Target:    worldpkg.Vec2(target),
 		Width:     width,
 		Height:    height,
+		Wrap:      w.config.Wrap,
 		Obstacles: w.obstacles,
 	}
 	path, goal, ok := worldpkg.ComputeNavigationPath(req, actors, ignoreID)
@@ -28,11 +28,11 @@ func (c playerPathController) DeriveFacing(dx, dy float64, fallback string) stri
 	return string(deriveFacing(dx, dy, FacingDirection(fallback)))
 }
 
-func (c playerPathController) Dimensions() (float64, float64) {
+func (c playerPathController) Bounds() worldpkg.Bounds {
 	if c.world == nil {
-		return 0, 0
+		return worldpkg.Bounds{}
 	}
-	return c.world.dimensions()
+	return c.world.bounds()
 }
 
 func (c playerPathController) ComputePlayerPath(actorID string, target worldpkg.Vec2) ([]worldpkg.Vec2, worldpkg.Vec2, bool) {
@@ -46,6 +46,13 @@ func (c playerPathController) ComputePlayerPath(actorID string, target worldpkg.
 	return c.world.computePlayerPath(player, target)
 }
 
+func (c playerPathController) NodeBlocked(node worldpkg.Vec2) bool {
+	if c.world == nil {
+		return false
+	}
+	return worldpkg.PathNodeBlocked(c.world.obstacles, node)
+}
+
 func toPlayerPathActor(player *playerState) *worldpkg.PlayerPathActor {
 	if player == nil {
 		return nil
@@ -4,12 +4,16 @@ import (
 	"math"
 	"testing"
 
+	worldpkg "mine-and-die/server/internal/world"
 	"mine-and-die/server/logging"
 	stats "mine-and-die/server/stats"
 )
 
 func TestFollowPlayerPathNormalizesIntentVectors(t *testing.T) {
 	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	// The hand-placed waypoint would otherwise sit inside a seeded obstacle
+	// and trigger a reroute instead of steering.
+	w.obstacles = nil
 	player := &playerState{
 		ActorState: actorState{Actor: Actor{
 			ID:        "path-player",
@@ -80,3 +84,54 @@ func TestFollowPlayerPathNormalizesIntentVectors(t *testing.T) {
 		t.Fatalf("expected unit-length intent, got magnitude %.6f", math.Hypot(intentPayload.DX, intentPayload.DY))
 	}
 }
+
+func TestFollowPlayerPathReroutesAroundNewObstacle(t *testing.T) {
+	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	w.obstacles = nil
+
+	player := newTestPlayerState("path-player")
+	player.X = 144
+	player.Y = 304
+	w.AddPlayer(player)
+
+	target := vec2{X: 720, Y: 304}
+	if !w.ensurePlayerPath(player, target, 1) {
+		t.Fatalf("expected a path across the open field")
+	}
+	for _, node := range player.Path.Path {
+		if math.Abs(node.Y-target.Y) > navCellSize {
+			t.Fatalf("test setup error: expected a straight path, got node %+v", node)
+		}
+	}
+
+	next := player.Path.Path[0]
+	wall := Obstacle{ID: "wall", X: next.X - navCellSize/2, Y: next.Y - 4*navCellSize, Width: navCellSize, Height: 8 * navCellSize}
+	w.obstacles = []Obstacle{wall}
+	if !worldpkg.PathNodeBlocked(w.obstacles, next) {
+		t.Fatalf("test setup error: expected the wall to cover the next waypoint %+v", next)
+	}
+
+	w.followPlayerPath(player, 2)
+
+	if len(player.Path.Path) == 0 {
+		t.Fatalf("expected the path to be recomputed, got none")
+	}
+	if player.Path.PathTarget != target {
+		t.Fatalf("expected the recomputed path to keep target %+v, got %+v", target, player.Path.PathTarget)
+	}
+	detoured := false
+	for _, node := range player.Path.Path {
+		if worldpkg.PathNodeBlocked(w.obstacles, node) {
+			t.Fatalf("expected the recomputed path to avoid the wall, node %+v is blocked", node)
+		}
+		if node.Y < wall.Y || node.Y > wall.Y+wall.Height {
+			detoured = true
+		}
+	}
+	if !detoured {
+		t.Fatalf("expected the recomputed path to route around the wall, got %+v", player.Path.Path)
+	}
+	if player.IntentX == 0 && player.IntentY == 0 {
+		t.Fatalf("expected the player to keep moving along the new path")
+	}
+}
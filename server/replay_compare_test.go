@@ -0,0 +1,200 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	stats "mine-and-die/server/stats"
+)
+
+// replayScript lists the commands issued on each tick of a replay, starting
+// with tick 1. Ticks past the end of the script run with no commands.
+type replayScript [][]Command
+
+// assertReplayLockstep builds two worlds from the same config and seed, runs
+// setup on each, and drives both through script for ticks ticks. After every
+// tick the worlds' full dumps must be byte-identical; the first tick where
+// they differ fails the test and names the sections that diverged.
+func assertReplayLockstep(t *testing.T, cfg worldConfig, setup func(*World), script replayScript, ticks int) {
+	t.Helper()
+
+	worlds := [2]*World{
+		newTestWorld(cfg, logging.NopPublisher{}),
+		newTestWorld(cfg, logging.NopPublisher{}),
+	}
+	for _, world := range worlds {
+		if setup != nil {
+			setup(world)
+		}
+	}
+	compareReplayDumps(t, 0, worlds)
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	now := time.Unix(0, 0)
+	for tick := 1; tick <= ticks; tick++ {
+		now = now.Add(step)
+		var commands []Command
+		if tick <= len(script) {
+			commands = script[tick-1]
+		}
+		for _, world := range worlds {
+			world.Step(uint64(tick), now, dt, cloneReplayCommands(commands, now), nil)
+		}
+		compareReplayDumps(t, tick, worlds)
+	}
+}
+
+// cloneReplayCommands gives each world its own copy of a tick's commands so
+// nothing one world does to a command can leak into the other.
+func cloneReplayCommands(commands []Command, issuedAt time.Time) []Command {
+	if len(commands) == 0 {
+		return nil
+	}
+	cloned := make([]Command, len(commands))
+	for i, cmd := range commands {
+		if cmd.Move != nil {
+			move := *cmd.Move
+			cmd.Move = &move
+		}
+		if cmd.Action != nil {
+			action := *cmd.Action
+			cmd.Action = &action
+		}
+		if cmd.Heartbeat != nil {
+			heartbeat := *cmd.Heartbeat
+			cmd.Heartbeat = &heartbeat
+		}
+		if cmd.Path != nil {
+			path := *cmd.Path
+			cmd.Path = &path
+		}
+		if cmd.IssuedAt.IsZero() {
+			cmd.IssuedAt = issuedAt
+		}
+		cloned[i] = cmd
+	}
+	return cloned
+}
+
+func compareReplayDumps(t *testing.T, tick int, worlds [2]*World) {
+	t.Helper()
+
+	dumps := [2][]byte{}
+	for i, world := range worlds {
+		data, err := world.Dump()
+		if err != nil {
+			t.Fatalf("replay tick %d: failed to dump world %d: %v", tick, i, err)
+		}
+		dumps[i] = data
+	}
+	if bytes.Equal(dumps[0], dumps[1]) {
+		return
+	}
+
+	var sections [2]map[string]json.RawMessage
+	for i, data := range dumps {
+		if err := json.Unmarshal(data, &sections[i]); err != nil {
+			t.Fatalf("replay tick %d: failed to decode dump %d: %v", tick, i, err)
+		}
+	}
+	var diverged []string
+	for key, value := range sections[0] {
+		if !bytes.Equal(value, sections[1][key]) {
+			diverged = append(diverged, key)
+		}
+	}
+	for key := range sections[1] {
+		if _, ok := sections[0][key]; !ok {
+			diverged = append(diverged, key)
+		}
+	}
+	sort.Strings(diverged)
+	key := diverged[0]
+	t.Fatalf("replay diverged at tick %d in %v\nfirst %s:  %s\nsecond %s: %s", tick, diverged, key, sections[0][key], key, sections[1][key])
+}
+
+func TestReplayLockstepMovementCombatAndLoot(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Seed = "replay-lockstep"
+
+	const (
+		heroID   = "replay-hero"
+		goblinID = "replay-goblin"
+	)
+	setup := func(world *World) {
+		hero := newTestPlayerState(heroID)
+		hero.X = 600
+		hero.Y = 600
+		hero.Facing = FacingRight
+		hero.LastHeartbeat = time.Unix(0, 0)
+		hero.Cooldowns = make(map[string]time.Time)
+		world.players[hero.ID] = hero
+
+		goblin := &npcState{
+			ActorState: actorState{Actor: Actor{
+				ID:        goblinID,
+				X:         hero.X + playerHalf*2 + meleeAttackReach,
+				Y:         hero.Y,
+				Health:    meleeAttackDamage,
+				MaxHealth: 50,
+				Inventory: NewInventory(),
+			}},
+			Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+			Type:  NPCTypeGoblin,
+		}
+		if _, err := goblin.Inventory.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 3}); err != nil {
+			t.Fatalf("failed to give the goblin gold: %v", err)
+		}
+		world.npcs[goblin.ID] = goblin
+	}
+
+	move := func(dx, dy float64, facing FacingDirection) []Command {
+		return []Command{{ActorID: heroID, Type: CommandMove, Move: &MoveCommand{DX: dx, DY: dy, Facing: facing}}}
+	}
+	action := func(name string) []Command {
+		return []Command{{ActorID: heroID, Type: CommandAction, Action: &ActionCommand{Name: name}}}
+	}
+	script := replayScript{
+		move(1, 0, FacingRight),
+		move(1, 0, FacingRight),
+		move(0, 0, FacingRight),
+		action(effectTypeAttack),
+		nil,
+		action(effectTypeFireball),
+		move(1, 0, FacingRight),
+		move(1, 0, FacingRight),
+		move(1, 0, FacingRight),
+		move(0, 1, FacingDown),
+		move(0, 0, FacingDown),
+		action(effectTypeAttack),
+	}
+
+	assertReplayLockstep(t, cfg, setup, script, 4*tickRate)
+
+	// Drive the script once more on its own to confirm it exercised combat
+	// and loot rather than trivially agreeing on an idle world.
+	world := newTestWorld(cfg, logging.NopPublisher{})
+	setup(world)
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	now := time.Unix(0, 0)
+	for tick, commands := range script {
+		now = now.Add(step)
+		world.Step(uint64(tick+1), now, dt, cloneReplayCommands(commands, now), nil)
+	}
+	if _, alive := world.npcs[goblinID]; alive {
+		t.Fatalf("expected the scripted swing to defeat the goblin")
+	}
+	if len(world.groundItems) == 0 {
+		t.Fatalf("expected the goblin's gold to drop")
+	}
+	hero := world.players[heroID]
+	if hero.X == 600 && hero.Y == 600 {
+		t.Fatalf("expected the scripted moves to move the hero")
+	}
+}
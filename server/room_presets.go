@@ -0,0 +1,79 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	worldpkg "mine-and-die/server/internal/world"
+)
+
+var (
+	ErrUnknownRoomPreset  = errors.New("unknown room preset")
+	ErrRoomPresetConflict = errors.New("room already uses a different preset")
+)
+
+const (
+	// RoomPresetArena is a compact PvP map with cover and no NPCs or hazards.
+	RoomPresetArena = "arena"
+	// RoomPresetDungeon is a large PvE map with NPCs, lava, and gold.
+	RoomPresetDungeon = "dungeon"
+)
+
+// builtinRoomPresets returns the world configurations every RoomManager
+// starts with.
+func builtinRoomPresets() map[string]worldConfig {
+	arena := worldpkg.DefaultConfig()
+	arena.Seed = "arena"
+	arena.Width = 2400
+	arena.Height = 1800
+	arena.Obstacles = true
+	arena.ObstaclesCount = 6
+
+	dungeon := worldpkg.DefaultConfig()
+	dungeon.Seed = "dungeon"
+	dungeon.Width = 3200
+	dungeon.Height = 2400
+	dungeon.Obstacles = true
+	dungeon.ObstaclesCount = 12
+	dungeon.GoldMines = true
+	dungeon.GoldMineCount = 4
+	dungeon.NPCs = true
+	dungeon.GoblinCount = 8
+	dungeon.RatCount = 6
+	dungeon.Lava = true
+	dungeon.LavaCount = 4
+
+	return map[string]worldConfig{
+		RoomPresetArena:   arena,
+		RoomPresetDungeon: dungeon,
+	}
+}
+
+// RegisterPreset adds or replaces a named world configuration that rooms can
+// be created with. The size must fit the manager's MaxWorldSize; the stored
+// configuration is normalized.
+func (m *RoomManager) RegisterPreset(name string, cfg worldConfig) error {
+	if !validRoomName(name) {
+		return fmt.Errorf("%w: preset %q", ErrInvalidRoomName, name)
+	}
+	if err := cfg.ValidateSize(m.cfg.MaxWorldSize); err != nil {
+		return fmt.Errorf("preset %q: %w", name, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.presets[name] = cfg.Normalized()
+	return nil
+}
+
+// Presets returns the registered preset names in sorted order.
+func (m *RoomManager) Presets() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.presets))
+	for name := range m.presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
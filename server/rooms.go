@@ -0,0 +1,267 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+const (
+	// DefaultRoom names the room used when a client does not ask for one.
+	DefaultRoom = "default"
+
+	maxRoomNameLength = 32
+
+	// DefaultMaxRooms caps the number of open rooms when the manager is
+	// created without an explicit limit.
+	DefaultMaxRooms = 16
+	// DefaultRoomIdleTimeout is how long a room may sit without players,
+	// subscribers, or parked reconnect sessions before it is closed.
+	DefaultRoomIdleTimeout = 5 * time.Minute
+)
+
+var (
+	ErrInvalidRoomName = errors.New("invalid room name")
+	ErrRoomLimit       = errors.New("room limit reached")
+	ErrRoomsClosed     = errors.New("room manager closed")
+)
+
+// RoomManager hosts isolated hubs keyed by room name. Each room owns its own
+// world, subscribers, and simulation loop; nothing is shared between rooms
+// besides the base configuration and log publisher they were created with.
+type RoomManager struct {
+	cfg         HubConfig
+	pubs        []logging.Publisher
+	maxRooms    int
+	idleTimeout time.Duration
+
+	// run drives a room's simulation loop. Tests replace it to step rooms
+	// manually.
+	run func(hub *Hub, stop <-chan struct{})
+	now func() time.Time
+
+	mu      sync.Mutex
+	rooms   map[string]*room
+	presets map[string]worldConfig
+	closed  bool
+}
+
+type room struct {
+	hub    *Hub
+	preset string
+	stop   chan struct{}
+	// idleSince is when the room was last seen empty, or zero while it is
+	// occupied.
+	idleSince time.Time
+}
+
+// NewRoomManager returns a manager that creates rooms from cfg. A maxRooms of
+// zero or less selects DefaultMaxRooms. The built-in presets are registered
+// unless they exceed cfg.MaxWorldSize.
+func NewRoomManager(cfg HubConfig, maxRooms int, pubs ...logging.Publisher) *RoomManager {
+	if maxRooms <= 0 {
+		maxRooms = DefaultMaxRooms
+	}
+	m := &RoomManager{
+		cfg:         cfg,
+		pubs:        pubs,
+		maxRooms:    maxRooms,
+		idleTimeout: DefaultRoomIdleTimeout,
+		run:         (*Hub).RunSimulation,
+		now:         time.Now,
+		rooms:       make(map[string]*room),
+		presets:     make(map[string]worldConfig),
+	}
+	for name, preset := range builtinRoomPresets() {
+		_ = m.RegisterPreset(name, preset)
+	}
+	return m
+}
+
+// Room returns the hub for the named room, creating it with the base world
+// configuration and starting its simulation loop on first use. An empty name
+// selects DefaultRoom.
+func (m *RoomManager) Room(name string) (*Hub, error) {
+	return m.RoomWithPreset(name, "")
+}
+
+// RoomWithPreset is Room with the world of a newly created room taken from the
+// named preset. An empty preset attaches to the room whatever it was created
+// with; a different preset than the existing room's is refused.
+func (m *RoomManager) RoomWithPreset(name, preset string) (*Hub, error) {
+	if name == "" {
+		name = DefaultRoom
+	}
+	if !validRoomName(name) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRoomName, name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, ErrRoomsClosed
+	}
+	if existing, ok := m.rooms[name]; ok {
+		if preset != "" && preset != existing.preset {
+			return nil, fmt.Errorf("%w: %q uses %q", ErrRoomPresetConflict, name, existing.preset)
+		}
+		return existing.hub, nil
+	}
+	hubCfg := m.cfg
+	if preset != "" {
+		world, ok := m.presets[preset]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRoomPreset, preset)
+		}
+		hubCfg.World = world
+	}
+	if len(m.rooms) >= m.maxRooms {
+		m.reapIdleRoomsLocked(m.now())
+	}
+	if len(m.rooms) >= m.maxRooms {
+		return nil, ErrRoomLimit
+	}
+
+	created := &room{
+		hub:    NewHubWithConfig(hubCfg, m.pubs...),
+		preset: preset,
+		stop:   make(chan struct{}),
+	}
+	m.rooms[name] = created
+	go m.run(created.hub, created.stop)
+	return created.hub, nil
+}
+
+// Lookup returns the hub for an existing room without creating it. An empty
+// name selects DefaultRoom.
+func (m *RoomManager) Lookup(name string) (*Hub, bool) {
+	if name == "" {
+		name = DefaultRoom
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.rooms[name]
+	if !ok {
+		return nil, false
+	}
+	return existing.hub, true
+}
+
+// Rooms returns the names of the open rooms in sorted order.
+func (m *RoomManager) Rooms() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.rooms))
+	for name := range m.rooms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetIdleTimeout sets how long an empty room is kept open. Zero or negative
+// values restore DefaultRoomIdleTimeout.
+func (m *RoomManager) SetIdleTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultRoomIdleTimeout
+	}
+	m.mu.Lock()
+	m.idleTimeout = timeout
+	m.mu.Unlock()
+}
+
+// ReapIdleRooms closes rooms that have been empty for longer than the idle
+// timeout, checking every interval until stop is closed.
+func (m *RoomManager) ReapIdleRooms(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		m.mu.Lock()
+		m.reapIdleRoomsLocked(m.now())
+		m.mu.Unlock()
+	}
+}
+
+// reapIdleRoomsLocked stops and forgets every room other than DefaultRoom
+// that has been empty for the idle timeout, and starts the idle clock on rooms
+// that just emptied. Callers must hold m.mu.
+func (m *RoomManager) reapIdleRoomsLocked(now time.Time) {
+	if m.closed {
+		return
+	}
+	for name, existing := range m.rooms {
+		if name == DefaultRoom {
+			continue
+		}
+		if existing.hub.occupied() {
+			existing.idleSince = time.Time{}
+			continue
+		}
+		if existing.idleSince.IsZero() {
+			existing.idleSince = now
+			continue
+		}
+		if now.Sub(existing.idleSince) >= m.idleTimeout {
+			close(existing.stop)
+			delete(m.rooms, name)
+		}
+	}
+}
+
+// Close stops every room's simulation loop. Rooms cannot be created after
+// Close returns.
+func (m *RoomManager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	for _, existing := range m.rooms {
+		close(existing.stop)
+	}
+}
+
+// occupied reports whether the hub has players in its world, subscribers, or
+// reconnect sessions still holding a dropped player or their banked items.
+func (h *Hub) occupied() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.world.players) > 0 || len(h.subscribers) > 0 {
+		return true
+	}
+	for _, session := range h.reconnectSessions {
+		if session.Player != nil || session.Swept {
+			return true
+		}
+	}
+	return false
+}
+
+// validRoomName accepts short names made of ASCII letters, digits, '-' and
+// '_' so room names are safe to echo in URLs and logs.
+func validRoomName(name string) bool {
+	if name == "" || len(name) > maxRoomNameLength {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	worldpkg "mine-and-die/server/internal/world"
+	"mine-and-die/server/logging"
+)
+
+func TestRoomsRunIndependentSimulations(t *testing.T) {
+	manager := NewRoomManager(DefaultHubConfig(), 0, logging.NopPublisher{})
+	manager.run = func(*Hub, <-chan struct{}) {}
+	defer manager.Close()
+
+	alpha, err := manager.Room("alpha")
+	if err != nil {
+		t.Fatalf("failed to create room alpha: %v", err)
+	}
+	beta, err := manager.Room("beta")
+	if err != nil {
+		t.Fatalf("failed to create room beta: %v", err)
+	}
+	if alpha == beta {
+		t.Fatalf("expected rooms to get distinct hubs")
+	}
+	if again, err := manager.Room("alpha"); err != nil || again != alpha {
+		t.Fatalf("expected joining alpha again to attach to the same hub, got %p err=%v", again, err)
+	}
+
+	alphaJoin := alpha.Join()
+	alphaSecond := alpha.Join()
+	betaJoin := beta.Join()
+	if len(alpha.world.players) != 2 || len(beta.world.players) != 1 {
+		t.Fatalf("expected joins to stay in their room, got alpha=%d beta=%d", len(alpha.world.players), len(beta.world.players))
+	}
+
+	betaStart := *beta.world.players[betaJoin.ID]
+	alphaStart := *alpha.world.players[alphaJoin.ID]
+	if _, ok, reason := alpha.UpdateIntent(alphaJoin.ID, 1, 0, string(FacingRight)); !ok {
+		t.Fatalf("expected intent to be accepted in alpha, got %q", reason)
+	}
+	if _, ok, _ := beta.UpdateIntent(alphaSecond.ID, 1, 0, string(FacingRight)); ok {
+		t.Fatalf("expected beta to reject %s, which only joined alpha", alphaSecond.ID)
+	}
+
+	dt := 1.0 / float64(tickRate)
+	for i := 0; i < 5; i++ {
+		runAdvance(alpha, dt)
+	}
+	runAdvance(beta, dt)
+
+	alphaMoved := alpha.world.players[alphaJoin.ID]
+	if alphaMoved.X <= alphaStart.X {
+		t.Fatalf("expected alpha player to move right, x went from %.2f to %.2f", alphaStart.X, alphaMoved.X)
+	}
+	betaNow := beta.world.players[betaJoin.ID]
+	if betaNow.X != betaStart.X || betaNow.Y != betaStart.Y {
+		t.Fatalf("expected beta player to stay put, moved from (%.2f,%.2f) to (%.2f,%.2f)", betaStart.X, betaStart.Y, betaNow.X, betaNow.Y)
+	}
+	if alpha.Tick() != 5 || beta.Tick() != 1 {
+		t.Fatalf("expected independent tick counters, got alpha=%d beta=%d", alpha.Tick(), beta.Tick())
+	}
+
+	if got := manager.Rooms(); len(got) != 2 || got[0] != "alpha" || got[1] != "beta" {
+		t.Fatalf("expected rooms [alpha beta], got %v", got)
+	}
+	if _, err := manager.Room("no spaces"); !errors.Is(err, ErrInvalidRoomName) {
+		t.Fatalf("expected invalid room name error, got %v", err)
+	}
+}
+
+func TestRoomManagerStartsAndStopsRoomLoops(t *testing.T) {
+	manager := NewRoomManager(DefaultHubConfig(), 1, logging.NopPublisher{})
+	hub, err := manager.Room("")
+	if err != nil {
+		t.Fatalf("failed to create default room: %v", err)
+	}
+	if _, ok := manager.Lookup(DefaultRoom); !ok {
+		t.Fatalf("expected empty room name to select %q", DefaultRoom)
+	}
+	if _, err := manager.Room("overflow"); !errors.Is(err, ErrRoomLimit) {
+		t.Fatalf("expected room limit error, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.Tick() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected room simulation loop to advance ticks")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	manager.Close()
+	if _, err := manager.Room("later"); !errors.Is(err, ErrRoomsClosed) {
+		t.Fatalf("expected closed manager to refuse new rooms, got %v", err)
+	}
+}
+
+func TestRoomPresetConfiguresNewRoomWorld(t *testing.T) {
+	manager := NewRoomManager(DefaultHubConfig(), 0, logging.NopPublisher{})
+	manager.run = func(*Hub, <-chan struct{}) {}
+	defer manager.Close()
+
+	arena, err := manager.RoomWithPreset("match-1", RoomPresetArena)
+	if err != nil {
+		t.Fatalf("failed to create arena room: %v", err)
+	}
+	want := builtinRoomPresets()[RoomPresetArena].Normalized()
+	got := arena.CurrentConfig()
+	if got.Seed != want.Seed || got.Width != want.Width || got.Height != want.Height {
+		t.Fatalf("expected arena world %s %gx%g, got %s %gx%g", want.Seed, want.Width, want.Height, got.Seed, got.Width, got.Height)
+	}
+	if !got.Obstacles || got.ObstaclesCount != want.ObstaclesCount || got.NPCs || got.Lava {
+		t.Fatalf("expected arena obstacle/NPC toggles from the preset, got %+v", got)
+	}
+	reference := newHub()
+	reference.ResetWorld(want)
+	if len(arena.world.obstacles) == 0 || !reflect.DeepEqual(arena.world.obstacles, reference.world.obstacles) {
+		t.Fatalf("expected arena obstacles generated from the preset, got %d want %d", len(arena.world.obstacles), len(reference.world.obstacles))
+	}
+	if len(arena.world.npcs) != 0 {
+		t.Fatalf("expected arena to spawn no NPCs, got %d", len(arena.world.npcs))
+	}
+
+	plain, err := manager.Room("lobby")
+	if err != nil {
+		t.Fatalf("failed to create plain room: %v", err)
+	}
+	if cfg := plain.CurrentConfig(); cfg.Obstacles || cfg.Seed != worldpkg.DefaultSeed {
+		t.Fatalf("expected a room without a preset to use the base world, got %+v", cfg)
+	}
+
+	if again, err := manager.RoomWithPreset("match-1", ""); err != nil || again != arena {
+		t.Fatalf("expected to attach to match-1 without naming its preset, got %p err=%v", again, err)
+	}
+	if _, err := manager.RoomWithPreset("match-1", RoomPresetDungeon); !errors.Is(err, ErrRoomPresetConflict) {
+		t.Fatalf("expected preset conflict for match-1, got %v", err)
+	}
+	if _, err := manager.RoomWithPreset("match-2", "racing"); !errors.Is(err, ErrUnknownRoomPreset) {
+		t.Fatalf("expected unknown preset error, got %v", err)
+	}
+
+	oversized := worldpkg.DefaultConfig()
+	oversized.Width = worldpkg.MaxDimension * 2
+	if err := manager.RegisterPreset("huge", oversized); !errors.Is(err, worldpkg.ErrWorldSizeOutOfRange) {
+		t.Fatalf("expected oversized preset to be rejected, got %v", err)
+	}
+}
+
+func TestRoomManagerReapsIdleRooms(t *testing.T) {
+	manager := NewRoomManager(DefaultHubConfig(), 2, logging.NopPublisher{})
+	manager.run = func(*Hub, <-chan struct{}) {}
+	defer manager.Close()
+	now := time.Unix(0, 0)
+	manager.now = func() time.Time { return now }
+
+	if _, err := manager.Room(""); err != nil {
+		t.Fatalf("failed to create default room: %v", err)
+	}
+	idle, err := manager.Room("idle")
+	if err != nil {
+		t.Fatalf("failed to create idle room: %v", err)
+	}
+	join := idle.Join()
+	if _, err := manager.Room("blocked"); !errors.Is(err, ErrRoomLimit) {
+		t.Fatalf("expected the occupied room to hold the cap, got %v", err)
+	}
+
+	idle.Disconnect(join.ID)
+	manager.mu.Lock()
+	manager.reapIdleRoomsLocked(now)
+	manager.mu.Unlock()
+	if _, ok := manager.Lookup("idle"); !ok {
+		t.Fatalf("expected a room that just emptied to stay open for the idle timeout")
+	}
+
+	now = now.Add(DefaultRoomIdleTimeout)
+	if _, err := manager.Room("fresh"); err != nil {
+		t.Fatalf("expected reaping the idle room to free a slot, got %v", err)
+	}
+	if _, ok := manager.Lookup("idle"); ok {
+		t.Fatalf("expected the idle room to be reaped")
+	}
+	if _, ok := manager.Lookup(DefaultRoom); !ok {
+		t.Fatalf("expected the default room to survive reaping")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	journal "mine-and-die/server/internal/journal"
 	"mine-and-die/server/internal/sim"
 	simpatches "mine-and-die/server/internal/sim/patches/typed"
+	"mine-and-die/server/internal/simutil"
 )
 
 var (
@@ -117,6 +118,7 @@ func (a *legacyEngineAdapter) Snapshot() sim.Snapshot {
 		Players:        simPlayers,
 		NPCs:           simNPCsFromLegacy(npcs),
 		GroundItems:    itemspkg.CloneGroundItems(groundItems),
+		Corpses:        simCorpsesFromLegacy(a.world.CorpsesSnapshot()),
 		EffectEvents:   internaleffects.SimEffectTriggersFromLegacy(triggers),
 		Obstacles:      simObstaclesFromLegacy(obstacles),
 		AliveEffectIDs: aliveEffectIDs,
@@ -239,7 +241,7 @@ func legacyCommandsFromSim(cmds []sim.Command) []Command {
 			}
 		}
 		if cmd.Action != nil {
-			converted[i].Action = &ActionCommand{Name: cmd.Action.Name}
+			converted[i].Action = &ActionCommand{Name: cmd.Action.Name, AimX: cmd.Action.AimX, AimY: cmd.Action.AimY, TargetID: cmd.Action.TargetID, Emote: cmd.Action.Emote}
 		}
 		if cmd.Heartbeat != nil {
 			converted[i].Heartbeat = &HeartbeatCommand{
@@ -278,7 +280,7 @@ func simCommandsFromLegacy(cmds []Command) []sim.Command {
 			}
 		}
 		if cmd.Action != nil {
-			converted[i].Action = &sim.ActionCommand{Name: cmd.Action.Name}
+			converted[i].Action = &sim.ActionCommand{Name: cmd.Action.Name, AimX: cmd.Action.AimX, AimY: cmd.Action.AimY, TargetID: cmd.Action.TargetID, Emote: cmd.Action.Emote}
 		}
 		if cmd.Heartbeat != nil {
 			converted[i].Heartbeat = &sim.HeartbeatCommand{
@@ -303,13 +305,13 @@ func simPlayersFromLegacy(players []Player) []sim.Player {
 	}
 	converted := make([]sim.Player, len(players))
 	for i, player := range players {
-		converted[i] = sim.Player{Actor: simActorFromLegacy(player.Actor)}
+		converted[i] = sim.Player{Actor: simActorFromLegacy(player.Actor), Name: player.Name}
 	}
 	return converted
 }
 
 func legacyPlayerFromSim(player sim.Player) Player {
-	return Player{Actor: legacyActorFromSim(player.Actor)}
+	return Player{Actor: legacyActorFromSim(player.Actor), Name: player.Name}
 }
 
 func legacyPlayersFromSim(players []sim.Player) []Player {
@@ -367,6 +369,29 @@ func legacyActorsFromSimSnapshot(snapshot sim.Snapshot) ([]Player, []NPC) {
 	return players, npcs
 }
 
+// simCorpseFromLegacy converts a corpse into the shape sent to clients.
+func simCorpseFromLegacy(corpse corpseState) sim.Corpse {
+	return sim.Corpse{
+		ID:        corpse.ID,
+		NPCType:   string(corpse.NPCType),
+		X:         corpse.X,
+		Y:         corpse.Y,
+		Inventory: sim.Inventory{Slots: itemspkg.SimInventorySlotsFromAny(corpse.Inventory.Clone().Slots)},
+		OwnerID:   corpse.OwnerID,
+	}
+}
+
+func simCorpsesFromLegacy(corpses []corpseState) []sim.Corpse {
+	if len(corpses) == 0 {
+		return nil
+	}
+	converted := make([]sim.Corpse, len(corpses))
+	for i, corpse := range corpses {
+		converted[i] = simCorpseFromLegacy(corpse)
+	}
+	return converted
+}
+
 func simPatchesFromLegacy(patches []Patch) []sim.Patch {
 	if len(patches) == 0 {
 		return nil
@@ -388,6 +413,8 @@ func simKeyframeFromLegacy(frame keyframe) sim.Keyframe {
 		legacyNPCs        []NPC
 		legacyObstacles   []Obstacle
 		legacyGroundItems []itemspkg.GroundItem
+		corpses           []sim.Corpse
+		scheduledTasks    []sim.ScheduledTask
 		legacyConfig      worldConfig
 	)
 
@@ -403,32 +430,66 @@ func simKeyframeFromLegacy(frame keyframe) sim.Keyframe {
 	if typed, ok := frame.GroundItems.([]itemspkg.GroundItem); ok {
 		legacyGroundItems = typed
 	}
+	if typed, ok := frame.Corpses.([]sim.Corpse); ok {
+		corpses = typed
+	}
+	if typed, ok := frame.ScheduledTasks.([]sim.ScheduledTask); ok {
+		scheduledTasks = typed
+	}
+	var rngState *sim.RNGState
+	if typed, ok := frame.RNG.(*sim.RNGState); ok && typed != nil {
+		state := *typed
+		rngState = &state
+	}
 	if typed, ok := frame.Config.(worldConfig); ok {
 		legacyConfig = typed
 	}
 
 	return sim.Keyframe{
-		Tick:        frame.Tick,
-		Sequence:    frame.Sequence,
-		Players:     simPlayersFromLegacy(legacyPlayers),
-		NPCs:        simNPCsFromLegacy(legacyNPCs),
-		Obstacles:   simObstaclesFromLegacy(legacyObstacles),
-		GroundItems: itemspkg.CloneGroundItems(legacyGroundItems),
-		Config:      simWorldConfigFromLegacy(legacyConfig),
-		RecordedAt:  frame.RecordedAt,
+		Tick:           frame.Tick,
+		Sequence:       frame.Sequence,
+		Players:        simPlayersFromLegacy(legacyPlayers),
+		NPCs:           simNPCsFromLegacy(legacyNPCs),
+		Obstacles:      simObstaclesFromLegacy(legacyObstacles),
+		GroundItems:    itemspkg.CloneGroundItems(legacyGroundItems),
+		Corpses:        simutil.CloneCorpses(corpses),
+		ScheduledTasks: simutil.CloneScheduledTasks(scheduledTasks),
+		RNG:            rngState,
+		ActiveEffects:  journal.CloneEffectSpawnEvents(frame.ActiveEffects),
+		Config:         simWorldConfigFromLegacy(legacyConfig),
+		RecordedAt:     frame.RecordedAt,
 	}
 }
 
 func legacyKeyframeFromSim(frame sim.Keyframe) keyframe {
+	// Leave the legacy field nil when nothing is pending so empty keyframes
+	// round-trip unchanged.
+	var scheduledTasks any
+	if len(frame.ScheduledTasks) > 0 {
+		scheduledTasks = simutil.CloneScheduledTasks(frame.ScheduledTasks)
+	}
+	var rngState any
+	if frame.RNG != nil {
+		state := *frame.RNG
+		rngState = &state
+	}
+	var corpses any
+	if len(frame.Corpses) > 0 {
+		corpses = simutil.CloneCorpses(frame.Corpses)
+	}
 	return keyframe{
-		Tick:        frame.Tick,
-		Sequence:    frame.Sequence,
-		Players:     legacyPlayersFromSim(frame.Players),
-		NPCs:        legacyNPCsFromSim(frame.NPCs),
-		Obstacles:   legacyObstaclesFromSim(frame.Obstacles),
-		GroundItems: itemspkg.CloneGroundItems(frame.GroundItems),
-		Config:      legacyWorldConfigFromSim(frame.Config),
-		RecordedAt:  frame.RecordedAt,
+		Tick:           frame.Tick,
+		Sequence:       frame.Sequence,
+		Players:        legacyPlayersFromSim(frame.Players),
+		NPCs:           legacyNPCsFromSim(frame.NPCs),
+		Obstacles:      legacyObstaclesFromSim(frame.Obstacles),
+		GroundItems:    itemspkg.CloneGroundItems(frame.GroundItems),
+		Corpses:        corpses,
+		ScheduledTasks: scheduledTasks,
+		RNG:            rngState,
+		ActiveEffects:  journal.CloneEffectSpawnEvents(frame.ActiveEffects),
+		Config:         legacyWorldConfigFromSim(frame.Config),
+		RecordedAt:     frame.RecordedAt,
 	}
 }
 
@@ -514,12 +575,73 @@ func simWorldConfigFromLegacy(cfg worldConfig) sim.WorldConfig {
 		NPCCount:       cfg.NPCCount,
 		Lava:           cfg.Lava,
 		LavaCount:      cfg.LavaCount,
+		Stash:          cfg.Stash,
 		Seed:           cfg.Seed,
 		Width:          cfg.Width,
 		Height:         cfg.Height,
+		Wrap:           cfg.Wrap,
+		PatrolRoutes:   simPatrolRoutesFromLegacy(cfg.PatrolRoutes),
+		Waves:          simWaveConfigFromLegacy(cfg.Waves),
+		Difficulty:     cfg.Difficulty,
+		LootBags:       cfg.LootBags,
+		BossCount:      cfg.BossCount,
+	}
+}
+
+func simWaveConfigFromLegacy(waves *WaveConfig) *sim.WaveConfig {
+	if waves == nil {
+		return nil
+	}
+	return &sim.WaveConfig{
+		DelaySeconds:   waves.DelaySeconds,
+		GoblinsPerWave: waves.GoblinsPerWave,
+		RatsPerWave:    waves.RatsPerWave,
+		MaxWaves:       waves.MaxWaves,
 	}
 }
 
+func legacyWaveConfigFromSim(waves *sim.WaveConfig) *WaveConfig {
+	if waves == nil {
+		return nil
+	}
+	return &WaveConfig{
+		DelaySeconds:   waves.DelaySeconds,
+		GoblinsPerWave: waves.GoblinsPerWave,
+		RatsPerWave:    waves.RatsPerWave,
+		MaxWaves:       waves.MaxWaves,
+	}
+}
+
+func simPatrolRoutesFromLegacy(routes []PatrolRoute) []sim.PatrolRoute {
+	if len(routes) == 0 {
+		return nil
+	}
+	converted := make([]sim.PatrolRoute, len(routes))
+	for i, route := range routes {
+		points := make([]sim.PatrolPoint, len(route.Waypoints))
+		for j, point := range route.Waypoints {
+			points[j] = sim.PatrolPoint{X: point.X, Y: point.Y}
+		}
+		converted[i] = sim.PatrolRoute{Waypoints: points}
+	}
+	return converted
+}
+
+func legacyPatrolRoutesFromSim(routes []sim.PatrolRoute) []PatrolRoute {
+	if len(routes) == 0 {
+		return nil
+	}
+	converted := make([]PatrolRoute, len(routes))
+	for i, route := range routes {
+		points := make([]PatrolPoint, len(route.Waypoints))
+		for j, point := range route.Waypoints {
+			points[j] = PatrolPoint{X: point.X, Y: point.Y}
+		}
+		converted[i] = PatrolRoute{Waypoints: points}
+	}
+	return converted
+}
+
 func legacyWorldConfigFromSim(cfg sim.WorldConfig) worldConfig {
 	return worldConfig{
 		Obstacles:      cfg.Obstacles,
@@ -532,9 +654,16 @@ func legacyWorldConfigFromSim(cfg sim.WorldConfig) worldConfig {
 		NPCCount:       cfg.NPCCount,
 		Lava:           cfg.Lava,
 		LavaCount:      cfg.LavaCount,
+		Stash:          cfg.Stash,
 		Seed:           cfg.Seed,
 		Width:          cfg.Width,
 		Height:         cfg.Height,
+		Wrap:           cfg.Wrap,
+		PatrolRoutes:   legacyPatrolRoutesFromSim(cfg.PatrolRoutes),
+		Waves:          legacyWaveConfigFromSim(cfg.Waves),
+		Difficulty:     cfg.Difficulty,
+		LootBags:       cfg.LootBags,
+		BossCount:      cfg.BossCount,
 	}
 }
 
@@ -568,6 +697,13 @@ func convertPatchPayloadToSim(payload any) any {
 			return nil
 		}
 		return sim.HealthPayload{Health: value.Health, MaxHealth: value.MaxHealth}
+	case AbsorbPayload:
+		return sim.AbsorbPayload{Absorb: value.Absorb}
+	case *AbsorbPayload:
+		if value == nil {
+			return nil
+		}
+		return sim.AbsorbPayload{Absorb: value.Absorb}
 	case InventoryPayload:
 		return sim.InventoryPayload{Slots: itemspkg.SimInventorySlotsFromAny(value.Slots)}
 	case *InventoryPayload:
@@ -672,6 +808,8 @@ func convertPatchPayloadFromSim(payload any) any {
 		return PlayerIntentPayload{DX: value.DX, DY: value.DY}
 	case sim.HealthPayload:
 		return HealthPayload{Health: value.Health, MaxHealth: value.MaxHealth}
+	case sim.AbsorbPayload:
+		return AbsorbPayload{Absorb: value.Absorb}
 	case sim.InventoryPayload:
 		slots := itemspkg.CloneInventorySlots(value.Slots)
 		return itemspkg.SimInventoryPayloadFromSlots[sim.InventorySlot, InventoryPayload](slots)
@@ -695,6 +833,7 @@ func simActorFromLegacy(actor Actor) sim.Actor {
 		Facing:    toSimFacing(actor.Facing),
 		Health:    actor.Health,
 		MaxHealth: actor.MaxHealth,
+		Absorb:    actor.Absorb,
 		Inventory: itemspkg.AssembleInventory(actor.Inventory.Slots, func(slot InventorySlot) sim.InventorySlot {
 			return sim.InventorySlot{
 				Slot: slot.Slot,
@@ -715,19 +854,28 @@ func simActorFromLegacy(actor Actor) sim.Actor {
 				},
 			}
 		}, itemspkg.EquipmentValueFromSlots[sim.EquippedItem, sim.Equipment]),
+		CollisionLayer: sim.CollisionLayer(actor.CollisionLayer),
+		CollisionMask:  sim.CollisionLayer(actor.CollisionMask),
+		InCombat:       actor.InCombat,
+		LastDamagedBy:  actor.LastDamagedBy,
 	}
 }
 
 func legacyActorFromSim(actor sim.Actor) Actor {
 	return Actor{
-		ID:        actor.ID,
-		X:         actor.X,
-		Y:         actor.Y,
-		Facing:    legacyFacingFromSim(actor.Facing),
-		Health:    actor.Health,
-		MaxHealth: actor.MaxHealth,
-		Inventory: itemspkg.InventoryFromSim(actor.Inventory, inventorySlotFromSim, itemspkg.InventoryValueFromSlots[InventorySlot, Inventory]),
-		Equipment: itemspkg.EquipmentFromSim(actor.Equipment, equippedItemFromSim, itemspkg.EquipmentValueFromSlots[EquippedItem, Equipment]),
+		ID:             actor.ID,
+		X:              actor.X,
+		Y:              actor.Y,
+		Facing:         legacyFacingFromSim(actor.Facing),
+		Health:         actor.Health,
+		MaxHealth:      actor.MaxHealth,
+		Absorb:         actor.Absorb,
+		Inventory:      itemspkg.InventoryFromSim(actor.Inventory, inventorySlotFromSim, itemspkg.InventoryValueFromSlots[InventorySlot, Inventory]),
+		Equipment:      itemspkg.EquipmentFromSim(actor.Equipment, equippedItemFromSim, itemspkg.EquipmentValueFromSlots[EquippedItem, Equipment]),
+		CollisionLayer: CollisionLayer(actor.CollisionLayer),
+		CollisionMask:  CollisionLayer(actor.CollisionMask),
+		InCombat:       actor.InCombat,
+		LastDamagedBy:  actor.LastDamagedBy,
 	}
 }
 
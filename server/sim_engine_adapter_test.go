@@ -1436,7 +1436,7 @@ func TestLegacyAdapterKeyframeBySequenceCopiesConfig(t *testing.T) {
 		t.Fatalf("expected adapter to return keyframe %d", frame.Sequence)
 	}
 
-	if fetched.Config != expected {
+	if !reflect.DeepEqual(fetched.Config, expected) {
 		t.Fatalf("unexpected adapter keyframe config: got %#v want %#v", fetched.Config, expected)
 	}
 
@@ -1448,7 +1448,7 @@ func TestLegacyAdapterKeyframeBySequenceCopiesConfig(t *testing.T) {
 		t.Fatalf("expected adapter to return keyframe %d on second fetch", frame.Sequence)
 	}
 
-	if again.Config != expected {
+	if !reflect.DeepEqual(again.Config, expected) {
 		t.Fatalf("expected adapter keyframe config to remain unchanged, got %#v want %#v", again.Config, expected)
 	}
 
@@ -1815,6 +1815,10 @@ func TestSimEffectEventBatchConversionRoundTrip(t *testing.T) {
 						VelocityX:      3,
 						VelocityY:      4,
 						RangeRemaining: 9,
+						TargetX:        11,
+						TargetY:        12,
+						ArcDistance:    15,
+						Altitude:       3,
 					},
 					AttachedActorID: "player-2",
 					Follow:          effectcontract.FollowTarget,
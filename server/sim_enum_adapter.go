@@ -72,6 +72,10 @@ func toSimNPCType(value NPCType) sim.NPCType {
 		return sim.NPCTypeGoblin
 	case NPCTypeRat:
 		return sim.NPCTypeRat
+	case NPCTypeBoss:
+		return sim.NPCTypeBoss
+	case NPCTypeFamiliar:
+		return sim.NPCTypeFamiliar
 	default:
 		return ""
 	}
@@ -83,6 +87,10 @@ func legacyNPCTypeFromSim(value sim.NPCType) NPCType {
 		return NPCTypeGoblin
 	case sim.NPCTypeRat:
 		return NPCTypeRat
+	case sim.NPCTypeBoss:
+		return NPCTypeBoss
+	case sim.NPCTypeFamiliar:
+		return NPCTypeFamiliar
 	default:
 		return ""
 	}
@@ -144,6 +152,8 @@ func toSimPatchKind(value PatchKind) sim.PatchKind {
 		return sim.PatchPlayerInventory
 	case PatchPlayerEquipment:
 		return sim.PatchPlayerEquipment
+	case PatchPlayerAbsorb:
+		return sim.PatchPlayerAbsorb
 	case PatchPlayerRemoved:
 		return sim.PatchPlayerRemoved
 	case PatchNPCPos:
@@ -164,6 +174,12 @@ func toSimPatchKind(value PatchKind) sim.PatchKind {
 		return sim.PatchGroundItemPos
 	case PatchGroundItemQty:
 		return sim.PatchGroundItemQty
+	case PatchCorpseAdded:
+		return sim.PatchCorpseAdded
+	case PatchCorpseContents:
+		return sim.PatchCorpseContents
+	case PatchCorpseRemoved:
+		return sim.PatchCorpseRemoved
 	default:
 		return ""
 	}
@@ -183,6 +199,8 @@ func legacyPatchKindFromSim(value sim.PatchKind) PatchKind {
 		return PatchPlayerInventory
 	case sim.PatchPlayerEquipment:
 		return PatchPlayerEquipment
+	case sim.PatchPlayerAbsorb:
+		return PatchPlayerAbsorb
 	case sim.PatchPlayerRemoved:
 		return PatchPlayerRemoved
 	case sim.PatchNPCPos:
@@ -203,6 +221,12 @@ func legacyPatchKindFromSim(value sim.PatchKind) PatchKind {
 		return PatchGroundItemPos
 	case sim.PatchGroundItemQty:
 		return PatchGroundItemQty
+	case sim.PatchCorpseAdded:
+		return PatchCorpseAdded
+	case sim.PatchCorpseContents:
+		return PatchCorpseContents
+	case sim.PatchCorpseRemoved:
+		return PatchCorpseRemoved
 	default:
 		return ""
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"time"
 
 	effectcontract "mine-and-die/server/effects/contract"
@@ -17,7 +18,6 @@ import (
 	worldpkg "mine-and-die/server/internal/world"
 	statuspkg "mine-and-die/server/internal/world/status"
 	"mine-and-die/server/logging"
-	loggingeconomy "mine-and-die/server/logging/economy"
 	logginglifecycle "mine-and-die/server/logging/lifecycle"
 	stats "mine-and-die/server/stats"
 )
@@ -55,6 +55,14 @@ type MoveCommand struct {
 // ActionCommand identifies an ability or interaction trigger.
 type ActionCommand struct {
 	Name string
+	// AimX/AimY is an optional aim vector for projectile actions. A zero
+	// vector keeps the caster's cardinal facing.
+	AimX float64
+	AimY float64
+	// TargetID names the actor a targeted action applies to.
+	TargetID string
+	// Emote names the animation an emote action plays.
+	Emote string
 }
 
 // PathCommand identifies a navigation target for A* pathfinding.
@@ -93,6 +101,7 @@ type World struct {
 	nextEffectID            uint64
 	nextNPCID               uint64
 	nextGroundItemID        uint64
+	nextCorpseID            uint64
 	aiLibrary               *ai.Library
 	config                  worldConfig
 	rng                     *rand.Rand
@@ -107,8 +116,51 @@ type World struct {
 
 	groundItems       map[string]*itemspkg.GroundItemState
 	groundItemsByTile map[itemspkg.GroundTileKey]map[string]*itemspkg.GroundItemState
+	stashes           map[string]*Inventory
+	corpses           map[string]*corpseState
+	lootTables        map[NPCType]LootTable
+	staleInventory    StaleInventoryPolicy
+	staleStashKeys    map[string]string
+	tickRate          int
+	effectCatalogPath string
+	timeScale         float64
+	timeOffset        time.Duration
+	meleeArc          float64
+	meleeCombo        MeleeComboConfig
+	meleeCombos       map[string]*combat.MeleeComboState
+	damageLedgers     map[string]*damageLedger
 	journal           Journal
 	internalWorld     *worldpkg.World
+
+	// lagCompensationTicks bounds how far melee hit tests rewind targets
+	// toward the swing's origin tick; meleeRewinds holds the origin ticks of
+	// swings staged this step, keyed by owner. Zero disables the rewind.
+	lagCompensationTicks uint64
+	meleeRewinds         map[string]uint64
+
+	// scheduledTasks holds deferred actions ordered by target tick; handlers
+	// are looked up by task kind when they fire.
+	scheduledTasks        []scheduledTask
+	scheduledTaskHandlers map[string]scheduledTaskHandler
+	nextScheduledTaskID   uint64
+
+	// disconnectAfter and disconnectOverrides set the heartbeat timeout used
+	// by the stale player sweep; zero falls back to the package default.
+	disconnectAfter     time.Duration
+	disconnectOverrides map[string]time.Duration
+
+	// idleKickAfter removes players that keep heartbeating but send no
+	// movement or action commands for this long; zero disables the kick.
+	idleKickAfter time.Duration
+
+	// aiDecisionBudget caps NPC state machine runs per tick and aiCursor names
+	// the NPC the next tick resumes from when the budget deferred some.
+	aiDecisionBudget int
+	aiCursor         string
+
+	// npcWave counts the NPC respawn waves spawned since the world was
+	// seeded.
+	npcWave int
 }
 
 func (w *World) LegacyWorldMarker() {}
@@ -173,11 +225,13 @@ func legacyConstructWorld(cfg worldConfig, publisher logging.Publisher, deps wor
 	}
 
 	constructorDeps := worldpkg.Deps{
-		Publisher:        effectivePublisher,
-		RNG:              deps.RNG,
-		JournalRetention: deps.JournalRetention,
-		JournalTelemetry: deps.JournalTelemetry,
-		OnConstructed:    deps.OnConstructed,
+		Publisher:         effectivePublisher,
+		RNG:               deps.RNG,
+		JournalRetention:  deps.JournalRetention,
+		JournalTelemetry:  deps.JournalTelemetry,
+		OnConstructed:     deps.OnConstructed,
+		TickRate:          deps.TickRate,
+		EffectCatalogPath: deps.EffectCatalogPath,
 	}
 
 	constructed, err := worldpkg.New(normalized, constructorDeps)
@@ -235,6 +289,8 @@ func legacyConstructWorld(cfg worldConfig, publisher logging.Publisher, deps wor
 		journal:             constructed.JournalState(),
 		nextEffectID:        constructed.NextEffectID(),
 		internalWorld:       constructed,
+		tickRate:            normalizeTickRate(deps.TickRate),
+		effectCatalogPath:   deps.EffectCatalogPath,
 	}
 	if w.config.Seed == "" {
 		w.config.Seed = normalized.Seed
@@ -247,6 +303,8 @@ func legacyConstructWorld(cfg worldConfig, publisher logging.Publisher, deps wor
 	}
 
 	w.statusEffectDefs = newStatusEffectDefinitions(w)
+	w.registerScheduledTask(scheduledTaskRecall, w.completeRecall)
+	w.registerScheduledTask(scheduledTaskNPCWave, w.spawnNPCWave)
 
 	if stateLookup := constructed.AbilityOwnerStateLookup(); stateLookup != nil {
 		w.abilityOwnerStateLookup = worldpkg.AbilityOwnerStateLookup[*actorState](stateLookup)
@@ -320,6 +378,15 @@ func legacyConstructWorld(cfg worldConfig, publisher logging.Publisher, deps wor
 			}
 			w.maybeSpawnBloodSplatter(eff, npc, now)
 		},
+		OnHit: func(effect any, target any, now time.Time) {
+			eff, _ := effect.(*effectState)
+			npc, _ := target.(*npcState)
+			if eff == nil || npc == nil {
+				return
+			}
+			w.alertNPCPack(eff, npc)
+			w.advanceNPCPhase(npc)
+		},
 		IsAlive: func(target any) bool {
 			npc, _ := target.(*npcState)
 			if npc == nil {
@@ -425,13 +492,14 @@ func (w *World) Snapshot(now time.Time) ([]Player, []NPC) {
 	return players, npcs
 }
 
-// flushEffectTriggersLocked drains the queued fire-and-forget triggers. Callers
+// flushEffectTriggersLocked drains the queued fire-and-forget triggers,
+// collapsing repeat hits of one effect on one target within the tick. Callers
 // must hold the world mutex.
 func (w *World) flushEffectTriggersLocked() []EffectTrigger {
 	if w == nil || w.internalWorld == nil {
 		return nil
 	}
-	drained := w.internalWorld.DrainEffectTriggers()
+	drained := internaleffects.DedupeTriggers(w.internalWorld.DrainEffectTriggers())
 	if len(drained) == 0 {
 		return nil
 	}
@@ -501,7 +569,12 @@ func (w *World) handleNPCDefeat(npc *npcState) {
 	if _, ok := w.npcs[npc.ID]; !ok {
 		return
 	}
-	w.dropAllInventory(&npc.ActorState, "death")
+	w.grantNPCLoot(npc)
+	if w.config.LootBags {
+		w.spawnCorpse(npc)
+	} else {
+		w.dropAllInventory(&npc.ActorState, "death")
+	}
 	delete(w.npcs, npc.ID)
 	w.purgeEntityPatches(npc.ID)
 }
@@ -516,6 +589,9 @@ func (w *World) pruneDefeatedNPCs() {
 			defeated = append(defeated, npc)
 		}
 	}
+	// Loot rolls share the world RNG, so same-tick defeats resolve in ID
+	// order rather than map order to keep drops reproducible per seed.
+	sort.Slice(defeated, func(i, j int) bool { return defeated[i].ID < defeated[j].ID })
 	for _, npc := range defeated {
 		w.handleNPCDefeat(npc)
 	}
@@ -524,12 +600,15 @@ func (w *World) pruneDefeatedNPCs() {
 // Step advances the simulation by a single tick applying all staged commands.
 func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command, emitEffectEvent func(effectcontract.EffectLifecycleEvent)) []string {
 	if dt <= 0 {
-		dt = 1.0 / float64(tickRate)
+		dt = 1.0 / float64(w.ticksPerSecond())
 	}
+	wallNow := now
+	now, dt = w.scaleStep(now, dt)
 
 	w.currentTick = tick
 
 	w.resolveStats(tick)
+	w.runScheduledTasks(tick, now)
 
 	aiCommands := w.runAI(tick, now)
 	if len(aiCommands) > 0 {
@@ -540,8 +619,9 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 	}
 
 	type stagedAction struct {
-		actorID string
-		command *ActionCommand
+		actorID    string
+		originTick uint64
+		command    *ActionCommand
 	}
 
 	stagedActions := make([]stagedAction, 0)
@@ -596,7 +676,14 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 			if cmd.Action == nil {
 				continue
 			}
-			stagedActions = append(stagedActions, stagedAction{actorID: cmd.ActorID, command: cmd.Action})
+			stagedActions = append(stagedActions, stagedAction{actorID: cmd.ActorID, originTick: cmd.OriginTick, command: cmd.Action})
+			if player, ok := w.players[cmd.ActorID]; ok {
+				if !cmd.IssuedAt.IsZero() {
+					player.LastInput = cmd.IssuedAt
+				} else {
+					player.LastInput = now
+				}
+			}
 		case CommandHeartbeat:
 			if cmd.Heartbeat == nil {
 				continue
@@ -641,14 +728,14 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 
 	actorsForCollisions := make([]*actorState, 0, len(w.players)+len(w.npcs))
 	proposedPlayerStates := make(map[string]*actorState, len(w.players))
-	width, height := w.dimensions()
+	bounds := w.bounds()
 	// Movement system.
 	for id, player := range w.players {
 		// Operate on a copy so player coordinates can be committed via
 		// SetPosition after all collision resolution completes.
 		scratch := player.ActorState
 		if player.IntentX != 0 || player.IntentY != 0 {
-			moveActorWithObstacles(&scratch, dt, w.obstacles, width, height)
+			moveActorInBounds(&scratch, dt, w.obstacles, bounds, w.effectiveMoveSpeed(&player.ActorState, now))
 		}
 		proposedPlayerStates[id] = &scratch
 		actorsForCollisions = append(actorsForCollisions, &scratch)
@@ -659,13 +746,13 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 		initialNPCPositions[id] = vec2{X: npc.X, Y: npc.Y}
 		scratch := npc.ActorState
 		if npc.IntentX != 0 || npc.IntentY != 0 {
-			moveActorWithObstacles(&scratch, dt, w.obstacles, width, height)
+			moveActorInBounds(&scratch, dt, w.obstacles, bounds, w.effectiveMoveSpeed(&npc.ActorState, now))
 		}
 		proposedNPCStates[id] = &scratch
 		actorsForCollisions = append(actorsForCollisions, &scratch)
 	}
 
-	resolveActorCollisions(actorsForCollisions, w.obstacles, width, height)
+	resolveActorCollisions(actorsForCollisions, w.obstacles, bounds)
 
 	proposedPositions := make(map[string]vec2, len(proposedPlayerStates))
 	for id, state := range proposedPlayerStates {
@@ -697,9 +784,12 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 
 			intent, ok := combat.StageMeleeIntent(combat.MeleeAbilityTriggerConfig{
 				AbilityGate:  w.meleeAbilityGate,
-				IntentConfig: meleeIntentConfig,
+				IntentConfig: w.meleeIntentConfig(),
+				Combo:        w.meleeCombo,
+				ComboState:   w.meleeComboState,
 			}, action.actorID, now)
 			if ok {
+				w.stageMeleeRewind(action.actorID, action.originTick)
 				w.effectManager.EnqueueIntent(intent)
 			}
 		case effectTypeFireball:
@@ -712,15 +802,56 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 			if !ok {
 				continue
 			}
+			combatTpl.SpawnOffset = w.projectileSpawnOffset(combatTpl)
 
 			intent, ok := combat.StageProjectileIntent(combat.ProjectileAbilityTriggerConfig{
 				AbilityGate:  w.projectileAbilityGate,
 				IntentConfig: projectileIntentConfig,
 				Template:     combatTpl,
+				AimX:         action.command.AimX,
+				AimY:         action.command.AimY,
 			}, action.actorID, now)
 			if ok {
 				w.effectManager.EnqueueIntent(intent)
 			}
+		case effectTypeFirebomb:
+			w.castProjectile(action.actorID, effectTypeFirebomb, action.command.AimX, action.command.AimY, "", now)
+		case effectTypeRicochet:
+			w.castProjectile(action.actorID, effectTypeRicochet, action.command.AimX, action.command.AimY, "", now)
+		case effectTypeSeeker:
+			w.castSeeker(action.actorID, action.command.TargetID, action.command.AimX, action.command.AimY, now)
+		case actionStealth:
+			w.castStealth(action.actorID, now)
+		case effectTypeDetect:
+			w.castDetect(action.actorID, now)
+		case effectTypeShield:
+			w.castShield(action.actorID, now)
+		case actionParry:
+			w.castParry(action.actorID, now)
+		case actionHaste:
+			w.castHaste(action.actorID, now)
+		case actionTaunt:
+			w.castTaunt(action.actorID, now)
+		case actionSummon:
+			w.castSummon(action.actorID, now)
+		case actionRecall:
+			w.castRecall(action.actorID, now)
+		case effectTypeHeal:
+			w.castTargetedHeal(action.actorID, action.command.TargetID, now)
+		case effectTypeHealBurst:
+			w.castHealBurst(action.actorID, now)
+		case effectTypeBeam:
+			w.castBeam(action.actorID, now)
+		case effectTypeGravityWell:
+			w.castGravityWell(action.actorID, now)
+		case effectTypeExplosion:
+			w.castExplosion(action.actorID, now)
+		case effectTypeFirePatch:
+			w.castFirePatch(action.actorID, now)
+		case actionCancel:
+			w.cancelActorEffects(action.actorID)
+		case actionEmote:
+			w.playEmote(action.actorID, action.command.Emote, now)
 		}
 	}
 
@@ -735,6 +866,11 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 	w.applyEnvironmentalStatusEffects(actorsForHazards, now)
 
 	w.advanceStatusEffects(now)
+	w.expireAbsorbs(now)
+	w.expireCombat()
+	w.regenerateHealth(dt)
+	w.decayMeleeCombos(now)
+	w.decayDamageLedgers()
 	if w.effectManager != nil {
 		dispatcher := w.recordEffectLifecycleEvent
 		if emitEffectEvent != nil {
@@ -748,14 +884,18 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 	w.advanceEffects(now, dt)
 	w.pruneEffects(now)
 	w.pruneDefeatedNPCs()
+	w.expireCorpses()
+	w.breakStealthOnDeath()
+	w.expireSummons()
+	w.queueNextNPCWave()
 
 	// Lifecycle system: remove stale players.
-	cutoff := now.Add(-disconnectAfter)
 	removedPlayers := make([]string, 0)
 	for id, player := range w.players {
 		if player.LastHeartbeat.IsZero() {
 			continue
 		}
+		cutoff := wallNow.Add(-w.disconnectTimeoutFor(id))
 		if player.LastHeartbeat.Before(cutoff) {
 			if w.publisher != nil {
 				logginglifecycle.PlayerDisconnected(
@@ -767,6 +907,23 @@ func (w *World) Step(tick uint64, now time.Time, dt float64, commands []Command,
 					map[string]any{"lastHeartbeat": player.LastHeartbeat},
 				)
 			}
+			w.releaseStaleInventory(player)
+			delete(w.players, id)
+			removedPlayers = append(removedPlayers, id)
+			continue
+		}
+		if w.isIdle(player, wallNow) {
+			if w.publisher != nil {
+				logginglifecycle.PlayerDisconnected(
+					context.Background(),
+					w.publisher,
+					w.currentTick,
+					logging.EntityRef{ID: id, Kind: logging.EntityKind("player")},
+					logginglifecycle.PlayerDisconnectedPayload{Reason: "idle"},
+					map[string]any{"lastInput": player.LastInput},
+				)
+			}
+			w.releaseStaleInventory(player)
 			delete(w.players, id)
 			removedPlayers = append(removedPlayers, id)
 		}
@@ -794,6 +951,7 @@ func (w *World) applyPlayerPositionMutations(initial map[string]vec2, proposed m
 	}
 
 	worldpkg.ApplyPlayerPositionMutations(initial, proposed, actors, func(id string, target worldpkg.Vec2) {
+		w.recordSessionDistance(id, target.X, target.Y)
 		w.SetPosition(id, target.X, target.Y)
 	})
 }
@@ -845,11 +1003,11 @@ func (w *World) npcSpawner() ai.WorldNPCSpawner {
 			}
 			return w.subsystemRNG(label)
 		},
-		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2, goldQty, potionQty int) {
+		SpawnGoblinFunc: func(x, y float64, waypoints []worldpkg.Vec2) {
 			if w == nil {
 				return
 			}
-			w.spawnGoblinAt(x, y, waypoints, goldQty, potionQty)
+			w.spawnGoblinAt(x, y, waypoints)
 		},
 		SpawnRatFunc: func(x, y float64) {
 			if w == nil {
@@ -857,39 +1015,20 @@ func (w *World) npcSpawner() ai.WorldNPCSpawner {
 			}
 			w.spawnRatAt(x, y)
 		},
+		SpawnBossFunc: func(x, y float64) {
+			if w == nil {
+				return
+			}
+			w.spawnBossAt(x, y)
+		},
 	}
 }
 
-func (w *World) spawnGoblinAt(x, y float64, waypoints []vec2, goldQty, potionQty int) {
+func (w *World) spawnGoblinAt(x, y float64, waypoints []vec2) {
 	w.nextNPCID++
 	id := fmt.Sprintf("npc-goblin-%d", w.nextNPCID)
-	inventory := NewInventory()
-	if goldQty > 0 {
-		if _, err := inventory.AddStack(ItemStack{Type: ItemTypeGold, Quantity: goldQty}); err != nil {
-			loggingeconomy.ItemGrantFailed(
-				context.Background(),
-				w.publisher,
-				w.currentTick,
-				logging.EntityRef{ID: id, Kind: logging.EntityKind("npc")},
-				loggingeconomy.ItemGrantFailedPayload{ItemType: string(ItemTypeGold), Quantity: goldQty, Reason: "seed_goblin"},
-				map[string]any{"error": err.Error()},
-			)
-		}
-	}
-	if potionQty > 0 {
-		if _, err := inventory.AddStack(ItemStack{Type: ItemTypeHealthPotion, Quantity: potionQty}); err != nil {
-			loggingeconomy.ItemGrantFailed(
-				context.Background(),
-				w.publisher,
-				w.currentTick,
-				logging.EntityRef{ID: id, Kind: logging.EntityKind("npc")},
-				loggingeconomy.ItemGrantFailedPayload{ItemType: string(ItemTypeHealthPotion), Quantity: potionQty, Reason: "seed_goblin"},
-				map[string]any{"error": err.Error()},
-			)
-		}
-	}
-
 	statsComp := stats.DefaultComponent(stats.ArchetypeGoblin)
+	w.applyNPCDifficulty(&statsComp)
 	maxHealth := statsComp.GetDerived(stats.DerivedMaxHealth)
 
 	goblin := &npcState{
@@ -901,7 +1040,7 @@ func (w *World) spawnGoblinAt(x, y float64, waypoints []vec2, goldQty, potionQty
 				Facing:    defaultFacing,
 				Health:    maxHealth,
 				MaxHealth: maxHealth,
-				Inventory: inventory,
+				Inventory: NewInventory(),
 				Equipment: NewEquipment(),
 			},
 		},
@@ -926,7 +1065,7 @@ func (w *World) initializeGoblinState(goblin *npcState) {
 		WaypointCount: len(goblin.Waypoints),
 	})
 
-	resolveObstaclePenetration(&goblin.ActorState, w.obstacles, w.width(), w.height())
+	resolveObstaclePenetration(&goblin.ActorState, w.obstacles, w.bounds())
 	goblin.Blackboard.LastPos = vec2{X: goblin.X, Y: goblin.Y}
 	w.npcs[goblin.ID] = goblin
 }
@@ -939,6 +1078,7 @@ func (w *World) spawnRatAt(x, y float64) {
 	w.nextNPCID++
 	id := fmt.Sprintf("npc-rat-%d", w.nextNPCID)
 	statsComp := stats.DefaultComponent(stats.ArchetypeRat)
+	w.applyNPCDifficulty(&statsComp)
 	maxHealth := statsComp.GetDerived(stats.DerivedMaxHealth)
 
 	rat := &npcState{
@@ -959,9 +1099,6 @@ func (w *World) spawnRatAt(x, y float64) {
 		ExperienceReward: 8,
 		Home:             vec2{X: x, Y: y},
 	}
-	if _, err := rat.Inventory.AddStack(ItemStack{Type: ItemTypeRatTail, Quantity: 1}); err != nil {
-		_ = rat.Inventory.RemoveAllOf(ItemTypeRatTail)
-	}
 	w.initializeRatState(rat)
 }
 
@@ -978,7 +1115,7 @@ func (w *World) initializeRatState(rat *npcState) {
 		WaypointCount: len(rat.Waypoints),
 	})
 
-	resolveObstaclePenetration(&rat.ActorState, w.obstacles, w.width(), w.height())
+	resolveObstaclePenetration(&rat.ActorState, w.obstacles, w.bounds())
 	rat.Blackboard.LastPos = vec2{X: rat.X, Y: rat.Y}
 	w.npcs[rat.ID] = rat
 }
@@ -0,0 +1,195 @@
+package server
+
+import "time"
+
+// StaleInventoryPolicy controls what happens to a player's carried items when
+// the heartbeat sweep removes them.
+type StaleInventoryPolicy string
+
+const (
+	// StaleInventoryDiscard removes the player along with everything they carry.
+	StaleInventoryDiscard StaleInventoryPolicy = ""
+	// StaleInventoryDrop scatters inventory and equipment on the ground like a death.
+	StaleInventoryDrop StaleInventoryPolicy = "drop"
+	// StaleInventoryBank moves inventory and equipment into the player's stash.
+	StaleInventoryBank StaleInventoryPolicy = "bank"
+)
+
+// SetStaleInventoryPolicy selects how stale players' items are preserved.
+func (w *World) SetStaleInventoryPolicy(policy StaleInventoryPolicy) {
+	if w == nil {
+		return
+	}
+	w.staleInventory = policy
+}
+
+// SetDisconnectTimeout sets how long a player may go without a heartbeat
+// before the sweep removes them. Zero or negative values restore the default.
+func (w *World) SetDisconnectTimeout(timeout time.Duration) {
+	if w == nil {
+		return
+	}
+	if timeout < 0 {
+		timeout = 0
+	}
+	w.disconnectAfter = timeout
+}
+
+// SetIdleKickTimeout sets how long a connected player may go without movement
+// or action commands before the sweep removes them. Heartbeats do not count as
+// activity. Zero or negative values disable the idle kick.
+func (w *World) SetIdleKickTimeout(timeout time.Duration) {
+	if w == nil {
+		return
+	}
+	if timeout < 0 {
+		timeout = 0
+	}
+	w.idleKickAfter = timeout
+}
+
+// isIdle reports whether the player's last movement or action command, or
+// their join when they never sent one, is older than the idle kick timeout.
+func (w *World) isIdle(player *playerState, now time.Time) bool {
+	if w.idleKickAfter <= 0 || player == nil || player.LastInput.IsZero() {
+		return false
+	}
+	return player.LastInput.Before(now.Add(-w.idleKickAfter))
+}
+
+// SetPlayerDisconnectTimeout overrides the heartbeat timeout for a single
+// player. Zero or negative values restore the hub-wide timeout.
+func (h *Hub) SetPlayerDisconnectTimeout(playerID string, timeout time.Duration) {
+	if h == nil || playerID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.world.SetPlayerDisconnectTimeout(playerID, timeout)
+}
+
+// SetPlayerDisconnectTimeout overrides the heartbeat timeout for one player,
+// for example to give admins or mobile clients more slack. Zero or negative
+// values clear the override. Overrides are kept by player ID so they survive a
+// reconnect.
+func (w *World) SetPlayerDisconnectTimeout(playerID string, timeout time.Duration) {
+	if w == nil || playerID == "" {
+		return
+	}
+	if timeout <= 0 {
+		delete(w.disconnectOverrides, playerID)
+		return
+	}
+	if w.disconnectOverrides == nil {
+		w.disconnectOverrides = make(map[string]time.Duration)
+	}
+	w.disconnectOverrides[playerID] = timeout
+}
+
+// disconnectTimeoutFor resolves the heartbeat timeout that applies to the
+// player: their override, then the world setting, then disconnectAfter.
+func (w *World) disconnectTimeoutFor(playerID string) time.Duration {
+	if timeout, ok := w.disconnectOverrides[playerID]; ok {
+		return timeout
+	}
+	if w.disconnectAfter > 0 {
+		return w.disconnectAfter
+	}
+	return disconnectAfter
+}
+
+// releaseStaleInventory applies the stale inventory policy before the player
+// is removed from the world.
+func (w *World) releaseStaleInventory(player *playerState) {
+	if w == nil || player == nil {
+		return
+	}
+	switch w.staleInventory {
+	case StaleInventoryDrop:
+		w.dropAllInventory(&player.ActorState, "disconnect")
+	case StaleInventoryBank:
+		w.bankStaleInventory(player)
+	}
+}
+
+// bankStaleInventory moves every carried and equipped stack into the stash
+// registered for the player with setStaleStashKey, or the player's own stash
+// when none is. Stacks the stash refuses fall back to a ground drop so nothing
+// is lost.
+func (w *World) bankStaleInventory(player *playerState) {
+	key := player.ID
+	if registered, ok := w.staleStashKeys[player.ID]; ok {
+		key = registered
+		delete(w.staleStashKeys, player.ID)
+	}
+	stash := w.stashFor(key)
+	stacks := player.Inventory.DrainAll()
+	for _, equipped := range player.Equipment.DrainAll() {
+		stacks = append(stacks, equipped.Item)
+	}
+
+	var leftover []ItemStack
+	for _, stack := range stacks {
+		if _, err := stash.AddStack(stack); err != nil {
+			leftover = append(leftover, stack)
+		}
+	}
+	if len(leftover) == 0 {
+		return
+	}
+	for _, stack := range leftover {
+		_, _ = player.Inventory.AddStack(stack)
+	}
+	w.dropAllInventory(&player.ActorState, "disconnect")
+}
+
+// setStaleStashKey makes the bank policy stash the player's items under key
+// instead of their player ID, so whoever holds key can claim them back.
+func (w *World) setStaleStashKey(playerID, key string) {
+	if w == nil || playerID == "" || key == "" {
+		return
+	}
+	if w.staleStashKeys == nil {
+		w.staleStashKeys = make(map[string]string)
+	}
+	w.staleStashKeys[playerID] = key
+}
+
+// claimStash moves everything stashed under key into the player's carried
+// inventory and forgets the stash. Stacks that do not fit go to the player's
+// own stash.
+func (w *World) claimStash(key string, player *playerState) {
+	if w == nil || player == nil {
+		return
+	}
+	stash, ok := w.stashes[key]
+	delete(w.stashes, key)
+	if !ok || stash == nil {
+		return
+	}
+	for _, stack := range stash.DrainAll() {
+		if _, err := player.Inventory.AddStack(stack); err != nil {
+			_, _ = w.stashFor(player.ID).AddStack(stack)
+		}
+	}
+}
+
+// retireStaleStash moves everything stashed under key into playerID's own
+// stash and drops the key's registration, so later banking for the player
+// lands in that stash too.
+func (w *World) retireStaleStash(key, playerID string) {
+	if w == nil {
+		return
+	}
+	if w.staleStashKeys[playerID] == key {
+		delete(w.staleStashKeys, playerID)
+	}
+	stash, ok := w.stashes[key]
+	delete(w.stashes, key)
+	if !ok || stash == nil || key == playerID {
+		return
+	}
+	for _, stack := range stash.DrainAll() {
+		_, _ = w.stashFor(playerID).AddStack(stack)
+	}
+}
@@ -20,9 +20,11 @@ type (
 	Actor                = state.Actor
 	Player               = state.Player
 	FacingDirection      = state.FacingDirection
+	CollisionLayer       = state.CollisionLayer
 	actorState           = state.ActorState
 	playerState          = state.PlayerState
 	playerPathState      = state.PlayerPathState
+	SessionStats         = state.SessionStats
 	npcState             = state.NPCState
 	NPC                  = state.NPC
 	NPCType              = state.NPCType
@@ -77,8 +79,15 @@ const (
 	FacingRight   FacingDirection = state.FacingRight
 	defaultFacing FacingDirection = state.DefaultFacing
 
-	NPCTypeGoblin NPCType = state.NPCTypeGoblin
-	NPCTypeRat    NPCType = state.NPCTypeRat
+	CollisionLayerDefault  CollisionLayer = state.CollisionLayerDefault
+	CollisionLayerGhost    CollisionLayer = state.CollisionLayerGhost
+	CollisionLayerFriendly CollisionLayer = state.CollisionLayerFriendly
+	CollisionLayerAll      CollisionLayer = state.CollisionLayerAll
+
+	NPCTypeGoblin   NPCType = state.NPCTypeGoblin
+	NPCTypeRat      NPCType = state.NPCTypeRat
+	NPCTypeBoss     NPCType = state.NPCTypeBoss
+	NPCTypeFamiliar NPCType = state.NPCTypeFamiliar
 )
 
 const (
@@ -93,6 +102,8 @@ const (
 	ItemTypeRefinedOre    ItemType = state.ItemTypeRefinedOre
 )
 
+const MaxPotionStack = state.MaxPotionStack
+
 var (
 	NewItemDefinition           = state.NewItemDefinition
 	NewInventory                = state.NewInventory
@@ -9,6 +9,7 @@ func computeDerived(total ValueSet) DerivedSet {
 	resonance := clamp(total[StatResonance], 0, 1e9)
 	focus := clamp(total[StatFocus], 0, 1e9)
 	speed := clamp(total[StatSpeed], 0, 1e9)
+	precision := clamp(total[StatPrecision], 0, 1e9)
 
 	derived[DerivedMaxHealth] = computeMaxHealth(might)
 	derived[DerivedMaxMana] = computeMaxMana(resonance)
@@ -19,6 +20,16 @@ func computeDerived(total ValueSet) DerivedSet {
 	derived[DerivedCastSpeed] = clamp(1+focus*castSpeedScalar, 0.1, 5)
 	derived[DerivedCooldownRate] = clamp(1+speed*cooldownRateScalar, 0.1, 5)
 	derived[DerivedStaggerResist] = clamp(staggerBase+might*staggerMightScalar, 0, 1)
+	derived[DerivedPickupReach] = clamp(1+(speed-pickupReachBaseSpeed)*pickupReachSpeedScalar, 0.5, 3)
+	derived[DerivedCritChance] = clamp(precision*precisionCritScalar, 0, 1)
+	derived[DerivedCritMultiplier] = clamp(critMultiplierBase+precision*precisionCritMultiplierScalar, 1, 5)
+	derived[DerivedDamageTakenPhysical] = computeDamageTaken(total[StatResistPhysical])
+	derived[DerivedDamageTakenFire] = computeDamageTaken(total[StatResistFire])
+	derived[DerivedDamageTakenPoison] = computeDamageTaken(total[StatResistPoison])
+	derived[DerivedLifesteal] = clamp(total[StatLifesteal]*lifestealScalar, 0, 1)
+	derived[DerivedCooldownReduction] = clamp(total[StatCooldownReduction]*cooldownReductionScalar, 0, maxCooldownReduction)
+	derived[DerivedDamageDealt] = clamp(1+total[StatDamageBonus]*damageBonusScalar, 0, maxDamageDealt)
+	derived[DerivedHealthRegen] = might * mightRegenScalar
 
 	return derived
 }
@@ -31,6 +42,12 @@ func computeMaxMana(resonance float64) float64 {
 	return baseManaFlat + resonance*resonanceManaScalar
 }
 
+// computeDamageTaken converts a resistance into an incoming damage multiplier.
+// Negative resistances are weaknesses and amplify the damage instead.
+func computeDamageTaken(resistance float64) float64 {
+	return clamp(1-resistance*resistanceScalar, 0, maxDamageTaken)
+}
+
 func computeDamageScalar(attribute float64, coeff float64) float64 {
 	scaled := 1 + coeff*(1-math.Pow(decayRatio, attribute))
 	return clamp(scaled, 0.1, 10)
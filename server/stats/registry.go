@@ -7,6 +7,8 @@ const (
 	ArchetypePlayer Archetype = iota
 	ArchetypeGoblin
 	ArchetypeRat
+	ArchetypeBoss
+	ArchetypeFamiliar
 )
 
 var archetypeBase = map[Archetype]ValueSet{
@@ -28,6 +30,18 @@ var archetypeBase = map[Archetype]ValueSet{
 		StatFocus:     3,
 		StatSpeed:     6,
 	},
+	ArchetypeBoss: {
+		StatMight:     40,
+		StatResonance: 24,
+		StatFocus:     14,
+		StatSpeed:     7,
+	},
+	ArchetypeFamiliar: {
+		StatMight:     14,
+		StatResonance: 6,
+		StatFocus:     6,
+		StatSpeed:     9,
+	},
 }
 
 // DefaultBase returns a copy of the base values for the given archetype.
@@ -73,4 +87,26 @@ const (
 	damagePhysicalScalar = 0.12
 	damageMagicalScalar  = 0.14
 	decayRatio           = 0.94
+	// Pickup reach is normalised so the default player archetype resolves to 1.
+	pickupReachBaseSpeed   = 11.0
+	pickupReachSpeedScalar = 0.05
+	// Archetypes carry no precision, so crits stay off until a source grants it.
+	precisionCritScalar           = 0.01
+	critMultiplierBase            = 1.5
+	precisionCritMultiplierScalar = 0.005
+	// Resistances are percentages: 100 grants immunity, -100 doubles damage.
+	resistanceScalar = 0.01
+	maxDamageTaken   = 2.0
+	// Lifesteal is a percentage of damage dealt returned to the attacker.
+	lifestealScalar = 0.01
+	// Cooldown reduction is a percentage shaved off every ability cooldown.
+	// The cap keeps abilities from ever firing faster than twice their base rate.
+	cooldownReductionScalar = 0.01
+	maxCooldownReduction    = 0.5
+	// Damage bonus is a percentage added to all damage the actor deals;
+	// negative values weaken it.
+	damageBonusScalar = 0.01
+	maxDamageDealt    = 10.0
+	// Health regenerated per second once an actor is out of combat.
+	mightRegenScalar = 0.05
 )
@@ -1,5 +1,7 @@
 package stats
 
+import "sort"
+
 // Snapshot captures the component totals and derived stats for serialization.
 type Snapshot struct {
 	Totals  ValueSet
@@ -20,3 +22,48 @@ func (c *Component) Restore(snapshot Snapshot) {
 	c.version = snapshot.Version
 	c.dirty = false
 }
+
+// Sources lists every modifier applied to the component as the change that
+// recreates it, ordered by layer and then by source.
+func (c *Component) Sources() []CommandStatChange {
+	var changes []CommandStatChange
+	for layer := Layer(0); layer < LayerCount; layer++ {
+		entries := c.sources[layer]
+		keys := make([]SourceKey, 0, len(entries))
+		for key := range entries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].Kind != keys[j].Kind {
+				return keys[i].Kind < keys[j].Kind
+			}
+			return keys[i].ID < keys[j].ID
+		})
+		for _, key := range keys {
+			src := entries[key]
+			changes = append(changes, CommandStatChange{
+				Layer:         layer,
+				Source:        key,
+				Delta:         src.delta,
+				ExpiresAtTick: src.expiresAtTick,
+			})
+		}
+	}
+	return changes
+}
+
+// ComponentFromSources rebuilds a component from the changes returned by
+// Sources and resolves it at the given tick.
+func ComponentFromSources(changes []CommandStatChange, tick uint64) Component {
+	c := Component{}
+	c.ensureInit()
+	for _, change := range changes {
+		if change.Layer >= LayerCount || change.Remove {
+			continue
+		}
+		c.applySource(change.Layer, change.Source, change.Delta, change.ExpiresAtTick)
+	}
+	c.dirty = true
+	c.Resolve(tick)
+	return c
+}
@@ -13,6 +13,13 @@ const (
 	StatResonance
 	StatFocus
 	StatSpeed
+	StatPrecision
+	StatResistPhysical
+	StatResistFire
+	StatResistPoison
+	StatLifesteal
+	StatCooldownReduction
+	StatDamageBonus
 
 	StatCount
 )
@@ -30,6 +37,16 @@ const (
 	DerivedCastSpeed
 	DerivedCooldownRate
 	DerivedStaggerResist
+	DerivedPickupReach
+	DerivedCritChance
+	DerivedCritMultiplier
+	DerivedDamageTakenPhysical
+	DerivedDamageTakenFire
+	DerivedDamageTakenPoison
+	DerivedLifesteal
+	DerivedCooldownReduction
+	DerivedDamageDealt
+	DerivedHealthRegen
 
 	DerivedCount
 )
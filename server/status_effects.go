@@ -16,7 +16,10 @@ import (
 var _ statuspkg.StatusEffectInstance = (*statusEffectInstance)(nil)
 
 const (
-	StatusEffectBurning StatusEffectType = StatusEffectType(statuspkg.StatusEffectBurning)
+	StatusEffectBurning   StatusEffectType = StatusEffectType(statuspkg.StatusEffectBurning)
+	StatusEffectParrying  StatusEffectType = StatusEffectType(statuspkg.StatusEffectParrying)
+	StatusEffectHasted    StatusEffectType = StatusEffectType(statuspkg.StatusEffectHasted)
+	StatusEffectStealthed StatusEffectType = StatusEffectType(statuspkg.StatusEffectStealthed)
 )
 
 var (
@@ -55,6 +58,12 @@ func newStatusEffectDefinitions(w *World) map[StatusEffectType]statuspkg.ApplySt
 		VisualEffectType: effectTypeBurningVisual,
 		VisualFootprint:  playerHalf * 2,
 		DamagePerSecond:  lavaDamagePerSecond,
+		DamageRate: func() float64 {
+			if w == nil {
+				return lavaDamagePerSecond
+			}
+			return w.config.LavaDamageRate()
+		},
 		BuildContractVisualIntent: func(cfg statuspkg.BurningContractVisualConfig) (effectcontract.EffectIntent, bool) {
 			actor := (*actorState)(cfg.Actor)
 			if actor == nil || actor.ID == "" {
@@ -64,7 +73,7 @@ func newStatusEffectDefinitions(w *World) map[StatusEffectType]statuspkg.ApplySt
 			if lifetime <= 0 {
 				lifetime = burningTickInterval
 			}
-			return NewStatusVisualIntent(actor, cfg.SourceID, effectTypeBurningVisual, lifetime)
+			return newStatusVisualIntentAt(actor, cfg.SourceID, effectTypeBurningVisual, lifetime, w.ticksPerSecond())
 		},
 		EnqueueIntent: func(intent effectcontract.EffectIntent) {
 			if w == nil || w.effectManager == nil {
@@ -105,8 +114,24 @@ func newStatusEffectDefinitions(w *World) map[StatusEffectType]statuspkg.ApplySt
 			Duration:     burningStatusEffectDuration,
 			TickInterval: burningTickInterval,
 			InitialTick:  true,
+			Stacking:     statuspkg.StackRefresh,
 			Lifecycle:    lifecycle,
 		},
+		Parrying: statuspkg.TimedStatusEffectDefinitionConfig{
+			Type:     string(StatusEffectParrying),
+			Duration: parryDuration,
+			Stacking: statuspkg.StackIgnore,
+		},
+		Hasted: statuspkg.TimedStatusEffectDefinitionConfig{
+			Type:     string(StatusEffectHasted),
+			Duration: hasteDuration,
+			Stacking: statuspkg.StackRefresh,
+		},
+		Stealthed: statuspkg.TimedStatusEffectDefinitionConfig{
+			Type:     string(StatusEffectStealthed),
+			Duration: stealthDuration,
+			Stacking: statuspkg.StackRefresh,
+		},
 	})
 
 	result := make(map[StatusEffectType]statuspkg.ApplyStatusEffectDefinition, len(defs))
@@ -173,14 +198,7 @@ func (w *World) applyStatusEffect(target *actorState, cond StatusEffectType, sou
 			if w == nil {
 				return
 			}
-			actorRef := logging.EntityRef{}
-			if source != "" {
-				actorRef = w.entityRef(source)
-			}
-			targetRef := logging.EntityRef{}
-			if target != nil {
-				targetRef = w.entityRef(target.ID)
-			}
+			actorRef, targetRef := w.statusEffectRefs(source, target.ID)
 			payload := loggingstatuseffects.AppliedPayload{StatusEffect: string(cond), SourceID: source}
 			if duration > 0 {
 				payload.DurationMs = duration.Milliseconds()
@@ -195,6 +213,28 @@ func (w *World) applyStatusEffect(target *actorState, cond StatusEffectType, sou
 				nil,
 			)
 		},
+		RecordRefreshed: func(duration time.Duration) {
+			if w == nil {
+				return
+			}
+			actorRef, targetRef := w.statusEffectRefs(source, target.ID)
+			payload := loggingstatuseffects.RefreshedPayload{StatusEffect: string(cond), SourceID: source}
+			if duration > 0 {
+				payload.DurationMs = duration.Milliseconds()
+			}
+			loggingstatuseffects.Refreshed(
+				context.Background(),
+				w.publisher,
+				w.currentTick,
+				actorRef,
+				targetRef,
+				payload,
+				nil,
+			)
+		},
+		RecordTick: func(time.Time) {
+			w.recordStatusEffectTick(target, string(cond), source)
+		},
 	})
 }
 
@@ -320,9 +360,62 @@ func (w *World) statusEffectsAdvanceConfig(actor *actorState) statuspkg.AdvanceA
 			}
 			w.recordEffectEnd(eff, "status-effect-expire")
 		},
+		RecordTick: func(key string, value any, _ time.Time) {
+			inst, _ := value.(*statusEffectInstance)
+			if inst == nil {
+				return
+			}
+			w.recordStatusEffectTick(actor, key, inst.SourceID)
+		},
+		RecordExpired: func(key string, value any, _ time.Time) {
+			inst, _ := value.(*statusEffectInstance)
+			if w == nil || inst == nil || actor == nil {
+				return
+			}
+			actorRef, targetRef := w.statusEffectRefs(inst.SourceID, actor.ID)
+			loggingstatuseffects.Expired(
+				context.Background(),
+				w.publisher,
+				w.currentTick,
+				actorRef,
+				targetRef,
+				loggingstatuseffects.ExpiredPayload{StatusEffect: key, SourceID: inst.SourceID},
+				nil,
+			)
+		},
 	}
 }
 
+// statusEffectRefs builds the log references for a status effect event: the
+// actor is whoever applied it, when known, and the target is the recipient.
+func (w *World) statusEffectRefs(sourceID, targetID string) (logging.EntityRef, logging.EntityRef) {
+	actorRef := logging.EntityRef{}
+	if sourceID != "" {
+		actorRef = w.entityRef(sourceID)
+	}
+	targetRef := logging.EntityRef{}
+	if targetID != "" {
+		targetRef = w.entityRef(targetID)
+	}
+	return actorRef, targetRef
+}
+
+func (w *World) recordStatusEffectTick(target *actorState, status, sourceID string) {
+	if w == nil || target == nil {
+		return
+	}
+	actorRef, targetRef := w.statusEffectRefs(sourceID, target.ID)
+	loggingstatuseffects.Ticked(
+		context.Background(),
+		w.publisher,
+		w.currentTick,
+		actorRef,
+		targetRef,
+		loggingstatuseffects.TickedPayload{StatusEffect: status, SourceID: sourceID},
+		nil,
+	)
+}
+
 func newStatusEffectInstanceHandle(inst *statusEffectInstance, actor *actorState) statuspkg.StatusEffectInstanceHandle {
 	if inst != nil {
 		inst.SetActorState(actor)
@@ -401,6 +494,18 @@ func newStatusEffectInstanceHandle(inst *statusEffectInstance, actor *actorState
 			}
 			inst.LastTick = at
 		},
+		SetStacks: func(value int) {
+			if inst == nil {
+				return
+			}
+			inst.Stacks = value
+		},
+		Stacks: func() int {
+			if inst == nil {
+				return 0
+			}
+			return inst.Stacks
+		},
 		Attachment: statuspkg.StatusEffectInstanceAttachment{
 			SetStatus: func(effectType string) {
 				if inst == nil || effectType == "" {
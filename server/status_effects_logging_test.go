@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	loggingstatuseffects "mine-and-die/server/logging/status_effects"
+)
+
+type statusEffectCapturePublisher struct {
+	events []logging.Event
+}
+
+func (p *statusEffectCapturePublisher) Publish(_ context.Context, event logging.Event) {
+	if event.Category == "status_effects" {
+		p.events = append(p.events, event)
+	}
+}
+
+func TestBurningPublishesApplyTickRefreshAndExpireInOrder(t *testing.T) {
+	pub := &statusEffectCapturePublisher{}
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), pub)
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	target := newTestPlayerState("target")
+	target.X = 200
+	target.Y = 200
+	world.players[target.ID] = target
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	start := time.Unix(0, 0)
+
+	world.applyStatusEffect(&target.ActorState, StatusEffectBurning, "igniter", start)
+	current := start
+	tick := uint64(1)
+	for ; tick < 10*tickRate; tick++ {
+		current = current.Add(step)
+		if tick == tickRate {
+			world.applyStatusEffect(&target.ActorState, StatusEffectBurning, "igniter", current)
+		}
+		world.Step(tick, current, dt, nil, nil)
+		if _, burning := target.StatusEffects[StatusEffectBurning]; !burning {
+			break
+		}
+	}
+	if _, burning := target.StatusEffects[StatusEffectBurning]; burning {
+		t.Fatalf("expected burning to expire")
+	}
+
+	if len(pub.events) < 4 {
+		t.Fatalf("expected apply, tick, refresh and expire events, got %d", len(pub.events))
+	}
+	for i, event := range pub.events {
+		if event.Actor.ID != "igniter" || len(event.Targets) != 1 || event.Targets[0].ID != target.ID {
+			t.Fatalf("event %d: expected igniter → target refs, got actor %+v targets %+v", i, event.Actor, event.Targets)
+		}
+	}
+
+	types := make([]logging.EventType, len(pub.events))
+	for i, event := range pub.events {
+		types[i] = event.Type
+	}
+	if types[0] != loggingstatuseffects.EventApplied || types[1] != loggingstatuseffects.EventTicked {
+		t.Fatalf("expected apply then the initial tick, got %v", types)
+	}
+	if last := types[len(types)-1]; last != loggingstatuseffects.EventExpired {
+		t.Fatalf("expected expiry to be the final event, got %v", types)
+	}
+	refreshes := 0
+	for _, eventType := range types[2 : len(types)-1] {
+		switch eventType {
+		case loggingstatuseffects.EventRefreshed:
+			refreshes++
+		case loggingstatuseffects.EventTicked:
+		default:
+			t.Fatalf("expected only ticks and the refresh between apply and expire, got %v", types)
+		}
+	}
+	if refreshes != 1 {
+		t.Fatalf("expected exactly one refresh, got %v", types)
+	}
+}
@@ -431,7 +431,7 @@ func (a *effectParityAggregator) record(summary effectParitySummary) {
 	}
 }
 
-func (a *effectParityAggregator) snapshot(totalTicks uint64) map[string]telemetryEffectParityEntry {
+func (a *effectParityAggregator) snapshot(totalTicks uint64, rate int) map[string]telemetryEffectParityEntry {
 	if a == nil {
 		return nil
 	}
@@ -445,7 +445,7 @@ func (a *effectParityAggregator) snapshot(totalTicks uint64) map[string]telemetr
 		if totals == nil {
 			continue
 		}
-		result[effectType] = totals.toSnapshot(totalTicks)
+		result[effectType] = totals.toSnapshot(totalTicks, rate)
 	}
 	if len(result) == 0 {
 		return nil
@@ -496,7 +496,7 @@ type telemetryEffectParityEntry struct {
 	VictimBuckets        map[string]uint64 `json:"victimBuckets,omitempty"`
 }
 
-func (t *effectParityTotals) toSnapshot(totalTicks uint64) telemetryEffectParityEntry {
+func (t *effectParityTotals) toSnapshot(totalTicks uint64, rate int) telemetryEffectParityEntry {
 	entry := telemetryEffectParityEntry{
 		Hits:   t.Hits,
 		Damage: t.Damage,
@@ -510,7 +510,7 @@ func (t *effectParityTotals) toSnapshot(totalTicks uint64) telemetryEffectParity
 	if t.FirstHitSamples > 0 {
 		avgTicks := float64(t.FirstHitLatencyTicks) / float64(t.FirstHitSamples)
 		entry.FirstHitLatencyTicks = avgTicks
-		entry.FirstHitLatencyMs = avgTicks * 1000.0 / float64(tickRate)
+		entry.FirstHitLatencyMs = avgTicks * 1000.0 / float64(normalizeTickRate(rate))
 	}
 	if len(t.VictimBuckets) > 0 {
 		copy := make(map[string]uint64, len(t.VictimBuckets))
@@ -532,10 +532,13 @@ func normalizeMetricKey(value string) string {
 type telemetryCounters struct {
 	metrics        telemetry.Metrics
 	metricsAdapter telemetryMetricsAdapter
+	tickRate       int
 
+	broadcastsTotal              atomic.Uint64
 	bytesSent                    atomic.Uint64
 	entitiesSent                 atomic.Uint64
 	tickDurationMillis           atomic.Int64
+	tickDurations                tickDurationHistogram
 	lastBroadcastBytes           atomic.Uint64
 	lastBroadcastEntities        atomic.Uint64
 	debug                        bool
@@ -555,7 +558,8 @@ type telemetryCounters struct {
 	triggerEnqueued        simpleCounter
 	journalDrops           simpleCounter
 
-	commandDrops layeredCounter
+	commandDrops       layeredCounter
+	commandDropsByType simpleCounter
 
 	tickBudgetOverruns               simpleCounter
 	tickBudgetLastOverrunMillis      atomic.Int64
@@ -592,6 +596,7 @@ type telemetrySnapshot struct {
 	EffectTriggers           telemetryEffectTriggersSnapshot  `json:"effectTriggers"`
 	JournalDrops             map[string]uint64                `json:"journalDrops,omitempty"`
 	CommandDrops             map[string]map[string]uint64     `json:"commandDrops,omitempty"`
+	CommandDropsByType       map[string]uint64                `json:"commandDropsByType,omitempty"`
 	SubscriberQueues         telemetrySubscriberQueueSnapshot `json:"subscriberQueues"`
 	BroadcastQueue           telemetryBroadcastQueueSnapshot  `json:"broadcastQueue"`
 	EffectParity             telemetryEffectParitySnapshot    `json:"effectParity"`
@@ -649,6 +654,22 @@ func newTelemetryCounters(metrics telemetry.Metrics) *telemetryCounters {
 	return t
 }
 
+// setTickRate records the simulation frequency used to convert tick counts
+// into wall-clock figures. It must be called before the hub starts ticking.
+func (t *telemetryCounters) setTickRate(rate int) {
+	if t == nil {
+		return
+	}
+	t.tickRate = normalizeTickRate(rate)
+}
+
+func (t *telemetryCounters) ticksPerSecond() int {
+	if t == nil {
+		return tickRate
+	}
+	return normalizeTickRate(t.tickRate)
+}
+
 func (t *telemetryCounters) AttachMetrics(metrics telemetry.Metrics) {
 	if t == nil {
 		return
@@ -664,6 +685,7 @@ func (t *telemetryCounters) RecordBroadcast(bytes, entities int) {
 	if entities < 0 {
 		entities = 0
 	}
+	t.broadcastsTotal.Add(1)
 	t.bytesSent.Add(uint64(bytes))
 	t.entitiesSent.Add(uint64(entities))
 	t.lastBroadcastBytes.Store(uint64(bytes))
@@ -677,6 +699,7 @@ func (t *telemetryCounters) RecordTickDuration(duration time.Duration) {
 		millis = 0
 	}
 	t.tickDurationMillis.Store(millis)
+	t.tickDurations.observe(duration)
 	total := t.totalTicks.Add(1)
 	t.metricsAdapter.RecordTickDuration(duration, total)
 	if t.debug {
@@ -708,7 +731,7 @@ func (t *telemetryCounters) RecordTickBudgetOverrun(duration, budget time.Durati
 		return 0
 	}
 	if budget <= 0 {
-		budget = time.Second / time.Duration(tickRate)
+		budget = time.Second / time.Duration(t.ticksPerSecond())
 	}
 	bucket := tickBudgetOverrunBucket(duration, budget)
 	if bucket != "" {
@@ -864,6 +887,7 @@ func (t *telemetryCounters) RecordCommandDropped(reason string, cmdType string)
 		secondary = "unknown"
 	}
 	t.commandDrops.add(reason, secondary, 1)
+	t.commandDropsByType.add(secondary, 1)
 	t.metricsAdapter.RecordCommandDrop(reason, secondary)
 }
 
@@ -951,7 +975,8 @@ func (t *telemetryCounters) DebugEnabled() bool {
 
 func (t *telemetryCounters) Snapshot() telemetrySnapshot {
 	totalTicks := t.totalTicks.Load()
-	tickBudget := time.Second / time.Duration(tickRate)
+	rate := t.ticksPerSecond()
+	tickBudget := time.Second / time.Duration(rate)
 	depth := t.subscriberQueueDepth.Load()
 	maxDepth := t.subscriberQueueMaxDepth.Load()
 	drops := t.subscriberQueueDrops.Load()
@@ -961,8 +986,8 @@ func (t *telemetryCounters) Snapshot() telemetrySnapshot {
 	var dropRate float64
 	var broadcastDropRate float64
 	if totalTicks > 0 {
-		dropRate = float64(drops) * float64(tickRate) / float64(totalTicks)
-		broadcastDropRate = float64(broadcastDrops) * float64(tickRate) / float64(totalTicks)
+		dropRate = float64(drops) * float64(rate) / float64(totalTicks)
+		broadcastDropRate = float64(broadcastDrops) * float64(rate) / float64(totalTicks)
 	}
 	snapshot := telemetrySnapshot{
 		BytesSent:                t.bytesSent.Load(),
@@ -985,8 +1010,9 @@ func (t *telemetryCounters) Snapshot() telemetrySnapshot {
 		EffectTriggers: telemetryEffectTriggersSnapshot{
 			EnqueuedTotal: t.triggerEnqueued.snapshot(),
 		},
-		JournalDrops: t.journalDrops.snapshot(),
-		CommandDrops: t.commandDrops.snapshot(),
+		JournalDrops:       t.journalDrops.snapshot(),
+		CommandDrops:       t.commandDrops.snapshot(),
+		CommandDropsByType: t.commandDropsByType.snapshot(),
 		SubscriberQueues: telemetrySubscriberQueueSnapshot{
 			Depth:             depth,
 			MaxDepth:          maxDepth,
@@ -1001,7 +1027,7 @@ func (t *telemetryCounters) Snapshot() telemetrySnapshot {
 		},
 		EffectParity: telemetryEffectParitySnapshot{
 			TotalTicks: totalTicks,
-			Entries:    t.effectParity.snapshot(totalTicks),
+			Entries:    t.effectParity.snapshot(totalTicks, rate),
 		},
 	}
 	tickBudgetSnapshot := telemetryTickBudgetSnapshot{
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	metricKeyTickDurationSeconds = "telemetry_tick_duration_seconds"
+
+	// tickDurationBucketCount is the number of finite buckets in the tick
+	// duration histogram; an implicit +Inf bucket follows them.
+	tickDurationBucketCount = 9
+)
+
+// tickDurationBucketBounds returns the upper bounds, in seconds, of the tick
+// duration histogram. They bracket the 66ms budget of the default tick rate.
+func tickDurationBucketBounds() [tickDurationBucketCount]float64 {
+	return [tickDurationBucketCount]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+}
+
+// tickDurationHistogram counts tick durations into fixed buckets so /metrics
+// can export a Prometheus histogram without a client library.
+type tickDurationHistogram struct {
+	counts   [tickDurationBucketCount + 1]atomic.Uint64
+	sumNanos atomic.Int64
+	total    atomic.Uint64
+}
+
+func (h *tickDurationHistogram) observe(duration time.Duration) {
+	if duration < 0 {
+		duration = 0
+	}
+	seconds := duration.Seconds()
+	bounds := tickDurationBucketBounds()
+	idx := len(bounds)
+	for i, bound := range bounds {
+		if seconds <= bound {
+			idx = i
+			break
+		}
+	}
+	h.counts[idx].Add(1)
+	h.sumNanos.Add(duration.Nanoseconds())
+	h.total.Add(1)
+}
+
+// WritePrometheus renders the broadcast, command drop, effect, and tick
+// duration counters in the Prometheus text exposition format.
+func (t *telemetryCounters) WritePrometheus(w io.Writer) error {
+	var b strings.Builder
+	if t == nil {
+		t = &telemetryCounters{}
+	}
+
+	writePrometheusScalar(&b, metricKeyBroadcastTotal, "counter", "State broadcasts sent to subscribers.", float64(t.broadcastsTotal.Load()))
+	writePrometheusScalar(&b, metricKeyBroadcastBytesTotal, "counter", "Bytes written across all state broadcasts.", float64(t.bytesSent.Load()))
+	writePrometheusScalar(&b, metricKeyBroadcastEntitiesTotal, "counter", "Entities included across all state broadcasts.", float64(t.entitiesSent.Load()))
+
+	writePrometheusHeader(&b, metricKeyCommandDropsTotalPrefix, "counter", "Commands dropped before reaching the simulation.")
+	drops := t.commandDrops.snapshot()
+	reasons := make([]string, 0, len(drops))
+	for reason := range drops {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		types := make([]string, 0, len(drops[reason]))
+		for cmdType := range drops[reason] {
+			types = append(types, cmdType)
+		}
+		sort.Strings(types)
+		for _, cmdType := range types {
+			fmt.Fprintf(&b, "%s{reason=\"%s\",type=\"%s\"} %d\n", metricKeyCommandDropsTotalPrefix, escapePrometheusLabel(reason), escapePrometheusLabel(cmdType), drops[reason][cmdType])
+		}
+	}
+
+	writePrometheusScalar(&b, metricKeyEffectsActiveGauge, "gauge", "Contract effect instances currently alive.", float64(t.effectsActiveGauge.Load()))
+	writePrometheusScalar(&b, metricKeyTickTotal, "counter", "Simulation ticks processed.", float64(t.totalTicks.Load()))
+
+	writePrometheusHeader(&b, metricKeyTickDurationSeconds, "histogram", "Wall time spent stepping the simulation per tick.")
+	var cumulative uint64
+	for i, bound := range tickDurationBucketBounds() {
+		cumulative += t.tickDurations.counts[i].Load()
+		fmt.Fprintf(&b, "%s_bucket{le=\"%s\"} %d\n", metricKeyTickDurationSeconds, formatPrometheusFloat(bound), cumulative)
+	}
+	cumulative += t.tickDurations.counts[tickDurationBucketCount].Load()
+	fmt.Fprintf(&b, "%s_bucket{le=\"+Inf\"} %d\n", metricKeyTickDurationSeconds, cumulative)
+	sum := time.Duration(t.tickDurations.sumNanos.Load()).Seconds()
+	fmt.Fprintf(&b, "%s_sum %s\n", metricKeyTickDurationSeconds, formatPrometheusFloat(sum))
+	fmt.Fprintf(&b, "%s_count %d\n", metricKeyTickDurationSeconds, cumulative)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePrometheusHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writePrometheusScalar(b *strings.Builder, name, kind, help string, value float64) {
+	writePrometheusHeader(b, name, kind, help)
+	fmt.Fprintf(b, "%s %s\n", name, formatPrometheusFloat(value))
+}
+
+func formatPrometheusFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapePrometheusLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// WriteMetrics renders the hub telemetry for a Prometheus scrape.
+func (h *Hub) WriteMetrics(w io.Writer) error {
+	return h.telemetry.WritePrometheus(w)
+}
@@ -1,6 +1,10 @@
 package server
 
-import worldpkg "mine-and-die/server/internal/world"
+import (
+	"math/rand"
+
+	worldpkg "mine-and-die/server/internal/world"
+)
 
 func fullyFeaturedTestWorldConfig() worldConfig {
 	cfg := worldpkg.DefaultConfig()
@@ -24,3 +28,26 @@ func newHubWithFullWorld() *Hub {
 	hub.ResetWorld(fullyFeaturedTestWorldConfig())
 	return hub
 }
+
+// newSeededTestHub builds a hub whose world derives from seed and starts with
+// an empty obstacle field so movement tests are not perturbed by terrain.
+// configure runs before the world is generated; the returned RNG is the
+// world's root generator for assertions about random draws.
+func newSeededTestHub(seed string, configure ...func(*worldConfig)) (*Hub, *rand.Rand) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Seed = seed
+	cfg.Obstacles = false
+	cfg.ObstaclesCount = 0
+	cfg.GoldMines = false
+	cfg.GoldMineCount = 0
+	cfg.Lava = false
+	cfg.LavaCount = 0
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	hub := newHub()
+	hub.ResetWorld(cfg)
+	hub.world.obstacles = nil
+	return hub, hub.world.rng
+}
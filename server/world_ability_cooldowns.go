@@ -0,0 +1,28 @@
+package server
+
+import (
+	"time"
+
+	combat "mine-and-die/server/internal/combat"
+	stats "mine-and-die/server/stats"
+)
+
+// readyAbility gates a cast on the caster's cooldown registry with the same
+// bookkeeping as the melee and projectile ability gates: the caster's
+// cooldown reduction shortens the wait, and a cast that is ready stamps now
+// as its start. It reports false while the ability is still cooling down or
+// when the caster is unknown.
+func (w *World) readyAbility(casterID, abilityID string, cooldown time.Duration, now time.Time) bool {
+	if w == nil || casterID == "" {
+		return false
+	}
+	if player, ok := w.players[casterID]; ok && player != nil {
+		reduction := player.Stats.GetDerived(stats.DerivedCooldownReduction)
+		return combat.ReadyCooldown(&player.Cooldowns, abilityID, combat.ReducedCooldown(cooldown, reduction), now)
+	}
+	if npc, ok := w.npcs[casterID]; ok && npc != nil {
+		reduction := npc.Stats.GetDerived(stats.DerivedCooldownReduction)
+		return combat.ReadyCooldown(&npc.Cooldowns, abilityID, combat.ReducedCooldown(cooldown, reduction), now)
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+// recastProbe describes how to tell whether a cast of action took effect.
+type recastProbe struct {
+	action   string
+	cooldown time.Duration
+	// prepare runs before every cast to clear what the previous one left.
+	prepare func(w *World, caster *playerState)
+	// landed reports whether the cast stepped at now took effect. spawned is
+	// the number of contract effects the step spawned.
+	landed func(w *World, caster *playerState, spawned int, now time.Time) bool
+}
+
+func spawnedEffect(_ *World, _ *playerState, spawned int, _ time.Time) bool {
+	return spawned > 0
+}
+
+// assertRecastIgnoredInsideCooldown casts the probe's action, recasts it
+// halfway through its cooldown, and casts once more when the cooldown is up.
+// Only the first and last casts may take effect.
+func assertRecastIgnoredInsideCooldown(t *testing.T, probe recastProbe) {
+	t.Helper()
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Health = baselinePlayerMaxHealth - 50
+	world.players[caster.ID] = caster
+
+	dt := 1.0 / float64(tickRate)
+	start := time.Unix(0, 0)
+	tick := uint64(0)
+	cast := func(now time.Time) bool {
+		if probe.prepare != nil {
+			probe.prepare(world, caster)
+		}
+		collector := &effectEventCollector{}
+		tick++
+		world.Step(tick, now, dt, []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: probe.action}}}, collector.collect)
+		return probe.landed(world, caster, len(collector.spawns), now)
+	}
+
+	if !cast(start) {
+		t.Fatalf("expected the first %s cast to take effect", probe.action)
+	}
+	if cast(start.Add(probe.cooldown / 2)) {
+		t.Fatalf("expected a %s recast inside its cooldown to do nothing", probe.action)
+	}
+	if !cast(start.Add(probe.cooldown)) {
+		t.Fatalf("expected %s to cast again once its cooldown elapsed", probe.action)
+	}
+}
+
+func TestAbilityRecastsInsideCooldownDoNothing(t *testing.T) {
+	probes := []recastProbe{
+		{action: effectTypeHealBurst, cooldown: healBurstCooldown, landed: spawnedEffect},
+		{action: effectTypeGravityWell, cooldown: gravityWellCooldown, landed: spawnedEffect},
+		{action: effectTypeFirePatch, cooldown: firePatchCooldown, landed: spawnedEffect},
+		{
+			action:   effectTypeShield,
+			cooldown: shieldCooldown,
+			// Break the previous pool so only the cooldown can refuse the cast.
+			prepare: func(w *World, caster *playerState) { w.setPlayerAbsorb(caster, 0) },
+			landed: func(_ *World, caster *playerState, _ int, _ time.Time) bool {
+				return caster.Absorb > 0
+			},
+		},
+		{
+			action:   actionParry,
+			cooldown: parryCooldown,
+			landed: func(w *World, caster *playerState, _ int, now time.Time) bool {
+				return w.isParrying(caster.ID, now)
+			},
+		},
+		{
+			action:   actionHaste,
+			cooldown: hasteCooldown,
+			landed: func(w *World, caster *playerState, _ int, now time.Time) bool {
+				return w.effectiveMoveSpeed(&caster.ActorState, now) > moveSpeed
+			},
+		},
+		{
+			action:   actionTaunt,
+			cooldown: tauntCooldown,
+			prepare: func(w *World, caster *playerState) {
+				if len(w.npcs) == 0 {
+					w.spawnGoblinAt(caster.X+50, caster.Y, nil)
+				}
+				for _, npc := range w.npcs {
+					npc.Blackboard.TauntedBy = ""
+				}
+			},
+			landed: func(w *World, caster *playerState, _ int, _ time.Time) bool {
+				for _, npc := range w.npcs {
+					if npc.Blackboard.TauntedBy == caster.ID {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		{
+			action:   actionSummon,
+			cooldown: summonCooldown,
+			prepare: func(w *World, caster *playerState) {
+				for _, id := range w.summonsOf(caster.ID) {
+					w.despawnNPC(w.npcs[id])
+				}
+			},
+			landed: func(w *World, caster *playerState, _ int, _ time.Time) bool {
+				return len(w.summonsOf(caster.ID)) > 0
+			},
+		},
+		{
+			action:   actionRecall,
+			cooldown: recallCooldown,
+			// Drop the pending recall so the recast starts a new one rather
+			// than completing it early.
+			prepare: func(w *World, caster *playerState) { w.cancelScheduledTasks(scheduledTaskRecall, caster.ID) },
+			landed: func(w *World, caster *playerState, _ int, _ time.Time) bool {
+				_, pending := w.pendingScheduledTask(scheduledTaskRecall, caster.ID)
+				return pending
+			},
+		},
+		{
+			action:   actionStealth,
+			cooldown: stealthCooldown,
+			prepare:  func(w *World, caster *playerState) { w.SetActorStealthed(caster.ID, false, time.Time{}) },
+			landed: func(_ *World, caster *playerState, _ int, _ time.Time) bool {
+				return isStealthed(&caster.ActorState)
+			},
+		},
+		{
+			action:   effectTypeDetect,
+			cooldown: detectCooldown,
+			landed: func(w *World, caster *playerState, _ int, now time.Time) bool {
+				for _, eff := range w.effects {
+					if eff.Type == effectTypeDetect && eff.Owner == caster.ID && eff.Start == now.UnixMilli() {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+	for _, probe := range probes {
+		t.Run(probe.action, func(t *testing.T) {
+			assertRecastIgnoredInsideCooldown(t, probe)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// effectTypeShield grants the caster an absorb pool through the "shield" action.
+	effectTypeShield = "shield"
+	shieldAbsorb     = 30.0
+	shieldDuration   = 5 * time.Second
+	// shieldCooldown runs from the cast, so breaking the pool early does not
+	// let the caster raise a new one.
+	shieldCooldown = 10 * time.Second
+)
+
+// GrantAbsorb gives the player an absorb pool that soaks incoming damage
+// before health until it is depleted or duration elapses. A new grant replaces
+// whatever remains of the previous one.
+func (w *World) GrantAbsorb(playerID string, amount float64, duration time.Duration, now time.Time) {
+	if w == nil || amount <= 0 || duration <= 0 {
+		return
+	}
+	player, ok := w.players[playerID]
+	if !ok || player == nil || player.Health <= 0 {
+		return
+	}
+	player.AbsorbExpiresAt = now.Add(duration)
+	w.setPlayerAbsorb(player, amount)
+}
+
+// castShield grants the caster a fresh absorb pool unless one is still active
+// or shieldCooldown has not passed since the last cast.
+func (w *World) castShield(casterID string, now time.Time) {
+	player, ok := w.players[casterID]
+	if !ok || player == nil || player.Health <= 0 || player.Absorb > 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeShield, shieldCooldown, now) {
+		return
+	}
+	w.GrantAbsorb(casterID, shieldAbsorb, shieldDuration, now)
+}
+
+// absorbDamage soaks damage into the target player's absorb pool and returns
+// the remainder that should reach health.
+func (w *World) absorbDamage(targetID string, damage float64) float64 {
+	if w == nil || damage <= 0 {
+		return damage
+	}
+	player, ok := w.players[targetID]
+	if !ok || player == nil || player.Absorb <= 0 {
+		return damage
+	}
+	soaked := math.Min(player.Absorb, damage)
+	w.setPlayerAbsorb(player, player.Absorb-soaked)
+	return damage - soaked
+}
+
+// expireAbsorbs clears absorb pools whose duration has elapsed. Players are
+// visited in ID order so the emitted patches are deterministic.
+func (w *World) expireAbsorbs(now time.Time) {
+	var expired []string
+	for id, player := range w.players {
+		if player == nil || player.Absorb <= 0 || now.Before(player.AbsorbExpiresAt) {
+			continue
+		}
+		expired = append(expired, id)
+	}
+	sort.Strings(expired)
+	for _, id := range expired {
+		w.setPlayerAbsorb(w.players[id], 0)
+	}
+}
+
+func (w *World) setPlayerAbsorb(player *playerState, absorb float64) {
+	if absorb < 0 {
+		absorb = 0
+	}
+	if player.Absorb == absorb {
+		return
+	}
+	player.Absorb = absorb
+	if absorb == 0 {
+		player.AbsorbExpiresAt = time.Time{}
+	}
+	player.Version++
+	w.appendPatch(PatchPlayerAbsorb, player.ID, PlayerAbsorbPayload{Absorb: absorb})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"math"
+	"sort"
+
+	ai "mine-and-die/server/internal/ai"
+)
+
+// npcPackAggroRadius bounds how far a struck NPC's call for help carries.
+const npcPackAggroRadius = 160.0
+
+// alertNPCPack makes the struck NPC and every living NPC of the same type
+// within npcPackAggroRadius acquire the attacker. Only players and their
+// summons trigger pack aggro so friendly fire between NPCs does not cascade.
+func (w *World) alertNPCPack(eff *effectState, struck *npcState) {
+	if w == nil || eff == nil || struck == nil {
+		return
+	}
+	if w.actorFaction(eff.Owner) != factionPlayers || w.sameFaction(eff.Owner, struck.ID) {
+		return
+	}
+
+	ids := make([]string, 0, len(w.npcs))
+	for id, npc := range w.npcs {
+		if npc == nil || npc.Type != struck.Type || npc.Health <= 0 {
+			continue
+		}
+		if id != struck.ID && math.Hypot(npc.X-struck.X, npc.Y-struck.Y) > npcPackAggroRadius {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		w.aggroNPC(w.npcs[id], eff.Owner)
+	}
+}
+
+// aggroNPC points the NPC at the target and, when it is idling or wandering,
+// switches it into its chase state on the next decision. NPCs already
+// fighting or fleeing keep their state and only retarget. NPCs held by another
+// player's taunt are left alone.
+func (w *World) aggroNPC(npc *npcState, targetID string) {
+	if w.tauntedByOther(npc, targetID) {
+		return
+	}
+	cfg := w.aiLibrary.ConfigByID(npc.AIConfigID)
+	if cfg == nil {
+		return
+	}
+	chase, ok := cfg.StateForBehavior(ai.BehaviorChase)
+	if !ok {
+		return
+	}
+	npc.Blackboard.TargetActorID = targetID
+	if behavior, ok := cfg.StateBehavior(npc.AIState); ok && behavior != ai.BehaviorIdle && behavior != ai.BehaviorWander {
+		return
+	}
+	npc.AIState = chase
+	npc.Blackboard.StateEnteredTick = w.currentTick
+	npc.Blackboard.NextDecisionAt = w.currentTick
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	effectcontract "mine-and-die/server/effects/contract"
+	worldpkg "mine-and-die/server/internal/world"
+)
+
+// effectDefinition looks up the catalog definition eff was spawned from.
+func (w *World) effectDefinition(eff *effectState) *effectcontract.EffectDefinition {
+	if w == nil || eff == nil || w.effectManager == nil {
+		return nil
+	}
+	definitionID := eff.Instance.DefinitionID
+	if definitionID == "" {
+		definitionID = eff.Type
+	}
+	return w.effectManager.Definitions()[definitionID]
+}
+
+// areaLineOfSightClear reports whether an area effect reaches a target at
+// (x, y). Definitions that set LineOfSight only reach targets with a clear line
+// from the centre of the footprint, traced over the navigation grid the same
+// way beams are clipped. Other definitions reach everything inside their area.
+func (w *World) areaLineOfSightClear(eff *effectState, x, y float64) bool {
+	def := w.effectDefinition(eff)
+	if def == nil || !def.LineOfSight {
+		return true
+	}
+	centerX := eff.X + eff.Width/2
+	centerY := eff.Y + eff.Height/2
+	dx, dy := w.bounds().Delta(centerX, centerY, x, y)
+	width, height := w.dimensions()
+	_, blocked := worldpkg.TraceLineOfSight(worldpkg.LineOfSightRequest{
+		From:      worldpkg.Vec2{X: centerX, Y: centerY},
+		To:        worldpkg.Vec2{X: centerX + dx, Y: centerY + dy},
+		Width:     width,
+		Height:    height,
+		Wrap:      w.config.Wrap,
+		Obstacles: w.obstacles,
+	})
+	return !blocked
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"math"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+	worldpkg "mine-and-die/server/internal/world"
+)
+
+const (
+	// effectTypeBeam is the channelled ray cast through the "beam" action.
+	// It damages the first actor in line of sight every tick for
+	// beamDuration ticks, or until the caster cancels it.
+	effectTypeBeam = effectcontract.EffectIDBeam
+	beamDuration   = 15
+	beamCooldown   = 6 * time.Second
+
+	// beamDefaultRange is how far a beam reaches when its intent does not
+	// carry a geometry length.
+	beamDefaultRange = 240.0
+	// beamDefaultDamage is the per-tick damage applied when the intent omits
+	// a healthDelta param.
+	beamDefaultDamage = 4.0
+)
+
+// castBeam enqueues a beam from a living caster along its facing. Casts inside
+// beamCooldown of the previous one are ignored.
+func (w *World) castBeam(casterID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeBeam, beamCooldown, now) {
+		return
+	}
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeBeam,
+		TypeID:        effectTypeBeam,
+		Delivery:      effectcontract.DeliveryKindBeam,
+		SourceActorID: casterID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeSegment,
+			Length: quantizeWorldCoord(beamDefaultRange),
+		},
+		DurationTicks: beamDuration,
+		Params:        map[string]int{"healthDelta": -int(beamDefaultDamage)},
+	})
+}
+
+// beamOwner anchors a beam on a living caster, aimed along its facing.
+func (w *World) beamOwner(actorID string) *internaleffects.BeamOwner {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return nil
+	}
+	dirX, dirY := facingToVector(actor.Facing)
+	return &internaleffects.BeamOwner{X: actor.X, Y: actor.Y, DirX: dirX, DirY: dirY}
+}
+
+// traceBeam clips a beam at the first cell the navigation grid marks as
+// blocked, so obstacles stop it the same way they stop pathing.
+func (w *World) traceBeam(fromX, fromY, toX, toY float64) (float64, float64) {
+	width, height := w.dimensions()
+	end, _ := worldpkg.TraceLineOfSight(worldpkg.LineOfSightRequest{
+		From:      worldpkg.Vec2{X: fromX, Y: fromY},
+		To:        worldpkg.Vec2{X: toX, Y: toY},
+		Width:     width,
+		Height:    height,
+		Wrap:      w.config.Wrap,
+		Obstacles: w.obstacles,
+	})
+	return end.X, end.Y
+}
+
+// resolveBeamHit damages the living actor closest to the caster whose body the
+// beam segment crosses. Equidistant actors resolve by ID to stay deterministic.
+func (w *World) resolveBeamHit(eff *effectState, segment internaleffects.BeamSegment, now time.Time) {
+	if w == nil || eff == nil {
+		return
+	}
+	line := FixedSegment{
+		A: FixedVec{X: QuantizeCoord(segment.StartX), Y: QuantizeCoord(segment.StartY)},
+		B: FixedVec{X: QuantizeCoord(segment.EndX), Y: QuantizeCoord(segment.EndY)},
+	}
+	radius := QuantizeCoord(playerHalf)
+
+	var (
+		closestID   string
+		closestDist = math.Inf(1)
+	)
+	consider := func(id string, actor *actorState) {
+		if id == eff.Owner || actor == nil || actor.Health <= 0 {
+			return
+		}
+		body := FixedCircle{Center: FixedVec{X: QuantizeCoord(actor.X), Y: QuantizeCoord(actor.Y)}, Radius: radius}
+		if !SegmentIntersectsCircle(line, body) {
+			return
+		}
+		dist := math.Hypot(actor.X-segment.StartX, actor.Y-segment.StartY)
+		if dist < closestDist || (dist == closestDist && id < closestID) {
+			closestID = id
+			closestDist = dist
+		}
+	}
+	for id, player := range w.players {
+		if player != nil {
+			consider(id, &player.ActorState)
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			consider(id, &npc.ActorState)
+		}
+	}
+	if closestID == "" {
+		return
+	}
+
+	if player, ok := w.players[closestID]; ok {
+		w.invokePlayerHitCallback(eff, player, now)
+		return
+	}
+	if npc, ok := w.npcs[closestID]; ok {
+		w.invokeNPCHitCallback(eff, npc, now)
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	ai "mine-and-die/server/internal/ai"
+	stats "mine-and-die/server/stats"
+)
+
+// npcPhase describes a stage of a multi-phase fight. The NPC enters the phase
+// the first time a hit leaves it below the health fraction, switching its AI
+// to the named state and gaining the stat bonus for the rest of its life.
+type npcPhase struct {
+	Below float64
+	State string
+	Bonus stats.StatDelta
+}
+
+// npcPhases lists the phases of each multi-phase NPC type in order.
+var npcPhases = map[NPCType][]npcPhase{
+	NPCTypeBoss: {
+		{Below: 0.5, State: "Barrage", Bonus: bossPhaseTwoBonus()},
+	},
+}
+
+// bossPhaseTwoBonus hardens the enraged boss against physical damage.
+func bossPhaseTwoBonus() stats.StatDelta {
+	delta := stats.NewStatDelta()
+	delta.Add[stats.StatResistPhysical] = 25
+	return delta
+}
+
+// spawnBossAt adds a boss NPC guarding the given position.
+func (w *World) spawnBossAt(x, y float64) *npcState {
+	w.nextNPCID++
+	id := fmt.Sprintf("npc-boss-%d", w.nextNPCID)
+	statsComp := stats.DefaultComponent(stats.ArchetypeBoss)
+	w.applyNPCDifficulty(&statsComp)
+	maxHealth := statsComp.GetDerived(stats.DerivedMaxHealth)
+
+	boss := &npcState{
+		ActorState: actorState{
+			Actor: Actor{
+				ID:        id,
+				X:         x,
+				Y:         y,
+				Facing:    defaultFacing,
+				Health:    maxHealth,
+				MaxHealth: maxHealth,
+				Inventory: NewInventory(),
+				Equipment: NewEquipment(),
+			},
+		},
+		Stats:            statsComp,
+		Type:             NPCTypeBoss,
+		ExperienceReward: 200,
+		Home:             vec2{X: x, Y: y},
+		Cooldowns:        make(map[string]time.Time),
+	}
+	ai.BootstrapNPC(ai.SpawnBootstrapConfig{
+		Library:    w.aiLibrary,
+		Type:       string(NPCTypeBoss),
+		ConfigID:   &boss.AIConfigID,
+		State:      &boss.AIState,
+		Blackboard: &boss.Blackboard,
+	})
+
+	resolveObstaclePenetration(&boss.ActorState, w.obstacles, w.bounds())
+	boss.Blackboard.LastPos = vec2{X: boss.X, Y: boss.Y}
+	w.npcs[boss.ID] = boss
+	return boss
+}
+
+// advanceNPCPhase moves a multi-phase NPC into every phase whose threshold the
+// latest hit crossed. Phases only advance, so healing never reverts them.
+func (w *World) advanceNPCPhase(npc *npcState) {
+	phases := npcPhases[npc.Type]
+	if len(phases) == 0 || npc.Health <= 0 || npc.MaxHealth <= 0 {
+		return
+	}
+	fraction := npc.Health / npc.MaxHealth
+	for int(npc.Phase) < len(phases) && fraction < phases[npc.Phase].Below {
+		w.enterNPCPhase(npc, phases[npc.Phase])
+		npc.Phase++
+	}
+}
+
+func (w *World) enterNPCPhase(npc *npcState, phase npcPhase) {
+	npc.Stats.Apply(stats.CommandStatChange{
+		Layer:  stats.LayerPermanent,
+		Source: stats.SourceKey{Kind: stats.SourceKindProgression, ID: fmt.Sprintf("phase-%d", npc.Phase+1)},
+		Delta:  phase.Bonus,
+	})
+	npc.Stats.Resolve(w.currentTick)
+
+	cfg := w.aiLibrary.ConfigByID(npc.AIConfigID)
+	state, ok := cfg.StateIndex(phase.State)
+	if !ok {
+		return
+	}
+	npc.AIState = state
+	npc.Blackboard.StateEnteredTick = w.currentTick
+	npc.Blackboard.NextDecisionAt = w.currentTick
+}
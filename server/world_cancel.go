@@ -0,0 +1,16 @@
+package server
+
+// actionCancel aborts the caster's in-flight effects through the
+// "cancelAction" action.
+const actionCancel = "cancelAction"
+
+// cancelActorEffects ends every live effect the actor owns whose end policy
+// opts into explicit cancellation, such as a held beam. The effects end with
+// the cancelled reason on this tick's effect pass, before they tick again.
+// No ability currently spends a resource pool, so there is nothing to refund.
+func (w *World) cancelActorEffects(actorID string) {
+	if w == nil || w.effectManager == nil || actorID == "" {
+		return
+	}
+	w.effectManager.CancelOwned(actorID)
+}
@@ -0,0 +1,57 @@
+package server
+
+import "math"
+
+// markCombat flags the actors involved in a damaging hit as in combat and
+// stamps the current tick so their combat timeout and regen window start
+// over. The target also remembers who hurt it; self-inflicted and ownerless
+// damage leave its previous attacker in place.
+func (w *World) markCombat(ownerID, targetID string, delta float64) {
+	if w == nil || delta >= 0 {
+		return
+	}
+	if target := w.actorByID(targetID); target != nil {
+		target.InCombat = true
+		target.LastCombatTick = w.currentTick
+		if ownerID != "" && ownerID != targetID {
+			target.LastDamagedBy = ownerID
+		}
+	}
+	if ownerID == targetID {
+		return
+	}
+	if owner := w.actorByID(ownerID); owner != nil {
+		owner.InCombat = true
+		owner.LastCombatTick = w.currentTick
+	}
+}
+
+// expireCombat clears the in-combat flag and last damager of actors whose
+// last damaging hit is older than the world's combat timeout.
+func (w *World) expireCombat() {
+	if w == nil {
+		return
+	}
+	window := uint64(math.Ceil(w.config.CombatTimeout() * float64(w.ticksPerSecond())))
+	for _, player := range w.players {
+		if player != nil && w.combatExpired(&player.ActorState, window) {
+			player.Version++
+		}
+	}
+	for _, npc := range w.npcs {
+		if npc != nil && w.combatExpired(&npc.ActorState, window) {
+			npc.Version++
+		}
+	}
+}
+
+// combatExpired drops the actor out of combat once window ticks have passed
+// since its last damaging hit. It reports whether the actor changed.
+func (w *World) combatExpired(actor *actorState, window uint64) bool {
+	if !actor.InCombat || w.currentTick < actor.LastCombatTick+window {
+		return false
+	}
+	actor.InCombat = false
+	actor.LastDamagedBy = ""
+	return true
+}
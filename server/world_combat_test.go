@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+func TestTakingDamageFlagsCombatUntilTimeout(t *testing.T) {
+	// The timeout is measured at the world's own tick rate.
+	for _, ticksPerSecond := range []int{tickRate, tickRate * 2} {
+		t.Run(fmt.Sprintf("%dHz", ticksPerSecond), func(t *testing.T) {
+			testCombatTimeoutAtTickRate(t, ticksPerSecond)
+		})
+	}
+}
+
+func testCombatTimeoutAtTickRate(t *testing.T, ticksPerSecond int) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.CombatTimeoutSeconds = 1
+	w := newTestWorld(cfg, logging.NopPublisher{})
+	w.obstacles = nil
+	w.tickRate = ticksPerSecond
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(ticksPerSecond)
+	target := newTestPlayerState("target")
+	target.X = 200
+	target.Y = 200
+	target.LastHeartbeat = now
+	w.AddPlayer(target)
+
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200 - playerHalf - meleeAttackReach/2
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	w.AddPlayer(attacker)
+
+	tick := uint64(0)
+	step := func(commands ...Command) {
+		tick++
+		now = now.Add(time.Duration(dt * float64(time.Second)))
+		w.Step(tick, now, dt, commands, nil)
+	}
+	snapshotActor := func(id string) Actor {
+		players, _ := w.Snapshot(now)
+		player := findPlayer(players, id)
+		if player == nil {
+			t.Fatalf("expected %s in the snapshot", id)
+		}
+		return player.Actor
+	}
+
+	step()
+	if actor := snapshotActor(target.ID); actor.InCombat || actor.LastDamagedBy != "" {
+		t.Fatalf("expected a fresh player out of combat, got inCombat=%v lastDamagedBy=%q", actor.InCombat, actor.LastDamagedBy)
+	}
+
+	step(Command{
+		ActorID:  attacker.ID,
+		Type:     CommandAction,
+		IssuedAt: now,
+		Action:   &ActionCommand{Name: effectTypeAttack},
+	})
+	if got := w.players[target.ID].Health; got >= baselinePlayerMaxHealth {
+		t.Fatalf("expected the swing to damage the target, health %.2f", got)
+	}
+	hitTick := tick
+	if actor := snapshotActor(target.ID); !actor.InCombat || actor.LastDamagedBy != attacker.ID {
+		t.Fatalf("expected the target in combat with %s, got inCombat=%v lastDamagedBy=%q", attacker.ID, actor.InCombat, actor.LastDamagedBy)
+	}
+	if actor := snapshotActor(attacker.ID); !actor.InCombat || actor.LastDamagedBy != "" {
+		t.Fatalf("expected the attacker in combat without a damager, got inCombat=%v lastDamagedBy=%q", actor.InCombat, actor.LastDamagedBy)
+	}
+
+	for tick < hitTick+uint64(ticksPerSecond-1) {
+		step()
+	}
+	if actor := snapshotActor(target.ID); !actor.InCombat {
+		t.Fatalf("expected the target to stay in combat inside the timeout")
+	}
+	step()
+	for _, id := range []string{target.ID, attacker.ID} {
+		if actor := snapshotActor(id); actor.InCombat || actor.LastDamagedBy != "" {
+			t.Fatalf("expected %s out of combat after the timeout, got inCombat=%v lastDamagedBy=%q", id, actor.InCombat, actor.LastDamagedBy)
+		}
+	}
+}
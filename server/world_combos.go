@@ -0,0 +1,45 @@
+package server
+
+import (
+	"time"
+
+	combat "mine-and-die/server/internal/combat"
+)
+
+// MeleeComboConfig describes how consecutive melee swings escalate.
+type MeleeComboConfig = combat.MeleeComboConfig
+
+// SetMeleeCombo enables melee combo chains. A zero config disables them and
+// forgets any chains in progress.
+func (w *World) SetMeleeCombo(cfg MeleeComboConfig) {
+	if w == nil {
+		return
+	}
+	w.meleeCombo = cfg
+	if !cfg.Enabled() {
+		w.meleeCombos = nil
+	}
+}
+
+// meleeComboState returns the actor's combo chain, creating it on first use.
+func (w *World) meleeComboState(actorID string) *combat.MeleeComboState {
+	if w.meleeCombos == nil {
+		w.meleeCombos = make(map[string]*combat.MeleeComboState)
+	}
+	state, ok := w.meleeCombos[actorID]
+	if !ok {
+		state = &combat.MeleeComboState{}
+		w.meleeCombos[actorID] = state
+	}
+	return state
+}
+
+// decayMeleeCombos drops chains whose window has lapsed so the next swing
+// starts from the base hit and departed actors do not linger.
+func (w *World) decayMeleeCombos(now time.Time) {
+	for id, state := range w.meleeCombos {
+		if state.Expired(w.meleeCombo, now) {
+			delete(w.meleeCombos, id)
+		}
+	}
+}
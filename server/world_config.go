@@ -3,3 +3,15 @@ package server
 import worldpkg "mine-and-die/server/internal/world"
 
 type worldConfig = worldpkg.Config
+
+// NPCSpawnWeight assigns a relative share of NPCCount to an NPC type.
+type NPCSpawnWeight = worldpkg.NPCSpawnWeight
+
+// PatrolRoute pins a seeded goblin to a fixed waypoint loop.
+type PatrolRoute = worldpkg.PatrolRoute
+
+// PatrolPoint is one stop on a PatrolRoute.
+type PatrolPoint = worldpkg.PatrolPoint
+
+// WaveConfig respawns defeated NPCs in waves.
+type WaveConfig = worldpkg.WaveConfig
@@ -1,6 +1,12 @@
 package server
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	worldpkg "mine-and-die/server/internal/world"
+	logging "mine-and-die/server/logging"
+)
 
 func TestWorldConfigNormalizedPreservesAggregateNPCCount(t *testing.T) {
 	cfg := worldConfig{
@@ -31,3 +37,65 @@ func TestWorldConfigNormalizedTotalsSpeciesCountsWhenProvided(t *testing.T) {
 		t.Fatalf("expected NPCCount to match species sum (7), got %d", normalized.NPCCount)
 	}
 }
+
+func TestWorldConfigNormalizedClampsDimensions(t *testing.T) {
+	normalized := worldConfig{Width: 100000, Height: 1}.Normalized()
+
+	if normalized.Width != worldpkg.MaxDimension {
+		t.Fatalf("expected width clamped to %g, got %g", worldpkg.MaxDimension, normalized.Width)
+	}
+	if normalized.Height != worldpkg.MinDimension {
+		t.Fatalf("expected height clamped to %g, got %g", worldpkg.MinDimension, normalized.Height)
+	}
+}
+
+func TestWorldConfigNPCWeightsProduceReproducibleProportions(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.GoblinCount = 0
+	cfg.RatCount = 0
+	cfg.NPCCount = 11
+	cfg.Seed = "weighted-population"
+	weights := []NPCSpawnWeight{
+		{Type: NPCTypeGoblin, Weight: 1},
+		{Type: NPCTypeRat, Weight: 3},
+	}
+
+	weighted, err := cfg.WithNPCWeights(weights)
+	if err != nil {
+		t.Fatalf("unexpected error applying weights: %v", err)
+	}
+	if weighted.GoblinCount+weighted.RatCount != 11 {
+		t.Fatalf("expected weighted counts to total 11, got %d goblins and %d rats", weighted.GoblinCount, weighted.RatCount)
+	}
+	if weighted.GoblinCount < 2 || weighted.GoblinCount > 3 {
+		t.Fatalf("expected 2-3 goblins for a 1:3 split of 11, got %d", weighted.GoblinCount)
+	}
+
+	again, err := cfg.WithNPCWeights(weights)
+	if err != nil {
+		t.Fatalf("unexpected error re-applying weights: %v", err)
+	}
+	if !reflect.DeepEqual(again, weighted) {
+		t.Fatalf("expected identical configs for identical seed and weights, got %+v and %+v", weighted, again)
+	}
+
+	countTypes := func() map[NPCType]int {
+		w := newTestWorld(weighted, logging.NopPublisher{})
+		counts := make(map[NPCType]int)
+		for _, npc := range w.npcs {
+			counts[npc.Type]++
+		}
+		return counts
+	}
+	first := countTypes()
+	if first[NPCTypeGoblin] != weighted.GoblinCount || first[NPCTypeRat] != weighted.RatCount {
+		t.Fatalf("expected population %d goblins/%d rats, got %v", weighted.GoblinCount, weighted.RatCount, first)
+	}
+	if second := countTypes(); second[NPCTypeGoblin] != first[NPCTypeGoblin] || second[NPCTypeRat] != first[NPCTypeRat] {
+		t.Fatalf("expected reproducible population, got %v and %v", first, second)
+	}
+
+	if _, err := cfg.WithNPCWeights([]NPCSpawnWeight{{Type: "dragon", Weight: 1}}); err == nil {
+		t.Fatalf("expected unknown npc type to be rejected")
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	itemspkg "mine-and-die/server/internal/items"
+	"mine-and-die/server/internal/sim"
+)
+
+const (
+	// corpseReach is how far from the player's body a corpse can still be
+	// looted.
+	corpseReach = tileSize / 2
+	// corpseLifetime is how long a corpse lasts before whatever is left in it
+	// spills onto the ground.
+	corpseLifetime = 2 * time.Minute
+)
+
+var (
+	errCorpseUnknownActor = errors.New("unknown_actor")
+	errCorpseNotNearby    = errors.New("no_corpse_nearby")
+	errCorpseInvalidSlot  = errors.New("invalid_corpse_slot")
+	errCorpseEmptySlot    = errors.New("empty_slot")
+	errCorpseLootLocked   = errors.New("loot_locked")
+)
+
+// corpseState is a loot bag left where an NPC died while the world has loot
+// bags enabled. It holds everything the NPC carried and equipped, and is
+// removed once it has been looted empty or spills its contents at
+// ExpiresAtTick. Like death drops, it is reserved for the player credited
+// with the kill until OwnerUntilTick.
+type corpseState struct {
+	ID             string    `json:"id"`
+	NPCType        NPCType   `json:"npcType"`
+	X              float64   `json:"x"`
+	Y              float64   `json:"y"`
+	Inventory      Inventory `json:"inventory"`
+	OwnerID        string    `json:"ownerId,omitempty"`
+	OwnerUntilTick uint64    `json:"ownerUntilTick,omitempty"`
+	ExpiresAtTick  uint64    `json:"expiresAtTick,omitempty"`
+}
+
+// lockedFor reports whether the corpse is reserved for someone other than
+// actorID at tick.
+func (c *corpseState) lockedFor(actorID string, tick uint64) bool {
+	if c.OwnerID == "" || c.OwnerID == actorID {
+		return false
+	}
+	return tick < c.OwnerUntilTick
+}
+
+// spawnCorpse moves the defeated NPC's inventory and equipment into a single
+// corpse at its position. NPCs that carry nothing leave no corpse.
+func (w *World) spawnCorpse(npc *npcState) *corpseState {
+	if w == nil || npc == nil {
+		return nil
+	}
+	var stacks []ItemStack
+	_ = w.MutateNPCInventory(npc.ID, func(inv *Inventory) error {
+		stacks = inv.DrainAll()
+		return nil
+	})
+	stacks = append(stacks, w.drainEquipment(&npc.ActorState, &npc.Version, npc.ID, PatchNPCEquipment, PatchNPCHealth, &npc.Stats)...)
+
+	contents := NewInventory()
+	for _, stack := range stacks {
+		if stack.Type == "" || stack.Quantity <= 0 {
+			continue
+		}
+		if _, err := contents.AddStack(stack); err != nil {
+			w.upsertGroundItem(&npc.ActorState, stack, "death")
+		}
+	}
+	if len(contents.Slots) == 0 {
+		return nil
+	}
+
+	w.nextCorpseID++
+	corpse := &corpseState{
+		ID:            fmt.Sprintf("corpse-%d", w.nextCorpseID),
+		NPCType:       npc.Type,
+		X:             npc.X,
+		Y:             npc.Y,
+		Inventory:     contents,
+		ExpiresAtTick: w.currentTick + uint64(corpseLifetime.Seconds()*float64(w.ticksPerSecond())),
+	}
+	if credit, ok := w.killCredit(npc.ID); ok {
+		if _, isPlayer := w.players[credit.KillerID]; isPlayer {
+			corpse.OwnerID = credit.KillerID
+			corpse.OwnerUntilTick = w.currentTick + w.lootLockWindowTicks()
+		}
+	}
+	if w.corpses == nil {
+		w.corpses = make(map[string]*corpseState)
+	}
+	w.corpses[corpse.ID] = corpse
+	w.appendPatch(PatchCorpseAdded, corpse.ID, simCorpseFromLegacy(*corpse))
+	return corpse
+}
+
+// removeCorpse forgets the corpse and tells clients it is gone.
+func (w *World) removeCorpse(id string) {
+	delete(w.corpses, id)
+	w.purgeEntityPatches(id)
+	w.appendPatch(PatchCorpseRemoved, id, nil)
+}
+
+// expireCorpses spills every corpse past its lifetime onto the ground where
+// it lay, in ID order so the scatter draws stay deterministic.
+func (w *World) expireCorpses() {
+	if w == nil || len(w.corpses) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(w.corpses))
+	for id, corpse := range w.corpses {
+		if corpse.ExpiresAtTick > 0 && w.currentTick >= corpse.ExpiresAtTick {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		corpse := w.corpses[id]
+		spill := &actorState{Actor: Actor{ID: corpse.ID, X: corpse.X, Y: corpse.Y}}
+		for _, stack := range corpse.Inventory.DrainAll() {
+			w.upsertGroundItem(spill, stack, "corpse_expired")
+		}
+		w.removeCorpse(id)
+	}
+}
+
+// CorpsesSnapshot returns copies of the corpses in the world, ordered by ID.
+func (w *World) CorpsesSnapshot() []corpseState {
+	if w == nil || len(w.corpses) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(w.corpses))
+	for id := range w.corpses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	corpses := make([]corpseState, 0, len(ids))
+	for _, id := range ids {
+		corpse := *w.corpses[id]
+		corpse.Inventory = corpse.Inventory.Clone()
+		corpses = append(corpses, corpse)
+	}
+	return corpses
+}
+
+// corpseInReach reports whether the corpse lies within looting reach of the
+// player.
+func (w *World) corpseInReach(player *playerState, corpse *corpseState) bool {
+	dx, dy := w.bounds().Delta(player.X, player.Y, corpse.X, corpse.Y)
+	return math.Hypot(dx, dy) <= playerHalf+corpseReach
+}
+
+// nearestCorpse returns the closest corpse within looting reach of the
+// player. Ties go to the lower corpse ID so the choice is deterministic.
+func (w *World) nearestCorpse(player *playerState) *corpseState {
+	var (
+		nearest *corpseState
+		best    float64
+	)
+	bounds := w.bounds()
+	for _, corpse := range w.corpses {
+		if !w.corpseInReach(player, corpse) {
+			continue
+		}
+		dx, dy := bounds.Delta(player.X, player.Y, corpse.X, corpse.Y)
+		distance := math.Hypot(dx, dy)
+		if nearest == nil || distance < best || (distance == best && corpse.ID < nearest.ID) {
+			nearest, best = corpse, distance
+		}
+	}
+	return nearest
+}
+
+// LootCorpse moves the full stack in corpseSlot of the named corpse, or of
+// the nearest one when corpseID is empty, into the player's inventory and
+// returns it with the corpse's ID. A corpse looted empty is removed.
+func (w *World) LootCorpse(playerID, corpseID string, corpseSlot int) (ItemStack, string, error) {
+	if w == nil {
+		return ItemStack{}, "", fmt.Errorf("world not initialised")
+	}
+	player, ok := w.players[playerID]
+	if !ok {
+		return ItemStack{}, "", errCorpseUnknownActor
+	}
+	var corpse *corpseState
+	if corpseID == "" {
+		corpse = w.nearestCorpse(player)
+	} else if named, ok := w.corpses[corpseID]; ok && w.corpseInReach(player, named) {
+		corpse = named
+	}
+	if corpse == nil {
+		return ItemStack{}, corpseID, errCorpseNotNearby
+	}
+	if corpse.lockedFor(playerID, w.currentTick) {
+		return ItemStack{}, corpse.ID, errCorpseLootLocked
+	}
+	if corpseSlot < 0 || corpseSlot >= len(corpse.Inventory.Slots) {
+		return ItemStack{}, corpse.ID, errCorpseInvalidSlot
+	}
+	slot := corpse.Inventory.Slots[corpseSlot]
+	if slot.Item.Quantity <= 0 || slot.Item.Type == "" {
+		return ItemStack{}, corpse.ID, errCorpseEmptySlot
+	}
+
+	removed, err := corpse.Inventory.RemoveQuantity(corpseSlot, slot.Item.Quantity)
+	if err != nil {
+		return ItemStack{}, corpse.ID, err
+	}
+	if err := w.mutateActorInventory(&player.ActorState, &player.Version, playerID, PatchPlayerInventory, func(inv *Inventory) error {
+		_, addErr := inv.AddStack(removed)
+		return addErr
+	}); err != nil {
+		_, _ = corpse.Inventory.AddStack(removed)
+		return ItemStack{}, corpse.ID, err
+	}
+	if len(corpse.Inventory.Slots) == 0 {
+		w.removeCorpse(corpse.ID)
+	} else {
+		w.appendPatch(PatchCorpseContents, corpse.ID, sim.CorpseContentsPayload{Slots: itemspkg.SimInventorySlotsFromAny(corpse.Inventory.Clone().Slots)})
+	}
+	return removed, corpse.ID, nil
+}
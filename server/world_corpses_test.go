@@ -0,0 +1,183 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"mine-and-die/server/internal/sim"
+)
+
+// corpseStateMessage drains pending patches into a full state message.
+func corpseStateMessage(t *testing.T, hub *Hub) stateMessage {
+	t.Helper()
+	data, _, err := hub.marshalState(nil, nil, nil, nil, true, true)
+	if err != nil {
+		t.Fatalf("marshalState returned error: %v", err)
+	}
+	var msg stateMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("failed to decode state message: %v", err)
+	}
+	return msg
+}
+
+func hasCorpsePatch(msg stateMessage, kind sim.PatchKind, corpseID string) bool {
+	for _, patch := range msg.Patches {
+		if patch.Kind == kind && patch.EntityID == corpseID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNPCDefeatLeavesLootableCorpse(t *testing.T) {
+	hubCfg := DefaultHubConfig()
+	hubCfg.NPCLootTables = map[NPCType]LootTable{NPCTypeGoblin: {
+		{Type: string(ItemTypeGold), MinQuantity: 7, MaxQuantity: 7, Chance: 1},
+		{Type: string(ItemTypeHealthPotion), MinQuantity: 2, MaxQuantity: 2, Chance: 1},
+	}}
+	hub := NewHubWithConfig(hubCfg)
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.LootBags = true
+	hub.ResetWorld(cfg)
+
+	hub.world.spawnGoblinAt(400, 400, nil)
+	var goblin *npcState
+	for _, npc := range hub.world.npcs {
+		if npc.Type == NPCTypeGoblin && npc.X == 400 && npc.Y == 400 {
+			goblin = npc
+		}
+	}
+	if goblin == nil {
+		t.Fatalf("expected spawned goblin")
+	}
+
+	killer := newTestPlayerState("corpse-killer")
+	hub.world.AddPlayer(killer)
+	hub.world.SetPosition(killer.ID, 400, 400)
+	bystander := newTestPlayerState("corpse-bystander")
+	hub.world.AddPlayer(bystander)
+	hub.world.SetPosition(bystander.ID, 400, 400)
+
+	before := groundTotalsByType(hub.world)
+	corpseStateMessage(t, hub)
+	lethal := &effectState{Type: effectTypeAttack, Owner: killer.ID, Params: map[string]float64{"healthDelta": -(goblin.Health + 5)}}
+	hub.world.invokeNPCHitCallback(lethal, goblin, time.Now())
+	hub.world.pruneDefeatedNPCs()
+
+	if _, ok := hub.world.npcs[goblin.ID]; ok {
+		t.Fatalf("expected goblin to be removed after defeat")
+	}
+	after := groundTotalsByType(hub.world)
+	for itemType, qty := range after {
+		if qty != before[itemType] {
+			t.Fatalf("expected no ground drops with loot bags, %s went from %d to %d", itemType, before[itemType], qty)
+		}
+	}
+	corpses := hub.world.CorpsesSnapshot()
+	if len(corpses) != 1 {
+		t.Fatalf("expected a single corpse, got %d", len(corpses))
+	}
+	corpse := corpses[0]
+	if len(corpse.Inventory.Slots) != 2 || corpse.Inventory.QuantityOf(ItemTypeGold) != 7 || corpse.Inventory.QuantityOf(ItemTypeHealthPotion) != 2 {
+		t.Fatalf("expected the corpse to hold 7 gold and 2 potions, got %+v", corpse.Inventory.Slots)
+	}
+	if corpse.X != 400 || corpse.Y != 400 {
+		t.Fatalf("expected the corpse where the goblin fell, got (%.1f, %.1f)", corpse.X, corpse.Y)
+	}
+
+	msg := corpseStateMessage(t, hub)
+	if len(msg.Corpses) != 1 || msg.Corpses[0].ID != corpse.ID || len(msg.Corpses[0].Inventory.Slots) != 2 {
+		t.Fatalf("expected the snapshot to carry the corpse and its contents, got %+v", msg.Corpses)
+	}
+	if !hasCorpsePatch(msg, sim.PatchCorpseAdded, corpse.ID) {
+		t.Fatalf("expected a corpse_added patch, got %+v", msg.Patches)
+	}
+	if frame, status := hub.lookupKeyframe(msg.Sequence); status == keyframeLookupFound && len(frame.Corpses) != 1 {
+		t.Fatalf("expected the keyframe to carry the corpse, got %+v", frame.Corpses)
+	}
+
+	result := hub.HandleCorpseLoot(bystander.ID, corpse.ID, 0)
+	if result.Status != "error" || result.Reason != "loot_locked" {
+		t.Fatalf("expected the corpse to be reserved for the killer, got %+v", result)
+	}
+
+	result = hub.HandleCorpseLoot(killer.ID, corpse.ID, 0)
+	if result.Status != "ok" || result.CorpseID != corpse.ID || result.Qty == 0 {
+		t.Fatalf("expected the first loot from %s to succeed, got %+v", corpse.ID, result)
+	}
+	if msg = corpseStateMessage(t, hub); !hasCorpsePatch(msg, sim.PatchCorpseContents, corpse.ID) {
+		t.Fatalf("expected a corpse_contents patch after looting, got %+v", msg.Patches)
+	}
+	result = hub.HandleCorpseLoot(killer.ID, "", 0)
+	if result.Status != "ok" || result.CorpseID != corpse.ID {
+		t.Fatalf("expected looting the nearest corpse to succeed, got %+v", result)
+	}
+	if qty := killer.Inventory.QuantityOf(ItemTypeGold); qty != 7 {
+		t.Fatalf("expected the looter to receive 7 gold, have %d", qty)
+	}
+	if qty := killer.Inventory.QuantityOf(ItemTypeHealthPotion); qty != 2 {
+		t.Fatalf("expected the looter to receive 2 potions, have %d", qty)
+	}
+	if remaining := hub.world.CorpsesSnapshot(); len(remaining) != 0 {
+		t.Fatalf("expected the emptied corpse to be removed, got %+v", remaining)
+	}
+	if msg = corpseStateMessage(t, hub); !hasCorpsePatch(msg, sim.PatchCorpseRemoved, corpse.ID) || len(msg.Corpses) != 0 {
+		t.Fatalf("expected a corpse_removed patch and no corpses, got %+v / %+v", msg.Patches, msg.Corpses)
+	}
+
+	result = hub.HandleCorpseLoot(killer.ID, corpse.ID, 0)
+	if result.Status != "error" || result.Reason != "no_corpse_nearby" {
+		t.Fatalf("expected no corpse left to loot, got %+v", result)
+	}
+}
+
+func TestExpiredCorpseSpillsContentsToGround(t *testing.T) {
+	hub := newHub()
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.LootBags = true
+	hub.ResetWorld(cfg)
+
+	hub.world.spawnGoblinAt(400, 400, nil)
+	var goblin *npcState
+	for _, npc := range hub.world.npcs {
+		if npc.Type == NPCTypeGoblin && npc.X == 400 && npc.Y == 400 {
+			goblin = npc
+		}
+	}
+	if goblin == nil {
+		t.Fatalf("expected spawned goblin")
+	}
+	if err := hub.world.MutateNPCInventory(goblin.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed goblin inventory: %v", err)
+	}
+	corpse := hub.world.spawnCorpse(goblin)
+	if corpse == nil || corpse.ExpiresAtTick <= hub.world.currentTick {
+		t.Fatalf("expected a corpse with a lifetime, got %+v", corpse)
+	}
+	held := corpse.Inventory.QuantityOf(ItemTypeGold)
+	before := groundTotalsByType(hub.world)[ItemTypeGold]
+
+	hub.world.currentTick = corpse.ExpiresAtTick - 1
+	hub.world.expireCorpses()
+	if len(hub.world.CorpsesSnapshot()) != 1 {
+		t.Fatalf("expected the corpse to last until its expiry tick")
+	}
+	corpseStateMessage(t, hub)
+
+	hub.world.currentTick = corpse.ExpiresAtTick
+	hub.world.expireCorpses()
+	if remaining := hub.world.CorpsesSnapshot(); len(remaining) != 0 {
+		t.Fatalf("expected the expired corpse to be removed, got %+v", remaining)
+	}
+	if got := groundTotalsByType(hub.world)[ItemTypeGold]; got != before+held {
+		t.Fatalf("expected %d gold spilled to the ground, have %d on top of %d", held, got-before, before)
+	}
+	if msg := corpseStateMessage(t, hub); !hasCorpsePatch(msg, sim.PatchCorpseRemoved, corpse.ID) {
+		t.Fatalf("expected a corpse_removed patch on expiry, got %+v", msg.Patches)
+	}
+}
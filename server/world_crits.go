@@ -0,0 +1,52 @@
+package server
+
+import stats "mine-and-die/server/stats"
+
+// effectParamCritical flags a hit effect that landed as a critical so clients
+// can render a dedicated visual.
+const effectParamCritical = "crit"
+
+// statsFor returns the stat component of the player or NPC with the given ID.
+func (w *World) statsFor(id string) *stats.Component {
+	if w == nil || id == "" {
+		return nil
+	}
+	if player, ok := w.players[id]; ok && player != nil {
+		return &player.Stats
+	}
+	if npc, ok := w.npcs[id]; ok && npc != nil {
+		return &npc.Stats
+	}
+	return nil
+}
+
+// rollCritical decides whether a damaging hit from eff lands as a critical
+// using the owner's derived crit chance. The world RNG is only consulted when
+// the outcome is uncertain so actors without precision never advance it.
+func (w *World) rollCritical(eff *effectState, targetID string) (float64, bool) {
+	if w == nil || eff == nil || targetID == "" {
+		return 1, false
+	}
+
+	critical := false
+	multiplier := 1.0
+	if comp := w.statsFor(eff.Owner); comp != nil {
+		chance := comp.GetDerived(stats.DerivedCritChance)
+		switch {
+		case chance >= 1:
+			critical = true
+		case chance > 0:
+			critical = w.randomFloat() < chance
+		}
+		if critical {
+			multiplier = comp.GetDerived(stats.DerivedCritMultiplier)
+		}
+	}
+
+	if critical {
+		w.SetEffectParam(eff, effectParamCritical, 1)
+	} else if eff.Params[effectParamCritical] > 0 {
+		w.SetEffectParam(eff, effectParamCritical, 0)
+	}
+	return multiplier, critical
+}
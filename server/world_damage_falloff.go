@@ -0,0 +1,56 @@
+package server
+
+import (
+	"math"
+
+	effectcontract "mine-and-die/server/effects/contract"
+)
+
+// effectDamageFalloff reports the falloff curve declared by the effect's
+// definition.
+func (w *World) effectDamageFalloff(eff *effectState) effectcontract.DamageFalloff {
+	def := w.effectDefinition(eff)
+	if def == nil {
+		return ""
+	}
+	return def.Falloff
+}
+
+// damageFalloffMultiplier returns how much of eff's damage reaches the target
+// given its distance from the centre of the effect's footprint. The radius is
+// the effect's "radius" param, falling back to half the footprint's wider
+// side. Effects without a falloff curve, and targets that have left, take the
+// full damage.
+func (w *World) damageFalloffMultiplier(eff *effectState, targetID string) float64 {
+	falloff := w.effectDamageFalloff(eff)
+	if falloff == "" {
+		return 1
+	}
+	target := w.actorByID(targetID)
+	if target == nil {
+		return 1
+	}
+	radius := eff.Params["radius"]
+	if radius <= 0 {
+		radius = math.Max(eff.Width, eff.Height) / 2
+	}
+	if radius <= 0 {
+		return 1
+	}
+	dx, dy := w.bounds().Delta(eff.X+eff.Width/2, eff.Y+eff.Height/2, target.X, target.Y)
+	return damageFalloffScale(falloff, math.Hypot(dx, dy)/radius)
+}
+
+// damageFalloffScale evaluates a falloff curve at the given fraction of the
+// radius, clamped to the area: 1 at the centre down to 0 at the edge.
+func damageFalloffScale(falloff effectcontract.DamageFalloff, fraction float64) float64 {
+	remaining := 1 - math.Min(math.Max(fraction, 0), 1)
+	switch falloff {
+	case effectcontract.DamageFalloffLinear:
+		return remaining
+	case effectcontract.DamageFalloffQuadratic:
+		return remaining * remaining
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,23 @@
+package server
+
+import stats "mine-and-die/server/stats"
+
+// applyNPCDifficulty scales a freshly spawned wild NPC's stats by the world
+// difficulty. Might is multiplied so max health grows in proportion, and the
+// damage bonus is raised by the same factor. Baseline worlds are untouched.
+func (w *World) applyNPCDifficulty(comp *stats.Component) {
+	scale := w.config.DifficultyScale()
+	if comp == nil || scale == 1 {
+		return
+	}
+	delta := stats.NewStatDelta()
+	delta.Mul[stats.StatMight] = scale
+	// The damage bonus is expressed in percent on top of the baseline.
+	delta.Add[stats.StatDamageBonus] = (scale - 1) * 100
+	comp.Apply(stats.CommandStatChange{
+		Layer:  stats.LayerEnvironment,
+		Source: stats.SourceKey{Kind: stats.SourceKindEnvironment, ID: "difficulty"},
+		Delta:  delta,
+	})
+	comp.Resolve(w.currentTick)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"mine-and-die/server/logging"
+	stats "mine-and-die/server/stats"
+)
+
+// spawnDifficultyNPCs spawns a goblin and a rat into a world at the given
+// difficulty and returns them.
+func spawnDifficultyNPCs(t *testing.T, difficulty float64) (*npcState, *npcState) {
+	t.Helper()
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Difficulty = difficulty
+	w := newTestWorld(cfg, logging.NopPublisher{})
+	w.obstacles = nil
+
+	w.spawnGoblinAt(200, 200, nil)
+	goblin := w.npcs[fmt.Sprintf("npc-goblin-%d", w.nextNPCID)]
+	w.spawnRatAt(300, 200)
+	rat := w.npcs[fmt.Sprintf("npc-rat-%d", w.nextNPCID)]
+	if goblin == nil || rat == nil {
+		t.Fatalf("expected spawned goblin and rat")
+	}
+	return goblin, rat
+}
+
+func TestHigherDifficultySpawnsNPCsWithProportionalMaxHealth(t *testing.T) {
+	baseGoblin, baseRat := spawnDifficultyNPCs(t, 0)
+	hardGoblin, hardRat := spawnDifficultyNPCs(t, 2)
+
+	for _, pair := range []struct {
+		name       string
+		base, hard *npcState
+	}{{"goblin", baseGoblin, hardGoblin}, {"rat", baseRat, hardRat}} {
+		if math.Abs(pair.hard.MaxHealth-2*pair.base.MaxHealth) > 1e-6 {
+			t.Fatalf("expected %s max health %.2f at difficulty 2, got %.2f", pair.name, 2*pair.base.MaxHealth, pair.hard.MaxHealth)
+		}
+		if pair.hard.Health != pair.hard.MaxHealth {
+			t.Fatalf("expected %s to spawn at full health, got %.2f/%.2f", pair.name, pair.hard.Health, pair.hard.MaxHealth)
+		}
+		if got := pair.base.Stats.GetDerived(stats.DerivedDamageDealt); got != 1 {
+			t.Fatalf("expected baseline %s damage multiplier 1, got %.2f", pair.name, got)
+		}
+		if got := pair.hard.Stats.GetDerived(stats.DerivedDamageDealt); math.Abs(got-2) > 1e-6 {
+			t.Fatalf("expected %s damage multiplier 2 at difficulty 2, got %.2f", pair.name, got)
+		}
+	}
+}
@@ -16,9 +16,25 @@ func (w *World) height() float64 {
 	return worldpkg.Height(w.config)
 }
 
+// ticksPerSecond reports the simulation frequency the world was built for.
+func (w *World) ticksPerSecond() int {
+	if w == nil || w.tickRate <= 0 {
+		return tickRate
+	}
+	return w.tickRate
+}
+
 func (w *World) dimensions() (float64, float64) {
 	if w == nil {
 		return worldpkg.DefaultWidth, worldpkg.DefaultHeight
 	}
 	return worldpkg.Dimensions(w.config)
 }
+
+// bounds reports the playable area along with the configured edge behaviour.
+func (w *World) bounds() worldpkg.Bounds {
+	if w == nil {
+		return worldpkg.Bounds{Width: worldpkg.DefaultWidth, Height: worldpkg.DefaultHeight}
+	}
+	return worldpkg.BoundsOf(w.config)
+}
@@ -0,0 +1,416 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	internaleffects "mine-and-die/server/internal/effects"
+	itemspkg "mine-and-die/server/internal/items"
+	"mine-and-die/server/internal/simutil"
+	worldpkg "mine-and-die/server/internal/world"
+	state "mine-and-die/server/internal/world/state"
+	"mine-and-die/server/logging"
+	stats "mine-and-die/server/stats"
+)
+
+// worldDumpVersion identifies the layout written by World.Dump. Loaders
+// reject dumps written with any other version.
+const worldDumpVersion = 1
+
+// worldDump is the JSON form of an authoritative world captured for bug
+// reports. It holds everything needed to rebuild the world between ticks.
+// Status effects and melee combo windows are not captured.
+type worldDump struct {
+	Version             uint64                     `json:"version"`
+	Tick                uint64                     `json:"tick"`
+	Seed                string                     `json:"seed"`
+	RNG                 *worldpkg.RNGState         `json:"rng,omitempty"`
+	Config              worldConfig                `json:"config"`
+	Players             []playerDump               `json:"players"`
+	NPCs                []npcDump                  `json:"npcs"`
+	Obstacles           []Obstacle                 `json:"obstacles"`
+	GroundItems         []groundItemDump           `json:"groundItems"`
+	Stashes             map[string]Inventory       `json:"stashes,omitempty"`
+	Corpses             []corpseState              `json:"corpses,omitempty"`
+	Effects             internaleffects.Checkpoint `json:"effects"`
+	ScheduledTasks      []scheduledTask            `json:"scheduledTasks,omitempty"`
+	AICursor            string                     `json:"aiCursor,omitempty"`
+	NPCWave             int                        `json:"npcWave,omitempty"`
+	NextNPCID           uint64                     `json:"nextNpcId"`
+	NextGroundItemID    uint64                     `json:"nextGroundItemId"`
+	NextCorpseID        uint64                     `json:"nextCorpseId,omitempty"`
+	NextEffectID        uint64                     `json:"nextEffectId"`
+	NextScheduledTaskID uint64                     `json:"nextScheduledTaskId"`
+}
+
+// actorDump carries the state shared by dumped players and NPCs. Stats are
+// stored as the modifier sources that rebuild the component.
+type actorDump struct {
+	Actor
+	IntentX        float64                   `json:"intentX,omitempty"`
+	IntentY        float64                   `json:"intentY,omitempty"`
+	Stats          []stats.CommandStatChange `json:"stats"`
+	Cooldowns      map[string]time.Time      `json:"cooldowns,omitempty"`
+	Version        uint64                    `json:"version"`
+	LastCombatTick uint64                    `json:"lastCombatTick,omitempty"`
+}
+
+type playerDump struct {
+	actorDump
+	LastInput       time.Time       `json:"lastInput"`
+	LastHeartbeat   time.Time       `json:"lastHeartbeat"`
+	LastRTT         time.Duration   `json:"lastRtt"`
+	Path            playerPathState `json:"path"`
+	AbsorbExpiresAt time.Time       `json:"absorbExpiresAt"`
+}
+
+type npcDump struct {
+	actorDump
+	Type             NPCType          `json:"type"`
+	ExperienceReward int              `json:"experienceReward"`
+	AIState          uint8            `json:"aiState"`
+	AIConfigID       uint16           `json:"aiConfigId"`
+	Blackboard       state.Blackboard `json:"blackboard"`
+	Waypoints        []vec2           `json:"waypoints,omitempty"`
+	Home             vec2             `json:"home"`
+	Phase            uint8            `json:"phase,omitempty"`
+	OwnerID          string           `json:"ownerId,omitempty"`
+	DespawnTick      uint64           `json:"despawnTick,omitempty"`
+}
+
+type groundItemDump struct {
+	itemspkg.GroundItem
+	Version        uint64 `json:"version"`
+	OwnerID        string `json:"ownerId,omitempty"`
+	OwnerUntilTick uint64 `json:"ownerUntilTick,omitempty"`
+}
+
+func dumpActor(actor *actorState, comp *stats.Component, cooldowns map[string]time.Time, version uint64) actorDump {
+	return actorDump{
+		Actor:          actor.SnapshotActor(),
+		IntentX:        actor.IntentX,
+		IntentY:        actor.IntentY,
+		Stats:          comp.Sources(),
+		Cooldowns:      cloneCooldowns(cooldowns),
+		Version:        version,
+		LastCombatTick: actor.LastCombatTick,
+	}
+}
+
+func (d actorDump) restore(tick uint64) (actorState, stats.Component, map[string]time.Time) {
+	actor := actorState{Actor: d.Actor, IntentX: d.IntentX, IntentY: d.IntentY, LastCombatTick: d.LastCombatTick}
+	actor.Inventory = d.Inventory.Clone()
+	actor.Equipment = d.Equipment.Clone()
+	return actor, stats.ComponentFromSources(d.Stats, tick), cloneCooldowns(d.Cooldowns)
+}
+
+func cloneCooldowns(cooldowns map[string]time.Time) map[string]time.Time {
+	if cooldowns == nil {
+		return nil
+	}
+	clone := make(map[string]time.Time, len(cooldowns))
+	for key, value := range cooldowns {
+		clone[key] = value
+	}
+	return clone
+}
+
+// Dump serializes the world into a loadable JSON snapshot. Entities are
+// written in ID order so dumps of identical worlds are byte-identical.
+func (w *World) Dump() ([]byte, error) {
+	if w == nil {
+		return nil, fmt.Errorf("world dump: nil world")
+	}
+	dump := worldDump{
+		Version:             worldDumpVersion,
+		Tick:                w.currentTick,
+		Seed:                w.seed,
+		RNG:                 w.rngStateSnapshot(),
+		Config:              w.config,
+		Players:             make([]playerDump, 0, len(w.players)),
+		NPCs:                make([]npcDump, 0, len(w.npcs)),
+		Obstacles:           append([]Obstacle(nil), w.obstacles...),
+		GroundItems:         make([]groundItemDump, 0, len(w.groundItems)),
+		Effects:             w.effectManager.Checkpoint(),
+		ScheduledTasks:      w.scheduledTasksSnapshot(),
+		AICursor:            w.aiCursor,
+		NPCWave:             w.npcWave,
+		NextNPCID:           w.nextNPCID,
+		NextGroundItemID:    w.nextGroundItemID,
+		NextCorpseID:        w.nextCorpseID,
+		NextEffectID:        w.nextEffectID,
+		NextScheduledTaskID: w.nextScheduledTaskID,
+	}
+
+	for _, id := range sortedKeys(w.players) {
+		player := w.players[id]
+		if player == nil {
+			continue
+		}
+		path := player.Path
+		path.Path = append([]vec2(nil), path.Path...)
+		dump.Players = append(dump.Players, playerDump{
+			actorDump:       dumpActor(&player.ActorState, &player.Stats, player.Cooldowns, player.Version),
+			LastInput:       player.LastInput,
+			LastHeartbeat:   player.LastHeartbeat,
+			LastRTT:         player.LastRTT,
+			Path:            path,
+			AbsorbExpiresAt: player.AbsorbExpiresAt,
+		})
+	}
+
+	for _, id := range sortedKeys(w.npcs) {
+		npc := w.npcs[id]
+		if npc == nil {
+			continue
+		}
+		board := npc.Blackboard
+		board.Path = append([]vec2(nil), board.Path...)
+		dump.NPCs = append(dump.NPCs, npcDump{
+			actorDump:        dumpActor(&npc.ActorState, &npc.Stats, npc.Cooldowns, npc.Version),
+			Type:             npc.Type,
+			ExperienceReward: npc.ExperienceReward,
+			AIState:          npc.AIState,
+			AIConfigID:       npc.AIConfigID,
+			Blackboard:       board,
+			Waypoints:        append([]vec2(nil), npc.Waypoints...),
+			Home:             npc.Home,
+			Phase:            npc.Phase,
+			OwnerID:          npc.OwnerID,
+			DespawnTick:      npc.DespawnTick,
+		})
+	}
+
+	for _, id := range sortedKeys(w.groundItems) {
+		item := w.groundItems[id]
+		if item == nil {
+			continue
+		}
+		dump.GroundItems = append(dump.GroundItems, groundItemDump{GroundItem: item.GroundItem, Version: item.Version, OwnerID: item.OwnerID, OwnerUntilTick: item.OwnerUntilTick})
+	}
+
+	if len(w.stashes) > 0 {
+		dump.Stashes = make(map[string]Inventory, len(w.stashes))
+		for id, stash := range w.stashes {
+			if stash != nil {
+				dump.Stashes[id] = stash.Clone()
+			}
+		}
+	}
+	dump.Corpses = w.CorpsesSnapshot()
+
+	return json.Marshal(dump)
+}
+
+// loadWorldDump rebuilds a world from the output of World.Dump. The world is
+// constructed from the dumped config and its generated obstacles and NPCs are
+// then replaced wholesale by the dumped ones.
+func loadWorldDump(data []byte, publisher logging.Publisher, deps worldpkg.Deps) (*World, error) {
+	var dump worldDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("world dump: decode: %w", err)
+	}
+	if dump.Version != worldDumpVersion {
+		return nil, fmt.Errorf("world dump: unsupported version %d", dump.Version)
+	}
+
+	cfg := dump.Config
+	if dump.Seed != "" {
+		cfg.Seed = dump.Seed
+	}
+	w := requireLegacyWorld(worldpkg.ConstructLegacy(cfg, publisher, deps))
+	if w == nil {
+		return nil, fmt.Errorf("world dump: constructor returned no world")
+	}
+	w.currentTick = dump.Tick
+
+	// The constructor's maps are shared with the internal world, so they are
+	// emptied in place rather than replaced.
+	for id := range w.players {
+		delete(w.players, id)
+	}
+	for id := range w.npcs {
+		delete(w.npcs, id)
+	}
+	w.ensureGroundItemStorage()
+	for id := range w.groundItems {
+		delete(w.groundItems, id)
+	}
+	for tile := range w.groundItemsByTile {
+		delete(w.groundItemsByTile, tile)
+	}
+	w.obstacles = append([]Obstacle(nil), dump.Obstacles...)
+
+	for _, entry := range dump.Players {
+		actor, comp, cooldowns := entry.restore(dump.Tick)
+		path := entry.Path
+		path.Path = append([]vec2(nil), path.Path...)
+		w.players[entry.ID] = &playerState{
+			ActorState:      actor,
+			Stats:           comp,
+			LastInput:       entry.LastInput,
+			LastHeartbeat:   entry.LastHeartbeat,
+			LastRTT:         entry.LastRTT,
+			Cooldowns:       cooldowns,
+			Path:            path,
+			Version:         entry.Version,
+			AbsorbExpiresAt: entry.AbsorbExpiresAt,
+		}
+	}
+
+	for _, entry := range dump.NPCs {
+		actor, comp, cooldowns := entry.restore(dump.Tick)
+		board := entry.Blackboard
+		board.Path = append([]vec2(nil), board.Path...)
+		w.npcs[entry.ID] = &npcState{
+			ActorState:       actor,
+			Stats:            comp,
+			Type:             entry.Type,
+			ExperienceReward: entry.ExperienceReward,
+			AIState:          entry.AIState,
+			AIConfigID:       entry.AIConfigID,
+			Blackboard:       board,
+			Waypoints:        append([]vec2(nil), entry.Waypoints...),
+			Home:             entry.Home,
+			Cooldowns:        cooldowns,
+			Version:          entry.Version,
+			Phase:            entry.Phase,
+			OwnerID:          entry.OwnerID,
+			DespawnTick:      entry.DespawnTick,
+		}
+	}
+
+	for _, entry := range dump.GroundItems {
+		item := &itemspkg.GroundItemState{
+			GroundItem:     entry.GroundItem,
+			Tile:           tileForPosition(entry.X, entry.Y),
+			Version:        entry.Version,
+			OwnerID:        entry.OwnerID,
+			OwnerUntilTick: entry.OwnerUntilTick,
+		}
+		w.groundItems[item.ID] = item
+		bucket := w.groundItemsByTile[item.Tile]
+		if bucket == nil {
+			bucket = make(map[string]*itemspkg.GroundItemState)
+			w.groundItemsByTile[item.Tile] = bucket
+		}
+		bucket[item.ID] = item
+	}
+
+	w.stashes = nil
+	for id, stash := range dump.Stashes {
+		if w.stashes == nil {
+			w.stashes = make(map[string]*Inventory, len(dump.Stashes))
+		}
+		clone := stash.Clone()
+		w.stashes[id] = &clone
+	}
+	w.corpses = nil
+	for _, entry := range dump.Corpses {
+		if w.corpses == nil {
+			w.corpses = make(map[string]*corpseState, len(dump.Corpses))
+		}
+		corpse := entry
+		corpse.Inventory = entry.Inventory.Clone()
+		w.corpses[corpse.ID] = &corpse
+	}
+
+	if dump.RNG != nil {
+		w.RestoreRNGState(*dump.RNG)
+	}
+	w.effectManager.RestoreCheckpoint(dump.Effects)
+	w.scheduledTasks = simutil.CloneScheduledTasks(dump.ScheduledTasks)
+	w.nextScheduledTaskID = dump.NextScheduledTaskID
+	w.aiCursor = dump.AICursor
+	w.npcWave = dump.NPCWave
+	w.nextNPCID = dump.NextNPCID
+	w.nextGroundItemID = dump.NextGroundItemID
+	w.nextCorpseID = dump.NextCorpseID
+	w.nextEffectID = dump.NextEffectID
+	if w.internalWorld != nil {
+		w.internalWorld.SetNextEffectID(dump.NextEffectID)
+	}
+	return w, nil
+}
+
+// sortedKeys returns the keys of a string-keyed map in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DumpWorld serializes the hub's authoritative world for a bug report.
+func (h *Hub) DumpWorld() ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.world.Dump()
+}
+
+// LoadWorldDump replaces the hub's world with one rebuilt from DumpWorld
+// output. Connected players missing from the dump are re-seeded so their
+// sessions stay attached, and subscribers receive a fresh keyframe.
+func (h *Hub) LoadWorldDump(data []byte) error {
+	now := h.now()
+
+	if h.engine != nil {
+		h.engine.DrainCommands()
+	}
+
+	h.mu.Lock()
+	newW, err := loadWorldDump(data, h.publisher, worldpkg.Deps{
+		Publisher:         h.publisher,
+		JournalTelemetry:  h.telemetry,
+		TickRate:          h.tickRate,
+		EffectCatalogPath: h.effectCatalogPath,
+	})
+	if err != nil {
+		h.mu.Unlock()
+		return err
+	}
+	newW.attachTelemetry(h.telemetry)
+	newW.AttachJournalTelemetry(h.telemetry)
+	newW.SetNPCLootTables(h.lootTables)
+	newW.SetStaleInventoryPolicy(h.staleInventory)
+	newW.SetDisconnectTimeout(h.disconnectAfter)
+	newW.SetIdleKickTimeout(h.idleKickAfter)
+	for id, timeout := range h.world.disconnectOverrides {
+		newW.SetPlayerDisconnectTimeout(id, timeout)
+	}
+	newW.SetMeleeArc(h.meleeArc)
+	newW.SetMeleeCombo(h.meleeCombo)
+	newW.SetLagCompensation(h.lagCompensation)
+	newW.SetAIDecisionBudget(h.aiBudget)
+	newW.SetTimeScale(h.timeScale)
+	if err := newW.registerEffectHooks(h.effectHooks); err != nil {
+		h.logf("[effects] failed to register effect hooks on loaded world: %v", err)
+	}
+	for id := range h.world.players {
+		if _, ok := newW.players[id]; !ok {
+			newW.AddPlayer(h.seedPlayerState(id, now))
+		}
+	}
+	h.world = newW
+	if h.adapter != nil {
+		h.adapter.SetWorld(newW)
+	}
+	h.config = newW.config
+	h.attachTelemetryMetrics()
+	h.resubscribeBaselines = nil
+	for token, session := range h.reconnectSessions {
+		if session.Player != nil {
+			delete(h.reconnectSessions, token)
+		}
+	}
+	h.mu.Unlock()
+
+	h.tick.Store(newW.currentTick)
+	h.resyncNext.Store(true)
+	h.forceKeyframe()
+	return nil
+}
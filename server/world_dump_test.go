@@ -0,0 +1,73 @@
+package server
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWorldDumpReloadReproducesSnapshots(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.broadcastFanout = nil
+
+	caster := hub.Join()
+	if _, ok, reason := hub.HandleAction(caster.ID, effectTypeFireball); !ok {
+		t.Fatalf("expected fireball action to be accepted, got %q", reason)
+	}
+	if ack, _ := hub.HandleConsoleCommand(caster.ID, "drop_gold", 5); ack.Status != "ok" {
+		t.Fatalf("expected drop_gold to succeed, got %+v", ack)
+	}
+	dt := 1.0 / float64(tickRate)
+	for i := 0; i < 3; i++ {
+		runAdvance(hub, dt)
+	}
+	if len(hub.world.effectManager.Instances()) == 0 {
+		t.Fatalf("expected an effect in flight before dumping")
+	}
+
+	dump, err := hub.DumpWorld()
+	if err != nil {
+		t.Fatalf("failed to dump world: %v", err)
+	}
+
+	restored, _ := newSeededTestHub("unrelated-seed")
+	restored.broadcastFanout = nil
+	if err := restored.LoadWorldDump(dump); err != nil {
+		t.Fatalf("failed to load world dump: %v", err)
+	}
+
+	redump, err := restored.DumpWorld()
+	if err != nil {
+		t.Fatalf("failed to dump restored world: %v", err)
+	}
+	if !bytes.Equal(dump, redump) {
+		t.Fatalf("expected reloaded world to dump identically\noriginal: %s\nrestored: %s", dump, redump)
+	}
+
+	now := hub.now()
+	wantPlayers, wantNPCs := hub.world.Snapshot(now)
+	gotPlayers, gotNPCs := restored.world.Snapshot(now)
+	sort.Slice(wantPlayers, func(i, j int) bool { return wantPlayers[i].ID < wantPlayers[j].ID })
+	sort.Slice(gotPlayers, func(i, j int) bool { return gotPlayers[i].ID < gotPlayers[j].ID })
+	sort.Slice(wantNPCs, func(i, j int) bool { return wantNPCs[i].ID < wantNPCs[j].ID })
+	sort.Slice(gotNPCs, func(i, j int) bool { return gotNPCs[i].ID < gotNPCs[j].ID })
+	if !reflect.DeepEqual(wantPlayers, gotPlayers) {
+		t.Fatalf("player snapshots differ after reload:\nwant %+v\ngot  %+v", wantPlayers, gotPlayers)
+	}
+	if !reflect.DeepEqual(wantNPCs, gotNPCs) {
+		t.Fatalf("NPC snapshots differ after reload:\nwant %+v\ngot  %+v", wantNPCs, gotNPCs)
+	}
+	wantItems, gotItems := hub.world.GroundItemsSnapshot(), restored.world.GroundItemsSnapshot()
+	sort.Slice(wantItems, func(i, j int) bool { return wantItems[i].ID < wantItems[j].ID })
+	sort.Slice(gotItems, func(i, j int) bool { return gotItems[i].ID < gotItems[j].ID })
+	if len(wantItems) == 0 || !reflect.DeepEqual(wantItems, gotItems) {
+		t.Fatalf("ground items differ after reload:\nwant %+v\ngot  %+v", wantItems, gotItems)
+	}
+	if !reflect.DeepEqual(hub.world.effectManager.ActiveSpawnEvents(), restored.world.effectManager.ActiveSpawnEvents()) {
+		t.Fatalf("active effects differ after reload")
+	}
+	if restored.tick.Load() != hub.tick.Load() {
+		t.Fatalf("expected restored hub tick %d, got %d", hub.tick.Load(), restored.tick.Load())
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+type (
+	// EffectHookSet bundles the OnSpawn and OnTick callbacks of a custom
+	// effect behavior.
+	EffectHookSet = internaleffects.HookSet
+	// EffectHookFunc is a single effect behavior callback. It receives the
+	// effect runtime, the live instance, and the current tick and time.
+	EffectHookFunc = internaleffects.HookFunc
+)
+
+// RegisterEffectHook makes hook available to effect definitions under name,
+// so an EffectDefinition whose Hooks.OnSpawn or Hooks.OnTick names it runs
+// the callbacks without changes to the effect manager. Names that are empty
+// or already registered are rejected.
+func (w *World) RegisterEffectHook(name string, hook EffectHookSet) error {
+	if w == nil || w.effectManager == nil {
+		return fmt.Errorf("effect hook %q: world has no effect manager", name)
+	}
+	return w.effectManager.RegisterHook(name, hook)
+}
+
+// registerEffectHooks registers every hook in sorted name order, stopping at
+// the first rejected one.
+func (w *World) registerEffectHooks(hooks map[string]EffectHookSet) error {
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := w.RegisterEffectHook(name, hooks[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterEffectHook registers a custom effect behavior hook on the running
+// world and remembers it so worlds built by later resets and dump loads get
+// it too.
+func (h *Hub) RegisterEffectHook(name string, hook EffectHookSet) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.world.RegisterEffectHook(name, hook); err != nil {
+		return err
+	}
+	if h.effectHooks == nil {
+		h.effectHooks = make(map[string]EffectHookSet)
+	}
+	h.effectHooks[name] = hook
+	return nil
+}
@@ -0,0 +1,44 @@
+package server
+
+import "time"
+
+const (
+	// actionEmote plays a cosmetic animation on the actor through the
+	// "emote" action.
+	actionEmote = "emote"
+
+	// emoteTriggerPrefix prefixes the emote name in the trigger type, so
+	// clients pick the animation from the type alone.
+	emoteTriggerPrefix = "emote."
+	emoteDurationMs    = 1500
+)
+
+// IsEmote reports whether the name is an emote clients know how to play.
+func IsEmote(name string) bool {
+	switch name {
+	case "wave", "cheer", "laugh", "point", "bow":
+		return true
+	default:
+		return false
+	}
+}
+
+// playEmote queues a fire-and-forget trigger anchored on the player. It goes
+// out through the same batch as hit visuals but spawns no contract effect and
+// has no impact, so health, cooldowns, and the journal are untouched.
+func (w *World) playEmote(actorID, emote string, now time.Time) {
+	if w == nil || !IsEmote(emote) {
+		return
+	}
+	player, ok := w.players[actorID]
+	if !ok || player == nil {
+		return
+	}
+	w.QueueEffectTrigger(EffectTrigger{
+		Type:     emoteTriggerPrefix + emote,
+		Duration: emoteDurationMs,
+		X:        player.X,
+		Y:        player.Y,
+		TargetID: actorID,
+	}, now)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+const (
+	// effectTypeExplosion damages the caster's enemies around them through the
+	// "explosion" action. Its definition declares a linear falloff, so damage
+	// drops off toward the edge of the blast.
+	effectTypeExplosion = effectcontract.EffectIDExplosion
+	explosionDamage     = 30.0
+	explosionRadius     = 100.0
+	explosionCooldown   = 8 * time.Second
+)
+
+// castExplosion enqueues an explosion centred on the caster. Casts inside
+// explosionCooldown of the previous one are ignored.
+func (w *World) castExplosion(casterID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeExplosion, explosionCooldown, now) {
+		return
+	}
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeExplosion,
+		TypeID:        effectTypeExplosion,
+		Delivery:      effectcontract.DeliveryKindArea,
+		SourceActorID: casterID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeCircle,
+			Radius: quantizeWorldCoord(explosionRadius),
+		},
+		DurationTicks: 1,
+		Params:        map[string]int{"healthDelta": -int(explosionDamage)},
+	})
+}
+
+// explosionOwner centres an explosion on a living caster.
+func (w *World) explosionOwner(actorID string) *internaleffects.AreaExplosionOwner {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return nil
+	}
+	return &internaleffects.AreaExplosionOwner{X: actor.X, Y: actor.Y}
+}
+
+// resolveExplosion hits every living actor inside the blast radius that is not
+// on the caster's side. The dispatcher scales each hit by the definition's
+// falloff. Targets are visited in ID order to keep telemetry stable.
+func (w *World) resolveExplosion(eff *effectState, now time.Time) {
+	if w == nil || eff == nil || w.actorFaction(eff.Owner) == "" {
+		return
+	}
+	radius := eff.Params["radius"]
+	centerX := eff.X + eff.Width/2
+	centerY := eff.Y + eff.Height/2
+	bounds := w.bounds()
+
+	var targets []string
+	consider := func(id string, actor *actorState) {
+		if actor == nil || actor.Health <= 0 || w.sameFaction(eff.Owner, id) {
+			return
+		}
+		dx, dy := bounds.Delta(centerX, centerY, actor.X, actor.Y)
+		if math.Hypot(dx, dy) > radius || !w.areaLineOfSightClear(eff, actor.X, actor.Y) {
+			return
+		}
+		targets = append(targets, id)
+	}
+	for id, player := range w.players {
+		if player != nil {
+			consider(id, &player.ActorState)
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			consider(id, &npc.ActorState)
+		}
+	}
+	sort.Strings(targets)
+	for _, id := range targets {
+		w.resolveTargetHit(eff, id, now)
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+const (
+	// factionPlayers groups every player-controlled actor.
+	factionPlayers = "players"
+	// factionMonsters groups every NPC archetype except summons. Wild NPCs do
+	// not pick sides among themselves, so goblins, rats, and bosses are all
+	// allies.
+	factionMonsters = "monsters"
+)
+
+// actorFaction reports which side the actor fights for, or an empty string
+// when the actor does not exist.
+func (w *World) actorFaction(id string) string {
+	if w == nil || id == "" {
+		return ""
+	}
+	if player, ok := w.players[id]; ok && player != nil {
+		return factionPlayers
+	}
+	if npc, ok := w.npcs[id]; ok && npc != nil {
+		if npc.OwnerID != "" {
+			// Summoned NPCs fight beside the players who called them.
+			return factionPlayers
+		}
+		return factionMonsters
+	}
+	return ""
+}
+
+// sameFaction reports whether both actors exist and fight for the same side.
+func (w *World) sameFaction(a, b string) bool {
+	faction := w.actorFaction(a)
+	return faction != "" && faction == w.actorFaction(b)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+const (
+	// effectTypeGravityWell drags the caster's enemies toward a fixed point
+	// through the "gravity-well" action.
+	effectTypeGravityWell = effectcontract.EffectIDGravityWell
+	gravityWellRadius     = 160.0
+	// gravityWellPull is how far each tick moves a pulled actor, in world
+	// units.
+	gravityWellPull     = 8.0
+	gravityWellDuration = 30
+	gravityWellCooldown = 10 * time.Second
+)
+
+// castGravityWell enqueues a gravity well centred where the caster stands.
+// Casts inside gravityWellCooldown of the previous one are ignored, so wells
+// cannot be stacked.
+func (w *World) castGravityWell(casterID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeGravityWell, gravityWellCooldown, now) {
+		return
+	}
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeGravityWell,
+		TypeID:        effectTypeGravityWell,
+		Delivery:      effectcontract.DeliveryKindArea,
+		SourceActorID: casterID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeCircle,
+			Radius: quantizeWorldCoord(gravityWellRadius),
+		},
+		DurationTicks: gravityWellDuration,
+		Params:        map[string]int{"pull": int(gravityWellPull)},
+	})
+}
+
+// gravityWellOwner anchors a gravity well on a living caster.
+func (w *World) gravityWellOwner(actorID string) *internaleffects.AreaPullOwner {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return nil
+	}
+	return &internaleffects.AreaPullOwner{X: actor.X, Y: actor.Y}
+}
+
+// resolveGravityPull moves every living actor inside the well that is not on
+// the caster's side one step toward the centre. A step never carries an actor
+// past the centre and stops at obstacle edges, so walls shield actors behind
+// them. Wells whose caster has left stop pulling. Actors are moved in ID order
+// so position patches are recorded deterministically.
+func (w *World) resolveGravityPull(eff *effectState) {
+	if w == nil || eff == nil || w.actorFaction(eff.Owner) == "" {
+		return
+	}
+	radius := eff.Params["radius"]
+	pull := eff.Params["pull"]
+	if pull <= 0 {
+		return
+	}
+	centerX := eff.X + eff.Width/2
+	centerY := eff.Y + eff.Height/2
+	bounds := w.bounds()
+
+	var pulled []string
+	consider := func(id string, actor *actorState) {
+		if actor == nil || actor.Health <= 0 || w.sameFaction(eff.Owner, id) {
+			return
+		}
+		dx, dy := bounds.Delta(actor.X, actor.Y, centerX, centerY)
+		distance := math.Hypot(dx, dy)
+		if distance == 0 || distance > radius {
+			return
+		}
+		pulled = append(pulled, id)
+	}
+	for id, player := range w.players {
+		if player != nil {
+			consider(id, &player.ActorState)
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			consider(id, &npc.ActorState)
+		}
+	}
+	sort.Strings(pulled)
+
+	for _, id := range pulled {
+		actor := w.actorByID(id)
+		scratch := *actor
+		dx, dy := bounds.Delta(scratch.X, scratch.Y, centerX, centerY)
+		if distance := math.Hypot(dx, dy); distance > pull {
+			dx *= pull / distance
+			dy *= pull / distance
+		}
+		displaceActorInBounds(&scratch, dx, dy, w.obstacles, bounds)
+		if scratch.X == actor.X && scratch.Y == actor.Y {
+			continue
+		}
+		if _, ok := w.players[id]; ok {
+			w.SetPosition(id, scratch.X, scratch.Y)
+		} else {
+			w.SetNPCPosition(id, scratch.X, scratch.Y)
+		}
+	}
+}
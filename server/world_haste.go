@@ -0,0 +1,38 @@
+package server
+
+import "time"
+
+const (
+	// actionHaste gives the caster the hasted status through the "haste"
+	// action.
+	actionHaste          = "haste"
+	hasteDuration        = 3 * time.Second
+	hasteSpeedMultiplier = 1.5
+	hasteCooldown        = 10 * time.Second
+)
+
+// castHaste speeds up a living caster for hasteDuration. Casts inside
+// hasteCooldown of the previous one are ignored.
+func (w *World) castHaste(casterID string, now time.Time) {
+	actor := w.actorByID(casterID)
+	if actor == nil || actor.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, actionHaste, hasteCooldown, now) {
+		return
+	}
+	w.applyStatusEffect(actor, StatusEffectHasted, casterID, now)
+}
+
+// effectiveMoveSpeed reports how fast the actor walks at now: the baseline
+// moveSpeed scaled by any active speed status.
+func (w *World) effectiveMoveSpeed(actor *actorState, now time.Time) float64 {
+	if actor == nil {
+		return moveSpeed
+	}
+	speed := moveSpeed
+	if inst := actor.StatusEffects[StatusEffectHasted]; inst != nil && now.Before(inst.ExpiresAt) {
+		speed *= hasteSpeedMultiplier
+	}
+	return speed
+}
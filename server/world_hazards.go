@@ -0,0 +1,100 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+const (
+	// effectTypeFirePatch leaves a burning patch on the ground where the caster
+	// stands through the "fire-patch" action.
+	effectTypeFirePatch = effectcontract.EffectIDFirePatch
+	firePatchRadius     = 50.0
+	// firePatchDamage is dealt to each enemy standing in the patch every
+	// firePatchPulseTicks ticks for firePatchDuration ticks.
+	firePatchDamage     = 6.0
+	firePatchPulseTicks = 5
+	firePatchDuration   = 150
+	firePatchCooldown   = 12 * time.Second
+)
+
+// castFirePatch enqueues a fire patch at the caster's feet. Casts inside
+// firePatchCooldown of the previous one are ignored.
+func (w *World) castFirePatch(casterID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeFirePatch, firePatchCooldown, now) {
+		return
+	}
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeFirePatch,
+		TypeID:        effectTypeFirePatch,
+		Delivery:      effectcontract.DeliveryKindArea,
+		SourceActorID: casterID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeCircle,
+			Radius: quantizeWorldCoord(firePatchRadius),
+		},
+		DurationTicks: firePatchDuration,
+		TickCadence:   firePatchPulseTicks,
+		Params:        map[string]int{"healthDelta": -int(firePatchDamage)},
+	})
+}
+
+// hazardOwner places a hazard under a living placer.
+func (w *World) hazardOwner(actorID string) *internaleffects.AreaHazardOwner {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return nil
+	}
+	return &internaleffects.AreaHazardOwner{X: actor.X, Y: actor.Y}
+}
+
+// resolveHazardPulse hurts every living actor standing in the hazard that is
+// not on the placer's side, so the placer and their allies can cross it
+// safely. Hazards whose placer has left stop pulsing. Targets are visited in
+// ID order to keep telemetry stable.
+func (w *World) resolveHazardPulse(eff *effectState, now time.Time) {
+	if w == nil || eff == nil || w.actorFaction(eff.Owner) == "" {
+		return
+	}
+	radius := eff.Params["radius"]
+	centerX := eff.X + eff.Width/2
+	centerY := eff.Y + eff.Height/2
+	bounds := w.bounds()
+
+	var targets []string
+	consider := func(id string, actor *actorState) {
+		if actor == nil || actor.Health <= 0 || w.sameFaction(eff.Owner, id) {
+			return
+		}
+		dx, dy := bounds.Delta(centerX, centerY, actor.X, actor.Y)
+		if math.Hypot(dx, dy) > radius || !w.areaLineOfSightClear(eff, actor.X, actor.Y) {
+			return
+		}
+		targets = append(targets, id)
+	}
+	for id, player := range w.players {
+		if player != nil {
+			consider(id, &player.ActorState)
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			consider(id, &npc.ActorState)
+		}
+	}
+	sort.Strings(targets)
+	for _, id := range targets {
+		w.resolveTargetHit(eff, id, now)
+	}
+}
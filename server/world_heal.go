@@ -0,0 +1,167 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	internaleffects "mine-and-die/server/internal/effects"
+)
+
+const (
+	// effectTypeHeal restores health to the actor named by the "heal" action.
+	effectTypeHeal = effectcontract.EffectIDHeal
+	healAmount     = 20.0
+	healRange      = 160.0
+	healCooldown   = 2 * time.Second
+
+	// effectTypeHealBurst restores health to the caster's allies around them
+	// through the "heal-burst" action.
+	effectTypeHealBurst = effectcontract.EffectIDHealBurst
+	healBurstAmount     = 12.0
+	healBurstRadius     = 96.0
+	healBurstCooldown   = 6 * time.Second
+)
+
+// healTargetRejection reports why caster cannot heal targetID, or "" when the
+// heal is allowed. An empty target means the caster. Targets must be living
+// actors on the caster's side within healRange of it.
+func (w *World) healTargetRejection(caster *actorState, targetID string) string {
+	casterID := caster.ID
+	if targetID == "" {
+		targetID = casterID
+	}
+	target := w.actorByID(targetID)
+	if target == nil || target.Health <= 0 || !w.sameFaction(casterID, targetID) {
+		return commandRejectInvalidTarget
+	}
+	if math.Hypot(target.X-caster.X, target.Y-caster.Y) > healRange {
+		return commandRejectOutOfRange
+	}
+	return ""
+}
+
+// castTargetedHeal enqueues a heal delivered to exactly the named actor. An
+// empty target heals the caster. The hub rejects invalid targets when the
+// command is queued; the tick checks again because the target may have moved
+// or died since. Casts inside healCooldown of the previous one are ignored.
+func (w *World) castTargetedHeal(casterID, targetID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 || w.healTargetRejection(caster, targetID) != "" {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeHeal, healCooldown, now) {
+		return
+	}
+	if targetID == "" {
+		targetID = casterID
+	}
+
+	footprint := quantizeWorldCoord(playerHalf * 2)
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeHeal,
+		TypeID:        effectTypeHeal,
+		Delivery:      effectcontract.DeliveryKindTarget,
+		SourceActorID: casterID,
+		TargetActorID: targetID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeRect,
+			Width:  footprint,
+			Height: footprint,
+		},
+		DurationTicks: 1,
+		Params:        map[string]int{"healthDelta": int(healAmount)},
+	})
+}
+
+// resolveTargetHit applies a targeted effect to the actor it was aimed at.
+// Nothing happens when the target left or died before the effect resolved.
+func (w *World) resolveTargetHit(eff *effectState, targetID string, now time.Time) {
+	if w == nil || eff == nil {
+		return
+	}
+	if player, ok := w.players[targetID]; ok && player != nil && player.Health > 0 {
+		w.invokePlayerHitCallback(eff, player, now)
+		return
+	}
+	if npc, ok := w.npcs[targetID]; ok && npc != nil && npc.Health > 0 {
+		w.invokeNPCHitCallback(eff, npc, now)
+	}
+}
+
+// castHealBurst enqueues a group heal centred on the caster. Casts inside
+// healBurstCooldown of the previous one are ignored.
+func (w *World) castHealBurst(casterID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeHealBurst, healBurstCooldown, now) {
+		return
+	}
+	w.effectManager.EnqueueIntent(effectcontract.EffectIntent{
+		EntryID:       effectTypeHealBurst,
+		TypeID:        effectTypeHealBurst,
+		Delivery:      effectcontract.DeliveryKindArea,
+		SourceActorID: casterID,
+		Geometry: effectcontract.EffectGeometry{
+			Shape:  effectcontract.GeometryShapeCircle,
+			Radius: quantizeWorldCoord(healBurstRadius),
+		},
+		DurationTicks: 1,
+		Params:        map[string]int{"healthDelta": int(healBurstAmount)},
+	})
+}
+
+// healBurstOwner centres a heal burst on a living caster.
+func (w *World) healBurstOwner(actorID string) *internaleffects.AreaHealOwner {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return nil
+	}
+	return &internaleffects.AreaHealOwner{X: actor.X, Y: actor.Y}
+}
+
+// resolveHealBurst heals every living actor inside the burst radius that
+// shares the caster's faction, the caster included. Enemies standing in the
+// burst are skipped. Allies are visited in ID order to keep telemetry stable.
+func (w *World) resolveHealBurst(eff *effectState, now time.Time) {
+	if w == nil || eff == nil {
+		return
+	}
+	radius := eff.Params["radius"]
+	centerX := eff.X + eff.Width/2
+	centerY := eff.Y + eff.Height/2
+
+	var allies []string
+	consider := func(id string, actor *actorState) {
+		if actor == nil || actor.Health <= 0 || !w.sameFaction(eff.Owner, id) {
+			return
+		}
+		if math.Hypot(actor.X-centerX, actor.Y-centerY) > radius {
+			return
+		}
+		allies = append(allies, id)
+	}
+	for id, player := range w.players {
+		if player != nil {
+			consider(id, &player.ActorState)
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			consider(id, &npc.ActorState)
+		}
+	}
+	sort.Strings(allies)
+	for _, id := range allies {
+		w.resolveTargetHit(eff, id, now)
+	}
+}
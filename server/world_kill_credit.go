@@ -0,0 +1,115 @@
+package server
+
+import (
+	"sort"
+	"time"
+)
+
+// killCreditWindow is how long damage keeps counting toward a kill. The last
+// actor to hurt a target within the window takes the kill even when the
+// finishing damage came from the environment or the target itself, and
+// everyone else who hurt it inside the window assists.
+const killCreditWindow = 10 * time.Second
+
+// KillCredit attributes a defeat. KillerID is empty when nobody but the
+// target or the environment hurt it within the window. Assists are sorted.
+type KillCredit struct {
+	KillerID string   `json:"killerId,omitempty"`
+	Assists  []string `json:"assists,omitempty"`
+}
+
+// damageLedger records who has recently hurt an actor and on which tick.
+type damageLedger struct {
+	lastHitter   string
+	contributors map[string]uint64
+	lastTick     uint64
+	credit       *KillCredit
+}
+
+// recordKillCredit notes a hit on targetID by ownerID and, when the hit left
+// the target dead, assigns the kill and scores it on the players' session
+// stats. Damage-over-time ticks carry the caster of the status as their
+// owner, so a burn keeps crediting whoever lit it. Heals and self-inflicted
+// damage do not count as contributions.
+func (w *World) recordKillCredit(ownerID, targetID string, delta float64) {
+	if w == nil || targetID == "" || delta >= 0 {
+		return
+	}
+	if w.damageLedgers == nil {
+		w.damageLedgers = make(map[string]*damageLedger)
+	}
+	ledger, ok := w.damageLedgers[targetID]
+	if !ok || ledger.credit != nil {
+		// A credited target that takes damage again has come back to life.
+		ledger = &damageLedger{contributors: make(map[string]uint64)}
+		w.damageLedgers[targetID] = ledger
+	}
+	if ownerID != "" && ownerID != targetID && w.actorByID(ownerID) != nil {
+		ledger.lastHitter = ownerID
+		ledger.contributors[ownerID] = w.currentTick
+		ledger.lastTick = w.currentTick
+	}
+
+	target := w.actorByID(targetID)
+	if target == nil || target.Health > 0 {
+		return
+	}
+	credit := ledger.resolve(w.currentTick, w.killCreditWindowTicks())
+	ledger.credit = &credit
+	if killer, ok := w.players[credit.KillerID]; ok && killer != nil {
+		killer.Session.Kills++
+	}
+	for _, id := range credit.Assists {
+		if assist, ok := w.players[id]; ok && assist != nil {
+			assist.Session.Assists++
+		}
+	}
+}
+
+// resolve picks the killer and assists from contributions inside the window
+// ending at tick.
+func (l *damageLedger) resolve(tick, window uint64) KillCredit {
+	credit := KillCredit{}
+	recent := func(at uint64) bool {
+		return tick-at <= window
+	}
+	if l.lastHitter != "" && recent(l.contributors[l.lastHitter]) {
+		credit.KillerID = l.lastHitter
+	}
+	for id, at := range l.contributors {
+		if id != credit.KillerID && recent(at) {
+			credit.Assists = append(credit.Assists, id)
+		}
+	}
+	sort.Strings(credit.Assists)
+	return credit
+}
+
+// killCredit reports how the actor's latest defeat was attributed. It is
+// available from the killing hit until the ledger decays.
+func (w *World) killCredit(actorID string) (KillCredit, bool) {
+	if w == nil {
+		return KillCredit{}, false
+	}
+	ledger, ok := w.damageLedgers[actorID]
+	if !ok || ledger.credit == nil {
+		return KillCredit{}, false
+	}
+	return *ledger.credit, true
+}
+
+// decayDamageLedgers drops ledgers of actors that have left the world and of
+// living actors nobody has hurt within the window.
+func (w *World) decayDamageLedgers() {
+	window := w.killCreditWindowTicks()
+	for id, ledger := range w.damageLedgers {
+		actor := w.actorByID(id)
+		if actor == nil || (actor.Health > 0 && w.currentTick-ledger.lastTick > window) {
+			delete(w.damageLedgers, id)
+		}
+	}
+}
+
+func (w *World) killCreditWindowTicks() uint64 {
+	return uint64(killCreditWindow.Seconds() * float64(w.ticksPerSecond()))
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	stats "mine-and-die/server/stats"
+)
+
+func TestBurningKillCreditsOriginalCaster(t *testing.T) {
+	hub := newHubWithFullWorld()
+	hub.world.obstacles = nil
+	hub.world.npcs = make(map[string]*npcState)
+	now := time.Now()
+
+	for _, id := range []string{"igniter", "striker"} {
+		player := newTestPlayerState(id)
+		player.X = 200
+		player.Y = 200
+		player.Facing = FacingRight
+		player.LastHeartbeat = now
+		player.Cooldowns = make(map[string]time.Time)
+		hub.world.players[id] = player
+	}
+	hub.world.players["igniter"].Y = 400
+
+	target := &npcState{
+		ActorState: actorState{Actor: Actor{
+			ID:        "target",
+			X:         200 + playerHalf + meleeAttackReach/2,
+			Y:         200,
+			Health:    meleeAttackDamage + 10,
+			MaxHealth: 100,
+			Inventory: NewInventory(),
+		}},
+		Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:  NPCTypeGoblin,
+	}
+	hub.world.npcs[target.ID] = target
+
+	dt := 1.0 / float64(tickRate)
+	step := time.Second / time.Duration(tickRate)
+	current := now
+
+	if _, ok, reason := hub.HandleAction("striker", effectTypeAttack); !ok {
+		t.Fatalf("expected melee attack to be accepted, got %q", reason)
+	}
+	_, _, _, _, _ = hub.advance(current, dt)
+	current = current.Add(step)
+	if target.Health != 10 {
+		t.Fatalf("expected the striker's swing to leave the target at 10, got %.1f", target.Health)
+	}
+
+	hub.mu.Lock()
+	hub.world.applyStatusEffect(&target.ActorState, StatusEffectBurning, "igniter", current)
+	hub.mu.Unlock()
+	for i := 0; i < 2*tickRate; i++ {
+		if _, alive := hub.world.npcs[target.ID]; !alive {
+			break
+		}
+		_, _, _, _, _ = hub.advance(current, dt)
+		current = current.Add(step)
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, alive := hub.world.npcs[target.ID]; alive {
+		t.Fatalf("expected burning to finish the target, health %.1f", target.Health)
+	}
+	credit, ok := hub.world.killCredit(target.ID)
+	if !ok {
+		t.Fatalf("expected the defeat to be attributed")
+	}
+	want := KillCredit{KillerID: "igniter", Assists: []string{"striker"}}
+	if !reflect.DeepEqual(credit, want) {
+		t.Fatalf("expected credit %+v, got %+v", want, credit)
+	}
+	igniter := hub.world.players["igniter"].Session
+	striker := hub.world.players["striker"].Session
+	if igniter.Kills != 1 || igniter.Assists != 0 {
+		t.Fatalf("expected the igniter to score the kill, got %+v", igniter)
+	}
+	if striker.Kills != 0 || striker.Assists != 1 {
+		t.Fatalf("expected the striker to score an assist, got %+v", striker)
+	}
+}
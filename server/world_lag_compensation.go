@@ -0,0 +1,132 @@
+package server
+
+import worldpkg "mine-and-die/server/internal/world"
+
+// SetLagCompensation lets melee swings hit targets where they stood at the
+// origin tick of the swinging command, rewinding at most maxTicks ticks. Zero
+// or negative values disable lag compensation.
+func (w *World) SetLagCompensation(maxTicks int) {
+	if w == nil {
+		return
+	}
+	if maxTicks < 0 {
+		maxTicks = 0
+	}
+	w.lagCompensationTicks = uint64(maxTicks)
+}
+
+// stageMeleeRewind remembers the origin tick of the melee command the actor
+// just issued so the swing's impact can be resolved against it.
+func (w *World) stageMeleeRewind(actorID string, originTick uint64) {
+	if w == nil || w.lagCompensationTicks == 0 || originTick == 0 {
+		return
+	}
+	if _, ok := w.players[actorID]; !ok {
+		return
+	}
+	if w.meleeRewinds == nil {
+		w.meleeRewinds = make(map[string]uint64)
+	}
+	w.meleeRewinds[actorID] = originTick
+}
+
+// takeMeleeRewind consumes the origin tick staged for the actor's swing and
+// returns the target positions to resolve it against at the given tick. A nil
+// rewind keeps live positions.
+func (w *World) takeMeleeRewind(actorID string, tick uint64) *positionRewind {
+	if w == nil || len(w.meleeRewinds) == 0 {
+		return nil
+	}
+	origin, ok := w.meleeRewinds[actorID]
+	if !ok {
+		return nil
+	}
+	delete(w.meleeRewinds, actorID)
+	if w.lagCompensationTicks == 0 {
+		return nil
+	}
+	if tick > w.lagCompensationTicks && origin < tick-w.lagCompensationTicks {
+		origin = tick - w.lagCompensationTicks
+	}
+	return w.rewindPositions(origin, tick)
+}
+
+// positionRewind interpolates actor positions between two recorded samples.
+// A nil to map stands for the live positions at the current tick.
+type positionRewind struct {
+	bounds worldpkg.Bounds
+	from   map[string]vec2
+	to     map[string]vec2
+	ratio  float64
+}
+
+// rewindPositions samples the keyframe history around the origin tick. The
+// newest keyframe at or before it is the start sample; the next keyframe, or
+// the live world when none was recorded since, is the end sample. Origins
+// older than the retained history, or not in the past, are not rewound.
+func (w *World) rewindPositions(origin, tick uint64) *positionRewind {
+	if origin >= tick {
+		return nil
+	}
+	frames := w.journal.Keyframes()
+	start := -1
+	for i, frame := range frames {
+		if frame.Tick > origin {
+			break
+		}
+		start = i
+	}
+	if start < 0 {
+		return nil
+	}
+
+	rewind := &positionRewind{
+		bounds: w.bounds(),
+		from:   keyframePositions(frames[start]),
+	}
+	fromTick, toTick := frames[start].Tick, tick
+	if start+1 < len(frames) && frames[start+1].Tick < tick {
+		rewind.to = keyframePositions(frames[start+1])
+		toTick = frames[start+1].Tick
+	}
+	if toTick > fromTick {
+		rewind.ratio = float64(origin-fromTick) / float64(toTick-fromTick)
+	}
+	return rewind
+}
+
+// keyframePositions indexes the player and NPC positions captured in frame.
+func keyframePositions(frame keyframe) map[string]vec2 {
+	positions := make(map[string]vec2)
+	if players, ok := frame.Players.([]Player); ok {
+		for _, player := range players {
+			positions[player.ID] = vec2{X: player.X, Y: player.Y}
+		}
+	}
+	if npcs, ok := frame.NPCs.([]NPC); ok {
+		for _, npc := range npcs {
+			positions[npc.ID] = vec2{X: npc.X, Y: npc.Y}
+		}
+	}
+	return positions
+}
+
+// position returns where the actor stood at the rewound tick. Actors missing
+// from the start sample keep their live position.
+func (r *positionRewind) position(id string, x, y float64) (float64, float64) {
+	if r == nil {
+		return x, y
+	}
+	from, ok := r.from[id]
+	if !ok {
+		return x, y
+	}
+	to := vec2{X: x, Y: y}
+	if r.to != nil {
+		if sample, ok := r.to[id]; ok {
+			to = sample
+		}
+	}
+	dx, dy := r.bounds.Delta(from.X, from.Y, to.X, to.Y)
+	return r.bounds.ConfineX(from.X + dx*r.ratio), r.bounds.ConfineY(from.Y + dy*r.ratio)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+// swingAtRewoundTarget records a keyframe with the target in reach at tick
+// 10, moves it out of reach, and swings at tick 14 with a command issued at
+// originTick. It returns the target's health after the swing resolves.
+func swingAtRewoundTarget(t *testing.T, maxRewind int, originTick uint64) float64 {
+	t.Helper()
+	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	w.obstacles = nil
+	w.SetLagCompensation(maxRewind)
+
+	now := time.Unix(0, 0)
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	w.AddPlayer(attacker)
+
+	target := newTestPlayerState("target")
+	target.X = 200 + playerHalf + meleeAttackReach/2
+	target.Y = 200
+	target.LastHeartbeat = now
+	w.AddPlayer(target)
+
+	w.RecordKeyframe(keyframe{
+		Tick:     10,
+		Sequence: 1,
+		Players: []Player{
+			{Actor: Actor{ID: attacker.ID, X: attacker.X, Y: attacker.Y}},
+			{Actor: Actor{ID: target.ID, X: target.X, Y: target.Y}},
+		},
+	})
+	target.X = 400
+
+	w.Step(14, now, 1.0/float64(tickRate), []Command{{
+		OriginTick: originTick,
+		ActorID:    attacker.ID,
+		Type:       CommandAction,
+		IssuedAt:   now,
+		Action:     &ActionCommand{Name: effectTypeAttack},
+	}}, nil)
+
+	return w.players[target.ID].Health
+}
+
+func TestLagCompensatedMeleeHitsTargetAtOriginTick(t *testing.T) {
+	hit := baselinePlayerMaxHealth - meleeAttackDamage
+
+	if got := swingAtRewoundTarget(t, 8, 10); math.Abs(got-hit) > 1e-6 {
+		t.Fatalf("expected the swing to land on the rewound target, health %.1f want %.1f", got, hit)
+	}
+	if got := swingAtRewoundTarget(t, 0, 10); got != baselinePlayerMaxHealth {
+		t.Fatalf("expected no hit without lag compensation, health %.1f", got)
+	}
+	// A two-tick window only rewinds halfway back, which is still out of reach.
+	if got := swingAtRewoundTarget(t, 2, 10); got != baselinePlayerMaxHealth {
+		t.Fatalf("expected the rewind to be capped by the window, health %.1f", got)
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import stats "mine-and-die/server/stats"
+
+// effectParamLifesteal grants an effect extra lifesteal, as a percentage of
+// the damage it deals, on top of the owner's stat.
+const effectParamLifesteal = "lifesteal"
+
+// lifestealFraction returns the share of eff's damage its owner recovers.
+func (w *World) lifestealFraction(eff *effectState) float64 {
+	fraction := eff.Params[effectParamLifesteal] * 0.01
+	if comp := w.statsFor(eff.Owner); comp != nil {
+		fraction += comp.GetDerived(stats.DerivedLifesteal)
+	}
+	if fraction > 1 {
+		return 1
+	}
+	return fraction
+}
+
+// applyLifesteal heals the owner of eff for its lifesteal share of the damage
+// that reached the target. Healing clamps to the owner's max health through
+// the regular health setters.
+func (w *World) applyLifesteal(eff *effectState, targetID string, damage float64) {
+	if w == nil || eff == nil || eff.Owner == "" || eff.Owner == targetID || damage <= 0 {
+		return
+	}
+	fraction := w.lifestealFraction(eff)
+	if fraction <= 0 {
+		return
+	}
+	heal := damage * fraction
+	if player, ok := w.players[eff.Owner]; ok && player != nil {
+		if player.Health > 0 {
+			w.SetHealth(player.ID, player.Health+heal)
+		}
+		return
+	}
+	if npc, ok := w.npcs[eff.Owner]; ok && npc != nil && npc.Health > 0 {
+		w.SetNPCHealth(npc.ID, npc.Health+heal)
+	}
+}
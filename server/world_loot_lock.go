@@ -0,0 +1,49 @@
+package server
+
+import (
+	"time"
+
+	itemspkg "mine-and-die/server/internal/items"
+)
+
+// lootLockWindow is how long a killer has the loot of their kill to
+// themselves before anyone else may pick it up.
+const lootLockWindow = 5 * time.Second
+
+// reserveDeathLoot wraps a drop logger so every stack the defeated actor drops
+// is reserved for the player credited with the kill. Drops that merge into a
+// stack already on the tile reserve the whole stack. Defeats without a player
+// killer leave the loot free for anyone.
+func (w *World) reserveDeathLoot(victimID string, logDrop func(*itemspkg.Actor, itemspkg.ItemStack, string, string)) func(*itemspkg.Actor, itemspkg.ItemStack, string, string) {
+	credit, ok := w.killCredit(victimID)
+	if !ok || credit.KillerID == "" {
+		return logDrop
+	}
+	if _, isPlayer := w.players[credit.KillerID]; !isPlayer {
+		return logDrop
+	}
+	until := w.currentTick + w.lootLockWindowTicks()
+	return func(actor *itemspkg.Actor, stack itemspkg.ItemStack, reason, stackID string) {
+		if item := w.groundItems[stackID]; item != nil {
+			item.OwnerID = credit.KillerID
+			item.OwnerUntilTick = until
+		}
+		if logDrop != nil {
+			logDrop(actor, stack, reason, stackID)
+		}
+	}
+}
+
+// lootLockedFailure reports a loot_locked failure when the stack the actor
+// would collect is still reserved for someone else.
+func (w *World) lootLockedFailure(actor *actorState, itemType ItemType) *itemspkg.PickupFailure {
+	item, distance := w.nearestGroundItem(actor, itemType)
+	if item == nil || distance > w.pickupRadiusFor(actor.ID) || !item.LockedFor(actor.ID, w.currentTick) {
+		return nil
+	}
+	return &itemspkg.PickupFailure{Reason: itemspkg.PickupFailureReasonLootLocked, StackID: item.ID, Distance: distance}
+}
+
+func (w *World) lootLockWindowTicks() uint64 {
+	return uint64(lootLockWindow.Seconds() * float64(w.ticksPerSecond()))
+}
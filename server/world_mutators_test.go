@@ -612,6 +612,38 @@ func TestSetPositionRecordsPatch(t *testing.T) {
 	}
 }
 
+func TestSetPositionTwiceInOneTickCoalescesPatches(t *testing.T) {
+	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	player := &playerState{ActorState: actorState{Actor: Actor{ID: "player-coalesce", X: 5, Y: 6, Health: baselinePlayerMaxHealth, MaxHealth: baselinePlayerMaxHealth}}, Stats: stats.DefaultComponent(stats.ArchetypePlayer)}
+	w.AddPlayer(player)
+	w.SetHealth("player-coalesce", baselinePlayerMaxHealth-10)
+
+	w.SetPosition("player-coalesce", 15, 25)
+	w.SetPosition("player-coalesce", 18, 22)
+
+	if player.Version != 3 {
+		t.Fatalf("expected every mutation to bump the version, got %d", player.Version)
+	}
+
+	patches := w.drainPatchesLocked()
+	if len(patches) != 2 {
+		t.Fatalf("expected health and a single position patch, got %d patches", len(patches))
+	}
+	if patches[0].Kind != PatchPlayerHealth {
+		t.Fatalf("expected health patch to keep its position, got %q", patches[0].Kind)
+	}
+	if patches[1].Kind != PatchPlayerPos {
+		t.Fatalf("expected coalesced position patch, got %q", patches[1].Kind)
+	}
+	payload, ok := patches[1].Payload.(PlayerPosPayload)
+	if !ok {
+		t.Fatalf("expected payload to be PlayerPosPayload, got %T", patches[1].Payload)
+	}
+	if payload.X != 18 || payload.Y != 22 {
+		t.Fatalf("expected final coords (18,22), got (%.2f, %.2f)", payload.X, payload.Y)
+	}
+}
+
 func TestSetFacingNoopDoesNotEmitPatch(t *testing.T) {
 	w := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
 	player := &playerState{ActorState: actorState{Actor: Actor{ID: "player-3", Facing: FacingRight, Health: baselinePlayerMaxHealth, MaxHealth: baselinePlayerMaxHealth}}, Stats: stats.DefaultComponent(stats.ArchetypePlayer)}
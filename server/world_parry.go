@@ -0,0 +1,56 @@
+package server
+
+import "time"
+
+const (
+	// actionParry puts the caster into the parrying status through the
+	// "parry" action.
+	actionParry   = "parry"
+	parryDuration = 500 * time.Millisecond
+	// parryCooldown outlasts the window so a parry cannot be held up
+	// indefinitely by recasting as soon as it closes.
+	parryCooldown = 1500 * time.Millisecond
+)
+
+// castParry starts a parry window on a living caster. Casts inside
+// parryCooldown of the previous one are ignored, so parrying again never
+// extends or renews a window.
+func (w *World) castParry(casterID string, now time.Time) {
+	actor := w.actorByID(casterID)
+	if actor == nil || actor.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, actionParry, parryCooldown, now) {
+		return
+	}
+	w.applyStatusEffect(actor, StatusEffectParrying, casterID, now)
+}
+
+// isParrying reports whether the actor's parry window is open at now.
+func (w *World) isParrying(actorID string, now time.Time) bool {
+	actor := w.actorByID(actorID)
+	if actor == nil || actor.Health <= 0 {
+		return false
+	}
+	inst := actor.StatusEffects[StatusEffectParrying]
+	return inst != nil && now.Before(inst.ExpiresAt)
+}
+
+// reflectProjectile returns a parried projectile's hit to whoever fired it.
+// The hit resolves as if the parrying actor had cast it, so the owner takes
+// the projectile's damage and on-hit statuses. Nothing happens when the owner
+// is gone or already dead.
+func (w *World) reflectProjectile(eff *effectState, parrierID string, now time.Time) {
+	if w == nil || eff == nil || eff.Owner == "" || eff.Owner == parrierID {
+		return
+	}
+	reflected := *eff
+	reflected.Owner = parrierID
+	if player, ok := w.players[eff.Owner]; ok && player != nil && player.Health > 0 {
+		w.invokePlayerHitCallback(&reflected, player, now)
+		return
+	}
+	if npc, ok := w.npcs[eff.Owner]; ok && npc != nil && npc.Health > 0 {
+		w.invokeNPCHitCallback(&reflected, npc, now)
+	}
+}
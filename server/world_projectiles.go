@@ -0,0 +1,145 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	effectcontract "mine-and-die/server/effects/contract"
+	combat "mine-and-die/server/internal/combat"
+)
+
+const (
+	// effectTypeFirebomb is the lobbed projectile cast through the "firebomb"
+	// action. Its definition uses parabolic motion, so it sails over everything
+	// in its path and burns every enemy standing where it lands.
+	effectTypeFirebomb = effectcontract.EffectIDFirebomb
+	firebombSpeed      = 200.0
+	firebombRange      = 6 * 40.0
+	firebombArcHeight  = 60.0
+	firebombSize       = 32.0
+	firebombDamage     = 20.0
+	firebombCooldown   = 4 * time.Second
+
+	// effectTypeSeeker is the homing projectile cast through the "seeker"
+	// action. It locks onto the named target, or the nearest enemy within
+	// seekerHomingRange, and turns toward it as it flies.
+	effectTypeSeeker  = effectcontract.EffectIDSeeker
+	seekerSpeed       = 240.0
+	seekerRange       = 6 * 40.0
+	seekerSize        = 20.0
+	seekerTurnRate    = math.Pi
+	seekerHomingRange = 5 * 40.0
+	seekerDamage      = 10.0
+	seekerCooldown    = 3 * time.Second
+
+	// effectTypeRicochet is the bouncing projectile cast through the
+	// "ricochet" action. It reflects off up to ricochetBounces obstacles
+	// before the next one stops it.
+	effectTypeRicochet = effectcontract.EffectIDRicochet
+	ricochetSpeed      = 320.0
+	ricochetRange      = 8 * 40.0
+	ricochetSize       = 16.0
+	ricochetBounces    = 2
+	ricochetDamage     = 12.0
+	ricochetCooldown   = 3 * time.Second
+)
+
+var (
+	firebombLifetime = time.Duration(firebombRange / firebombSpeed * float64(time.Second))
+	seekerLifetime   = time.Duration(seekerRange / seekerSpeed * float64(time.Second))
+	ricochetLifetime = time.Duration(ricochetRange / ricochetSpeed * float64(time.Second))
+)
+
+// castProjectile enqueues the projectile registered under typeID for a living
+// caster, gated on the template's cooldown. The aim vector overrides the
+// caster's facing when set, and targetID locks homing templates onto an actor.
+func (w *World) castProjectile(casterID, typeID string, aimX, aimY float64, targetID string, now time.Time) {
+	if w == nil || w.effectManager == nil {
+		return
+	}
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	tpl := w.projectileTemplates[typeID]
+	combatTpl, ok := projectileIntentTemplateFromConfig(tpl)
+	if !ok {
+		return
+	}
+	if !w.readyAbility(casterID, typeID, tpl.Cooldown, now) {
+		return
+	}
+	combatTpl.SpawnOffset = w.projectileSpawnOffset(combatTpl)
+
+	owner, ok := combat.NewProjectileIntentOwnerFromActor(&combat.AbilityActor{
+		ID:     caster.ID,
+		X:      caster.X,
+		Y:      caster.Y,
+		Facing: string(caster.Facing),
+	})
+	if !ok {
+		return
+	}
+	owner.AimX = aimX
+	owner.AimY = aimY
+	intent, ok := combat.NewProjectileIntent(projectileIntentConfig, owner, combatTpl)
+	if !ok {
+		return
+	}
+	intent.TargetActorID = targetID
+	w.effectManager.EnqueueIntent(intent)
+}
+
+// castSeeker fires a seeker locked onto targetID. Without a named target it
+// locks onto the nearest living enemy within seekerHomingRange, and flies
+// straight along the aim when there is none.
+func (w *World) castSeeker(casterID, targetID string, aimX, aimY float64, now time.Time) {
+	if targetID == "" {
+		targetID = w.nearestEnemy(casterID, seekerHomingRange)
+	}
+	w.castProjectile(casterID, effectTypeSeeker, aimX, aimY, targetID, now)
+}
+
+// nearestEnemy returns the closest living actor within radius of the caster
+// that is not on the caster's side. Ties resolve by ID so the pick does not
+// depend on map order.
+func (w *World) nearestEnemy(casterID string, radius float64) string {
+	caster := w.actorByID(casterID)
+	if caster == nil {
+		return ""
+	}
+	bounds := w.bounds()
+	var ids []string
+	candidates := make(map[string]*actorState)
+	for id, player := range w.players {
+		if player != nil {
+			ids = append(ids, id)
+			candidates[id] = &player.ActorState
+		}
+	}
+	for id, npc := range w.npcs {
+		if npc != nil {
+			ids = append(ids, id)
+			candidates[id] = &npc.ActorState
+		}
+	}
+	sort.Strings(ids)
+
+	nearest := ""
+	best := 0.0
+	for _, id := range ids {
+		actor := candidates[id]
+		if id == casterID || actor.Health <= 0 || w.sameFaction(casterID, id) {
+			continue
+		}
+		dx, dy := bounds.Delta(caster.X, caster.Y, actor.X, actor.Y)
+		distance := math.Hypot(dx, dy)
+		if distance > radius || (nearest != "" && distance >= best) {
+			continue
+		}
+		nearest = id
+		best = distance
+	}
+	return nearest
+}
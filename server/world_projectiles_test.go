@@ -0,0 +1,167 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	stats "mine-and-die/server/stats"
+)
+
+func TestFirebombLandsOnItsTargetPointOverBystanders(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Facing = FacingRight
+	world.AddPlayer(caster)
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+	tick := uint64(1)
+	lob := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeFirebomb}}}
+	world.Step(tick, now, dt, lob, nil)
+
+	var bomb *effectState
+	for _, eff := range world.effects {
+		if eff != nil && eff.Type == effectTypeFirebomb && eff.Projectile != nil {
+			bomb = eff
+		}
+	}
+	if bomb == nil {
+		t.Fatalf("expected the firebomb action to spawn a projectile")
+	}
+	if bomb.Projectile.ArcTargetX <= caster.X+firebombRange/2 {
+		t.Fatalf("expected the firebomb to land at full range, target x %.1f", bomb.Projectile.ArcTargetX)
+	}
+
+	bystander := newTestPlayerState("bystander")
+	bystander.X = caster.X + firebombRange/3
+	bystander.Y = caster.Y
+	world.AddPlayer(bystander)
+	target := newTestPlayerState("target")
+	target.X = bomb.Projectile.ArcTargetX
+	target.Y = bomb.Projectile.ArcTargetY
+	world.AddPlayer(target)
+
+	for i := 0; i < int(firebombLifetime/time.Second+1)*tickRate; i++ {
+		tick++
+		now = now.Add(time.Duration(dt * float64(time.Second)))
+		world.Step(tick, now, dt, nil, nil)
+	}
+
+	if got := world.players[bystander.ID].Health; got != baselinePlayerMaxHealth {
+		t.Fatalf("expected the firebomb to fly over the bystander, health %.2f", got)
+	}
+	if got := world.players[target.ID].Health; got > baselinePlayerMaxHealth-firebombDamage {
+		t.Fatalf("expected the firebomb to burn the actor at its landing point, health %.2f", got)
+	}
+}
+
+func TestSeekerHomesOntoNamedTargetOrNearestEnemy(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Facing = FacingRight
+	world.AddPlayer(caster)
+
+	// Both targets sit well off the caster's facing, where a straight shot
+	// would miss them.
+	rival := newTestPlayerState("rival")
+	rival.X = caster.X + 100
+	rival.Y = caster.Y + 100
+	world.AddPlayer(rival)
+	goblin := &npcState{
+		ActorState: actorState{Actor: Actor{
+			ID:        "goblin",
+			X:         caster.X + 100,
+			Y:         caster.Y - 100,
+			Health:    50,
+			MaxHealth: 50,
+			Inventory: NewInventory(),
+		}},
+		Stats: stats.DefaultComponent(stats.ArchetypeGoblin),
+		Type:  NPCTypeGoblin,
+	}
+	world.npcs[goblin.ID] = goblin
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+	tick := uint64(0)
+	cast := func(targetID string) string {
+		tick++
+		now = now.Add(seekerCooldown)
+		fire := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeSeeker, TargetID: targetID}}}
+		world.Step(tick, now, dt, fire, nil)
+		locked := ""
+		for _, eff := range world.effects {
+			if eff != nil && eff.Type == effectTypeSeeker && eff.Projectile != nil && eff.Projectile.RemainingRange > 0 {
+				locked = eff.Projectile.HomingTargetID
+			}
+		}
+		for i := 0; i < int(seekerLifetime/time.Second+1)*tickRate; i++ {
+			tick++
+			now = now.Add(time.Duration(dt * float64(time.Second)))
+			world.Step(tick, now, dt, nil, nil)
+		}
+		return locked
+	}
+
+	if locked := cast(rival.ID); locked != rival.ID {
+		t.Fatalf("expected the seeker to lock onto the named target, got %q", locked)
+	}
+	if got := world.players[rival.ID].Health; got != baselinePlayerMaxHealth-seekerDamage {
+		t.Fatalf("expected the seeker to curve into the named target, health %.2f", got)
+	}
+
+	if locked := cast(""); locked != goblin.ID {
+		t.Fatalf("expected an untargeted seeker to lock onto the nearest enemy, got %q", locked)
+	}
+	if goblin.Health >= 50 {
+		t.Fatalf("expected the seeker to curve into the nearest enemy, health %.2f", goblin.Health)
+	}
+}
+
+func TestRicochetBouncesOffWallsBeforeStopping(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = []Obstacle{{ID: "wall", X: 300, Y: 100, Width: 20, Height: 200}}
+	world.npcs = make(map[string]*npcState)
+
+	caster := newTestPlayerState("caster")
+	caster.X = 200
+	caster.Y = 200
+	caster.Facing = FacingRight
+	world.AddPlayer(caster)
+
+	// The target stands behind the caster, so only a shot that came back off
+	// the wall can reach it.
+	target := newTestPlayerState("target")
+	target.X = caster.X - 80
+	target.Y = caster.Y
+	world.AddPlayer(target)
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+	tick := uint64(1)
+	fire := []Command{{ActorID: caster.ID, Type: CommandAction, Action: &ActionCommand{Name: effectTypeRicochet}}}
+	world.Step(tick, now, dt, fire, nil)
+	for i := 0; i < int(ricochetLifetime/time.Second+1)*tickRate; i++ {
+		tick++
+		now = now.Add(time.Duration(dt * float64(time.Second)))
+		world.Step(tick, now, dt, nil, nil)
+	}
+
+	if got := world.players[caster.ID].Health; got != baselinePlayerMaxHealth {
+		t.Fatalf("expected the rebound to pass through its owner, health %.2f", got)
+	}
+	if got := world.players[target.ID].Health; got != baselinePlayerMaxHealth-ricochetDamage {
+		t.Fatalf("expected the ricochet to rebound off the wall into the target, health %.2f", got)
+	}
+}
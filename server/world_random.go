@@ -61,3 +61,31 @@ func centralTopLeftRange(total, center, margin, size float64) (float64, float64)
 func centralCenterRange(total, center, margin, padding float64) (float64, float64) {
 	return worldpkg.CentralCenterRange(total, center, margin, padding)
 }
+
+// RNGState captures the position of the world RNG stream for keyframes and
+// dumps. It reports false when the RNG was injected and cannot be captured.
+func (w *World) RNGState() (worldpkg.RNGState, bool) {
+	if w == nil || w.internalWorld == nil || w.internalWorld.RNG() != w.rng {
+		return worldpkg.RNGState{}, false
+	}
+	return w.internalWorld.RNGState()
+}
+
+// RestoreRNGState moves the world RNG to a captured position so the draws
+// that follow match the run it was captured from.
+func (w *World) RestoreRNGState(state worldpkg.RNGState) bool {
+	if w == nil || w.internalWorld == nil || w.internalWorld.RNG() != w.rng {
+		return false
+	}
+	return w.internalWorld.RestoreRNGState(state)
+}
+
+// rngStateSnapshot returns the captured RNG position, or nil when the RNG
+// cannot be captured.
+func (w *World) rngStateSnapshot() *worldpkg.RNGState {
+	state, ok := w.RNGState()
+	if !ok {
+		return nil
+	}
+	return &state
+}
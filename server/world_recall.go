@@ -0,0 +1,56 @@
+package server
+
+import "time"
+
+const (
+	// actionRecall saves the caster's position through the "recall" action and
+	// returns them to it once recallDelay has passed. Recasting while a recall
+	// is pending returns them at once; taking damage cancels it.
+	actionRecall        = "recall"
+	scheduledTaskRecall = "recall"
+	recallDelay         = 3 * time.Second
+	// recallCooldown runs from the cast that starts a recall. Completing a
+	// pending recall early is not gated.
+	recallCooldown = 6 * time.Second
+)
+
+// castRecall starts a recall for a living player, or completes the pending one
+// early on recast. A new recall cannot start inside recallCooldown of the last
+// one, even if damage cancelled it.
+func (w *World) castRecall(casterID string, now time.Time) {
+	if w == nil {
+		return
+	}
+	player, ok := w.players[casterID]
+	if !ok || player == nil || player.Health <= 0 {
+		return
+	}
+	if task, pending := w.pendingScheduledTask(scheduledTaskRecall, casterID); pending {
+		w.cancelScheduledTasks(scheduledTaskRecall, casterID)
+		w.completeRecall(task, now)
+		return
+	}
+	if !w.readyAbility(casterID, actionRecall, recallCooldown, now) {
+		return
+	}
+	delay := uint64(recallDelay.Seconds() * float64(w.ticksPerSecond()))
+	w.scheduleTask(delay, scheduledTaskRecall, casterID, map[string]float64{"x": player.X, "y": player.Y})
+}
+
+// completeRecall moves the player back to the point saved by the task. Players
+// who left or died in the meantime stay where they are.
+func (w *World) completeRecall(task scheduledTask, _ time.Time) {
+	player, ok := w.players[task.ActorID]
+	if !ok || player == nil || player.Health <= 0 {
+		return
+	}
+	w.SetPosition(task.ActorID, task.Params["x"], task.Params["y"])
+}
+
+// interruptRecall cancels the actor's pending recall when a hit hurt them.
+func (w *World) interruptRecall(actorID string, delta float64) {
+	if w == nil || delta >= 0 {
+		return
+	}
+	w.cancelScheduledTasks(scheduledTaskRecall, actorID)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+func TestRecallReturnsPlayerAfterDelayUnlessDamaged(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	world.obstacles = nil
+	world.npcs = make(map[string]*npcState)
+
+	player := newTestPlayerState("recaller")
+	player.X = 200
+	player.Y = 200
+	world.players[player.ID] = player
+
+	dt := 1.0 / float64(tickRate)
+	start := time.Unix(0, 0)
+	at := func(tick uint64) time.Time {
+		return start.Add(time.Duration(tick) * time.Second / time.Duration(tickRate))
+	}
+	recall := []Command{{ActorID: player.ID, Type: CommandAction, Action: &ActionCommand{Name: actionRecall}}}
+	delay := uint64(recallDelay.Seconds() * float64(tickRate))
+
+	world.Step(1, at(1), dt, recall, nil)
+	world.SetPosition(player.ID, 400, 300)
+	tick := uint64(2)
+	for ; tick <= 1+delay; tick++ {
+		if player.X != 400 || player.Y != 300 {
+			t.Fatalf("tick %d: expected the recall to wait for its delay, player at (%.1f, %.1f)", tick, player.X, player.Y)
+		}
+		world.Step(tick, at(tick), dt, nil, nil)
+	}
+	if player.X != 200 || player.Y != 200 {
+		t.Fatalf("expected recall to return the player to (200, 200), got (%.1f, %.1f)", player.X, player.Y)
+	}
+
+	for ; at(tick).Before(at(1).Add(recallCooldown)); tick++ {
+		world.Step(tick, at(tick), dt, nil, nil)
+	}
+	world.Step(tick, at(tick), dt, recall, nil)
+	world.SetPosition(player.ID, 400, 300)
+	hit := &effectState{Type: effectTypeAttack, Owner: "ambusher", Params: map[string]float64{"healthDelta": -5}}
+	world.invokePlayerHitCallback(hit, player, at(tick))
+	if _, pending := world.pendingScheduledTask(scheduledTaskRecall, player.ID); pending {
+		t.Fatalf("expected damage to cancel the pending recall")
+	}
+	for end := tick + delay + 1; tick <= end; tick++ {
+		world.Step(tick, at(tick), dt, nil, nil)
+	}
+	if player.X != 400 || player.Y != 300 {
+		t.Fatalf("expected a cancelled recall to leave the player at (400, 300), got (%.1f, %.1f)", player.X, player.Y)
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"math"
+	"sort"
+
+	"mine-and-die/server/stats"
+)
+
+// regenerateHealth heals living players who have gone RegenDelaySeconds
+// without dealing or taking damage by their DerivedHealthRegen rate for dt
+// seconds, up to max health. Players are visited in ID order so the emitted
+// patches are deterministic.
+func (w *World) regenerateHealth(dt float64) {
+	if w == nil || dt <= 0 || w.config.RegenDelaySeconds <= 0 {
+		return
+	}
+	window := uint64(math.Ceil(w.config.RegenDelaySeconds * float64(w.ticksPerSecond())))
+
+	ids := make([]string, 0, len(w.players))
+	for id, player := range w.players {
+		if player == nil || player.Health <= 0 || player.Health >= player.MaxHealth {
+			continue
+		}
+		if w.currentTick < player.LastCombatTick+window {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		player := w.players[id]
+		rate := player.Stats.GetDerived(stats.DerivedHealthRegen)
+		if rate <= 0 {
+			continue
+		}
+		w.SetHealth(id, math.Min(player.Health+rate*dt, player.MaxHealth))
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+	"mine-and-die/server/stats"
+)
+
+func TestOutOfCombatRegenStartsAfterWindowAndResetsOnDamage(t *testing.T) {
+	// The window is measured at the world's own tick rate.
+	for _, ticksPerSecond := range []int{tickRate, tickRate * 2} {
+		t.Run(fmt.Sprintf("%dHz", ticksPerSecond), func(t *testing.T) {
+			testOutOfCombatRegenAtTickRate(t, ticksPerSecond)
+		})
+	}
+}
+
+func testOutOfCombatRegenAtTickRate(t *testing.T, ticksPerSecond int) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.RegenDelaySeconds = 1
+	w := newTestWorld(cfg, logging.NopPublisher{})
+	w.obstacles = nil
+	w.tickRate = ticksPerSecond
+
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(ticksPerSecond)
+	target := newTestPlayerState("target")
+	target.X = 200
+	target.Y = 200
+	target.LastHeartbeat = now
+	w.AddPlayer(target)
+	w.SetHealth(target.ID, 50)
+
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200 - playerHalf - meleeAttackReach/2
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	w.AddPlayer(attacker)
+
+	rate := target.Stats.GetDerived(stats.DerivedHealthRegen)
+	if rate <= 0 {
+		t.Fatalf("expected players to have a positive regen rate, got %.2f", rate)
+	}
+
+	tick := uint64(0)
+	step := func(commands ...Command) {
+		tick++
+		now = now.Add(time.Duration(dt * float64(time.Second)))
+		w.Step(tick, now, dt, commands, nil)
+	}
+
+	for tick < uint64(ticksPerSecond-1) {
+		step()
+	}
+	if got := w.players[target.ID].Health; got != 50 {
+		t.Fatalf("expected no regen inside the out-of-combat window, health %.2f", got)
+	}
+	step()
+	if got, want := w.players[target.ID].Health, 50+rate*dt; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("expected regen once the window elapsed, health %.4f want %.4f", got, want)
+	}
+
+	step(Command{
+		ActorID:  attacker.ID,
+		Type:     CommandAction,
+		IssuedAt: now,
+		Action:   &ActionCommand{Name: effectTypeAttack},
+	})
+	hit := w.players[target.ID].Health
+	if hit >= 50 {
+		t.Fatalf("expected the swing to damage the target, health %.2f", hit)
+	}
+	if got := w.players[attacker.ID].LastCombatTick; got != tick {
+		t.Fatalf("expected dealing damage to reset the attacker's window, last combat tick %d want %d", got, tick)
+	}
+
+	for i := 0; i < ticksPerSecond-1; i++ {
+		step()
+	}
+	if got := w.players[target.ID].Health; got != hit {
+		t.Fatalf("expected damage to restart the out-of-combat window, health %.4f want %.4f", got, hit)
+	}
+	step()
+	if got := w.players[target.ID].Health; got <= hit {
+		t.Fatalf("expected regen to resume after a quiet window, health %.4f", got)
+	}
+}
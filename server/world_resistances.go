@@ -0,0 +1,65 @@
+package server
+
+import (
+	effectcontract "mine-and-die/server/effects/contract"
+	stats "mine-and-die/server/stats"
+)
+
+// damageTakenStat maps a contract damage type onto the derived multiplier
+// that scales it. Untyped damage bypasses resistances.
+func damageTakenStat(damageType effectcontract.DamageType) (stats.DerivedID, bool) {
+	switch damageType {
+	case effectcontract.DamageTypePhysical:
+		return stats.DerivedDamageTakenPhysical, true
+	case effectcontract.DamageTypeFire:
+		return stats.DerivedDamageTakenFire, true
+	case effectcontract.DamageTypePoison:
+		return stats.DerivedDamageTakenPoison, true
+	default:
+		return 0, false
+	}
+}
+
+// effectDamageType reports the damage type declared by the effect's definition.
+func (w *World) effectDamageType(eff *effectState) effectcontract.DamageType {
+	if w == nil || eff == nil || w.effectManager == nil {
+		return ""
+	}
+	definitionID := eff.Instance.DefinitionID
+	if definitionID == "" {
+		definitionID = eff.Type
+	}
+	def, ok := w.effectManager.Definitions()[definitionID]
+	if !ok || def == nil {
+		return ""
+	}
+	return def.DamageType
+}
+
+// damageTakenMultiplier returns how much of eff's damage the target takes
+// after its resistance (or weakness) to the effect's damage type.
+func (w *World) damageTakenMultiplier(eff *effectState, targetID string) float64 {
+	derived, ok := damageTakenStat(w.effectDamageType(eff))
+	if !ok {
+		return 1
+	}
+	comp := w.statsFor(targetID)
+	if comp == nil || comp.Version() == 0 {
+		// Unresolved components carry zeroed derived stats.
+		return 1
+	}
+	return comp.GetDerived(derived)
+}
+
+// damageDealtMultiplier returns how much the owner's damage bonus scales eff's
+// damage. Owners that are not actors deal their damage unchanged.
+func (w *World) damageDealtMultiplier(eff *effectState) float64 {
+	if eff == nil {
+		return 1
+	}
+	comp := w.statsFor(eff.Owner)
+	if comp == nil || comp.Version() == 0 {
+		return 1
+	}
+	return comp.GetDerived(stats.DerivedDamageDealt)
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"mine-and-die/server/internal/sim"
+	"mine-and-die/server/internal/simutil"
+)
+
+// scheduledTask is the keyframe-serializable form of a deferred world action.
+type scheduledTask = sim.ScheduledTask
+
+// scheduledTaskHandler runs a deferred world action once its tick arrives.
+type scheduledTaskHandler func(task scheduledTask, now time.Time)
+
+// registerScheduledTask binds a task kind to the handler that runs it. Handlers
+// are registered on the world rather than stored with the task so pending work
+// stays plain data and can be written to keyframes.
+func (w *World) registerScheduledTask(kind string, handler scheduledTaskHandler) {
+	if w == nil || kind == "" || handler == nil {
+		return
+	}
+	if w.scheduledTaskHandlers == nil {
+		w.scheduledTaskHandlers = make(map[string]scheduledTaskHandler)
+	}
+	w.scheduledTaskHandlers[kind] = handler
+}
+
+// scheduleTask defers a task of the given kind by delayTicks from the current
+// tick and returns its ID. A delay of zero is treated as one so a task queued
+// while tasks are firing never runs in the same pass.
+func (w *World) scheduleTask(delayTicks uint64, kind, actorID string, params map[string]float64) uint64 {
+	if w == nil || kind == "" {
+		return 0
+	}
+	if delayTicks == 0 {
+		delayTicks = 1
+	}
+	w.nextScheduledTaskID++
+	task := scheduledTask{
+		ID:      w.nextScheduledTaskID,
+		Tick:    w.currentTick + delayTicks,
+		Kind:    kind,
+		ActorID: actorID,
+	}
+	if len(params) > 0 {
+		task.Params = make(map[string]float64, len(params))
+		for key, value := range params {
+			task.Params[key] = value
+		}
+	}
+
+	// Keep pending tasks ordered by tick, then by ID, so tasks due on the same
+	// tick fire in the order they were scheduled.
+	idx := sort.Search(len(w.scheduledTasks), func(i int) bool {
+		return w.scheduledTasks[i].Tick > task.Tick
+	})
+	w.scheduledTasks = append(w.scheduledTasks, scheduledTask{})
+	copy(w.scheduledTasks[idx+1:], w.scheduledTasks[idx:])
+	w.scheduledTasks[idx] = task
+	return task.ID
+}
+
+// runScheduledTasks fires every pending task whose target tick has been
+// reached. Tasks with no registered handler are dropped.
+func (w *World) runScheduledTasks(tick uint64, now time.Time) {
+	if w == nil || len(w.scheduledTasks) == 0 {
+		return
+	}
+	due := 0
+	for due < len(w.scheduledTasks) && w.scheduledTasks[due].Tick <= tick {
+		due++
+	}
+	if due == 0 {
+		return
+	}
+	fired := make([]scheduledTask, due)
+	copy(fired, w.scheduledTasks[:due])
+	w.scheduledTasks = append(w.scheduledTasks[:0], w.scheduledTasks[due:]...)
+
+	for _, task := range fired {
+		if handler, ok := w.scheduledTaskHandlers[task.Kind]; ok {
+			handler(task, now)
+		}
+	}
+}
+
+// pendingScheduledTask returns the earliest pending task of the given kind for
+// the actor.
+func (w *World) pendingScheduledTask(kind, actorID string) (scheduledTask, bool) {
+	if w == nil {
+		return scheduledTask{}, false
+	}
+	for _, task := range w.scheduledTasks {
+		if task.Kind == kind && task.ActorID == actorID {
+			return task, true
+		}
+	}
+	return scheduledTask{}, false
+}
+
+// cancelScheduledTasks drops every pending task of the given kind for the
+// actor and reports how many were removed.
+func (w *World) cancelScheduledTasks(kind, actorID string) int {
+	if w == nil || len(w.scheduledTasks) == 0 {
+		return 0
+	}
+	kept := w.scheduledTasks[:0]
+	for _, task := range w.scheduledTasks {
+		if task.Kind == kind && task.ActorID == actorID {
+			continue
+		}
+		kept = append(kept, task)
+	}
+	removed := len(w.scheduledTasks) - len(kept)
+	w.scheduledTasks = kept
+	return removed
+}
+
+// scheduledTasksSnapshot returns a copy of the pending tasks in firing order
+// for inclusion in keyframes.
+func (w *World) scheduledTasksSnapshot() []scheduledTask {
+	if w == nil {
+		return nil
+	}
+	return simutil.CloneScheduledTasks(w.scheduledTasks)
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+func TestScheduledTaskFiresExactlyOnTargetTick(t *testing.T) {
+	world := newTestWorld(fullyFeaturedTestWorldConfig(), logging.NopPublisher{})
+	now := time.Unix(0, 0)
+	dt := 1.0 / float64(tickRate)
+
+	world.Step(1, now, dt, nil, nil)
+
+	var firedAt []uint64
+	world.registerScheduledTask("test.ping", func(task scheduledTask, _ time.Time) {
+		if task.ActorID != "player-1" || task.Params["value"] != 7 {
+			t.Fatalf("unexpected task payload: %+v", task)
+		}
+		firedAt = append(firedAt, world.currentTick)
+	})
+	id := world.scheduleTask(3, "test.ping", "player-1", map[string]float64{"value": 7})
+
+	pending := world.scheduledTasksSnapshot()
+	if len(pending) != 1 || pending[0].ID != id || pending[0].Tick != 4 {
+		t.Fatalf("expected pending task for tick 4, got %+v", pending)
+	}
+
+	for tick := uint64(2); tick <= 6; tick++ {
+		now = now.Add(time.Second / time.Duration(tickRate))
+		world.Step(tick, now, dt, nil, nil)
+		if tick < 4 && len(firedAt) != 0 {
+			t.Fatalf("task fired early on tick %d", tick)
+		}
+	}
+
+	if len(firedAt) != 1 || firedAt[0] != 4 {
+		t.Fatalf("expected task to fire once on tick 4, fired on %v", firedAt)
+	}
+	if pending := world.scheduledTasksSnapshot(); len(pending) != 0 {
+		t.Fatalf("expected no pending tasks after firing, got %+v", pending)
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import "math"
+
+// recordSessionHit credits an applied health change to the session stats of
+// the players involved. Only damage counts; heals and self-inflicted damage
+// are ignored for the dealer. Kills and assists are scored by
+// recordKillCredit.
+func (w *World) recordSessionHit(ownerID, targetID string, delta float64) {
+	if w == nil || delta >= 0 {
+		return
+	}
+	damage := -delta
+
+	if target, ok := w.players[targetID]; ok && target != nil {
+		target.Session.DamageTaken += damage
+	}
+
+	owner, ok := w.players[ownerID]
+	if !ok || owner == nil || ownerID == targetID {
+		return
+	}
+	owner.Session.DamageDealt += damage
+}
+
+// recordSessionGold adds gold the player picked up or mined.
+func (w *World) recordSessionGold(playerID string, quantity int) {
+	if w == nil || quantity <= 0 {
+		return
+	}
+	if player, ok := w.players[playerID]; ok && player != nil {
+		player.Session.GoldCollected += quantity
+	}
+}
+
+// recordSessionDistance adds the length of a movement step before the player
+// is moved to (x, y).
+func (w *World) recordSessionDistance(playerID string, x, y float64) {
+	if w == nil {
+		return
+	}
+	if player, ok := w.players[playerID]; ok && player != nil {
+		player.Session.DistanceTraveled += math.Hypot(x-player.X, y-player.Y)
+	}
+}
+
+// SessionStats returns the player's session stats.
+func (h *Hub) SessionStats(playerID string) (SessionStats, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	player, ok := h.world.players[playerID]
+	if !ok || player == nil {
+		return SessionStats{}, false
+	}
+	return player.Session, true
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSessionStatsCountDamageKillsGoldAndDistance(t *testing.T) {
+	hub := newHubWithFullWorld()
+	now := time.Now()
+
+	attacker := newTestPlayerState("attacker")
+	attacker.X = 200
+	attacker.Y = 200
+	attacker.Facing = FacingRight
+	attacker.LastHeartbeat = now
+	attacker.Cooldowns = make(map[string]time.Time)
+	hub.world.players[attacker.ID] = attacker
+
+	target := newTestPlayerState("target")
+	target.X = 200 + playerHalf + meleeAttackReach/2
+	target.Y = 200
+	target.Facing = FacingLeft
+	target.LastHeartbeat = now
+	hub.world.players[target.ID] = target
+
+	if _, ok, reason := hub.HandleAction(attacker.ID, effectTypeAttack); !ok {
+		t.Fatalf("expected melee attack to be accepted, got %q", reason)
+	}
+	runAdvance(hub, 1.0/float64(tickRate))
+
+	dealt, _ := hub.SessionStats(attacker.ID)
+	taken, _ := hub.SessionStats(target.ID)
+	if math.Abs(dealt.DamageDealt-meleeAttackDamage) > 1e-6 || dealt.Kills != 0 {
+		t.Fatalf("expected attacker to have dealt %.1f damage and no kills, got %+v", meleeAttackDamage, dealt)
+	}
+	if math.Abs(taken.DamageTaken-meleeAttackDamage) > 1e-6 || taken.DamageDealt != 0 {
+		t.Fatalf("expected target to have taken %.1f damage, got %+v", meleeAttackDamage, taken)
+	}
+
+	hub.mu.Lock()
+	hub.world.SetHealth(target.ID, 1)
+	attacker.Cooldowns[effectTypeAttack] = time.Now().Add(-meleeAttackCooldown)
+	hub.mu.Unlock()
+	if _, ok, reason := hub.HandleAction(attacker.ID, effectTypeAttack); !ok {
+		t.Fatalf("expected finishing attack to be accepted, got %q", reason)
+	}
+	runAdvance(hub, 1.0/float64(tickRate))
+	dealt, _ = hub.SessionStats(attacker.ID)
+	if dealt.Kills != 1 || math.Abs(dealt.DamageDealt-(meleeAttackDamage+1)) > 1e-6 {
+		t.Fatalf("expected the finishing blow to score a kill for 1 damage, got %+v", dealt)
+	}
+
+	if err := hub.world.MutateInventory(target.ID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 7})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed target gold: %v", err)
+	}
+	if ack, _ := hub.HandleConsoleCommand(target.ID, "drop_gold", 7); ack.Status != "ok" {
+		t.Fatalf("expected drop to succeed, ack=%+v", ack)
+	}
+	hub.mu.Lock()
+	hub.world.SetPosition(attacker.ID, target.X, target.Y)
+	hub.mu.Unlock()
+	if ack, _ := hub.HandleConsoleCommand(attacker.ID, "pickup_gold", 0); ack.Status != "ok" {
+		t.Fatalf("expected pickup to succeed, ack=%+v", ack)
+	}
+	if stats, _ := hub.SessionStats(attacker.ID); stats.GoldCollected != 7 {
+		t.Fatalf("expected attacker to have collected 7 gold, got %+v", stats)
+	}
+
+	if _, ok, reason := hub.UpdateIntent(attacker.ID, 0, 1, string(FacingDown)); !ok {
+		t.Fatalf("expected intent to be accepted, got %q", reason)
+	}
+	runAdvance(hub, 1.0/float64(tickRate))
+	if stats, _ := hub.SessionStats(attacker.ID); stats.DistanceTraveled <= 0 {
+		t.Fatalf("expected movement to add distance traveled, got %+v", stats)
+	}
+
+	var reported bool
+	for _, player := range hub.DiagnosticsSnapshot() {
+		if player.ID == attacker.ID {
+			reported = player.Session.Kills == 1 && player.Session.GoldCollected == 7
+		}
+	}
+	if !reported {
+		t.Fatalf("expected diagnostics to report the attacker's session stats")
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// stashReach is the extra distance beyond the player's body that still
+// counts as standing next to a stash.
+const stashReach = tileSize / 2
+
+var (
+	errStashUnknownActor         = errors.New("unknown_actor")
+	errStashNotNearby            = errors.New("no_stash_nearby")
+	errStashInvalidInventorySlot = errors.New("invalid_inventory_slot")
+	errStashInvalidSlot          = errors.New("invalid_stash_slot")
+	errStashEmptySlot            = errors.New("empty_slot")
+)
+
+// stashFor returns the persistent stash belonging to playerID, creating it on
+// first use. Stashes are keyed by player ID so they outlive the carried
+// inventory, which is dropped on death.
+func (w *World) stashFor(playerID string) *Inventory {
+	if w.stashes == nil {
+		w.stashes = make(map[string]*Inventory)
+	}
+	stash, ok := w.stashes[playerID]
+	if !ok {
+		inv := NewInventory()
+		stash = &inv
+		w.stashes[playerID] = stash
+	}
+	return stash
+}
+
+// StashContents returns a copy of the player's stash.
+func (w *World) StashContents(playerID string) Inventory {
+	if w == nil {
+		return NewInventory()
+	}
+	stash, ok := w.stashes[playerID]
+	if !ok || stash == nil {
+		return NewInventory()
+	}
+	return stash.Clone()
+}
+
+// hasStash reports whether a stash is kept under key.
+func (w *World) hasStash(key string) bool {
+	if w == nil {
+		return false
+	}
+	_, ok := w.stashes[key]
+	return ok
+}
+
+// nearStash reports whether the player stands within reach of any stash obstacle.
+func (w *World) nearStash(player *playerState) bool {
+	if w == nil || player == nil {
+		return false
+	}
+	for _, obs := range w.obstacles {
+		if obs.Type != obstacleTypeStash {
+			continue
+		}
+		if circleRectOverlap(player.X, player.Y, playerHalf+stashReach, obs) {
+			return true
+		}
+	}
+	return false
+}
+
+// DepositToStash moves the full stack in inventorySlot into the player's stash.
+func (w *World) DepositToStash(playerID string, inventorySlot int) (ItemStack, error) {
+	if w == nil {
+		return ItemStack{}, fmt.Errorf("world not initialised")
+	}
+	player, ok := w.players[playerID]
+	if !ok {
+		return ItemStack{}, errStashUnknownActor
+	}
+	if !w.nearStash(player) {
+		return ItemStack{}, errStashNotNearby
+	}
+	if inventorySlot < 0 || inventorySlot >= len(player.Inventory.Slots) {
+		return ItemStack{}, errStashInvalidInventorySlot
+	}
+	slot := player.Inventory.Slots[inventorySlot]
+	if slot.Item.Quantity <= 0 || slot.Item.Type == "" {
+		return ItemStack{}, errStashEmptySlot
+	}
+
+	var removed ItemStack
+	if err := w.mutateActorInventory(&player.ActorState, &player.Version, playerID, PatchPlayerInventory, func(inv *Inventory) error {
+		var innerErr error
+		removed, innerErr = inv.RemoveQuantity(inventorySlot, slot.Item.Quantity)
+		return innerErr
+	}); err != nil {
+		return ItemStack{}, err
+	}
+
+	if _, err := w.stashFor(playerID).AddStack(removed); err != nil {
+		_ = w.mutateActorInventory(&player.ActorState, &player.Version, playerID, PatchPlayerInventory, func(inv *Inventory) error {
+			_, addErr := inv.AddStack(removed)
+			return addErr
+		})
+		return ItemStack{}, err
+	}
+	return removed, nil
+}
+
+// WithdrawFromStash moves the full stack in stashSlot back into the player's inventory.
+func (w *World) WithdrawFromStash(playerID string, stashSlot int) (ItemStack, error) {
+	if w == nil {
+		return ItemStack{}, fmt.Errorf("world not initialised")
+	}
+	player, ok := w.players[playerID]
+	if !ok {
+		return ItemStack{}, errStashUnknownActor
+	}
+	if !w.nearStash(player) {
+		return ItemStack{}, errStashNotNearby
+	}
+	stash := w.stashFor(playerID)
+	if stashSlot < 0 || stashSlot >= len(stash.Slots) {
+		return ItemStack{}, errStashInvalidSlot
+	}
+	slot := stash.Slots[stashSlot]
+	if slot.Item.Quantity <= 0 || slot.Item.Type == "" {
+		return ItemStack{}, errStashEmptySlot
+	}
+
+	removed, err := stash.RemoveQuantity(stashSlot, slot.Item.Quantity)
+	if err != nil {
+		return ItemStack{}, err
+	}
+
+	if err := w.mutateActorInventory(&player.ActorState, &player.Version, playerID, PatchPlayerInventory, func(inv *Inventory) error {
+		_, addErr := inv.AddStack(removed)
+		return addErr
+	}); err != nil {
+		_, _ = stash.AddStack(removed)
+		return ItemStack{}, err
+	}
+	return removed, nil
+}
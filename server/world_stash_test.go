@@ -0,0 +1,106 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStashKeepsDepositedGoldAcrossDeath(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Stash = true
+	hub := newHub()
+	hub.ResetWorld(cfg)
+
+	var stash *Obstacle
+	for i := range hub.world.obstacles {
+		if hub.world.obstacles[i].Type == obstacleTypeStash {
+			stash = &hub.world.obstacles[i]
+			break
+		}
+	}
+	if stash == nil {
+		t.Fatalf("expected stash obstacle to be generated")
+	}
+
+	playerID := "player-stash"
+	player := newTestPlayerState(playerID)
+	hub.world.AddPlayer(player)
+	hub.world.SetPosition(playerID, stash.X-playerHalf-1, stash.Y+stash.Height/2)
+
+	if err := hub.world.MutateInventory(playerID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 25})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+
+	ack, ok := hub.HandleConsoleCommand(playerID, "stash_deposit", 0)
+	if !ok {
+		t.Fatalf("expected stash_deposit to be handled")
+	}
+	if ack.Status != "ok" || ack.Qty != 25 {
+		t.Fatalf("expected deposit of 25 gold, got %+v", ack)
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != 0 {
+		t.Fatalf("expected carried gold to be banked, have %d", qty)
+	}
+	if qty := hub.world.StashContents(playerID).QuantityOf(ItemTypeGold); qty != 25 {
+		t.Fatalf("expected stash to hold 25 gold, got %d", qty)
+	}
+
+	if err := hub.world.MutateInventory(playerID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 5})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed carried gold: %v", err)
+	}
+
+	lethal := &effectState{Type: effectTypeAttack, Owner: "stash-killer", Params: map[string]float64{"healthDelta": -(player.Health + 5)}}
+	hub.world.invokePlayerHitCallback(lethal, player, time.Now())
+	if player.Health > 0 {
+		t.Fatalf("expected player to die, health %.2f", player.Health)
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != 0 {
+		t.Fatalf("expected carried gold to drop on death, have %d", qty)
+	}
+
+	ack, ok = hub.HandleConsoleCommand(playerID, "stash_withdraw", 0)
+	if !ok {
+		t.Fatalf("expected stash_withdraw to be handled")
+	}
+	if ack.Status != "ok" || ack.Qty != 25 {
+		t.Fatalf("expected withdrawal of 25 gold, got %+v", ack)
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != 25 {
+		t.Fatalf("expected banked gold back in inventory, have %d", qty)
+	}
+	if qty := hub.world.StashContents(playerID).QuantityOf(ItemTypeGold); qty != 0 {
+		t.Fatalf("expected stash to be empty after withdrawal, got %d", qty)
+	}
+}
+
+func TestStashRequiresAdjacency(t *testing.T) {
+	cfg := fullyFeaturedTestWorldConfig()
+	cfg.Stash = true
+	hub := newHub()
+	hub.ResetWorld(cfg)
+
+	playerID := "player-far"
+	player := newTestPlayerState(playerID)
+	hub.world.AddPlayer(player)
+	hub.world.SetPosition(playerID, 0, 0)
+	if err := hub.world.MutateInventory(playerID, func(inv *Inventory) error {
+		_, err := inv.AddStack(ItemStack{Type: ItemTypeGold, Quantity: 3})
+		return err
+	}); err != nil {
+		t.Fatalf("failed to seed gold: %v", err)
+	}
+
+	ack, _ := hub.HandleConsoleCommand(playerID, "stash_deposit", 0)
+	if ack.Status != "error" || ack.Reason != "no_stash_nearby" {
+		t.Fatalf("expected no_stash_nearby error, got %+v", ack)
+	}
+	if qty := player.Inventory.QuantityOf(ItemTypeGold); qty != 3 {
+		t.Fatalf("expected inventory to be untouched, have %d", qty)
+	}
+}
@@ -0,0 +1,195 @@
+package server
+
+import "time"
+
+const (
+	// actionStealth gives the caster the stealthed status through the
+	// "stealth" action.
+	actionStealth   = "stealth"
+	stealthDuration = 8 * time.Second
+	stealthCooldown = 16 * time.Second
+
+	// effectTypeDetect is the reveal ping cast through the "detect" action.
+	effectTypeDetect = "detect"
+	detectRadius     = 240.0
+	detectDuration   = 3 * time.Second
+	detectCooldown   = 8 * time.Second
+)
+
+// castStealth hides a living caster from other players for stealthDuration.
+// Casts inside stealthCooldown of the previous one are ignored.
+func (w *World) castStealth(casterID string, now time.Time) {
+	actor := w.actorByID(casterID)
+	if actor == nil || actor.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, actionStealth, stealthCooldown, now) {
+		return
+	}
+	w.applyStatusEffect(actor, StatusEffectStealthed, casterID, now)
+}
+
+// SetActorStealthed applies or clears the stealthed status on an actor without
+// going through the stealth action's cooldown.
+func (w *World) SetActorStealthed(id string, stealthed bool, now time.Time) {
+	if w == nil || id == "" {
+		return
+	}
+	actor := w.actorByID(id)
+	if actor == nil {
+		return
+	}
+	if !stealthed {
+		delete(actor.StatusEffects, StatusEffectStealthed)
+		return
+	}
+	if actor.Health > 0 {
+		w.applyStatusEffect(actor, StatusEffectStealthed, id, now)
+	}
+}
+
+// isStealthed reports whether the actor is alive and carries the stealthed
+// status.
+func isStealthed(actor *actorState) bool {
+	return actor != nil && actor.Health > 0 && actor.StatusEffects[StatusEffectStealthed] != nil
+}
+
+// breakStealthOnDeath strips the stealthed status from actors that died this
+// tick so they show up in every snapshot and stay visible if revived.
+func (w *World) breakStealthOnDeath() {
+	for _, player := range w.players {
+		if player != nil && player.Health <= 0 {
+			delete(player.StatusEffects, StatusEffectStealthed)
+		}
+	}
+	for _, npc := range w.npcs {
+		if npc != nil && npc.Health <= 0 {
+			delete(npc.StatusEffects, StatusEffectStealthed)
+		}
+	}
+}
+
+// castDetect registers a detect area centred on the caster. While it lasts,
+// stealthed actors inside its radius are visible to the caster. Casts inside
+// detectCooldown of the previous one are ignored.
+func (w *World) castDetect(casterID string, now time.Time) {
+	caster := w.actorByID(casterID)
+	if caster == nil || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, effectTypeDetect, detectCooldown, now) {
+		return
+	}
+	id := w.allocateEffectID()
+	effect := &effectState{
+		ID:        id,
+		Type:      effectTypeDetect,
+		Owner:     casterID,
+		Start:     now.UnixMilli(),
+		Duration:  detectDuration.Milliseconds(),
+		X:         caster.X - detectRadius,
+		Y:         caster.Y - detectRadius,
+		Width:     detectRadius * 2,
+		Height:    detectRadius * 2,
+		Params:    map[string]float64{"radius": detectRadius},
+		ExpiresAt: now.Add(detectDuration),
+	}
+	if !w.registerEffect(effect) {
+		return
+	}
+	w.recordEffectSpawn(effectTypeDetect, "ability")
+}
+
+// stealthedActors returns every actor currently hidden by the stealthed
+// status, keyed by ID.
+func (w *World) stealthedActors() map[string]*actorState {
+	if w == nil {
+		return nil
+	}
+	var stealthed map[string]*actorState
+	add := func(actor *actorState) {
+		if !isStealthed(actor) {
+			return
+		}
+		if stealthed == nil {
+			stealthed = make(map[string]*actorState)
+		}
+		stealthed[actor.ID] = actor
+	}
+	for _, player := range w.players {
+		if player != nil {
+			add(&player.ActorState)
+		}
+	}
+	for _, npc := range w.npcs {
+		if npc != nil {
+			add(&npc.ActorState)
+		}
+	}
+	return stealthed
+}
+
+// hiddenActorsFor returns the stealthed actors the viewer cannot see. Viewers
+// always see themselves, and see others inside one of their own detect areas.
+func (w *World) hiddenActorsFor(viewerID string, stealthed map[string]*actorState) map[string]struct{} {
+	if w == nil || len(stealthed) == 0 {
+		return nil
+	}
+	var hidden map[string]struct{}
+	for id, actor := range stealthed {
+		if id == viewerID || w.revealedBy(viewerID, actor) {
+			continue
+		}
+		if hidden == nil {
+			hidden = make(map[string]struct{})
+		}
+		hidden[id] = struct{}{}
+	}
+	return hidden
+}
+
+// hiddenEffectsFor returns the IDs of live effects owned by or following a
+// hidden actor, whose position would otherwise give the actor away.
+func (w *World) hiddenEffectsFor(hidden map[string]struct{}) map[string]struct{} {
+	if w == nil || len(hidden) == 0 {
+		return nil
+	}
+	effects := make(map[string]struct{})
+	for _, eff := range w.effects {
+		if eff == nil {
+			continue
+		}
+		if _, ok := hidden[eff.Owner]; ok {
+			effects[eff.ID] = struct{}{}
+		}
+	}
+	if w.effectManager != nil {
+		for id, instance := range w.effectManager.Instances() {
+			if instance == nil {
+				continue
+			}
+			_, owner := hidden[instance.OwnerActorID]
+			_, follow := hidden[instance.FollowActorID]
+			if owner || follow {
+				effects[id] = struct{}{}
+			}
+		}
+	}
+	return effects
+}
+
+// revealedBy reports whether one of the viewer's detect areas covers actor.
+func (w *World) revealedBy(viewerID string, actor *actorState) bool {
+	for _, eff := range w.effects {
+		if eff == nil || eff.Type != effectTypeDetect || eff.Owner != viewerID {
+			continue
+		}
+		radius := eff.Params["radius"]
+		dx := actor.X - (eff.X + eff.Width/2)
+		dy := actor.Y - (eff.Y + eff.Height/2)
+		if dx*dx+dy*dy <= radius*radius {
+			return true
+		}
+	}
+	return false
+}
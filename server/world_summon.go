@@ -0,0 +1,139 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	ai "mine-and-die/server/internal/ai"
+	stats "mine-and-die/server/stats"
+)
+
+const (
+	// actionSummon calls a familiar to fight beside the caster through the
+	// "summon" action.
+	actionSummon     = "summon"
+	summonLifetime   = 20 * time.Second
+	summonSpawnReach = 3 * playerHalf
+	summonCooldown   = 15 * time.Second
+)
+
+// castSummon spawns a familiar next to a living player. Each player commands
+// at most one familiar, so summoning again dismisses the previous one. Casts
+// inside summonCooldown of the previous one are ignored.
+func (w *World) castSummon(casterID string, now time.Time) {
+	caster, ok := w.players[casterID]
+	if !ok || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, actionSummon, summonCooldown, now) {
+		return
+	}
+	for _, id := range w.summonsOf(casterID) {
+		w.despawnNPC(w.npcs[id])
+	}
+
+	dx, dy := facingToVector(caster.Facing)
+	familiar := w.spawnFamiliarAt(caster.X+dx*summonSpawnReach, caster.Y+dy*summonSpawnReach, casterID)
+	familiar.DespawnTick = w.currentTick + uint64(durationToTicksAt(summonLifetime, w.ticksPerSecond()))
+}
+
+// spawnFamiliarAt adds a familiar owned by ownerID at the given position. It
+// fights for its owner's faction and carries no loot.
+func (w *World) spawnFamiliarAt(x, y float64, ownerID string) *npcState {
+	w.nextNPCID++
+	id := fmt.Sprintf("npc-familiar-%d", w.nextNPCID)
+	statsComp := stats.DefaultComponent(stats.ArchetypeFamiliar)
+	maxHealth := statsComp.GetDerived(stats.DerivedMaxHealth)
+
+	familiar := &npcState{
+		ActorState: actorState{
+			Actor: Actor{
+				ID:        id,
+				X:         x,
+				Y:         y,
+				Facing:    defaultFacing,
+				Health:    maxHealth,
+				MaxHealth: maxHealth,
+				Inventory: NewInventory(),
+				Equipment: NewEquipment(),
+			},
+		},
+		Stats:     statsComp,
+		Type:      NPCTypeFamiliar,
+		Home:      vec2{X: x, Y: y},
+		Cooldowns: make(map[string]time.Time),
+		OwnerID:   ownerID,
+	}
+	ai.BootstrapNPC(ai.SpawnBootstrapConfig{
+		Library:    w.aiLibrary,
+		Type:       string(NPCTypeFamiliar),
+		ConfigID:   &familiar.AIConfigID,
+		State:      &familiar.AIState,
+		Blackboard: &familiar.Blackboard,
+	})
+
+	resolveObstaclePenetration(&familiar.ActorState, w.obstacles, w.bounds())
+	familiar.Blackboard.LastPos = vec2{X: familiar.X, Y: familiar.Y}
+	w.npcs[familiar.ID] = familiar
+	return familiar
+}
+
+// expireSummons removes summoned NPCs whose lifetime has run out or whose
+// owner has died or left the world.
+func (w *World) expireSummons() {
+	expired := make([]string, 0)
+	for id, npc := range w.npcs {
+		if npc == nil || npc.OwnerID == "" {
+			continue
+		}
+		owner, ok := w.players[npc.OwnerID]
+		if !ok || owner.Health <= 0 || w.currentTick >= npc.DespawnTick {
+			expired = append(expired, id)
+		}
+	}
+	sort.Strings(expired)
+	for _, id := range expired {
+		w.despawnNPC(w.npcs[id])
+	}
+}
+
+// summonsOf lists the IDs of the NPCs summoned by ownerID in sorted order.
+func (w *World) summonsOf(ownerID string) []string {
+	ids := make([]string, 0)
+	for id, npc := range w.npcs {
+		if npc != nil && npc.OwnerID == ownerID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// despawnNPC removes the NPC without the loot and rewards of a defeat.
+func (w *World) despawnNPC(npc *npcState) {
+	if npc == nil {
+		return
+	}
+	if _, ok := w.npcs[npc.ID]; !ok {
+		return
+	}
+	delete(w.npcs, npc.ID)
+	w.purgeEntityPatches(npc.ID)
+}
+
+// summonAllies reports whether two actors fight for the same side and at least
+// one of them is a summoned NPC. Melee swings pass over such pairs so a
+// familiar never strikes its owner and players never strike their familiars.
+func (w *World) summonAllies(a, b string) bool {
+	if !w.isSummon(a) && !w.isSummon(b) {
+		return false
+	}
+	return w.sameFaction(a, b)
+}
+
+// isSummon reports whether the actor is an NPC summoned by a player.
+func (w *World) isSummon(id string) bool {
+	npc, ok := w.npcs[id]
+	return ok && npc != nil && npc.OwnerID != ""
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	ai "mine-and-die/server/internal/ai"
+)
+
+const (
+	// actionTaunt forces nearby NPCs onto the caster through the "taunt"
+	// action.
+	actionTaunt   = "taunt"
+	tauntRadius   = 160.0
+	tauntDuration = 4 * time.Second
+	tauntCooldown = 8 * time.Second
+)
+
+// castTaunt makes every living NPC within tauntRadius of the caster target it
+// for tauntDuration, overriding the target the NPC's AI would otherwise pick.
+// Idle and wandering NPCs switch into their chase state like aggroed ones.
+// Only players can taunt, and casts inside tauntCooldown of the previous one
+// are ignored. Summons on the caster's side and archetypes without a chase
+// state ignore it.
+func (w *World) castTaunt(casterID string, now time.Time) {
+	caster, ok := w.players[casterID]
+	if !ok || caster.Health <= 0 {
+		return
+	}
+	if !w.readyAbility(casterID, actionTaunt, tauntCooldown, now) {
+		return
+	}
+
+	ids := make([]string, 0, len(w.npcs))
+	for id, npc := range w.npcs {
+		if npc == nil || npc.Health <= 0 || w.sameFaction(casterID, id) {
+			continue
+		}
+		if math.Hypot(npc.X-caster.X, npc.Y-caster.Y) > tauntRadius {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	until := w.currentTick + uint64(durationToTicksAt(tauntDuration, w.ticksPerSecond()))
+	for _, id := range ids {
+		npc := w.npcs[id]
+		cfg := w.aiLibrary.ConfigByID(npc.AIConfigID)
+		if cfg == nil {
+			continue
+		}
+		if _, ok := cfg.StateForBehavior(ai.BehaviorChase); !ok {
+			continue
+		}
+		npc.Blackboard.TauntedBy = casterID
+		npc.Blackboard.TauntUntil = until
+		w.aggroNPC(npc, casterID)
+	}
+}
+
+// tauntedByOther reports whether the NPC is held by a taunt from someone other
+// than targetID.
+func (w *World) tauntedByOther(npc *npcState, targetID string) bool {
+	taunter := npc.Blackboard.TauntedBy
+	return taunter != "" && taunter != targetID && w.currentTick < npc.Blackboard.TauntUntil
+}
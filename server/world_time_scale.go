@@ -0,0 +1,54 @@
+package server
+
+import "time"
+
+const (
+	minTimeScale = 0.05
+	maxTimeScale = 4.0
+)
+
+// SetTimeScale multiplies every subsequent step delta by scale, e.g. 0.25 for
+// slow motion. Non-positive values restore real time; others are limited to
+// the [minTimeScale, maxTimeScale] range. Contract effects counted in ticks
+// (EffectIntent.DurationTicks and definition lifetimes) are excluded and keep
+// ending after the same number of ticks.
+func (w *World) SetTimeScale(scale float64) {
+	if w == nil {
+		return
+	}
+	if scale <= 0 {
+		scale = 1
+	}
+	if scale < minTimeScale {
+		scale = minTimeScale
+	}
+	if scale > maxTimeScale {
+		scale = maxTimeScale
+	}
+	w.timeScale = scale
+}
+
+// TimeScale reports the active time-scale multiplier.
+func (w *World) TimeScale() float64 {
+	if w == nil || w.timeScale <= 0 {
+		return 1
+	}
+	return w.timeScale
+}
+
+// scaleStep applies the time scale to a step. It returns the scaled delta and
+// the gameplay clock, which drifts from wall time by the accumulated
+// difference so cooldowns and effect expiry slow down along with movement.
+// Heartbeat bookkeeping keeps using wall time.
+func (w *World) scaleStep(now time.Time, dt float64) (time.Time, float64) {
+	scale := w.TimeScale()
+	if scale != 1 {
+		scaled := dt * scale
+		w.timeOffset += time.Duration((scaled - dt) * float64(time.Second))
+		dt = scaled
+	}
+	if w.timeOffset == 0 {
+		return now, dt
+	}
+	return now.Add(w.timeOffset), dt
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	ai "mine-and-die/server/internal/ai"
+)
+
+// scheduledTaskNPCWave spawns the next NPC respawn wave. Its "wave" param
+// carries the wave number.
+const scheduledTaskNPCWave = "npcWave"
+
+// queueNextNPCWave schedules the next respawn wave once every wild NPC has
+// been defeated. Worlds without waves configured, with a wave already pending,
+// or that have run through MaxWaves are left alone.
+func (w *World) queueNextNPCWave() {
+	waves := w.config.Waves
+	if waves == nil || !w.config.NPCs {
+		return
+	}
+	if waves.MaxWaves > 0 && w.npcWave >= waves.MaxWaves {
+		return
+	}
+	for _, npc := range w.npcs {
+		if npc != nil && npc.OwnerID == "" {
+			return
+		}
+	}
+	if _, pending := w.pendingScheduledTask(scheduledTaskNPCWave, ""); pending {
+		return
+	}
+	delay := uint64(math.Ceil(waves.DelaySeconds * float64(w.ticksPerSecond())))
+	w.scheduleTask(delay, scheduledTaskNPCWave, "", map[string]float64{"wave": float64(w.npcWave + 1)})
+}
+
+// spawnNPCWave spawns the goblins and rats of the task's wave. Each wave draws
+// its positions from its own seeded stream, so a replay of the same seed
+// respawns every wave in the same places.
+func (w *World) spawnNPCWave(task scheduledTask, _ time.Time) {
+	wave := int(task.Params["wave"])
+	if wave <= w.npcWave {
+		return
+	}
+	w.npcWave = wave
+
+	goblins, rats := w.config.WaveComposition(wave)
+	spawner := w.npcSpawner()
+	spawner.SubsystemRNGFunc = func(label string) *rand.Rand {
+		return w.subsystemRNG(fmt.Sprintf("waves.%d.%s", wave, label))
+	}
+	ai.SpawnExtraGoblins(spawner, goblins)
+	ai.SpawnExtraRats(spawner, rats)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"mine-and-die/server/logging"
+)
+
+// countNPCTypes tallies the world's NPCs by type.
+func countNPCTypes(w *World) map[NPCType]int {
+	counts := make(map[NPCType]int)
+	for _, npc := range w.npcs {
+		counts[npc.Type]++
+	}
+	return counts
+}
+
+// npcPositions records where every NPC stands, keyed by ID.
+func npcPositions(w *World) map[string]vec2 {
+	positions := make(map[string]vec2, len(w.npcs))
+	for id, npc := range w.npcs {
+		positions[id] = vec2{X: npc.X, Y: npc.Y}
+	}
+	return positions
+}
+
+func TestClearingAWaveSpawnsTheNextAfterTheDelay(t *testing.T) {
+	runWaves := func() []map[string]vec2 {
+		cfg := fullyFeaturedTestWorldConfig()
+		cfg.GoblinCount = 1
+		cfg.RatCount = 1
+		cfg.NPCCount = 2
+		cfg.Waves = &WaveConfig{DelaySeconds: 2, GoblinsPerWave: 1, RatsPerWave: 2}
+		w := newTestWorld(cfg, logging.NopPublisher{})
+		w.obstacles = nil
+
+		dt := 1.0 / float64(tickRate)
+		now := time.Unix(0, 0)
+		tick := uint64(0)
+		step := func() {
+			tick++
+			w.Step(tick, now, dt, nil, nil)
+			now = now.Add(time.Second / tickRate)
+		}
+		delay := 2 * tickRate
+
+		spawned := make([]map[string]vec2, 0, 2)
+		for wave := 1; wave <= 2; wave++ {
+			for _, npc := range w.npcs {
+				npc.Health = 0
+			}
+			step()
+			if len(w.npcs) != 0 {
+				t.Fatalf("expected wave %d to be cleared, %d NPCs left", wave-1, len(w.npcs))
+			}
+			for i := 1; i < delay; i++ {
+				step()
+				if len(w.npcs) != 0 {
+					t.Fatalf("expected wave %d to wait out the delay, spawned %d ticks after the clear", wave, i)
+				}
+			}
+			step()
+
+			want := map[NPCType]int{NPCTypeGoblin: 1 + wave, NPCTypeRat: 1 + 2*wave}
+			if got := countNPCTypes(w); !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected wave %d composition %v, got %v", wave, want, got)
+			}
+			spawned = append(spawned, npcPositions(w))
+		}
+		return spawned
+	}
+
+	first := runWaves()
+	if !reflect.DeepEqual(first, runWaves()) {
+		t.Fatalf("expected waves to respawn deterministically from the seed")
+	}
+}
@@ -11,7 +11,9 @@ import (
 )
 
 func Execute(stdout io.Writer, stderr io.Writer, args []string) error {
-	_ = stdout
+	if len(args) > 0 && args[0] == "verify" {
+		return executeVerify(stdout, stderr, args[1:])
+	}
 
 	flagSet := flag.NewFlagSet("effectsgen", flag.ContinueOnError)
 	flagSet.SetOutput(stderr)
@@ -90,3 +92,57 @@ func Execute(stdout io.Writer, stderr io.Writer, args []string) error {
 
 	return pipeline.Run(options)
 }
+
+// executeVerify runs the verify subcommand: it loads the contracts and catalog,
+// validates the registry, and optionally checks the committed generated files,
+// reporting every issue at once.
+func executeVerify(stdout io.Writer, stderr io.Writer, args []string) error {
+	flagSet := flag.NewFlagSet("effectsgen verify", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+
+	var opts pipeline.VerifyOptions
+
+	flagSet.StringVar(&opts.ContractsDir, "contracts", "", "Path to the Go contracts package directory.")
+	flagSet.StringVar(&opts.RegistryPath, "registry", "", "Path to the Go registry source file.")
+	flagSet.StringVar(&opts.DefinitionsPath, "definitions", "", "Path to the JSON catalog definitions file.")
+	flagSet.StringVar(&opts.OutputPath, "out", "", "Optional path to the committed TypeScript output; enables the emit check.")
+	flagSet.StringVar(&opts.HashGoOutputPath, "hash-go", "", "Path to the committed Go catalog hash file (emit check).")
+	flagSet.StringVar(&opts.HashGoPackage, "hash-go-pkg", "", "Go package name for the Go catalog hash file (emit check).")
+	flagSet.StringVar(&opts.HashTSOutputPath, "hash-ts", "", "Path to the committed TypeScript catalog hash file (emit check).")
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage of %s:\n", flagSet.Name())
+		flagSet.PrintDefaults()
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if opts.ContractsDir == "" {
+		flagSet.Usage()
+		return fmt.Errorf("effectsgen verify: missing required flag --contracts")
+	}
+	if opts.RegistryPath == "" {
+		flagSet.Usage()
+		return fmt.Errorf("effectsgen verify: missing required flag --registry")
+	}
+	if opts.DefinitionsPath == "" {
+		flagSet.Usage()
+		return fmt.Errorf("effectsgen verify: missing required flag --definitions")
+	}
+
+	if extra := flagSet.Args(); len(extra) > 0 {
+		flagSet.Usage()
+		return fmt.Errorf("effectsgen verify: unexpected arguments: %s", strings.Join(extra, " "))
+	}
+
+	if err := pipeline.Verify(opts); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "effectsgen verify: ok")
+	return nil
+}
@@ -75,3 +75,100 @@ func TestExecuteRunsPipeline(t *testing.T) {
 		t.Fatalf("expected TypeScript hash output file to be created: %v", err)
 	}
 }
+
+func TestExecuteVerifyReportsRegistryMismatch(t *testing.T) {
+	tempDir := t.TempDir()
+	contractsDir, registryPath := testutil.WriteContractFixtures(t, tempDir)
+	definitionsPath := filepath.Join(tempDir, "definitions.json")
+	definitions := `[
+  {"id": "fireball", "contractId": "fireball", "definition": {"typeId": "fireball"}},
+  {"id": "frost-nova", "contractId": "frost-nova", "definition": {"typeId": "frost-nova"}}
+]`
+	if err := os.WriteFile(definitionsPath, []byte(definitions), 0o644); err != nil {
+		t.Fatalf("failed to write definitions stub: %v", err)
+	}
+	outputPath := filepath.Join(tempDir, "out", "effect-contracts.ts")
+
+	args := []string{
+		"verify",
+		"--contracts=" + contractsDir,
+		"--registry=" + registryPath,
+		"--definitions=" + definitionsPath,
+		"--out=" + outputPath,
+		"--hash-go=" + filepath.Join(tempDir, "out", "effect_catalog_hash.generated.go"),
+		"--hash-go-pkg=contract",
+		"--hash-ts=" + filepath.Join(tempDir, "out", "effect-contracts-hash.ts"),
+	}
+	err := Execute(io.Discard, io.Discard, args)
+	if err == nil {
+		t.Fatal("expected verify to fail when a catalog entry references an unknown contract")
+	}
+	if !strings.Contains(err.Error(), "2 issue(s)") {
+		t.Fatalf("expected the registry issue and the skipped emit check aggregated, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "catalog entry frost-nova references unknown contractId frost-nova") {
+		t.Fatalf("expected issue to name the entry and its contract, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "emit check skipped") {
+		t.Fatalf("expected the emit check to be skipped, got %v", err)
+	}
+
+	if err := os.WriteFile(definitionsPath, []byte(`[{"id": "fireball", "contractId": "fireball", "definition": {"typeId": "fireball"}}]`), 0o644); err != nil {
+		t.Fatalf("failed to rewrite definitions stub: %v", err)
+	}
+	var stdout strings.Builder
+	if err := Execute(&stdout, io.Discard, args[:4]); err != nil {
+		t.Fatalf("expected verify to pass once the catalog matches the registry, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "ok") {
+		t.Fatalf("expected verify to report success, got %q", stdout.String())
+	}
+}
+
+func TestExecuteVerifyDetectsStaleGeneratedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	contractsDir, registryPath := testutil.WriteContractFixtures(t, tempDir)
+	definitionsPath := filepath.Join(tempDir, "definitions.json")
+	definitions := `[
+  {"id": "fireball", "contractId": "fireball", "definition": {"typeId": "fireball"}}
+]`
+	if err := os.WriteFile(definitionsPath, []byte(definitions), 0o644); err != nil {
+		t.Fatalf("failed to write definitions stub: %v", err)
+	}
+	outputPath := filepath.Join(tempDir, "out", "effect-contracts.ts")
+	hashGoPath := filepath.Join(tempDir, "out", "effect_catalog_hash.generated.go")
+	hashTSPath := filepath.Join(tempDir, "out", "effect-contracts-hash.ts")
+	outputFlags := []string{
+		"--contracts=" + contractsDir,
+		"--registry=" + registryPath,
+		"--definitions=" + definitionsPath,
+		"--out=" + outputPath,
+		"--hash-go=" + hashGoPath,
+		"--hash-go-pkg=contract",
+		"--hash-ts=" + hashTSPath,
+	}
+	if err := Execute(io.Discard, io.Discard, outputFlags); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	verifyArgs := append([]string{"verify"}, outputFlags...)
+	if err := Execute(io.Discard, io.Discard, verifyArgs); err != nil {
+		t.Fatalf("expected verify to pass against freshly generated files, got %v", err)
+	}
+
+	stale := `[
+  {"id": "fireball", "contractId": "fireball", "definition": {"typeId": "fireball"}, "jsEffect": "projectile/fireball"}
+]`
+	if err := os.WriteFile(definitionsPath, []byte(stale), 0o644); err != nil {
+		t.Fatalf("failed to update definitions stub: %v", err)
+	}
+	err := Execute(io.Discard, io.Discard, verifyArgs)
+	if err == nil {
+		t.Fatal("expected verify to fail once the catalog changes without regenerating")
+	}
+	for _, path := range []string{outputPath, hashGoPath, hashTSPath} {
+		if !strings.Contains(err.Error(), path+" is out of date") {
+			t.Fatalf("expected %s to be reported as out of date, got %v", path, err)
+		}
+	}
+}
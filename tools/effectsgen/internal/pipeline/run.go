@@ -35,30 +35,7 @@ func Run(opts Options) error {
 		return err
 	}
 
-	definitions, decls, err := loadContractMetadata(opts.ContractsDir, opts.RegistryPath)
-	if err != nil {
-		return err
-	}
-
-	entries, err := loadCatalogEntries(opts.DefinitionsPath)
-	if err != nil {
-		return err
-	}
-
-	defIndex := make(map[string]contractDefinition, len(definitions))
-	for _, def := range definitions {
-		defIndex[def.ID] = def
-	}
-	for i := range entries {
-		entry := &entries[i]
-		def, ok := defIndex[entry.ContractID]
-		if !ok {
-			return fmt.Errorf("effectsgen: catalog entry %s references unknown contractId %s", entry.ID, entry.ContractID)
-		}
-		entry.ManagedByClient = def.ClientOwned
-	}
-
-	module, err := generateEffectCatalogModule(definitions, decls, entries)
+	module, err := buildCatalogModule(opts.ContractsDir, opts.RegistryPath, opts.DefinitionsPath)
 	if err != nil {
 		return err
 	}
@@ -70,28 +47,65 @@ func Run(opts Options) error {
 		return fmt.Errorf("effectsgen: failed writing output %s: %w", opts.OutputPath, err)
 	}
 
-	hash := sha256.Sum256(module)
-	hashHex := hex.EncodeToString(hash[:])
+	goSource, tsSource := renderHashSources(module, opts.HashGoPackage)
 
-	goSource := fmt.Sprintf("// Code generated by effectsgen. DO NOT EDIT.\n\npackage %s\n\nconst EffectCatalogHash = \"%s\"\n", opts.HashGoPackage, hashHex)
 	if err := os.MkdirAll(filepath.Dir(opts.HashGoOutputPath), 0o755); err != nil {
 		return fmt.Errorf("effectsgen: failed creating Go hash output directory: %w", err)
 	}
-	if err := os.WriteFile(opts.HashGoOutputPath, []byte(goSource), 0o644); err != nil {
+	if err := os.WriteFile(opts.HashGoOutputPath, goSource, 0o644); err != nil {
 		return fmt.Errorf("effectsgen: failed writing Go hash output %s: %w", opts.HashGoOutputPath, err)
 	}
 
-	tsSource := fmt.Sprintf("// Code generated by effectsgen. DO NOT EDIT.\n\nexport const effectCatalogHash = \"%s\" as const;\n", hashHex)
 	if err := os.MkdirAll(filepath.Dir(opts.HashTSOutputPath), 0o755); err != nil {
 		return fmt.Errorf("effectsgen: failed creating TypeScript hash output directory: %w", err)
 	}
-	if err := os.WriteFile(opts.HashTSOutputPath, []byte(tsSource), 0o644); err != nil {
+	if err := os.WriteFile(opts.HashTSOutputPath, tsSource, 0o644); err != nil {
 		return fmt.Errorf("effectsgen: failed writing TypeScript hash output %s: %w", opts.HashTSOutputPath, err)
 	}
 
 	return nil
 }
 
+// buildCatalogModule loads the contracts and catalog and renders the
+// TypeScript module without writing it.
+func buildCatalogModule(contractsDir, registryPath, definitionsPath string) ([]byte, error) {
+	definitions, decls, err := loadContractMetadata(contractsDir, registryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := loadCatalogEntries(definitionsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defIndex := make(map[string]contractDefinition, len(definitions))
+	for _, def := range definitions {
+		defIndex[def.ID] = def
+	}
+	for i := range entries {
+		entry := &entries[i]
+		def, ok := defIndex[entry.ContractID]
+		if !ok {
+			return nil, fmt.Errorf("effectsgen: catalog entry %s references unknown contractId %s", entry.ID, entry.ContractID)
+		}
+		entry.ManagedByClient = def.ClientOwned
+	}
+
+	return generateEffectCatalogModule(definitions, decls, entries)
+}
+
+// renderHashSources returns the Go and TypeScript sources exporting the hash
+// of the generated module.
+func renderHashSources(module []byte, goPackage string) ([]byte, []byte) {
+	hash := sha256.Sum256(module)
+	hashHex := hex.EncodeToString(hash[:])
+
+	goSource := fmt.Sprintf("// Code generated by effectsgen. DO NOT EDIT.\n\npackage %s\n\nconst EffectCatalogHash = \"%s\"\n", goPackage, hashHex)
+	tsSource := fmt.Sprintf("// Code generated by effectsgen. DO NOT EDIT.\n\nexport const effectCatalogHash = \"%s\" as const;\n", hashHex)
+	return []byte(goSource), []byte(tsSource)
+}
+
 func validateOptions(opts Options) error {
 	if strings.TrimSpace(opts.ContractsDir) == "" {
 		return fmt.Errorf("effectsgen: contracts directory is required")
@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// VerifyOptions defines the inputs for a verification pass. Verification never
+// writes files; it reports every problem it finds in a single result.
+type VerifyOptions struct {
+	// ContractsDir points to the Go package containing contract payload structs.
+	ContractsDir string
+	// RegistryPath is the path to the Go source file that registers contract IDs.
+	RegistryPath string
+	// DefinitionsPath locates the JSON catalog definitions authored by designers.
+	DefinitionsPath string
+	// OutputPath, HashGoOutputPath, HashGoPackage, and HashTSOutputPath
+	// optionally enable the emit check, which regenerates the artifacts in
+	// memory and compares them against the committed files. Either all or none
+	// of them must be set.
+	OutputPath       string
+	HashGoOutputPath string
+	HashGoPackage    string
+	HashTSOutputPath string
+}
+
+// VerifyError aggregates the issues found by Verify.
+type VerifyError struct {
+	Issues []string
+}
+
+func (e *VerifyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "effectsgen verify: %d issue(s) found", len(e.Issues))
+	for _, issue := range e.Issues {
+		b.WriteString("\n  - ")
+		b.WriteString(issue)
+	}
+	return b.String()
+}
+
+// Verify runs load, registry validation, and the optional emit check in order. It returns nil when everything passes and
+// a *VerifyError listing every issue otherwise. Load failures stop the pass
+// since later stages depend on the loaded data.
+func Verify(opts VerifyOptions) error {
+	if err := validateVerifyOptions(opts); err != nil {
+		return err
+	}
+
+	definitions, _, err := loadContractMetadata(opts.ContractsDir, opts.RegistryPath)
+	if err != nil {
+		return &VerifyError{Issues: []string{err.Error()}}
+	}
+	entries, err := loadCatalogEntries(opts.DefinitionsPath)
+	if err != nil {
+		return &VerifyError{Issues: []string{err.Error()}}
+	}
+
+	issues := validateRegistry(definitions, entries)
+	registryValid := len(issues) == 0
+
+	if opts.OutputPath != "" {
+		if registryValid {
+			issues = append(issues, verifyEmittedArtifacts(opts)...)
+		} else {
+			issues = append(issues, "emit check skipped: registry validation failed")
+		}
+	}
+
+	if len(issues) > 0 {
+		return &VerifyError{Issues: issues}
+	}
+	return nil
+}
+
+func validateVerifyOptions(opts VerifyOptions) error {
+	if strings.TrimSpace(opts.ContractsDir) == "" {
+		return fmt.Errorf("effectsgen: contracts directory is required")
+	}
+	if strings.TrimSpace(opts.RegistryPath) == "" {
+		return fmt.Errorf("effectsgen: registry path is required")
+	}
+	if strings.TrimSpace(opts.DefinitionsPath) == "" {
+		return fmt.Errorf("effectsgen: definitions path is required")
+	}
+
+	emitFields := []string{opts.OutputPath, opts.HashGoOutputPath, opts.HashGoPackage, opts.HashTSOutputPath}
+	set := 0
+	for _, field := range emitFields {
+		if strings.TrimSpace(field) != "" {
+			set++
+		}
+	}
+	if set != 0 && set != len(emitFields) {
+		return fmt.Errorf("effectsgen: emit check requires output, Go hash output, Go hash package, and TypeScript hash output together")
+	}
+	return nil
+}
+
+// validateRegistry checks every catalog entry against the contract registry
+// and reports all mismatches instead of stopping at the first one.
+func validateRegistry(definitions []contractDefinition, entries []catalogEntry) []string {
+	known := make(map[string]struct{}, len(definitions))
+	for _, def := range definitions {
+		known[def.ID] = struct{}{}
+	}
+
+	var issues []string
+	for i, entry := range entries {
+		if i > 0 && entries[i-1].ID == entry.ID {
+			issues = append(issues, fmt.Sprintf("catalog entry %s is defined more than once", entry.ID))
+		}
+		if _, ok := known[entry.ContractID]; !ok {
+			issues = append(issues, fmt.Sprintf("catalog entry %s references unknown contractId %s", entry.ID, entry.ContractID))
+		}
+	}
+	return issues
+}
+
+// verifyEmittedArtifacts regenerates the catalog module and hash sources in
+// memory, exactly as Run would write them, and reports every committed file
+// that differs from them.
+func verifyEmittedArtifacts(opts VerifyOptions) []string {
+	module, err := buildCatalogModule(opts.ContractsDir, opts.RegistryPath, opts.DefinitionsPath)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	goSource, tsSource := renderHashSources(module, opts.HashGoPackage)
+
+	var issues []string
+	for _, artifact := range []struct {
+		path string
+		want []byte
+	}{
+		{opts.OutputPath, module},
+		{opts.HashGoOutputPath, goSource},
+		{opts.HashTSOutputPath, tsSource},
+	} {
+		got, err := os.ReadFile(artifact.path)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("generated file %s cannot be read: %v", artifact.path, err))
+			continue
+		}
+		if !bytes.Equal(got, artifact.want) {
+			issues = append(issues, fmt.Sprintf("generated file %s is out of date; rerun effectsgen", artifact.path))
+		}
+	}
+	return issues
+}